@@ -27,7 +27,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/util/retry"
 	"k8s.io/ingress-gce/pkg/annotations"
-	backendconfig "k8s.io/ingress-gce/pkg/apis/backendconfig/v1beta1"
+	backendconfig "k8s.io/ingress-gce/pkg/apis/backendconfig/v1"
 	"k8s.io/ingress-gce/pkg/e2e"
 	"k8s.io/ingress-gce/pkg/fuzz"
 	"k8s.io/ingress-gce/pkg/fuzz/features"
@@ -93,7 +93,7 @@ func TestAffinity(t *testing.T) {
 				annotations.BackendConfigKey: `{"default":"backendconfig-1"}`,
 			}
 
-			if _, err := Framework.BackendConfigClient.CloudV1beta1().BackendConfigs(s.Namespace).Create(tc.beConfig); err != nil {
+			if _, err := Framework.BackendConfigClient.CloudV1().BackendConfigs(s.Namespace).Create(tc.beConfig); err != nil {
 				t.Fatalf("error creating BackendConfig: %v", err)
 			}
 			t.Logf("BackendConfig created (%s/%s) ", s.Namespace, tc.beConfig.Name)
@@ -129,7 +129,7 @@ func TestAffinity(t *testing.T) {
 
 			// Test modifications
 			if err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
-				bc, err := Framework.BackendConfigClient.CloudV1beta1().BackendConfigs(s.Namespace).Get(tc.beConfig.Name, metav1.GetOptions{})
+				bc, err := Framework.BackendConfigClient.CloudV1().BackendConfigs(s.Namespace).Get(tc.beConfig.Name, metav1.GetOptions{})
 				if err != nil {
 					return err
 				}
@@ -138,7 +138,7 @@ func TestAffinity(t *testing.T) {
 				}
 				bc.Spec.SessionAffinity.AffinityType = tc.transition.affinity
 				bc.Spec.SessionAffinity.AffinityCookieTtlSec = &tc.transition.ttl
-				_, err = Framework.BackendConfigClient.CloudV1beta1().BackendConfigs(s.Namespace).Update(bc)
+				_, err = Framework.BackendConfigClient.CloudV1().BackendConfigs(s.Namespace).Update(bc)
 				return err
 			}); err != nil {
 				t.Errorf("Failed to update BackendConfig affinity settings for %s: %v", t.Name(), err)
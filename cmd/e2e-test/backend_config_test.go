@@ -28,7 +28,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/wait"
 
 	"k8s.io/ingress-gce/pkg/annotations"
-	backendconfigv1beta1 "k8s.io/ingress-gce/pkg/apis/backendconfig/v1beta1"
+	backendconfigv1 "k8s.io/ingress-gce/pkg/apis/backendconfig/v1"
 	"k8s.io/ingress-gce/pkg/e2e"
 	"k8s.io/ingress-gce/pkg/fuzz"
 )
@@ -44,7 +44,7 @@ func TestBackendConfigNegatives(t *testing.T) {
 	for _, tc := range []struct {
 		desc           string
 		svcAnnotations map[string]string
-		backendConfig  *backendconfigv1beta1.BackendConfig
+		backendConfig  *backendconfigv1.BackendConfig
 		secretName     string
 		expectedMsg    string
 	}{
@@ -80,7 +80,7 @@ func TestBackendConfigNegatives(t *testing.T) {
 			t.Parallel()
 
 			if tc.backendConfig != nil {
-				if _, err := Framework.BackendConfigClient.CloudV1beta1().BackendConfigs(s.Namespace).Create(tc.backendConfig); err != nil {
+				if _, err := Framework.BackendConfigClient.CloudV1().BackendConfigs(s.Namespace).Create(tc.backendConfig); err != nil {
 					t.Fatalf("Error creating backend config: %v", err)
 				}
 				t.Logf("Backend config %s/%s created", s.Namespace, tc.backendConfig.Name)
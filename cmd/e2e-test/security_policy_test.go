@@ -99,7 +99,7 @@ func TestSecurityPolicyEnable(t *testing.T) {
 		}
 
 		testBackendConfig := fuzz.NewBackendConfigBuilder("", "backendconfig-1").SetSecurityPolicy(testSecurityPolicy.Name).Build()
-		testBackendConfig, err = Framework.BackendConfigClient.CloudV1beta1().BackendConfigs(s.Namespace).Create(testBackendConfig)
+		testBackendConfig, err = Framework.BackendConfigClient.CloudV1().BackendConfigs(s.Namespace).Create(testBackendConfig)
 		if err != nil {
 			t.Fatalf("Error creating test backend config: %v", err)
 		}
@@ -171,7 +171,7 @@ func TestSecurityPolicyTransition(t *testing.T) {
 		}
 
 		testBackendConfig := fuzz.NewBackendConfigBuilder("", "backendconfig-1").SetSecurityPolicy(testSecurityPolicyAllow.Name).Build()
-		testBackendConfig, err = Framework.BackendConfigClient.CloudV1beta1().BackendConfigs(s.Namespace).Create(testBackendConfig)
+		testBackendConfig, err = Framework.BackendConfigClient.CloudV1().BackendConfigs(s.Namespace).Create(testBackendConfig)
 		if err != nil {
 			t.Fatalf("Error creating test backend config: %v", err)
 		}
@@ -215,7 +215,7 @@ func TestSecurityPolicyTransition(t *testing.T) {
 
 		for _, step := range steps {
 			testBackendConfig.Spec.SecurityPolicy.Name = step.securityPolicyToSet
-			testBackendConfig, err = Framework.BackendConfigClient.CloudV1beta1().BackendConfigs(s.Namespace).Update(testBackendConfig)
+			testBackendConfig, err = Framework.BackendConfigClient.CloudV1().BackendConfigs(s.Namespace).Update(testBackendConfig)
 			if err != nil {
 				t.Fatalf("Error updating test backend config: %v", err)
 			}
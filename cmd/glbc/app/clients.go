@@ -18,6 +18,7 @@ package app
 
 import (
 	"bytes"
+	"fmt"
 	"io"
 	"io/ioutil"
 	"net/http"
@@ -55,6 +56,31 @@ func NewKubeConfig() (*rest.Config, error) {
 	return clientcmd.BuildConfigFromFlags(flags.F.APIServerHost, flags.F.KubeConfigFile)
 }
 
+// ReadOnlyKubeConfig returns a copy of config whose clients fail every
+// request which is not a GET, so that they only read the cluster.
+func ReadOnlyKubeConfig(config *rest.Config) *rest.Config {
+	config = rest.CopyConfig(config)
+	wrap := config.WrapTransport
+	config.WrapTransport = func(rt http.RoundTripper) http.RoundTripper {
+		if wrap != nil {
+			rt = wrap(rt)
+		}
+		return readOnlyRoundTripper{rt}
+	}
+	return config
+}
+
+type readOnlyRoundTripper struct {
+	http.RoundTripper
+}
+
+func (rt readOnlyRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet {
+		return nil, fmt.Errorf("refusing %s %s from a read-only client", req.Method, req.URL.Path)
+	}
+	return rt.RoundTripper.RoundTrip(req)
+}
+
 // NewGCEClient returns a client to the GCE environment. This will block until
 // a valid configuration file can be read.
 func NewGCEClient() *gce.Cloud {
@@ -90,6 +90,32 @@ func NewNamer(kubeClient kubernetes.Interface, clusterName, fwName string) (*uti
 	return namer, nil
 }
 
+// LookupNamer returns the naming policy recorded in the cluster without
+// recording one if there is none, unlike NewNamer. The cluster UID is
+// clusterName if it is not empty.
+func LookupNamer(kubeClient kubernetes.Interface, clusterName, fwName string) (*utils.Namer, error) {
+	cfgVault := storage.NewConfigMapVault(kubeClient, metav1.NamespaceSystem, uidConfigMapName)
+	name := clusterName
+	if name == "" {
+		val, found, err := cfgVault.Get(storage.UIDDataKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to retrieve %v: %v", storage.UIDDataKey, err)
+		}
+		if !found {
+			return nil, fmt.Errorf("%v not found in ConfigMap %v, the cluster UID must be given", storage.UIDDataKey, uidConfigMapName)
+		}
+		name = val
+	}
+	fw_name, err := useDefaultOrLookupVault(cfgVault, storage.ProviderDataKey, fwName)
+	if err != nil {
+		return nil, err
+	}
+	if fw_name == "" {
+		fw_name = name
+	}
+	return utils.NewNamer(name, fw_name), nil
+}
+
 // useDefaultOrLookupVault returns either a 'defaultName' or if unset, obtains
 // a name from a ConfigMap.  The returned value follows this priority:
 //
@@ -0,0 +1,116 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"time"
+
+	apiextensionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	"k8s.io/client-go/kubernetes"
+	restclient "k8s.io/client-go/rest"
+	"k8s.io/klog"
+
+	"k8s.io/ingress-gce/pkg/backendconfig"
+	backendconfigclient "k8s.io/ingress-gce/pkg/backendconfig/client/clientset/versioned"
+	ingctx "k8s.io/ingress-gce/pkg/context"
+	"k8s.io/ingress-gce/pkg/flags"
+	"k8s.io/ingress-gce/pkg/webhook"
+)
+
+// webhookClientTimeout bounds the requests made while validating an object,
+// so that they complete well within the API server's webhook timeout.
+const webhookClientTimeout = 5 * time.Second
+
+// RunWebhookServer starts the admission webhook server. It is run by every
+// replica, regardless of leader election.
+func RunWebhookServer(kubeConfig *restclient.Config) {
+	config := restclient.CopyConfig(kubeConfig)
+	config.Timeout = webhookClientTimeout
+	kubeClient, err := kubernetes.NewForConfig(restclient.AddUserAgent(config, "admission-webhook"))
+	if err != nil {
+		klog.Fatalf("Failed to create kubernetes client for admission webhooks: %v", err)
+	}
+
+	server, err := webhook.NewServer(flags.F.WebhookPort, flags.F.WebhookCertFile, flags.F.WebhookKeyFile)
+	if err != nil {
+		klog.Fatalf("Failed to create admission webhook server: %v", err)
+	}
+	if flags.F.EnableBackendConfig {
+		validator, err := webhook.NewBackendConfigValidator(kubeClient)
+		if err != nil {
+			klog.Fatalf("Failed to create BackendConfig validator: %v", err)
+		}
+		server.Handle(webhook.BackendConfigPath, validator.Admit)
+		server.HandleConversion(webhook.BackendConfigConversionPath, backendconfig.Convert)
+	}
+	server.Handle(webhook.IngressPath, newIngressValidator(config, kubeClient).Admit)
+
+	klog.V(0).Infof("Running admission webhook server on :%v", flags.F.WebhookPort)
+	klog.Fatal(server.Run())
+}
+
+// newIngressValidator returns an IngressValidator backed by its own Service
+// and BackendConfig informers, as the informers of the controllers only run
+// on the leader.
+func newIngressValidator(config *restclient.Config, kubeClient kubernetes.Interface) *webhook.IngressValidator {
+	var backendConfigClient backendconfigclient.Interface
+	if flags.F.EnableBackendConfig {
+		var err error
+		backendConfigClient, err = backendconfigclient.NewForConfig(config)
+		if err != nil {
+			klog.Fatalf("Failed to create BackendConfig client for admission webhooks: %v", err)
+		}
+	}
+	ctx := ingctx.NewControllerContext(kubeClient, backendConfigClient, nil, nil, nil, ingctx.ControllerContextConfig{
+		Namespace:    flags.F.WatchNamespace,
+		ResyncPeriod: flags.F.ResyncPeriod,
+	})
+
+	stopCh := make(chan struct{})
+	go ctx.ServiceInformer.Run(stopCh)
+	if backendConfigClient != nil {
+		go ctx.BackendConfigInformer.Run(stopCh)
+	}
+	return webhook.NewIngressValidator(ctx)
+}
+
+// ConversionWebhookClientConfig returns the configuration with which the API
+// server calls the conversion webhook served on path.
+func ConversionWebhookClientConfig(path string) (*apiextensionsv1beta1.WebhookClientConfig, error) {
+	parts := strings.Split(flags.F.ConversionWebhookService, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("invalid conversion webhook service %q, should be of the form namespace/name", flags.F.ConversionWebhookService)
+	}
+	config := &apiextensionsv1beta1.WebhookClientConfig{
+		Service: &apiextensionsv1beta1.ServiceReference{
+			Namespace: parts[0],
+			Name:      parts[1],
+			Path:      &path,
+		},
+	}
+	if flags.F.WebhookCAFile != "" {
+		caBundle, err := ioutil.ReadFile(flags.F.WebhookCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("error reading webhook CA bundle: %v", err)
+		}
+		config.CABundle = caBundle
+	}
+	return config, nil
+}
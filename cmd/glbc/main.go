@@ -34,6 +34,7 @@ import (
 	"k8s.io/client-go/tools/leaderelection/resourcelock"
 	"k8s.io/client-go/tools/record"
 	backendconfigclient "k8s.io/ingress-gce/pkg/backendconfig/client/clientset/versioned"
+	frontendconfigclient "k8s.io/ingress-gce/pkg/frontendconfig/client/clientset/versioned"
 
 	ingctx "k8s.io/ingress-gce/pkg/context"
 	"k8s.io/ingress-gce/pkg/controller"
@@ -44,8 +45,10 @@ import (
 	"k8s.io/ingress-gce/pkg/crd"
 	"k8s.io/ingress-gce/pkg/firewalls"
 	"k8s.io/ingress-gce/pkg/flags"
+	"k8s.io/ingress-gce/pkg/frontendconfig"
 	_ "k8s.io/ingress-gce/pkg/klog"
 	"k8s.io/ingress-gce/pkg/version"
+	"k8s.io/ingress-gce/pkg/webhook"
 )
 
 func main() {
@@ -79,6 +82,20 @@ func main() {
 		klog.Fatalf("Failed to create kubernetes client config: %v", err)
 	}
 
+	if flags.F.Plan {
+		if err := runPlan(kubeConfig); err != nil {
+			klog.Fatalf("Failed to plan: %v", err)
+		}
+		return
+	}
+
+	if flags.F.FindOrphans || flags.F.DeleteOrphans {
+		if err := runOrphans(kubeConfig); err != nil {
+			klog.Fatalf("Failed to find or delete orphaned resources: %v", err)
+		}
+		return
+	}
+
 	kubeClient, err := kubernetes.NewForConfig(kubeConfig)
 	if err != nil {
 		klog.Fatalf("Failed to create kubernetes client: %v", err)
@@ -90,15 +107,31 @@ func main() {
 		klog.Fatalf("Failed to create kubernetes client for leader election: %v", err)
 	}
 
-	var backendConfigClient backendconfigclient.Interface
-	if flags.F.EnableBackendConfig {
+	// Webhooks are served before CRDs are ensured, since the API server may
+	// call the conversion webhook while stored objects are migrated.
+	if flags.F.EnableWebhook {
+		go app.RunWebhookServer(kubeConfig)
+	}
+
+	var crdHandler *crd.CRDHandler
+	if flags.F.EnableBackendConfig || flags.F.EnableFrontendConfig {
 		crdClient, err := crdclient.NewForConfig(kubeConfig)
 		if err != nil {
 			klog.Fatalf("Failed to create kubernetes CRD client: %v", err)
 		}
-		// TODO(rramkumar): Reuse this CRD handler for other CRD's coming.
-		crdHandler := crd.NewCRDHandler(crdClient)
+		crdHandler = crd.NewCRDHandler(crdClient)
+	}
+
+	var backendConfigClient backendconfigclient.Interface
+	if flags.F.EnableBackendConfig {
 		backendConfigCRDMeta := backendconfig.CRDMeta()
+		if flags.F.EnableWebhook && flags.F.ConversionWebhookService != "" {
+			webhookConfig, err := app.ConversionWebhookClientConfig(webhook.BackendConfigConversionPath)
+			if err != nil {
+				klog.Fatalf("Failed to configure BackendConfig conversion webhook: %v", err)
+			}
+			backendConfigCRDMeta.EnableConversionWebhook(webhookConfig)
+		}
 		if _, err := crdHandler.EnsureCRD(backendConfigCRDMeta); err != nil {
 			klog.Fatalf("Failed to ensure BackendConfig CRD: %v", err)
 		}
@@ -107,6 +140,25 @@ func main() {
 		if err != nil {
 			klog.Fatalf("Failed to create BackendConfig client: %v", err)
 		}
+		// Stored objects which fail to migrate remain readable, so the
+		// migration is retried on the next restart rather than being fatal.
+		if err := crdHandler.MigrateStorage(backendConfigCRDMeta, func() error {
+			return backendconfig.RewriteAll(backendConfigClient)
+		}); err != nil {
+			klog.Errorf("Failed to migrate stored BackendConfigs: %v", err)
+		}
+	}
+
+	var frontendConfigClient frontendconfigclient.Interface
+	if flags.F.EnableFrontendConfig {
+		if _, err := crdHandler.EnsureCRD(frontendconfig.CRDMeta()); err != nil {
+			klog.Fatalf("Failed to ensure FrontendConfig CRD: %v", err)
+		}
+
+		frontendConfigClient, err = frontendconfigclient.NewForConfig(kubeConfig)
+		if err != nil {
+			klog.Fatalf("Failed to create FrontendConfig client: %v", err)
+		}
 	}
 
 	namer, err := app.NewNamer(kubeClient, flags.F.ClusterName, firewalls.DefaultFirewallName)
@@ -126,7 +178,7 @@ func main() {
 		HealthCheckPath:               flags.F.HealthCheckPath,
 		DefaultBackendHealthCheckPath: flags.F.DefaultSvcHealthCheckPath,
 	}
-	ctx := ingctx.NewControllerContext(kubeClient, backendConfigClient, cloud, namer, ctxConfig)
+	ctx := ingctx.NewControllerContext(kubeClient, backendConfigClient, frontendConfigClient, cloud, namer, ctxConfig)
 	go app.RunHTTPServer(ctx.HealthCheck)
 
 	if !flags.F.LeaderElection.LeaderElect {
@@ -0,0 +1,101 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+
+	"k8s.io/api/core/v1"
+	extensions "k8s.io/api/extensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	restclient "k8s.io/client-go/rest"
+
+	"k8s.io/ingress-gce/cmd/glbc/app"
+	"k8s.io/ingress-gce/pkg/firewalls"
+	"k8s.io/ingress-gce/pkg/flags"
+	"k8s.io/ingress-gce/pkg/orphans"
+)
+
+// runOrphans prints the GCE resources of the cluster which its Ingresses
+// and Services do not use, and deletes them if -delete-orphans is set. The
+// cluster is only read.
+func runOrphans(kubeConfig *restclient.Config) error {
+	// The resources which regional load balancers use, such as instance
+	// groups, would be reported as orphans.
+	if flags.F.EnableL7Ilb {
+		return fmt.Errorf("the regional resources of internal load balancers are not looked up, so orphans cannot be found with -enable-l7-ilb")
+	}
+	kubeClient, err := kubernetes.NewForConfig(app.ReadOnlyKubeConfig(kubeConfig))
+	if err != nil {
+		return fmt.Errorf("failed to create kubernetes client: %v", err)
+	}
+	namer, err := app.LookupNamer(kubeClient, flags.F.ClusterName, firewalls.DefaultFirewallName)
+	if err != nil {
+		return err
+	}
+	cloud := app.NewGCEClient()
+	zones, err := cloud.ListZonesInRegion(cloud.Region())
+	if err != nil {
+		return err
+	}
+	var zoneNames []string
+	for _, zone := range zones {
+		zoneNames = append(zoneNames, zone.Name)
+	}
+
+	scanner := orphans.NewScanner(cloud.Compute(), namer, zoneNames)
+	inv, err := scanner.List()
+	if err != nil {
+		return err
+	}
+	// The cluster is listed after GCE, so that the resources of an Ingress
+	// being created are not reported. All namespaces are listed regardless
+	// of -watch-namespace, since resources are shared between them.
+	ingList, err := kubeClient.Extensions().Ingresses(metav1.NamespaceAll).List(metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	var ings []*extensions.Ingress
+	for i := range ingList.Items {
+		ings = append(ings, &ingList.Items[i])
+	}
+	svcList, err := kubeClient.CoreV1().Services(metav1.NamespaceAll).List(metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	var svcs []*v1.Service
+	for i := range svcList.Items {
+		svcs = append(svcs, &svcList.Items[i])
+	}
+
+	orphaned, err := inv.Orphans(ings, svcs)
+	if err != nil {
+		return err
+	}
+	if len(orphaned) == 0 {
+		fmt.Println("No orphaned resources.")
+		return nil
+	}
+	for _, r := range orphaned {
+		fmt.Println(r)
+	}
+	if !flags.F.DeleteOrphans {
+		return nil
+	}
+	return scanner.Delete(orphaned)
+}
@@ -0,0 +1,147 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	restclient "k8s.io/client-go/rest"
+	"k8s.io/klog"
+
+	"k8s.io/ingress-gce/cmd/glbc/app"
+	backendconfigclient "k8s.io/ingress-gce/pkg/backendconfig/client/clientset/versioned"
+	ingctx "k8s.io/ingress-gce/pkg/context"
+	"k8s.io/ingress-gce/pkg/controller"
+	"k8s.io/ingress-gce/pkg/firewalls"
+	"k8s.io/ingress-gce/pkg/flags"
+	frontendconfigclient "k8s.io/ingress-gce/pkg/frontendconfig/client/clientset/versioned"
+	"k8s.io/ingress-gce/pkg/plan"
+	"k8s.io/ingress-gce/pkg/utils"
+)
+
+// runPlan prints the changes to GCE resources which the controllers would
+// make, without making them. The cluster is only read, so the Ingresses are
+// not updated and the CRDs are neither ensured nor migrated.
+func runPlan(kubeConfig *restclient.Config) error {
+	switch flags.F.PlanOutput {
+	case "text", "json":
+	default:
+		return fmt.Errorf("invalid -plan-output %q", flags.F.PlanOutput)
+	}
+
+	kubeConfig = app.ReadOnlyKubeConfig(kubeConfig)
+	kubeClient, err := kubernetes.NewForConfig(kubeConfig)
+	if err != nil {
+		return fmt.Errorf("failed to create kubernetes client: %v", err)
+	}
+	var backendConfigClient backendconfigclient.Interface
+	if flags.F.EnableBackendConfig {
+		if backendConfigClient, err = backendconfigclient.NewForConfig(kubeConfig); err != nil {
+			return fmt.Errorf("failed to create BackendConfig client: %v", err)
+		}
+	}
+	var frontendConfigClient frontendconfigclient.Interface
+	if flags.F.EnableFrontendConfig {
+		if frontendConfigClient, err = frontendconfigclient.NewForConfig(kubeConfig); err != nil {
+			return fmt.Errorf("failed to create FrontendConfig client: %v", err)
+		}
+	}
+
+	namer, err := app.LookupNamer(kubeClient, flags.F.ClusterName, firewalls.DefaultFirewallName)
+	if err != nil {
+		return err
+	}
+	cloud := app.NewGCEClient()
+	ctxConfig := ingctx.ControllerContextConfig{
+		Namespace:                     flags.F.WatchNamespace,
+		ResyncPeriod:                  flags.F.ResyncPeriod,
+		DefaultBackendSvcPortID:       app.DefaultBackendServicePortID(kubeClient),
+		HealthCheckPath:               flags.F.HealthCheckPath,
+		DefaultBackendHealthCheckPath: flags.F.DefaultSvcHealthCheckPath,
+	}
+	ctx := ingctx.NewControllerContext(kubeClient, backendConfigClient, frontendConfigClient, cloud, namer, ctxConfig)
+
+	p := plan.NewPlan(cloud.ProjectID())
+	providers := controller.NewGCEProviders(cloud, namer)
+	providers.LoadBalancers = plan.NewLoadBalancers(providers.LoadBalancers, p)
+	providers.BackendPool = plan.NewBackendPool(providers.BackendPool, namer, cloud.Region(), p)
+	providers.HealthChecks = plan.NewHealthChecks(providers.HealthChecks, cloud.Region(), p)
+	providers.InstanceGroups = plan.NewInstanceGroups(providers.InstanceGroups, p)
+
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	lbc := controller.NewLoadBalancerControllerWithProviders(ctx, stopCh, providers)
+	fwc := firewalls.NewFirewallControllerWithCloud(ctx, flags.F.NodePortRanges.Values(), plan.NewFirewall(cloud, p))
+
+	ctx.Start(stopCh)
+	klog.V(0).Infof("Waiting for stores to sync")
+	wait.PollImmediateInfinite(ingctx.StoreSyncPollPeriod, func() (bool, error) {
+		return ctx.HasSynced(), nil
+	})
+	lbc.Init()
+
+	// The changes are printed even if a sync failed, since the others are
+	// still planned.
+	var errs []error
+	if err := lbc.Plan(); err != nil {
+		errs = append(errs, err)
+	}
+	if err := fwc.Plan(); err != nil {
+		errs = append(errs, fmt.Errorf("error syncing firewall: %v", err))
+	}
+	changes, err := p.Changes()
+	if err != nil {
+		return err
+	}
+	if err := printChanges(os.Stdout, changes, flags.F.PlanOutput); err != nil {
+		return err
+	}
+	if len(errs) > 0 {
+		return utils.JoinErrs(errs)
+	}
+	return nil
+}
+
+// printChanges writes changes to w in the given format.
+func printChanges(w io.Writer, changes []plan.Change, format string) error {
+	if format == "json" {
+		if changes == nil {
+			changes = []plan.Change{}
+		}
+		b, err := json.MarshalIndent(changes, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(w, "%s\n", b)
+		return err
+	}
+	if len(changes) == 0 {
+		_, err := fmt.Fprintln(w, "No changes.")
+		return err
+	}
+	for _, change := range changes {
+		if _, err := fmt.Fprintln(w, change.String()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package annotations
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ConditionsKey is the status annotation in which the controller records
+// the conditions of an Ingress, as a JSON list of IngressConditions.
+const ConditionsKey = StatusPrefix + "/conditions"
+
+// IngressConditionType is the type of an IngressCondition.
+type IngressConditionType string
+
+const (
+	// TranslatedCondition is true if the Ingress spec and annotations were
+	// translated into a url map.
+	TranslatedCondition IngressConditionType = "Translated"
+	// BackendsSyncedCondition is true if the instance groups, NEGs and
+	// backend services of the Ingress were synced.
+	BackendsSyncedCondition IngressConditionType = "BackendsSynced"
+	// FrontendSyncedCondition is true if the url map, target proxies,
+	// certificates and forwarding rules of the Ingress were synced.
+	FrontendSyncedCondition IngressConditionType = "FrontendSynced"
+	// ProgrammedCondition is true if the last sync of the Ingress succeeded
+	// and its status was updated. It is false if any phase failed.
+	ProgrammedCondition IngressConditionType = "Programmed"
+)
+
+// IngressCondition is the state of an Ingress in one aspect, modeled after
+// the conditions of core objects.
+type IngressCondition struct {
+	Type   IngressConditionType `json:"type"`
+	Status v1.ConditionStatus   `json:"status"`
+	// Reason is a CamelCase reason for the last transition.
+	Reason string `json:"reason,omitempty"`
+	// Message is a human readable description of the last transition.
+	Message string `json:"message,omitempty"`
+	// LastTransitionTime is when Status last changed.
+	LastTransitionTime metav1.Time `json:"lastTransitionTime"`
+}
+
+// Conditions returns the conditions recorded on the Ingress. Empty by
+// default.
+func (ing *Ingress) Conditions() ([]IngressCondition, error) {
+	val, ok := ing.v[ConditionsKey]
+	if !ok {
+		return nil, nil
+	}
+	var conditions []IngressCondition
+	if err := json.Unmarshal([]byte(val), &conditions); err != nil {
+		return nil, fmt.Errorf("invalid conditions: %v", err)
+	}
+	return conditions, nil
+}
+
+// SetCondition returns conditions with c added, or replacing the condition
+// of the same type. The last transition time of c is taken from the
+// replaced condition if the status did not change.
+func SetCondition(conditions []IngressCondition, c IngressCondition) []IngressCondition {
+	for i := range conditions {
+		if conditions[i].Type != c.Type {
+			continue
+		}
+		if conditions[i].Status == c.Status {
+			c.LastTransitionTime = conditions[i].LastTransitionTime
+		}
+		conditions[i] = c
+		return conditions
+	}
+	return append(conditions, c)
+}
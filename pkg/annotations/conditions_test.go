@@ -0,0 +1,82 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package annotations
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"k8s.io/api/core/v1"
+	extensions "k8s.io/api/extensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSetCondition(t *testing.T) {
+	before := metav1.NewTime(time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC))
+	now := metav1.NewTime(before.Add(time.Hour))
+	conditions := []IngressCondition{
+		{Type: TranslatedCondition, Status: v1.ConditionTrue, Reason: "Translated", LastTransitionTime: before},
+		{Type: ProgrammedCondition, Status: v1.ConditionTrue, Reason: "Programmed", LastTransitionTime: before},
+	}
+
+	// An unchanged status keeps its transition time, but not its message.
+	conditions = SetCondition(conditions, IngressCondition{Type: TranslatedCondition, Status: v1.ConditionTrue, Reason: "Translated", Message: "ok", LastTransitionTime: now})
+	// A changed status transitions now.
+	conditions = SetCondition(conditions, IngressCondition{Type: ProgrammedCondition, Status: v1.ConditionFalse, Reason: "BackendSyncError", LastTransitionTime: now})
+	// A new condition is appended.
+	conditions = SetCondition(conditions, IngressCondition{Type: BackendsSyncedCondition, Status: v1.ConditionFalse, Reason: "BackendSyncError", LastTransitionTime: now})
+
+	want := []IngressCondition{
+		{Type: TranslatedCondition, Status: v1.ConditionTrue, Reason: "Translated", Message: "ok", LastTransitionTime: before},
+		{Type: ProgrammedCondition, Status: v1.ConditionFalse, Reason: "BackendSyncError", LastTransitionTime: now},
+		{Type: BackendsSyncedCondition, Status: v1.ConditionFalse, Reason: "BackendSyncError", LastTransitionTime: now},
+	}
+	if !reflect.DeepEqual(conditions, want) {
+		t.Errorf("SetCondition() = %+v, want %+v", conditions, want)
+	}
+}
+
+func TestIngressConditions(t *testing.T) {
+	ing := FromIngress(&extensions.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{
+				ConditionsKey: `[{"type": "Programmed", "status": "True", "reason": "Programmed", "lastTransitionTime": "2019-01-01T00:00:00Z"}]`,
+			},
+		},
+	})
+	// Times are decoded in the local time zone.
+	want := []IngressCondition{
+		{
+			Type:               ProgrammedCondition,
+			Status:             v1.ConditionTrue,
+			Reason:             "Programmed",
+			LastTransitionTime: metav1.NewTime(time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC).Local()),
+		},
+	}
+	got, err := ing.Conditions()
+	if err != nil {
+		t.Fatalf("Conditions() = _, %v; want _, nil", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Conditions() = %+v, want %+v", got, want)
+	}
+
+	if _, err := FromIngress(&extensions.Ingress{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{ConditionsKey: "{"}}}).Conditions(); err == nil {
+		t.Errorf("Conditions() = _, nil; want error for invalid JSON")
+	}
+}
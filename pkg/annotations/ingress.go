@@ -17,9 +17,13 @@ limitations under the License.
 package annotations
 
 import (
+	"encoding/json"
+	"fmt"
 	"strconv"
+	"strings"
 
 	extensions "k8s.io/api/extensions/v1beta1"
+	"k8s.io/apimachinery/pkg/util/validation"
 )
 
 const (
@@ -41,6 +45,16 @@ const (
 	// responsibility to create/delete it.
 	StaticIPNameKey = "kubernetes.io/ingress.global-static-ip-name"
 
+	// RegionalStaticIPNameKey is the equivalent of StaticIPNameKey for
+	// Ingresses of the GceL7ILBIngressClass, whose forwarding rules are
+	// assigned a regional internal static ip.
+	RegionalStaticIPNameKey = "kubernetes.io/ingress.regional-static-ip-name"
+
+	// InternalSubnetworkKey tells the Ingress controller which subnetwork
+	// the forwarding rules of an Ingress of the GceL7ILBIngressClass take
+	// their ip from. If unset, the subnetwork of the cluster is used.
+	InternalSubnetworkKey = "networking.gke.io/internal-load-balancer-subnet"
+
 	// PreSharedCertKey represents the specific pre-shared SSL
 	// certicate for the Ingress controller to use. The controller *does not*
 	// manage this certificate, it is the users responsibility to create/delete it.
@@ -48,12 +62,37 @@ const (
 	// to the target proxies of the Ingress.
 	PreSharedCertKey = "ingress.gcp.kubernetes.io/pre-shared-cert"
 
+	// ManagedCertificatesKey tells the Ingress controller to provision a
+	// Google-managed SSL certificate for the hosts of the Ingress rules.
+	// The controller manages this certificate, and serves it alongside any
+	// other certificates of the Ingress once it is provisioned.
+	ManagedCertificatesKey = "ingress.gcp.kubernetes.io/managed-certificates"
+
+	// EnableIPv6Key tells the Ingress controller to serve an external
+	// Ingress on a global IPv6 address, next to its IPv4 address. The
+	// controller reserves and manages the IPv6 address.
+	EnableIPv6Key = "networking.gke.io/enable-ipv6"
+
+	// RouteRulesKey is the annotation key used by the Ingress to route
+	// requests on their headers and query parameters, and to split them
+	// across weighted backends. Its value is a JSON list of RouteRules,
+	// which are matched in order before the paths of the Ingress rules.
+	RouteRulesKey = "networking.gke.io/route-rules"
+
+	// PathActionsKey is the annotation key used by the Ingress to rewrite or
+	// redirect the requests of individual paths of its rules. Its value is a
+	// JSON list of PathActions.
+	PathActionsKey = "networking.gke.io/path-actions"
+
 	// IngressClassKey picks a specific "class" for the Ingress. The controller
 	// only processes Ingresses with this annotation either unset, or set
 	// to either gceIngessClass or the empty string.
 	IngressClassKey      = "kubernetes.io/ingress.class"
 	GceIngressClass      = "gce"
 	GceMultiIngressClass = "gce-multi-cluster"
+	// GceL7ILBIngressClass is the class of Ingresses which are served by
+	// a regional internal HTTP(S) load balancer.
+	GceL7ILBIngressClass = "gce-internal"
 
 	// Label key to denote which GCE zone a Kubernetes node is in.
 	ZoneKey     = "failure-domain.beta.kubernetes.io/zone"
@@ -68,8 +107,96 @@ const (
 	// SuppressFirewallXPNErrorKey is the annotation key used by firewall
 	// controller whether to supress firewallXPNError.
 	SuppressFirewallXPNErrorKey = "networking.gke.io/suppress-firewall-xpn-error"
+
+	// FrontendConfigKey is the annotation key used by the Ingress to
+	// reference a FrontendConfig in its namespace, which configures the
+	// frontend resources of its load balancer.
+	FrontendConfigKey = "networking.gke.io/v1beta1.FrontendConfig"
 )
 
+// maxRouteBackendWeight is the largest weight of a RouteBackend.
+const maxRouteBackendWeight = 1000
+
+// RouteRule is a rule of the RouteRulesKey annotation. It sends the
+// requests to Host which satisfy Match to its Backends.
+type RouteRule struct {
+	// Host is the host the rule applies to. All hosts if empty.
+	Host     string         `json:"host,omitempty"`
+	Match    RouteMatch     `json:"match,omitempty"`
+	Backends []RouteBackend `json:"backends"`
+}
+
+// RouteMatch matches requests on their path prefix, headers and query
+// parameters. An empty RouteMatch matches all requests.
+type RouteMatch struct {
+	PathPrefix      string       `json:"pathPrefix,omitempty"`
+	Headers         []ValueMatch `json:"headers,omitempty"`
+	QueryParameters []ValueMatch `json:"queryParameters,omitempty"`
+}
+
+// ValueMatch matches a header or query parameter by name.
+type ValueMatch struct {
+	Name string `json:"name"`
+	// Value is the exact value to match. If empty, the header or query
+	// parameter only has to be present.
+	Value string `json:"value,omitempty"`
+}
+
+// RouteBackend is a backend of a RouteRule, which receives a share of its
+// requests proportional to Weight.
+type RouteBackend struct {
+	extensions.IngressBackend
+	Weight int64 `json:"weight"`
+}
+
+// PathAction is an action of the PathActionsKey annotation, which rewrites
+// or redirects the requests of a path of the Ingress rules. Exactly one of
+// Rewrite and Redirect is set.
+type PathAction struct {
+	// Host is the host of the Ingress rule. The default host if empty.
+	Host string `json:"host,omitempty"`
+	// Path is the path of the Ingress rule. The default path if empty.
+	Path     string        `json:"path,omitempty"`
+	Rewrite  *PathRewrite  `json:"rewrite,omitempty"`
+	Redirect *PathRedirect `json:"redirect,omitempty"`
+}
+
+// PathRewrite rewrites requests before they are sent to the backend of the
+// path. Empty fields are left unchanged.
+type PathRewrite struct {
+	// PathPrefix replaces the part of the request path which matched the
+	// path of the Ingress rule.
+	PathPrefix string `json:"pathPrefix,omitempty"`
+	Host       string `json:"host,omitempty"`
+}
+
+// PathRedirect redirects requests instead of sending them to the backend of
+// the path. Empty fields are taken from the request.
+type PathRedirect struct {
+	Host string `json:"host,omitempty"`
+	// Path replaces the whole request path. At most one of Path and
+	// PathPrefix is set.
+	Path string `json:"path,omitempty"`
+	// PathPrefix replaces the part of the request path which matched the
+	// path of the Ingress rule.
+	PathPrefix string `json:"pathPrefix,omitempty"`
+	HTTPS      bool   `json:"https,omitempty"`
+	StripQuery bool   `json:"stripQuery,omitempty"`
+	// ResponseCode is one of MOVED_PERMANENTLY_DEFAULT, FOUND, SEE_OTHER,
+	// TEMPORARY_REDIRECT or PERMANENT_REDIRECT. Defaults to
+	// MOVED_PERMANENTLY_DEFAULT.
+	ResponseCode string `json:"responseCode,omitempty"`
+}
+
+// supportedRedirectResponseCodes are the response codes of a PathRedirect.
+var supportedRedirectResponseCodes = map[string]bool{
+	"MOVED_PERMANENTLY_DEFAULT": true,
+	"FOUND":                     true,
+	"SEE_OTHER":                 true,
+	"TEMPORARY_REDIRECT":        true,
+	"PERMANENT_REDIRECT":        true,
+}
+
 // Ingress represents ingress annotations.
 type Ingress struct {
 	v map[string]string
@@ -103,6 +230,33 @@ func (ing *Ingress) UseNamedTLS() string {
 	return val
 }
 
+// ManagedCertificates returns the managed certificates flag. False by
+// default.
+func (ing *Ingress) ManagedCertificates() bool {
+	val, ok := ing.v[ManagedCertificatesKey]
+	if !ok {
+		return false
+	}
+	v, err := strconv.ParseBool(val)
+	if err != nil {
+		return false
+	}
+	return v
+}
+
+// EnableIPv6 returns the IPv6 flag. False by default.
+func (ing *Ingress) EnableIPv6() bool {
+	val, ok := ing.v[EnableIPv6Key]
+	if !ok {
+		return false
+	}
+	v, err := strconv.ParseBool(val)
+	if err != nil {
+		return false
+	}
+	return v
+}
+
 func (ing *Ingress) StaticIPName() string {
 	val, ok := ing.v[StaticIPNameKey]
 	if !ok {
@@ -111,6 +265,18 @@ func (ing *Ingress) StaticIPName() string {
 	return val
 }
 
+// RegionalStaticIPName returns the name of the regional static ip of an
+// internal Ingress. Empty by default.
+func (ing *Ingress) RegionalStaticIPName() string {
+	return ing.v[RegionalStaticIPNameKey]
+}
+
+// InternalSubnetwork returns the name of the subnetwork of an internal
+// Ingress. Empty by default.
+func (ing *Ingress) InternalSubnetwork() string {
+	return ing.v[InternalSubnetworkKey]
+}
+
 func (ing *Ingress) IngressClass() string {
 	val, ok := ing.v[IngressClassKey]
 	if !ok {
@@ -132,3 +298,181 @@ func (ing *Ingress) SuppressFirewallXPNError() bool {
 	}
 	return v
 }
+
+// FrontendConfig returns the name of the FrontendConfig referenced by the
+// Ingress. Empty by default.
+func (ing *Ingress) FrontendConfig() string {
+	val, ok := ing.v[FrontendConfigKey]
+	if !ok {
+		return ""
+	}
+	return val
+}
+
+// RouteRules returns the route rules of the Ingress. Empty by default.
+func (ing *Ingress) RouteRules() ([]RouteRule, error) {
+	val, ok := ing.v[RouteRulesKey]
+	if !ok {
+		return nil, nil
+	}
+	var rules []RouteRule
+	decoder := json.NewDecoder(strings.NewReader(val))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&rules); err != nil {
+		return nil, fmt.Errorf("invalid route rules: %v", err)
+	}
+	for i, rule := range rules {
+		if err := validateRouteRule(rule); err != nil {
+			return nil, fmt.Errorf("invalid route rule %d: %v", i, err)
+		}
+	}
+	return rules, nil
+}
+
+func validateRouteRule(rule RouteRule) error {
+	if rule.Match.PathPrefix != "" && !strings.HasPrefix(rule.Match.PathPrefix, "/") {
+		return fmt.Errorf("path prefix %q does not start with /", rule.Match.PathPrefix)
+	}
+	for _, m := range rule.Match.Headers {
+		if m.Name == "" {
+			return fmt.Errorf("header match without a name")
+		}
+	}
+	for _, m := range rule.Match.QueryParameters {
+		if m.Name == "" {
+			return fmt.Errorf("query parameter match without a name")
+		}
+	}
+	if len(rule.Backends) == 0 {
+		return fmt.Errorf("no backends")
+	}
+	var total int64
+	for _, b := range rule.Backends {
+		if b.ServiceName == "" || (b.ServicePort.IntVal == 0 && b.ServicePort.StrVal == "") {
+			return fmt.Errorf("backend %+v has no service name or port", b.IngressBackend)
+		}
+		if b.Weight < 0 || b.Weight > maxRouteBackendWeight {
+			return fmt.Errorf("weight %d of backend %v is not between 0 and %d", b.Weight, b.ServiceName, maxRouteBackendWeight)
+		}
+		total += b.Weight
+	}
+	if len(rule.Backends) > 1 && total == 0 {
+		return fmt.Errorf("all backends have a weight of 0")
+	}
+	return nil
+}
+
+// PathActions returns the path actions of the Ingress. Empty by default.
+func (ing *Ingress) PathActions() ([]PathAction, error) {
+	val, ok := ing.v[PathActionsKey]
+	if !ok {
+		return nil, nil
+	}
+	var actions []PathAction
+	decoder := json.NewDecoder(strings.NewReader(val))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&actions); err != nil {
+		return nil, fmt.Errorf("invalid path actions: %v", err)
+	}
+	seen := map[PathAction]bool{}
+	for i, action := range actions {
+		if err := validatePathAction(action); err != nil {
+			return nil, fmt.Errorf("invalid path action %d: %v", i, err)
+		}
+		key := PathAction{Host: action.Host, Path: action.Path}
+		if seen[key] {
+			return nil, fmt.Errorf("invalid path action %d: duplicate action for host %q and path %q", i, action.Host, action.Path)
+		}
+		seen[key] = true
+	}
+	return actions, nil
+}
+
+func validatePathAction(action PathAction) error {
+	if (action.Rewrite == nil) == (action.Redirect == nil) {
+		return fmt.Errorf("exactly one of rewrite and redirect must be set")
+	}
+	if r := action.Rewrite; r != nil {
+		if r.PathPrefix == "" && r.Host == "" {
+			return fmt.Errorf("rewrite sets neither a path prefix nor a host")
+		}
+		if r.PathPrefix != "" && !strings.HasPrefix(r.PathPrefix, "/") {
+			return fmt.Errorf("rewrite path prefix %q does not start with /", r.PathPrefix)
+		}
+		return nil
+	}
+	r := action.Redirect
+	if r.Path != "" && r.PathPrefix != "" {
+		return fmt.Errorf("redirect sets both a path and a path prefix")
+	}
+	for _, p := range []string{r.Path, r.PathPrefix} {
+		if p != "" && !strings.HasPrefix(p, "/") {
+			return fmt.Errorf("redirect path %q does not start with /", p)
+		}
+	}
+	if r.Host == "" && r.Path == "" && r.PathPrefix == "" && !r.HTTPS && !r.StripQuery {
+		return fmt.Errorf("redirect does not change the request")
+	}
+	if r.ResponseCode != "" && !supportedRedirectResponseCodes[r.ResponseCode] {
+		return fmt.Errorf("unsupported redirect response code %q, should be one of MOVED_PERMANENTLY_DEFAULT, FOUND, SEE_OTHER, TEMPORARY_REDIRECT, or PERMANENT_REDIRECT", r.ResponseCode)
+	}
+	return nil
+}
+
+// ParseErrors returns the errors found parsing the annotations of the
+// Ingress which the controller interprets, keyed by annotation key. The
+// accessors above fall back to defaults for such annotations, or fail
+// when the load balancer is synced.
+func (ing *Ingress) ParseErrors() map[string]error {
+	errs := map[string]error{}
+	for _, key := range []string{AllowHTTPKey, SuppressFirewallXPNErrorKey, ManagedCertificatesKey, EnableIPv6Key} {
+		if val, ok := ing.v[key]; ok {
+			if _, err := strconv.ParseBool(val); err != nil {
+				errs[key] = fmt.Errorf("%q is not a boolean", val)
+			}
+		}
+	}
+	if val, ok := ing.v[StaticIPNameKey]; ok {
+		if err := validateResourceName(val); err != nil {
+			errs[StaticIPNameKey] = err
+		}
+	}
+	if val, ok := ing.v[FrontendConfigKey]; ok {
+		if msgs := validation.IsDNS1123Subdomain(val); len(msgs) > 0 {
+			errs[FrontendConfigKey] = fmt.Errorf("%q is not a valid FrontendConfig name: %s", val, strings.Join(msgs, ", "))
+		}
+	}
+	if _, err := ing.RouteRules(); err != nil {
+		errs[RouteRulesKey] = err
+	}
+	if _, err := ing.PathActions(); err != nil {
+		errs[PathActionsKey] = err
+	}
+	if val, ok := ing.v[PreSharedCertKey]; ok {
+		var names []string
+		for _, name := range strings.Split(val, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				names = append(names, name)
+			}
+		}
+		if len(names) == 0 {
+			errs[PreSharedCertKey] = fmt.Errorf("no certificate names in %q", val)
+		}
+		for _, name := range names {
+			if err := validateResourceName(name); err != nil {
+				errs[PreSharedCertKey] = err
+				break
+			}
+		}
+	}
+	return errs
+}
+
+// validateResourceName returns an error if name is not a valid name for a
+// GCE resource.
+func validateResourceName(name string) error {
+	if msgs := validation.IsDNS1035Label(name); len(msgs) > 0 {
+		return fmt.Errorf("%q is not a valid GCE resource name: %s", name, strings.Join(msgs, ", "))
+	}
+	return nil
+}
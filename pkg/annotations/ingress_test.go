@@ -17,19 +17,25 @@ limitations under the License.
 package annotations
 
 import (
+	"reflect"
 	"testing"
 
 	extensions "k8s.io/api/extensions/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 func TestIngress(t *testing.T) {
 	for _, tc := range []struct {
-		ing          *extensions.Ingress
-		allowHTTP    bool
-		useNamedTLS  string
-		staticIPName string
-		ingressClass string
+		ing                  *extensions.Ingress
+		allowHTTP            bool
+		useNamedTLS          string
+		staticIPName         string
+		ingressClass         string
+		managedCerts         bool
+		regionalStaticIPName string
+		internalSubnetwork   string
+		enableIPv6           bool
 	}{
 		{
 			ing:       &extensions.Ingress{},
@@ -51,6 +57,43 @@ func TestIngress(t *testing.T) {
 			staticIPName: "1.2.3.4",
 			ingressClass: "gce",
 		},
+		{
+			ing: &extensions.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						ManagedCertificatesKey: "true",
+					},
+				},
+			},
+			allowHTTP:    true,
+			managedCerts: true,
+		},
+		{
+			ing: &extensions.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						EnableIPv6Key: "true",
+					},
+				},
+			},
+			allowHTTP:  true,
+			enableIPv6: true,
+		},
+		{
+			ing: &extensions.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						IngressClassKey:         "gce-internal",
+						RegionalStaticIPNameKey: "internal-ip",
+						InternalSubnetworkKey:   "subnet",
+					},
+				},
+			},
+			allowHTTP:            true,
+			ingressClass:         "gce-internal",
+			regionalStaticIPName: "internal-ip",
+			internalSubnetwork:   "subnet",
+		},
 	} {
 		ing := FromIngress(tc.ing)
 		if x := ing.AllowHTTP(); x != tc.allowHTTP {
@@ -65,5 +108,250 @@ func TestIngress(t *testing.T) {
 		if x := ing.IngressClass(); x != tc.ingressClass {
 			t.Errorf("ingress %+v; IngressClass() = %v, want %v", tc.ing, x, tc.ingressClass)
 		}
+		if x := ing.ManagedCertificates(); x != tc.managedCerts {
+			t.Errorf("ingress %+v; ManagedCertificates() = %v, want %v", tc.ing, x, tc.managedCerts)
+		}
+		if x := ing.RegionalStaticIPName(); x != tc.regionalStaticIPName {
+			t.Errorf("ingress %+v; RegionalStaticIPName() = %v, want %v", tc.ing, x, tc.regionalStaticIPName)
+		}
+		if x := ing.InternalSubnetwork(); x != tc.internalSubnetwork {
+			t.Errorf("ingress %+v; InternalSubnetwork() = %v, want %v", tc.ing, x, tc.internalSubnetwork)
+		}
+		if x := ing.EnableIPv6(); x != tc.enableIPv6 {
+			t.Errorf("ingress %+v; EnableIPv6() = %v, want %v", tc.ing, x, tc.enableIPv6)
+		}
+	}
+}
+
+func TestIngressRouteRules(t *testing.T) {
+	ing := FromIngress(&extensions.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{
+				RouteRulesKey: `[{"host": "foo.com", "match": {"pathPrefix": "/api/", "headers": [{"name": "x-canary", "value": "true"}], "queryParameters": [{"name": "debug"}]}, "backends": [{"serviceName": "api", "servicePort": 80, "weight": 90}, {"serviceName": "api-canary", "servicePort": "http", "weight": 10}]}]`,
+			},
+		},
+	})
+	want := []RouteRule{
+		{
+			Host: "foo.com",
+			Match: RouteMatch{
+				PathPrefix:      "/api/",
+				Headers:         []ValueMatch{{Name: "x-canary", Value: "true"}},
+				QueryParameters: []ValueMatch{{Name: "debug"}},
+			},
+			Backends: []RouteBackend{
+				{IngressBackend: extensions.IngressBackend{ServiceName: "api", ServicePort: intstr.FromInt(80)}, Weight: 90},
+				{IngressBackend: extensions.IngressBackend{ServiceName: "api-canary", ServicePort: intstr.FromString("http")}, Weight: 10},
+			},
+		},
+	}
+	got, err := ing.RouteRules()
+	if err != nil {
+		t.Fatalf("RouteRules() = _, %v; want _, nil", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("RouteRules() = %+v, want %+v", got, want)
+	}
+
+	if got, err := FromIngress(&extensions.Ingress{}).RouteRules(); got != nil || err != nil {
+		t.Errorf("RouteRules() = %v, %v; want nil, nil", got, err)
+	}
+}
+
+func TestIngressPathActions(t *testing.T) {
+	ing := FromIngress(&extensions.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{
+				PathActionsKey: `[{"host": "foo.com", "path": "/api/svc/*", "rewrite": {"pathPrefix": "/", "host": "svc.internal"}}, {"path": "/old/*", "redirect": {"pathPrefix": "/new/", "https": true, "responseCode": "PERMANENT_REDIRECT"}}]`,
+			},
+		},
+	})
+	want := []PathAction{
+		{
+			Host:    "foo.com",
+			Path:    "/api/svc/*",
+			Rewrite: &PathRewrite{PathPrefix: "/", Host: "svc.internal"},
+		},
+		{
+			Path:     "/old/*",
+			Redirect: &PathRedirect{PathPrefix: "/new/", HTTPS: true, ResponseCode: "PERMANENT_REDIRECT"},
+		},
+	}
+	got, err := ing.PathActions()
+	if err != nil {
+		t.Fatalf("PathActions() = _, %v; want _, nil", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("PathActions() = %+v, want %+v", got, want)
+	}
+
+	if got, err := FromIngress(&extensions.Ingress{}).PathActions(); got != nil || err != nil {
+		t.Errorf("PathActions() = %v, %v; want nil, nil", got, err)
+	}
+}
+
+func TestIngressParseErrors(t *testing.T) {
+	for _, tc := range []struct {
+		desc        string
+		annotations map[string]string
+		wantKeys    []string
+	}{
+		{
+			desc: "no annotations",
+		},
+		{
+			desc: "valid annotations",
+			annotations: map[string]string{
+				AllowHTTPKey:                "false",
+				SuppressFirewallXPNErrorKey: "true",
+				StaticIPNameKey:             "my-address",
+				PreSharedCertKey:            "cert-a, cert-b",
+				FrontendConfigKey:           "my-frontend.config",
+				RouteRulesKey:               `[{"host": "foo.com", "match": {"headers": [{"name": "x-canary"}]}, "backends": [{"serviceName": "svc", "servicePort": 80, "weight": 90}, {"serviceName": "canary", "servicePort": "http", "weight": 10}]}]`,
+				PathActionsKey:              `[{"host": "foo.com", "path": "/api/*", "rewrite": {"pathPrefix": "/"}}, {"path": "/old", "redirect": {"path": "/new", "responseCode": "FOUND"}}]`,
+			},
+		},
+		{
+			desc: "invalid booleans",
+			annotations: map[string]string{
+				AllowHTTPKey:                "no",
+				SuppressFirewallXPNErrorKey: "yes",
+				ManagedCertificatesKey:      "on",
+				EnableIPv6Key:               "v6",
+			},
+			wantKeys: []string{AllowHTTPKey, SuppressFirewallXPNErrorKey, ManagedCertificatesKey, EnableIPv6Key},
+		},
+		{
+			desc: "invalid static IP name",
+			annotations: map[string]string{
+				StaticIPNameKey: "1.2.3.4",
+			},
+			wantKeys: []string{StaticIPNameKey},
+		},
+		{
+			desc: "invalid pre-shared certificate name",
+			annotations: map[string]string{
+				PreSharedCertKey: "cert-a,Cert_B",
+			},
+			wantKeys: []string{PreSharedCertKey},
+		},
+		{
+			desc: "invalid FrontendConfig name",
+			annotations: map[string]string{
+				FrontendConfigKey: "My_Config",
+			},
+			wantKeys: []string{FrontendConfigKey},
+		},
+		{
+			desc: "invalid route rules JSON",
+			annotations: map[string]string{
+				RouteRulesKey: `[{"hosts": ["foo.com"]}]`,
+			},
+			wantKeys: []string{RouteRulesKey},
+		},
+		{
+			desc: "route rule without backends",
+			annotations: map[string]string{
+				RouteRulesKey: `[{"match": {"pathPrefix": "/foo"}}]`,
+			},
+			wantKeys: []string{RouteRulesKey},
+		},
+		{
+			desc: "route rule with relative path prefix",
+			annotations: map[string]string{
+				RouteRulesKey: `[{"match": {"pathPrefix": "foo"}, "backends": [{"serviceName": "svc", "servicePort": 80}]}]`,
+			},
+			wantKeys: []string{RouteRulesKey},
+		},
+		{
+			desc: "route rule with out of range weight",
+			annotations: map[string]string{
+				RouteRulesKey: `[{"backends": [{"serviceName": "svc", "servicePort": 80, "weight": 1001}, {"serviceName": "canary", "servicePort": 80, "weight": 1}]}]`,
+			},
+			wantKeys: []string{RouteRulesKey},
+		},
+		{
+			desc: "route rule with zero total weight",
+			annotations: map[string]string{
+				RouteRulesKey: `[{"backends": [{"serviceName": "svc", "servicePort": 80}, {"serviceName": "canary", "servicePort": 80}]}]`,
+			},
+			wantKeys: []string{RouteRulesKey},
+		},
+		{
+			desc: "route rule with unnamed query parameter match",
+			annotations: map[string]string{
+				RouteRulesKey: `[{"match": {"queryParameters": [{"value": "true"}]}, "backends": [{"serviceName": "svc", "servicePort": 80}]}]`,
+			},
+			wantKeys: []string{RouteRulesKey},
+		},
+		{
+			desc: "path action without rewrite or redirect",
+			annotations: map[string]string{
+				PathActionsKey: `[{"path": "/api/*"}]`,
+			},
+			wantKeys: []string{PathActionsKey},
+		},
+		{
+			desc: "path action with both rewrite and redirect",
+			annotations: map[string]string{
+				PathActionsKey: `[{"path": "/api/*", "rewrite": {"host": "api"}, "redirect": {"https": true}}]`,
+			},
+			wantKeys: []string{PathActionsKey},
+		},
+		{
+			desc: "path rewrite with relative prefix",
+			annotations: map[string]string{
+				PathActionsKey: `[{"path": "/api/*", "rewrite": {"pathPrefix": "api"}}]`,
+			},
+			wantKeys: []string{PathActionsKey},
+		},
+		{
+			desc: "path redirect with path and prefix",
+			annotations: map[string]string{
+				PathActionsKey: `[{"path": "/api/*", "redirect": {"path": "/a", "pathPrefix": "/b"}}]`,
+			},
+			wantKeys: []string{PathActionsKey},
+		},
+		{
+			desc: "path redirect without changes",
+			annotations: map[string]string{
+				PathActionsKey: `[{"path": "/api/*", "redirect": {"responseCode": "FOUND"}}]`,
+			},
+			wantKeys: []string{PathActionsKey},
+		},
+		{
+			desc: "path redirect with unsupported response code",
+			annotations: map[string]string{
+				PathActionsKey: `[{"path": "/api/*", "redirect": {"https": true, "responseCode": "NOT_FOUND"}}]`,
+			},
+			wantKeys: []string{PathActionsKey},
+		},
+		{
+			desc: "duplicate path actions",
+			annotations: map[string]string{
+				PathActionsKey: `[{"path": "/api/*", "rewrite": {"host": "a"}}, {"path": "/api/*", "rewrite": {"host": "b"}}]`,
+			},
+			wantKeys: []string{PathActionsKey},
+		},
+		{
+			desc: "empty pre-shared certificate list",
+			annotations: map[string]string{
+				PreSharedCertKey: " , ",
+			},
+			wantKeys: []string{PreSharedCertKey},
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			ing := &extensions.Ingress{ObjectMeta: metav1.ObjectMeta{Annotations: tc.annotations}}
+			errs := FromIngress(ing).ParseErrors()
+			if len(errs) != len(tc.wantKeys) {
+				t.Fatalf("ParseErrors() = %v, want errors for %v", errs, tc.wantKeys)
+			}
+			for _, key := range tc.wantKeys {
+				if errs[key] == nil {
+					t.Errorf("ParseErrors() = %v, want error for %q", errs, key)
+				}
+			}
+		})
 	}
 }
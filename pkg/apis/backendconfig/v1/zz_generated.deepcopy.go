@@ -30,7 +30,7 @@ func (in *BackendConfig) DeepCopyInto(out *BackendConfig) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
@@ -52,6 +52,23 @@ func (in *BackendConfig) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackendConfigCondition) DeepCopyInto(out *BackendConfigCondition) {
+	*out = *in
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackendConfigCondition.
+func (in *BackendConfigCondition) DeepCopy() *BackendConfigCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(BackendConfigCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BackendConfigList) DeepCopyInto(out *BackendConfigList) {
 	*out = *in
@@ -118,6 +135,46 @@ func (in *BackendConfigSpec) DeepCopyInto(out *BackendConfigSpec) {
 		*out = new(SessionAffinityConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.HealthCheck != nil {
+		in, out := &in.HealthCheck, &out.HealthCheck
+		*out = new(HealthCheckConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CustomRequestHeaders != nil {
+		in, out := &in.CustomRequestHeaders, &out.CustomRequestHeaders
+		*out = new(CustomRequestHeadersConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CustomResponseHeaders != nil {
+		in, out := &in.CustomResponseHeaders, &out.CustomResponseHeaders
+		*out = new(CustomResponseHeadersConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Logging != nil {
+		in, out := &in.Logging, &out.Logging
+		*out = new(LogConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Balancing != nil {
+		in, out := &in.Balancing, &out.Balancing
+		*out = new(BalancingConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OutlierDetection != nil {
+		in, out := &in.OutlierDetection, &out.OutlierDetection
+		*out = new(OutlierDetectionConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CircuitBreakers != nil {
+		in, out := &in.CircuitBreakers, &out.CircuitBreakers
+		*out = new(CircuitBreakersConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LocalityLbPolicy != nil {
+		in, out := &in.LocalityLbPolicy, &out.LocalityLbPolicy
+		*out = new(string)
+		**out = **in
+	}
 	return
 }
 
@@ -134,6 +191,18 @@ func (in *BackendConfigSpec) DeepCopy() *BackendConfigSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BackendConfigStatus) DeepCopyInto(out *BackendConfigStatus) {
 	*out = *in
+	if in.Backends != nil {
+		in, out := &in.Backends, &out.Backends
+		*out = make([]BackendStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]BackendConfigCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -147,6 +216,63 @@ func (in *BackendConfigStatus) DeepCopy() *BackendConfigStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackendStatus) DeepCopyInto(out *BackendStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackendStatus.
+func (in *BackendStatus) DeepCopy() *BackendStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BackendStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BalancingConfig) DeepCopyInto(out *BalancingConfig) {
+	*out = *in
+	if in.Mode != nil {
+		in, out := &in.Mode, &out.Mode
+		*out = new(string)
+		**out = **in
+	}
+	if in.MaxRatePerInstance != nil {
+		in, out := &in.MaxRatePerInstance, &out.MaxRatePerInstance
+		*out = new(float64)
+		**out = **in
+	}
+	if in.MaxRatePerEndpoint != nil {
+		in, out := &in.MaxRatePerEndpoint, &out.MaxRatePerEndpoint
+		*out = new(float64)
+		**out = **in
+	}
+	if in.MaxUtilization != nil {
+		in, out := &in.MaxUtilization, &out.MaxUtilization
+		*out = new(float64)
+		**out = **in
+	}
+	if in.CapacityScaler != nil {
+		in, out := &in.CapacityScaler, &out.CapacityScaler
+		*out = new(float64)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BalancingConfig.
+func (in *BalancingConfig) DeepCopy() *BalancingConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(BalancingConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CDNConfig) DeepCopyInto(out *CDNConfig) {
 	*out = *in
@@ -155,6 +281,58 @@ func (in *CDNConfig) DeepCopyInto(out *CDNConfig) {
 		*out = new(CacheKeyPolicy)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.CacheMode != nil {
+		in, out := &in.CacheMode, &out.CacheMode
+		*out = new(string)
+		**out = **in
+	}
+	if in.ClientTtl != nil {
+		in, out := &in.ClientTtl, &out.ClientTtl
+		*out = new(int64)
+		**out = **in
+	}
+	if in.DefaultTtl != nil {
+		in, out := &in.DefaultTtl, &out.DefaultTtl
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MaxTtl != nil {
+		in, out := &in.MaxTtl, &out.MaxTtl
+		*out = new(int64)
+		**out = **in
+	}
+	if in.NegativeCaching != nil {
+		in, out := &in.NegativeCaching, &out.NegativeCaching
+		*out = new(bool)
+		**out = **in
+	}
+	if in.NegativeCachingPolicy != nil {
+		in, out := &in.NegativeCachingPolicy, &out.NegativeCachingPolicy
+		*out = make([]*NegativeCachingPolicy, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(NegativeCachingPolicy)
+				**out = **in
+			}
+		}
+	}
+	if in.SignedUrlCacheMaxAgeSec != nil {
+		in, out := &in.SignedUrlCacheMaxAgeSec, &out.SignedUrlCacheMaxAgeSec
+		*out = new(int64)
+		**out = **in
+	}
+	if in.SignedUrlKeys != nil {
+		in, out := &in.SignedUrlKeys, &out.SignedUrlKeys
+		*out = make([]*SignedUrlKey, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(SignedUrlKey)
+				**out = **in
+			}
+		}
+	}
 	return
 }
 
@@ -194,6 +372,52 @@ func (in *CacheKeyPolicy) DeepCopy() *CacheKeyPolicy {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CircuitBreakersConfig) DeepCopyInto(out *CircuitBreakersConfig) {
+	*out = *in
+	if in.ConnectTimeout != nil {
+		in, out := &in.ConnectTimeout, &out.ConnectTimeout
+		*out = new(Duration)
+		**out = **in
+	}
+	if in.MaxConnections != nil {
+		in, out := &in.MaxConnections, &out.MaxConnections
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MaxPendingRequests != nil {
+		in, out := &in.MaxPendingRequests, &out.MaxPendingRequests
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MaxRequests != nil {
+		in, out := &in.MaxRequests, &out.MaxRequests
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MaxRequestsPerConnection != nil {
+		in, out := &in.MaxRequestsPerConnection, &out.MaxRequestsPerConnection
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MaxRetries != nil {
+		in, out := &in.MaxRetries, &out.MaxRetries
+		*out = new(int64)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CircuitBreakersConfig.
+func (in *CircuitBreakersConfig) DeepCopy() *CircuitBreakersConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(CircuitBreakersConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ConnectionDrainingConfig) DeepCopyInto(out *ConnectionDrainingConfig) {
 	*out = *in
@@ -210,6 +434,115 @@ func (in *ConnectionDrainingConfig) DeepCopy() *ConnectionDrainingConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CustomRequestHeadersConfig) DeepCopyInto(out *CustomRequestHeadersConfig) {
+	*out = *in
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CustomRequestHeadersConfig.
+func (in *CustomRequestHeadersConfig) DeepCopy() *CustomRequestHeadersConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(CustomRequestHeadersConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CustomResponseHeadersConfig) DeepCopyInto(out *CustomResponseHeadersConfig) {
+	*out = *in
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CustomResponseHeadersConfig.
+func (in *CustomResponseHeadersConfig) DeepCopy() *CustomResponseHeadersConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(CustomResponseHeadersConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Duration) DeepCopyInto(out *Duration) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Duration.
+func (in *Duration) DeepCopy() *Duration {
+	if in == nil {
+		return nil
+	}
+	out := new(Duration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HealthCheckConfig) DeepCopyInto(out *HealthCheckConfig) {
+	*out = *in
+	if in.CheckIntervalSec != nil {
+		in, out := &in.CheckIntervalSec, &out.CheckIntervalSec
+		*out = new(int64)
+		**out = **in
+	}
+	if in.TimeoutSec != nil {
+		in, out := &in.TimeoutSec, &out.TimeoutSec
+		*out = new(int64)
+		**out = **in
+	}
+	if in.HealthyThreshold != nil {
+		in, out := &in.HealthyThreshold, &out.HealthyThreshold
+		*out = new(int64)
+		**out = **in
+	}
+	if in.UnhealthyThreshold != nil {
+		in, out := &in.UnhealthyThreshold, &out.UnhealthyThreshold
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Type != nil {
+		in, out := &in.Type, &out.Type
+		*out = new(string)
+		**out = **in
+	}
+	if in.Port != nil {
+		in, out := &in.Port, &out.Port
+		*out = new(int64)
+		**out = **in
+	}
+	if in.RequestPath != nil {
+		in, out := &in.RequestPath, &out.RequestPath
+		*out = new(string)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HealthCheckConfig.
+func (in *HealthCheckConfig) DeepCopy() *HealthCheckConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(HealthCheckConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *IAPConfig) DeepCopyInto(out *IAPConfig) {
 	*out = *in
@@ -231,6 +564,43 @@ func (in *IAPConfig) DeepCopy() *IAPConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LogConfig) DeepCopyInto(out *LogConfig) {
+	*out = *in
+	if in.SampleRate != nil {
+		in, out := &in.SampleRate, &out.SampleRate
+		*out = new(float64)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogConfig.
+func (in *LogConfig) DeepCopy() *LogConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(LogConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NegativeCachingPolicy) DeepCopyInto(out *NegativeCachingPolicy) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NegativeCachingPolicy.
+func (in *NegativeCachingPolicy) DeepCopy() *NegativeCachingPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(NegativeCachingPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OAuthClientCredentials) DeepCopyInto(out *OAuthClientCredentials) {
 	*out = *in
@@ -247,6 +617,77 @@ func (in *OAuthClientCredentials) DeepCopy() *OAuthClientCredentials {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OutlierDetectionConfig) DeepCopyInto(out *OutlierDetectionConfig) {
+	*out = *in
+	if in.BaseEjectionTime != nil {
+		in, out := &in.BaseEjectionTime, &out.BaseEjectionTime
+		*out = new(Duration)
+		**out = **in
+	}
+	if in.ConsecutiveErrors != nil {
+		in, out := &in.ConsecutiveErrors, &out.ConsecutiveErrors
+		*out = new(int64)
+		**out = **in
+	}
+	if in.ConsecutiveGatewayFailure != nil {
+		in, out := &in.ConsecutiveGatewayFailure, &out.ConsecutiveGatewayFailure
+		*out = new(int64)
+		**out = **in
+	}
+	if in.EnforcingConsecutiveErrors != nil {
+		in, out := &in.EnforcingConsecutiveErrors, &out.EnforcingConsecutiveErrors
+		*out = new(int64)
+		**out = **in
+	}
+	if in.EnforcingConsecutiveGatewayFailure != nil {
+		in, out := &in.EnforcingConsecutiveGatewayFailure, &out.EnforcingConsecutiveGatewayFailure
+		*out = new(int64)
+		**out = **in
+	}
+	if in.EnforcingSuccessRate != nil {
+		in, out := &in.EnforcingSuccessRate, &out.EnforcingSuccessRate
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(Duration)
+		**out = **in
+	}
+	if in.MaxEjectionPercent != nil {
+		in, out := &in.MaxEjectionPercent, &out.MaxEjectionPercent
+		*out = new(int64)
+		**out = **in
+	}
+	if in.SuccessRateMinimumHosts != nil {
+		in, out := &in.SuccessRateMinimumHosts, &out.SuccessRateMinimumHosts
+		*out = new(int64)
+		**out = **in
+	}
+	if in.SuccessRateRequestVolume != nil {
+		in, out := &in.SuccessRateRequestVolume, &out.SuccessRateRequestVolume
+		*out = new(int64)
+		**out = **in
+	}
+	if in.SuccessRateStdevFactor != nil {
+		in, out := &in.SuccessRateStdevFactor, &out.SuccessRateStdevFactor
+		*out = new(int64)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OutlierDetectionConfig.
+func (in *OutlierDetectionConfig) DeepCopy() *OutlierDetectionConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(OutlierDetectionConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SecurityPolicyConfig) DeepCopyInto(out *SecurityPolicyConfig) {
 	*out = *in
@@ -283,3 +724,19 @@ func (in *SessionAffinityConfig) DeepCopy() *SessionAffinityConfig {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SignedUrlKey) DeepCopyInto(out *SignedUrlKey) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SignedUrlKey.
+func (in *SignedUrlKey) DeepCopy() *SignedUrlKey {
+	if in == nil {
+		return nil
+	}
+	out := new(SignedUrlKey)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -29,14 +29,24 @@ import (
 
 func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenAPIDefinition {
 	return map[string]common.OpenAPIDefinition{
-		"k8s.io/ingress-gce/pkg/apis/backendconfig/v1.BackendConfig":            schema_pkg_apis_backendconfig_v1_BackendConfig(ref),
-		"k8s.io/ingress-gce/pkg/apis/backendconfig/v1.BackendConfigSpec":        schema_pkg_apis_backendconfig_v1_BackendConfigSpec(ref),
-		"k8s.io/ingress-gce/pkg/apis/backendconfig/v1.CDNConfig":                schema_pkg_apis_backendconfig_v1_CDNConfig(ref),
-		"k8s.io/ingress-gce/pkg/apis/backendconfig/v1.CacheKeyPolicy":           schema_pkg_apis_backendconfig_v1_CacheKeyPolicy(ref),
-		"k8s.io/ingress-gce/pkg/apis/backendconfig/v1.ConnectionDrainingConfig": schema_pkg_apis_backendconfig_v1_ConnectionDrainingConfig(ref),
-		"k8s.io/ingress-gce/pkg/apis/backendconfig/v1.IAPConfig":                schema_pkg_apis_backendconfig_v1_IAPConfig(ref),
-		"k8s.io/ingress-gce/pkg/apis/backendconfig/v1.OAuthClientCredentials":   schema_pkg_apis_backendconfig_v1_OAuthClientCredentials(ref),
-		"k8s.io/ingress-gce/pkg/apis/backendconfig/v1.SessionAffinityConfig":    schema_pkg_apis_backendconfig_v1_SessionAffinityConfig(ref),
+		"k8s.io/ingress-gce/pkg/apis/backendconfig/v1.BackendConfig":               schema_pkg_apis_backendconfig_v1_BackendConfig(ref),
+		"k8s.io/ingress-gce/pkg/apis/backendconfig/v1.BackendConfigSpec":           schema_pkg_apis_backendconfig_v1_BackendConfigSpec(ref),
+		"k8s.io/ingress-gce/pkg/apis/backendconfig/v1.BalancingConfig":             schema_pkg_apis_backendconfig_v1_BalancingConfig(ref),
+		"k8s.io/ingress-gce/pkg/apis/backendconfig/v1.CDNConfig":                   schema_pkg_apis_backendconfig_v1_CDNConfig(ref),
+		"k8s.io/ingress-gce/pkg/apis/backendconfig/v1.CacheKeyPolicy":              schema_pkg_apis_backendconfig_v1_CacheKeyPolicy(ref),
+		"k8s.io/ingress-gce/pkg/apis/backendconfig/v1.CircuitBreakersConfig":       schema_pkg_apis_backendconfig_v1_CircuitBreakersConfig(ref),
+		"k8s.io/ingress-gce/pkg/apis/backendconfig/v1.ConnectionDrainingConfig":    schema_pkg_apis_backendconfig_v1_ConnectionDrainingConfig(ref),
+		"k8s.io/ingress-gce/pkg/apis/backendconfig/v1.CustomRequestHeadersConfig":  schema_pkg_apis_backendconfig_v1_CustomRequestHeadersConfig(ref),
+		"k8s.io/ingress-gce/pkg/apis/backendconfig/v1.CustomResponseHeadersConfig": schema_pkg_apis_backendconfig_v1_CustomResponseHeadersConfig(ref),
+		"k8s.io/ingress-gce/pkg/apis/backendconfig/v1.Duration":                    schema_pkg_apis_backendconfig_v1_Duration(ref),
+		"k8s.io/ingress-gce/pkg/apis/backendconfig/v1.HealthCheckConfig":           schema_pkg_apis_backendconfig_v1_HealthCheckConfig(ref),
+		"k8s.io/ingress-gce/pkg/apis/backendconfig/v1.IAPConfig":                   schema_pkg_apis_backendconfig_v1_IAPConfig(ref),
+		"k8s.io/ingress-gce/pkg/apis/backendconfig/v1.LogConfig":                   schema_pkg_apis_backendconfig_v1_LogConfig(ref),
+		"k8s.io/ingress-gce/pkg/apis/backendconfig/v1.NegativeCachingPolicy":       schema_pkg_apis_backendconfig_v1_NegativeCachingPolicy(ref),
+		"k8s.io/ingress-gce/pkg/apis/backendconfig/v1.OAuthClientCredentials":      schema_pkg_apis_backendconfig_v1_OAuthClientCredentials(ref),
+		"k8s.io/ingress-gce/pkg/apis/backendconfig/v1.OutlierDetectionConfig":      schema_pkg_apis_backendconfig_v1_OutlierDetectionConfig(ref),
+		"k8s.io/ingress-gce/pkg/apis/backendconfig/v1.SessionAffinityConfig":       schema_pkg_apis_backendconfig_v1_SessionAffinityConfig(ref),
+		"k8s.io/ingress-gce/pkg/apis/backendconfig/v1.SignedUrlKey":                schema_pkg_apis_backendconfig_v1_SignedUrlKey(ref),
 	}
 }
 
@@ -119,11 +129,101 @@ func schema_pkg_apis_backendconfig_v1_BackendConfigSpec(ref common.ReferenceCall
 							Ref: ref("k8s.io/ingress-gce/pkg/apis/backendconfig/v1.SessionAffinityConfig"),
 						},
 					},
+					"healthCheck": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("k8s.io/ingress-gce/pkg/apis/backendconfig/v1.HealthCheckConfig"),
+						},
+					},
+					"customRequestHeaders": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("k8s.io/ingress-gce/pkg/apis/backendconfig/v1.CustomRequestHeadersConfig"),
+						},
+					},
+					"customResponseHeaders": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("k8s.io/ingress-gce/pkg/apis/backendconfig/v1.CustomResponseHeadersConfig"),
+						},
+					},
+					"logging": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("k8s.io/ingress-gce/pkg/apis/backendconfig/v1.LogConfig"),
+						},
+					},
+					"balancing": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("k8s.io/ingress-gce/pkg/apis/backendconfig/v1.BalancingConfig"),
+						},
+					},
+					"outlierDetection": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("k8s.io/ingress-gce/pkg/apis/backendconfig/v1.OutlierDetectionConfig"),
+						},
+					},
+					"circuitBreakers": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("k8s.io/ingress-gce/pkg/apis/backendconfig/v1.CircuitBreakersConfig"),
+						},
+					},
+					"localityLbPolicy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LocalityLbPolicy is the load balancing algorithm used within the scope of a locality. One of ROUND_ROBIN, LEAST_REQUEST, RING_HASH, RANDOM, ORIGINAL_DESTINATION or MAGLEV.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"k8s.io/ingress-gce/pkg/apis/backendconfig/v1.CDNConfig", "k8s.io/ingress-gce/pkg/apis/backendconfig/v1.ConnectionDrainingConfig", "k8s.io/ingress-gce/pkg/apis/backendconfig/v1.IAPConfig", "k8s.io/ingress-gce/pkg/apis/backendconfig/v1.SecurityPolicyConfig", "k8s.io/ingress-gce/pkg/apis/backendconfig/v1.SessionAffinityConfig"},
+			"k8s.io/ingress-gce/pkg/apis/backendconfig/v1.BalancingConfig", "k8s.io/ingress-gce/pkg/apis/backendconfig/v1.CDNConfig", "k8s.io/ingress-gce/pkg/apis/backendconfig/v1.CircuitBreakersConfig", "k8s.io/ingress-gce/pkg/apis/backendconfig/v1.ConnectionDrainingConfig", "k8s.io/ingress-gce/pkg/apis/backendconfig/v1.CustomRequestHeadersConfig", "k8s.io/ingress-gce/pkg/apis/backendconfig/v1.CustomResponseHeadersConfig", "k8s.io/ingress-gce/pkg/apis/backendconfig/v1.HealthCheckConfig", "k8s.io/ingress-gce/pkg/apis/backendconfig/v1.IAPConfig", "k8s.io/ingress-gce/pkg/apis/backendconfig/v1.LogConfig", "k8s.io/ingress-gce/pkg/apis/backendconfig/v1.OutlierDetectionConfig", "k8s.io/ingress-gce/pkg/apis/backendconfig/v1.SecurityPolicyConfig", "k8s.io/ingress-gce/pkg/apis/backendconfig/v1.SessionAffinityConfig"},
+	}
+}
+
+func schema_pkg_apis_backendconfig_v1_BalancingConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "BalancingConfig contains configuration for how traffic is distributed across the backends of the backend service.",
+				Properties: map[string]spec.Schema{
+					"mode": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Mode is the balancing mode of the backends. One of RATE or UTILIZATION. Network endpoint groups do not support UTILIZATION. Defaults to RATE.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"maxRatePerInstance": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MaxRatePerInstance is the maximum number of requests per second for each instance of an instance group backend. Only valid with RATE.",
+							Type:        []string{"number"},
+							Format:      "double",
+						},
+					},
+					"maxRatePerEndpoint": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MaxRatePerEndpoint is the maximum number of requests per second for each endpoint of a network endpoint group backend. Only valid with RATE.",
+							Type:        []string{"number"},
+							Format:      "double",
+						},
+					},
+					"maxUtilization": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MaxUtilization is the target CPU utilization of the instances of an instance group backend, in [0, 1]. Only valid with UTILIZATION.",
+							Type:        []string{"number"},
+							Format:      "double",
+						},
+					},
+					"capacityScaler": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CapacityScaler is a multiplier applied to the capacity of each backend, in [0, 1]. Defaults to 1.",
+							Type:        []string{"number"},
+							Format:      "double",
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{},
 	}
 }
 
@@ -144,12 +244,80 @@ func schema_pkg_apis_backendconfig_v1_CDNConfig(ref common.ReferenceCallback) co
 							Ref: ref("k8s.io/ingress-gce/pkg/apis/backendconfig/v1.CacheKeyPolicy"),
 						},
 					},
+					"cacheMode": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CacheMode specifies the cache setting for all responses from this backend. One of USE_ORIGIN_HEADERS, FORCE_CACHE_ALL or CACHE_ALL_STATIC.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"clientTtl": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ClientTtl specifies the maximum allowed TTL for cached content served by this origin, in seconds.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"defaultTtl": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DefaultTtl specifies the default TTL for cached content served by this origin for responses that do not have an existing valid TTL, in seconds.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"maxTtl": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MaxTtl specifies the maximum allowed TTL for cached content served by this origin, in seconds.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"negativeCaching": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NegativeCaching enables negative caching, which allows per-status code TTLs to be set in order to apply fine-grained caching for common errors or redirects.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"negativeCachingPolicy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NegativeCachingPolicy sets a cache TTL for the specified HTTP status codes. NegativeCaching must be enabled to configure it.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("k8s.io/ingress-gce/pkg/apis/backendconfig/v1.NegativeCachingPolicy"),
+									},
+								},
+							},
+						},
+					},
+					"signedUrlCacheMaxAgeSec": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SignedUrlCacheMaxAgeSec is the maximum number of seconds the response to a signed URL request will be considered fresh.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"signedUrlKeys": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SignedUrlKeys are the keys used to sign CDN URLs for this backend.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("k8s.io/ingress-gce/pkg/apis/backendconfig/v1.SignedUrlKey"),
+									},
+								},
+							},
+						},
+					},
 				},
 				Required: []string{"enabled"},
 			},
 		},
 		Dependencies: []string{
-			"k8s.io/ingress-gce/pkg/apis/backendconfig/v1.CacheKeyPolicy"},
+			"k8s.io/ingress-gce/pkg/apis/backendconfig/v1.CacheKeyPolicy", "k8s.io/ingress-gce/pkg/apis/backendconfig/v1.NegativeCachingPolicy", "k8s.io/ingress-gce/pkg/apis/backendconfig/v1.SignedUrlKey"},
 	}
 }
 
@@ -215,6 +383,61 @@ func schema_pkg_apis_backendconfig_v1_CacheKeyPolicy(ref common.ReferenceCallbac
 	}
 }
 
+func schema_pkg_apis_backendconfig_v1_CircuitBreakersConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "CircuitBreakersConfig contains configuration for limiting the volume of traffic sent to the backend service. Unset fields use the GCE defaults.",
+				Properties: map[string]spec.Schema{
+					"connectTimeout": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ConnectTimeout is the timeout for new connections to an endpoint.",
+							Ref:         ref("k8s.io/ingress-gce/pkg/apis/backendconfig/v1.Duration"),
+						},
+					},
+					"maxConnections": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MaxConnections is the maximum number of connections to the backend service.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"maxPendingRequests": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MaxPendingRequests is the maximum number of requests waiting for a connection to the backend service.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"maxRequests": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MaxRequests is the maximum number of parallel requests to the backend service.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"maxRequestsPerConnection": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MaxRequestsPerConnection is the maximum number of requests sent over a single connection. If unset, there is no limit.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"maxRetries": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MaxRetries is the maximum number of parallel retries to the backend service.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/ingress-gce/pkg/apis/backendconfig/v1.Duration"},
+	}
+}
+
 func schema_pkg_apis_backendconfig_v1_ConnectionDrainingConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -235,6 +458,150 @@ func schema_pkg_apis_backendconfig_v1_ConnectionDrainingConfig(ref common.Refere
 	}
 }
 
+func schema_pkg_apis_backendconfig_v1_CustomRequestHeadersConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "CustomRequestHeadersConfig contains configuration for custom request headers added by the load balancer before proxying requests to the backends.",
+				Properties: map[string]spec.Schema{
+					"headers": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Headers is a list of headers in the form \"Header-Name: value\". The value may reference variables such as {client_region}.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{},
+	}
+}
+
+func schema_pkg_apis_backendconfig_v1_CustomResponseHeadersConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "CustomResponseHeadersConfig contains configuration for custom response headers added by the load balancer before returning responses to clients.",
+				Properties: map[string]spec.Schema{
+					"headers": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Headers is a list of headers in the form \"Header-Name: value\".",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{},
+	}
+}
+
+func schema_pkg_apis_backendconfig_v1_Duration(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "Duration is a span of time with nanosecond resolution.",
+				Properties: map[string]spec.Schema{
+					"seconds": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Seconds is the number of seconds of the span of time, in [0, 315576000000].",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"nanos": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Nanos is the number of nanoseconds added to Seconds, in [0, 999999999].",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+				},
+				Required: []string{"seconds"},
+			},
+		},
+		Dependencies: []string{},
+	}
+}
+
+func schema_pkg_apis_backendconfig_v1_HealthCheckConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "HealthCheckConfig contains configuration for the health check. Settings specified here take precedence over those inferred from the readiness probe of the serving pods.",
+				Properties: map[string]spec.Schema{
+					"checkIntervalSec": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CheckIntervalSec is a health check parameter. See https://cloud.google.com/compute/docs/reference/rest/v1/healthChecks.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"timeoutSec": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TimeoutSec is a health check parameter. See https://cloud.google.com/compute/docs/reference/rest/v1/healthChecks.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"healthyThreshold": {
+						SchemaProps: spec.SchemaProps{
+							Description: "HealthyThreshold is a health check parameter. See https://cloud.google.com/compute/docs/reference/rest/v1/healthChecks.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"unhealthyThreshold": {
+						SchemaProps: spec.SchemaProps{
+							Description: "UnhealthyThreshold is a health check parameter. See https://cloud.google.com/compute/docs/reference/rest/v1/healthChecks.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"type": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Type is a health check parameter. One of HTTP, HTTPS or HTTP2.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"port": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Port is a health check parameter. If unset, the serving port of the backend is used.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"requestPath": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RequestPath is a health check parameter. See https://cloud.google.com/compute/docs/reference/rest/v1/healthChecks.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{},
+	}
+}
+
 func schema_pkg_apis_backendconfig_v1_IAPConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -261,6 +628,60 @@ func schema_pkg_apis_backendconfig_v1_IAPConfig(ref common.ReferenceCallback) co
 	}
 }
 
+func schema_pkg_apis_backendconfig_v1_LogConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "LogConfig contains configuration for logging requests served by the backend service.",
+				Properties: map[string]spec.Schema{
+					"enable": {
+						SchemaProps: spec.SchemaProps{
+							Description: "This field denotes whether to enable logging for the load balancer traffic served by this backend service.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"sampleRate": {
+						SchemaProps: spec.SchemaProps{
+							Description: "This field can only be specified if logging is enabled for this backend service. The value of the field must be in [0, 1]. This configures the sampling rate of requests to the load balancer where 1.0 means all logged requests are reported and 0.0 means no logged requests are reported. The default value is 1.0.",
+							Type:        []string{"number"},
+							Format:      "double",
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{},
+	}
+}
+
+func schema_pkg_apis_backendconfig_v1_NegativeCachingPolicy(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "NegativeCachingPolicy contains configuration for how negative caching is applied.",
+				Properties: map[string]spec.Schema{
+					"code": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The HTTP status code to define a TTL against. Only HTTP status codes 300, 301, 308, 404, 405, 410, 421, 451 and 501 can be specified as values, and you cannot specify a status code more than once.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"ttl": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The TTL (in seconds) for which to cache responses with the corresponding status code. The maximum allowed value is 1800s.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{},
+	}
+}
+
 func schema_pkg_apis_backendconfig_v1_OAuthClientCredentials(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -296,6 +717,95 @@ func schema_pkg_apis_backendconfig_v1_OAuthClientCredentials(ref common.Referenc
 	}
 }
 
+func schema_pkg_apis_backendconfig_v1_OutlierDetectionConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "OutlierDetectionConfig contains configuration for ejecting unhealthy endpoints from the load balancing pool based on the responses they serve. Unset fields use the GCE defaults.",
+				Properties: map[string]spec.Schema{
+					"baseEjectionTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "BaseEjectionTime is the base time that an endpoint is ejected for. The actual time is the base time multiplied by the number of times the endpoint has been ejected.",
+							Ref:         ref("k8s.io/ingress-gce/pkg/apis/backendconfig/v1.Duration"),
+						},
+					},
+					"consecutiveErrors": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ConsecutiveErrors is the number of consecutive errors before an endpoint is ejected.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"consecutiveGatewayFailure": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ConsecutiveGatewayFailure is the number of consecutive gateway failures (502, 503 or 504) before an endpoint is ejected.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"enforcingConsecutiveErrors": {
+						SchemaProps: spec.SchemaProps{
+							Description: "EnforcingConsecutiveErrors is the percentage chance, in [0, 100], that an endpoint is ejected when consecutive errors are detected.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"enforcingConsecutiveGatewayFailure": {
+						SchemaProps: spec.SchemaProps{
+							Description: "EnforcingConsecutiveGatewayFailure is the percentage chance, in [0, 100], that an endpoint is ejected when consecutive gateway failures are detected.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"enforcingSuccessRate": {
+						SchemaProps: spec.SchemaProps{
+							Description: "EnforcingSuccessRate is the percentage chance, in [0, 100], that an endpoint is ejected when an outlier is detected via success rate statistics.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"interval": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Interval is the time between ejection analysis sweeps.",
+							Ref:         ref("k8s.io/ingress-gce/pkg/apis/backendconfig/v1.Duration"),
+						},
+					},
+					"maxEjectionPercent": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MaxEjectionPercent is the maximum percentage, in [0, 100], of endpoints that can be ejected.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"successRateMinimumHosts": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SuccessRateMinimumHosts is the number of endpoints which must have enough request volume for success rate outlier detection to run.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"successRateRequestVolume": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SuccessRateRequestVolume is the minimum number of requests in an interval for an endpoint to be included in success rate outlier detection.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"successRateStdevFactor": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SuccessRateStdevFactor determines the ejection threshold for success rate outlier detection. The threshold is the mean success rate minus the standard deviation multiplied by this factor divided by 1000.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/ingress-gce/pkg/apis/backendconfig/v1.Duration"},
+	}
+}
+
 func schema_pkg_apis_backendconfig_v1_SessionAffinityConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -320,3 +830,38 @@ func schema_pkg_apis_backendconfig_v1_SessionAffinityConfig(ref common.Reference
 		Dependencies: []string{},
 	}
 }
+
+func schema_pkg_apis_backendconfig_v1_SignedUrlKey(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "SignedUrlKey represents a customer-supplied signing key used by Cloud CDN signed URLs.",
+				Properties: map[string]spec.Schema{
+					"keyName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "KeyName is the name of the key. It must comply with RFC1035.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"keyValue": {
+						SchemaProps: spec.SchemaProps{
+							Description: "KeyValue is a 128-bit secret key value encoded with base64url.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"secretName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The name of a k8s secret which stores the 128-bit secret key value encoded with base64url.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"keyName"},
+			},
+		},
+		Dependencies: []string{},
+	}
+}
@@ -17,11 +17,11 @@ limitations under the License.
 package v1beta1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // +genclient
-// +genclient:noStatus
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
 // +k8s:openapi-gen=true
@@ -36,16 +36,76 @@ type BackendConfig struct {
 // BackendConfigSpec is the spec for a BackendConfig resource
 // +k8s:openapi-gen=true
 type BackendConfigSpec struct {
-	Iap                *IAPConfig                `json:"iap,omitempty"`
-	Cdn                *CDNConfig                `json:"cdn,omitempty"`
-	SecurityPolicy     *SecurityPolicyConfig     `json:"securityPolicy,omitempty"`
-	TimeoutSec         *int64                    `json:"timeoutSec,omitempty"`
-	ConnectionDraining *ConnectionDrainingConfig `json:"connectionDraining,omitempty"`
-	SessionAffinity    *SessionAffinityConfig    `json:"sessionAffinity,omitempty"`
+	Iap                   *IAPConfig                   `json:"iap,omitempty"`
+	Cdn                   *CDNConfig                   `json:"cdn,omitempty"`
+	SecurityPolicy        *SecurityPolicyConfig        `json:"securityPolicy,omitempty"`
+	TimeoutSec            *int64                       `json:"timeoutSec,omitempty"`
+	ConnectionDraining    *ConnectionDrainingConfig    `json:"connectionDraining,omitempty"`
+	SessionAffinity       *SessionAffinityConfig       `json:"sessionAffinity,omitempty"`
+	HealthCheck           *HealthCheckConfig           `json:"healthCheck,omitempty"`
+	CustomRequestHeaders  *CustomRequestHeadersConfig  `json:"customRequestHeaders,omitempty"`
+	CustomResponseHeaders *CustomResponseHeadersConfig `json:"customResponseHeaders,omitempty"`
+	Logging               *LogConfig                   `json:"logging,omitempty"`
+	Balancing             *BalancingConfig             `json:"balancing,omitempty"`
+	OutlierDetection      *OutlierDetectionConfig      `json:"outlierDetection,omitempty"`
+	CircuitBreakers       *CircuitBreakersConfig       `json:"circuitBreakers,omitempty"`
+	// LocalityLbPolicy is the load balancing algorithm used within the scope
+	// of a locality. One of ROUND_ROBIN, LEAST_REQUEST, RING_HASH, RANDOM,
+	// ORIGINAL_DESTINATION or MAGLEV.
+	LocalityLbPolicy *string `json:"localityLbPolicy,omitempty"`
 }
 
 // BackendConfigStatus is the status for a BackendConfig resource
 type BackendConfigStatus struct {
+	// ObservedGeneration is the most recent generation of the BackendConfig
+	// that was applied to the backend services.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Backends lists the Service ports that use the BackendConfig and the
+	// backend services it was applied to.
+	Backends []BackendStatus `json:"backends,omitempty"`
+	// Conditions describe the current state of the BackendConfig.
+	Conditions []BackendConfigCondition `json:"conditions,omitempty"`
+}
+
+// BackendStatus describes the result of applying a BackendConfig to the
+// backend service of a single Service port.
+type BackendStatus struct {
+	// ServiceName is the name of the Service referencing the BackendConfig.
+	ServiceName string `json:"serviceName"`
+	// ServicePort is the name or number of the Service port.
+	ServicePort string `json:"servicePort"`
+	// BackendServiceName is the name of the GCE backend service.
+	BackendServiceName string `json:"backendServiceName"`
+	// Error is the error returned by GCE while applying the BackendConfig
+	// to the backend service, if any.
+	Error string `json:"error,omitempty"`
+}
+
+// BackendConfigConditionType is a valid value for BackendConfigCondition.Type.
+type BackendConfigConditionType string
+
+const (
+	// BackendConfigReady means the BackendConfig was applied to all backend
+	// services that use it.
+	BackendConfigReady BackendConfigConditionType = "Ready"
+	// BackendConfigError means the BackendConfig could not be applied to at
+	// least one backend service.
+	BackendConfigError BackendConfigConditionType = "Error"
+)
+
+// BackendConfigCondition describes the state of a BackendConfig at a
+// certain point.
+type BackendConfigCondition struct {
+	// Type of the condition.
+	Type BackendConfigConditionType `json:"type"`
+	// Status of the condition, one of True, False, Unknown.
+	Status corev1.ConditionStatus `json:"status"`
+	// Last time the condition transitioned from one status to another.
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+	// Unique, one-word, CamelCase reason for the condition's last transition.
+	Reason string `json:"reason,omitempty"`
+	// Human-readable message indicating details about last transition.
+	Message string `json:"message,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -81,6 +141,56 @@ type OAuthClientCredentials struct {
 type CDNConfig struct {
 	Enabled     bool            `json:"enabled"`
 	CachePolicy *CacheKeyPolicy `json:"cachePolicy,omitempty"`
+	// CacheMode specifies the cache setting for all responses from this
+	// backend. One of USE_ORIGIN_HEADERS, FORCE_CACHE_ALL or CACHE_ALL_STATIC.
+	CacheMode *string `json:"cacheMode,omitempty"`
+	// ClientTtl specifies the maximum allowed TTL for cached content served
+	// by this origin, in seconds.
+	ClientTtl *int64 `json:"clientTtl,omitempty"`
+	// DefaultTtl specifies the default TTL for cached content served by this
+	// origin for responses that do not have an existing valid TTL, in seconds.
+	DefaultTtl *int64 `json:"defaultTtl,omitempty"`
+	// MaxTtl specifies the maximum allowed TTL for cached content served by
+	// this origin, in seconds.
+	MaxTtl *int64 `json:"maxTtl,omitempty"`
+	// NegativeCaching enables negative caching, which allows per-status code
+	// TTLs to be set in order to apply fine-grained caching for common
+	// errors or redirects.
+	NegativeCaching *bool `json:"negativeCaching,omitempty"`
+	// NegativeCachingPolicy sets a cache TTL for the specified HTTP status
+	// codes. NegativeCaching must be enabled to configure it.
+	NegativeCachingPolicy []*NegativeCachingPolicy `json:"negativeCachingPolicy,omitempty"`
+	// SignedUrlCacheMaxAgeSec is the maximum number of seconds the response
+	// to a signed URL request will be considered fresh.
+	SignedUrlCacheMaxAgeSec *int64 `json:"signedUrlCacheMaxAgeSec,omitempty"`
+	// SignedUrlKeys are the keys used to sign CDN URLs for this backend.
+	SignedUrlKeys []*SignedUrlKey `json:"signedUrlKeys,omitempty"`
+}
+
+// NegativeCachingPolicy contains configuration for how negative caching is
+// applied.
+// +k8s:openapi-gen=true
+type NegativeCachingPolicy struct {
+	// The HTTP status code to define a TTL against. Only HTTP status codes
+	// 300, 301, 308, 404, 405, 410, 421, 451 and 501 can be specified as
+	// values, and you cannot specify a status code more than once.
+	Code int64 `json:"code,omitempty"`
+	// The TTL (in seconds) for which to cache responses with the
+	// corresponding status code. The maximum allowed value is 1800s.
+	Ttl int64 `json:"ttl,omitempty"`
+}
+
+// SignedUrlKey represents a customer-supplied signing key used by Cloud
+// CDN signed URLs.
+// +k8s:openapi-gen=true
+type SignedUrlKey struct {
+	// KeyName is the name of the key. It must comply with RFC1035.
+	KeyName string `json:"keyName"`
+	// KeyValue is a 128-bit secret key value encoded with base64url.
+	KeyValue string `json:"keyValue,omitempty"`
+	// The name of a k8s secret which stores the 128-bit secret key value
+	// encoded with base64url.
+	SecretName string `json:"secretName,omitempty"`
 }
 
 // CacheKeyPolicy contains configuration for how requests to a CDN-enabled backend are cached.
@@ -125,3 +235,163 @@ type SessionAffinityConfig struct {
 	AffinityType         string `json:"affinityType,omitempty"`
 	AffinityCookieTtlSec *int64 `json:"affinityCookieTtlSec,omitempty"`
 }
+
+// HealthCheckConfig contains configuration for the health check.
+// Settings specified here take precedence over those inferred from
+// the readiness probe of the serving pods.
+// +k8s:openapi-gen=true
+type HealthCheckConfig struct {
+	// CheckIntervalSec is a health check parameter. See
+	// https://cloud.google.com/compute/docs/reference/rest/v1/healthChecks.
+	CheckIntervalSec *int64 `json:"checkIntervalSec,omitempty"`
+	// TimeoutSec is a health check parameter. See
+	// https://cloud.google.com/compute/docs/reference/rest/v1/healthChecks.
+	TimeoutSec *int64 `json:"timeoutSec,omitempty"`
+	// HealthyThreshold is a health check parameter. See
+	// https://cloud.google.com/compute/docs/reference/rest/v1/healthChecks.
+	HealthyThreshold *int64 `json:"healthyThreshold,omitempty"`
+	// UnhealthyThreshold is a health check parameter. See
+	// https://cloud.google.com/compute/docs/reference/rest/v1/healthChecks.
+	UnhealthyThreshold *int64 `json:"unhealthyThreshold,omitempty"`
+	// Type is a health check parameter. One of HTTP, HTTPS or HTTP2.
+	Type *string `json:"type,omitempty"`
+	// Port is a health check parameter. If unset, the serving port of the
+	// backend is used.
+	Port *int64 `json:"port,omitempty"`
+	// RequestPath is a health check parameter. See
+	// https://cloud.google.com/compute/docs/reference/rest/v1/healthChecks.
+	RequestPath *string `json:"requestPath,omitempty"`
+}
+
+// CustomRequestHeadersConfig contains configuration for custom request headers
+// added by the load balancer before proxying requests to the backends.
+// +k8s:openapi-gen=true
+type CustomRequestHeadersConfig struct {
+	// Headers is a list of headers in the form "Header-Name: value". The
+	// value may reference variables such as {client_region}.
+	Headers []string `json:"headers,omitempty"`
+}
+
+// CustomResponseHeadersConfig contains configuration for custom response
+// headers added by the load balancer before returning responses to clients.
+// +k8s:openapi-gen=true
+type CustomResponseHeadersConfig struct {
+	// Headers is a list of headers in the form "Header-Name: value".
+	Headers []string `json:"headers,omitempty"`
+}
+
+// LogConfig contains configuration for logging requests served by the
+// backend service.
+// +k8s:openapi-gen=true
+type LogConfig struct {
+	// This field denotes whether to enable logging for the load balancer
+	// traffic served by this backend service.
+	Enable bool `json:"enable,omitempty"`
+	// This field can only be specified if logging is enabled for this
+	// backend service. The value of the field must be in [0, 1]. This
+	// configures the sampling rate of requests to the load balancer where
+	// 1.0 means all logged requests are reported and 0.0 means no logged
+	// requests are reported. The default value is 1.0.
+	SampleRate *float64 `json:"sampleRate,omitempty"`
+}
+
+// BalancingConfig contains configuration for how traffic is distributed
+// across the backends of the backend service.
+// +k8s:openapi-gen=true
+type BalancingConfig struct {
+	// Mode is the balancing mode of the backends. One of RATE or
+	// UTILIZATION. Network endpoint groups do not support UTILIZATION.
+	// Defaults to RATE.
+	Mode *string `json:"mode,omitempty"`
+	// MaxRatePerInstance is the maximum number of requests per second for
+	// each instance of an instance group backend. Only valid with RATE.
+	MaxRatePerInstance *float64 `json:"maxRatePerInstance,omitempty"`
+	// MaxRatePerEndpoint is the maximum number of requests per second for
+	// each endpoint of a network endpoint group backend. Only valid with
+	// RATE.
+	MaxRatePerEndpoint *float64 `json:"maxRatePerEndpoint,omitempty"`
+	// MaxUtilization is the target CPU utilization of the instances of an
+	// instance group backend, in [0, 1]. Only valid with UTILIZATION.
+	MaxUtilization *float64 `json:"maxUtilization,omitempty"`
+	// CapacityScaler is a multiplier applied to the capacity of each
+	// backend, in [0, 1]. Defaults to 1.
+	CapacityScaler *float64 `json:"capacityScaler,omitempty"`
+}
+
+// OutlierDetectionConfig contains configuration for ejecting unhealthy
+// endpoints from the load balancing pool based on the responses they serve.
+// Unset fields use the GCE defaults.
+// +k8s:openapi-gen=true
+type OutlierDetectionConfig struct {
+	// BaseEjectionTime is the base time that an endpoint is ejected for. The
+	// actual time is the base time multiplied by the number of times the
+	// endpoint has been ejected.
+	BaseEjectionTime *Duration `json:"baseEjectionTime,omitempty"`
+	// ConsecutiveErrors is the number of consecutive errors before an
+	// endpoint is ejected.
+	ConsecutiveErrors *int64 `json:"consecutiveErrors,omitempty"`
+	// ConsecutiveGatewayFailure is the number of consecutive gateway
+	// failures (502, 503 or 504) before an endpoint is ejected.
+	ConsecutiveGatewayFailure *int64 `json:"consecutiveGatewayFailure,omitempty"`
+	// EnforcingConsecutiveErrors is the percentage chance, in [0, 100], that
+	// an endpoint is ejected when consecutive errors are detected.
+	EnforcingConsecutiveErrors *int64 `json:"enforcingConsecutiveErrors,omitempty"`
+	// EnforcingConsecutiveGatewayFailure is the percentage chance, in
+	// [0, 100], that an endpoint is ejected when consecutive gateway failures
+	// are detected.
+	EnforcingConsecutiveGatewayFailure *int64 `json:"enforcingConsecutiveGatewayFailure,omitempty"`
+	// EnforcingSuccessRate is the percentage chance, in [0, 100], that an
+	// endpoint is ejected when an outlier is detected via success rate
+	// statistics.
+	EnforcingSuccessRate *int64 `json:"enforcingSuccessRate,omitempty"`
+	// Interval is the time between ejection analysis sweeps.
+	Interval *Duration `json:"interval,omitempty"`
+	// MaxEjectionPercent is the maximum percentage, in [0, 100], of endpoints
+	// that can be ejected.
+	MaxEjectionPercent *int64 `json:"maxEjectionPercent,omitempty"`
+	// SuccessRateMinimumHosts is the number of endpoints which must have
+	// enough request volume for success rate outlier detection to run.
+	SuccessRateMinimumHosts *int64 `json:"successRateMinimumHosts,omitempty"`
+	// SuccessRateRequestVolume is the minimum number of requests in an
+	// interval for an endpoint to be included in success rate outlier
+	// detection.
+	SuccessRateRequestVolume *int64 `json:"successRateRequestVolume,omitempty"`
+	// SuccessRateStdevFactor determines the ejection threshold for success
+	// rate outlier detection. The threshold is the mean success rate minus
+	// the standard deviation multiplied by this factor divided by 1000.
+	SuccessRateStdevFactor *int64 `json:"successRateStdevFactor,omitempty"`
+}
+
+// CircuitBreakersConfig contains configuration for limiting the volume of
+// traffic sent to the backend service. Unset fields use the GCE defaults.
+// +k8s:openapi-gen=true
+type CircuitBreakersConfig struct {
+	// ConnectTimeout is the timeout for new connections to an endpoint.
+	ConnectTimeout *Duration `json:"connectTimeout,omitempty"`
+	// MaxConnections is the maximum number of connections to the backend
+	// service.
+	MaxConnections *int64 `json:"maxConnections,omitempty"`
+	// MaxPendingRequests is the maximum number of requests waiting for a
+	// connection to the backend service.
+	MaxPendingRequests *int64 `json:"maxPendingRequests,omitempty"`
+	// MaxRequests is the maximum number of parallel requests to the backend
+	// service.
+	MaxRequests *int64 `json:"maxRequests,omitempty"`
+	// MaxRequestsPerConnection is the maximum number of requests sent over a
+	// single connection. If unset, there is no limit.
+	MaxRequestsPerConnection *int64 `json:"maxRequestsPerConnection,omitempty"`
+	// MaxRetries is the maximum number of parallel retries to the backend
+	// service.
+	MaxRetries *int64 `json:"maxRetries,omitempty"`
+}
+
+// Duration is a span of time with nanosecond resolution.
+// +k8s:openapi-gen=true
+type Duration struct {
+	// Seconds is the number of seconds of the span of time, in
+	// [0, 315576000000].
+	Seconds int64 `json:"seconds"`
+	// Nanos is the number of nanoseconds added to Seconds, in
+	// [0, 999999999].
+	Nanos int64 `json:"nanos,omitempty"`
+}
@@ -25,7 +25,7 @@ import (
 
 	"k8s.io/ingress-gce/pkg/annotations"
 	apisbackendconfig "k8s.io/ingress-gce/pkg/apis/backendconfig"
-	backendconfigv1beta1 "k8s.io/ingress-gce/pkg/apis/backendconfig/v1beta1"
+	backendconfigv1 "k8s.io/ingress-gce/pkg/apis/backendconfig/v1"
 	"k8s.io/ingress-gce/pkg/crd"
 )
 
@@ -35,22 +35,27 @@ var (
 	ErrNoBackendConfigForPort    = errors.New("no BackendConfig name found for service port.")
 )
 
+// CRDMeta returns the metadata of the BackendConfig CRD. BackendConfigs are
+// stored as v1, which is also the version consumed by the controller, and
+// are still served as v1beta1.
 func CRDMeta() *crd.CRDMeta {
 	meta := crd.NewCRDMeta(
 		apisbackendconfig.GroupName,
-		"v1beta1",
+		"v1",
 		"BackendConfig",
 		"BackendConfigList",
 		"backendconfig",
 		"backendconfigs",
 	)
-	meta.AddValidationInfo("k8s.io/ingress-gce/pkg/apis/backendconfig/v1beta1.BackendConfig", backendconfigv1beta1.GetOpenAPIDefinitions)
+	meta.AddServedVersion("v1beta1")
+	meta.AddValidationInfo("k8s.io/ingress-gce/pkg/apis/backendconfig/v1.BackendConfig", backendconfigv1.GetOpenAPIDefinitions)
+	meta.EnableStatusSubresource()
 	return meta
 }
 
 // GetBackendConfigForServicePort returns the corresponding BackendConfig for
 // the given ServicePort if specified.
-func GetBackendConfigForServicePort(backendConfigLister cache.Store, svc *apiv1.Service, svcPort *apiv1.ServicePort) (*backendconfigv1beta1.BackendConfig, error) {
+func GetBackendConfigForServicePort(backendConfigLister cache.Store, svc *apiv1.Service, svcPort *apiv1.ServicePort) (*backendconfigv1.BackendConfig, error) {
 	backendConfigs, err := annotations.FromService(svc).GetBackendConfigs()
 	if err != nil {
 		// If the user did not provide the annotation at all, then we
@@ -67,7 +72,7 @@ func GetBackendConfigForServicePort(backendConfigLister cache.Store, svc *apiv1.
 	}
 
 	obj, exists, err := backendConfigLister.Get(
-		&backendconfigv1beta1.BackendConfig{
+		&backendconfigv1.BackendConfig{
 			ObjectMeta: metav1.ObjectMeta{
 				Name:      configName,
 				Namespace: svc.Namespace,
@@ -80,5 +85,5 @@ func GetBackendConfigForServicePort(backendConfigLister cache.Store, svc *apiv1.
 		return nil, ErrBackendConfigDoesNotExist
 	}
 
-	return obj.(*backendconfigv1beta1.BackendConfig), nil
+	return obj.(*backendconfigv1.BackendConfig), nil
 }
@@ -100,6 +100,18 @@ func (c *FakeBackendConfigs) Update(backendConfig *backendconfigv1.BackendConfig
 	return obj.(*backendconfigv1.BackendConfig), err
 }
 
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeBackendConfigs) UpdateStatus(backendConfig *backendconfigv1.BackendConfig) (*backendconfigv1.BackendConfig, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceAction(backendconfigsResource, "status", c.ns, backendConfig), &backendconfigv1.BackendConfig{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*backendconfigv1.BackendConfig), err
+}
+
 // Delete takes name of the backendConfig and deletes it. Returns an error if one occurs.
 func (c *FakeBackendConfigs) Delete(name string, options *v1.DeleteOptions) error {
 	_, err := c.Fake.
@@ -39,6 +39,7 @@ type BackendConfigsGetter interface {
 type BackendConfigInterface interface {
 	Create(*v1beta1.BackendConfig) (*v1beta1.BackendConfig, error)
 	Update(*v1beta1.BackendConfig) (*v1beta1.BackendConfig, error)
+	UpdateStatus(*v1beta1.BackendConfig) (*v1beta1.BackendConfig, error)
 	Delete(name string, options *v1.DeleteOptions) error
 	DeleteCollection(options *v1.DeleteOptions, listOptions v1.ListOptions) error
 	Get(name string, options v1.GetOptions) (*v1beta1.BackendConfig, error)
@@ -132,6 +133,22 @@ func (c *backendConfigs) Update(backendConfig *v1beta1.BackendConfig) (result *v
 	return
 }
 
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+
+func (c *backendConfigs) UpdateStatus(backendConfig *v1beta1.BackendConfig) (result *v1beta1.BackendConfig, err error) {
+	result = &v1beta1.BackendConfig{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("backendconfigs").
+		Name(backendConfig.Name).
+		SubResource("status").
+		Body(backendConfig).
+		Do().
+		Into(result)
+	return
+}
+
 // Delete takes name of the backendConfig and deletes it. Returns an error if one occurs.
 func (c *backendConfigs) Delete(name string, options *v1.DeleteOptions) error {
 	return c.client.Delete().
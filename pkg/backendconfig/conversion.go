@@ -0,0 +1,65 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backendconfig
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	apisbackendconfig "k8s.io/ingress-gce/pkg/apis/backendconfig"
+	backendconfigv1 "k8s.io/ingress-gce/pkg/apis/backendconfig/v1"
+)
+
+// supportedVersions are the versions a BackendConfig can be converted between.
+var supportedVersions = map[string]bool{
+	"v1":      true,
+	"v1beta1": true,
+}
+
+// Convert converts a JSON encoded BackendConfig to desiredAPIVersion, which
+// is of the form "cloud.google.com/v1".
+func Convert(data []byte, desiredAPIVersion string) ([]byte, error) {
+	desired, err := schema.ParseGroupVersion(desiredAPIVersion)
+	if err != nil {
+		return nil, err
+	}
+	if desired.Group != apisbackendconfig.GroupName || !supportedVersions[desired.Version] {
+		return nil, fmt.Errorf("unsupported BackendConfig version %q", desiredAPIVersion)
+	}
+
+	// v1 and v1beta1 share the same schema, so the object is converted by
+	// decoding it into the v1 type and changing its apiVersion.
+	beConfig := &backendconfigv1.BackendConfig{}
+	if err := json.Unmarshal(data, beConfig); err != nil {
+		return nil, fmt.Errorf("error decoding BackendConfig: %v", err)
+	}
+	current, err := schema.ParseGroupVersion(beConfig.APIVersion)
+	if err != nil {
+		return nil, err
+	}
+	if current.Group != apisbackendconfig.GroupName || !supportedVersions[current.Version] || beConfig.Kind != "BackendConfig" {
+		return nil, fmt.Errorf("unsupported object %v, %v", beConfig.APIVersion, beConfig.Kind)
+	}
+	if current == desired {
+		return data, nil
+	}
+
+	beConfig.APIVersion = desiredAPIVersion
+	return json.Marshal(beConfig)
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backendconfig
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	backendconfigv1 "k8s.io/ingress-gce/pkg/apis/backendconfig/v1"
+)
+
+func TestConvert(t *testing.T) {
+	v1beta1Config := `{"apiVersion": "cloud.google.com/v1beta1", "kind": "BackendConfig", "metadata": {"name": "config", "namespace": "default"}, "spec": {"timeoutSec": 40, "cdn": {"enabled": true, "cachePolicy": {"includeHost": true}}}}`
+
+	testCases := []struct {
+		desc              string
+		data              string
+		desiredAPIVersion string
+		expectError       bool
+		expectAPIVersion  string
+	}{
+		{
+			desc:              "v1beta1 to v1",
+			data:              v1beta1Config,
+			desiredAPIVersion: "cloud.google.com/v1",
+			expectAPIVersion:  "cloud.google.com/v1",
+		},
+		{
+			desc:              "v1beta1 to v1beta1",
+			data:              v1beta1Config,
+			desiredAPIVersion: "cloud.google.com/v1beta1",
+			expectAPIVersion:  "cloud.google.com/v1beta1",
+		},
+		{
+			desc:              "v1 to v1beta1",
+			data:              `{"apiVersion": "cloud.google.com/v1", "kind": "BackendConfig", "metadata": {"name": "config", "namespace": "default"}, "spec": {"timeoutSec": 40, "cdn": {"enabled": true, "cachePolicy": {"includeHost": true}}}}`,
+			desiredAPIVersion: "cloud.google.com/v1beta1",
+			expectAPIVersion:  "cloud.google.com/v1beta1",
+		},
+		{
+			desc:              "unsupported desired version",
+			data:              v1beta1Config,
+			desiredAPIVersion: "cloud.google.com/v2",
+			expectError:       true,
+		},
+		{
+			desc:              "unsupported desired group",
+			data:              v1beta1Config,
+			desiredAPIVersion: "networking.gke.io/v1",
+			expectError:       true,
+		},
+		{
+			desc:              "not a BackendConfig",
+			data:              `{"apiVersion": "v1", "kind": "Service", "metadata": {"name": "svc"}}`,
+			desiredAPIVersion: "cloud.google.com/v1",
+			expectError:       true,
+		},
+		{
+			desc:              "malformed object",
+			data:              `{"apiVersion":`,
+			desiredAPIVersion: "cloud.google.com/v1",
+			expectError:       true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			data, err := Convert([]byte(tc.data), tc.desiredAPIVersion)
+			if tc.expectError {
+				if err == nil {
+					t.Errorf("Convert() = %s, want error", data)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Convert() = %v", err)
+			}
+
+			converted := &backendconfigv1.BackendConfig{}
+			if err := json.Unmarshal(data, converted); err != nil {
+				t.Fatalf("Error decoding converted object %s: %v", data, err)
+			}
+			original := &backendconfigv1.BackendConfig{}
+			if err := json.Unmarshal([]byte(tc.data), original); err != nil {
+				t.Fatalf("Error decoding original object: %v", err)
+			}
+			if converted.APIVersion != tc.expectAPIVersion {
+				t.Errorf("Got apiVersion %q, want %q", converted.APIVersion, tc.expectAPIVersion)
+			}
+			if !reflect.DeepEqual(converted.ObjectMeta, original.ObjectMeta) || !reflect.DeepEqual(converted.Spec, original.Spec) {
+				t.Errorf("Converted object %+v differs from original %+v", converted, original)
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backendconfig
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/klog"
+
+	backendconfigclient "k8s.io/ingress-gce/pkg/backendconfig/client/clientset/versioned"
+)
+
+// RewriteAll rewrites every BackendConfig without changing it, so that the
+// API server stores each of them in the storage version of the CRD. It is
+// meant to be passed to crd.CRDHandler.MigrateStorage.
+func RewriteAll(client backendconfigclient.Interface) error {
+	list, err := client.CloudV1().BackendConfigs(metav1.NamespaceAll).List(metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("error listing BackendConfigs: %v", err)
+	}
+
+	var failed []string
+	for _, item := range list.Items {
+		namespace, name := item.Namespace, item.Name
+		err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+			beConfig, err := client.CloudV1().BackendConfigs(namespace).Get(name, metav1.GetOptions{})
+			if err != nil {
+				return err
+			}
+			_, err = client.CloudV1().BackendConfigs(namespace).Update(beConfig)
+			return err
+		})
+		// BackendConfigs deleted in the meantime need no migration.
+		if err != nil && !errors.IsNotFound(err) {
+			klog.Errorf("Error rewriting BackendConfig %v/%v: %v", namespace, name, err)
+			failed = append(failed, fmt.Sprintf("%v/%v", namespace, name))
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("failed to rewrite BackendConfigs %v", failed)
+	}
+	klog.V(2).Infof("Rewrote %d BackendConfigs.", len(list.Items))
+	return nil
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backendconfig
+
+import (
+	"fmt"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	core "k8s.io/client-go/testing"
+
+	backendconfigv1 "k8s.io/ingress-gce/pkg/apis/backendconfig/v1"
+	"k8s.io/ingress-gce/pkg/backendconfig/client/clientset/versioned/fake"
+)
+
+func TestRewriteAll(t *testing.T) {
+	newConfig := func(namespace, name string) *backendconfigv1.BackendConfig {
+		return &backendconfigv1.BackendConfig{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name}}
+	}
+
+	testCases := []struct {
+		desc          string
+		failName      string
+		expectError   bool
+		expectUpdated []string
+	}{
+		{
+			desc:          "all BackendConfigs are rewritten",
+			expectUpdated: []string{"default/a", "default/b", "other/c"},
+		},
+		{
+			desc:          "failed rewrites are reported",
+			failName:      "b",
+			expectError:   true,
+			expectUpdated: []string{"default/a", "other/c"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			client := fake.NewSimpleClientset(newConfig("default", "a"), newConfig("default", "b"), newConfig("other", "c"))
+			var updated []string
+			client.PrependReactor("update", "backendconfigs", func(action core.Action) (bool, runtime.Object, error) {
+				obj := action.(core.UpdateAction).GetObject().(*backendconfigv1.BackendConfig)
+				if obj.Name == tc.failName {
+					return true, nil, fmt.Errorf("update failed")
+				}
+				updated = append(updated, fmt.Sprintf("%v/%v", obj.Namespace, obj.Name))
+				return false, nil, nil
+			})
+
+			err := RewriteAll(client)
+			if tc.expectError != (err != nil) {
+				t.Errorf("RewriteAll() = %v, want error = %v", err, tc.expectError)
+			}
+			if fmt.Sprint(updated) != fmt.Sprint(tc.expectUpdated) {
+				t.Errorf("Got updated BackendConfigs %v, want %v", updated, tc.expectUpdated)
+			}
+		})
+	}
+}
@@ -0,0 +1,226 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backendconfig
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/klog"
+
+	backendconfigv1 "k8s.io/ingress-gce/pkg/apis/backendconfig/v1"
+	backendconfigclient "k8s.io/ingress-gce/pkg/backendconfig/client/clientset/versioned"
+	"k8s.io/ingress-gce/pkg/utils"
+)
+
+const (
+	// reasonBackendsSynced is the condition reason used when the
+	// BackendConfig was applied to all of its backend services.
+	reasonBackendsSynced = "BackendsSynced"
+	// reasonSyncFailed is the condition reason used when the BackendConfig
+	// could not be applied to at least one backend service.
+	reasonSyncFailed = "SyncFailed"
+)
+
+// StatusRecorder records the result of applying BackendConfigs to backend
+// services in the status of the BackendConfigs.
+type StatusRecorder struct {
+	client backendconfigclient.Interface
+	store  cache.Store
+}
+
+// NewStatusRecorder returns a StatusRecorder which reads BackendConfigs from
+// the given store and writes their status through the given client.
+func NewStatusRecorder(client backendconfigclient.Interface, store cache.Store) *StatusRecorder {
+	return &StatusRecorder{client: client, store: store}
+}
+
+// Record records the result of syncing the backend service named beName for
+// the given ServicePort in the status of the BackendConfig it references.
+func (r *StatusRecorder) Record(sp utils.ServicePort, beName string, syncErr error) error {
+	if sp.BackendConfig == nil {
+		return nil
+	}
+	entry := backendconfigv1.BackendStatus{
+		ServiceName:        sp.ID.Service.Name,
+		ServicePort:        sp.ID.Port.String(),
+		BackendServiceName: beName,
+	}
+	if syncErr != nil {
+		entry.Error = syncErr.Error()
+	}
+	return r.updateStatus(sp.BackendConfig.Namespace, sp.BackendConfig.Name, func(beConfig *backendconfigv1.BackendConfig) {
+		beConfig.Status.ObservedGeneration = beConfig.Generation
+		setBackendStatus(&beConfig.Status, entry)
+		updateConditions(&beConfig.Status, metav1.Now())
+	})
+}
+
+// Prune removes status entries for Service ports which are not in svcPorts
+// or no longer reference the BackendConfig.
+func (r *StatusRecorder) Prune(svcPorts []utils.ServicePort) error {
+	inUse := sets.NewString()
+	for _, sp := range svcPorts {
+		if sp.BackendConfig != nil {
+			inUse.Insert(backendStatusKey(sp.BackendConfig.Namespace, sp.BackendConfig.Name, sp.ID.Service.Name, sp.ID.Port.String()))
+		}
+	}
+
+	var errs []string
+	for _, obj := range r.store.List() {
+		beConfig := obj.(*backendconfigv1.BackendConfig)
+		if len(beConfig.Status.Backends) == 0 {
+			continue
+		}
+		err := r.updateStatus(beConfig.Namespace, beConfig.Name, func(beConfig *backendconfigv1.BackendConfig) {
+			removeBackendStatuses(&beConfig.Status, func(bs backendconfigv1.BackendStatus) bool {
+				return !inUse.Has(backendStatusKey(beConfig.Namespace, beConfig.Name, bs.ServiceName, bs.ServicePort))
+			})
+			updateConditions(&beConfig.Status, metav1.Now())
+		})
+		if err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to prune BackendConfig status: %s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// updateStatus applies mutate to the BackendConfig with the given namespace
+// and name and writes back its status if it changed. The first attempt uses
+// the BackendConfig from the store, retries on conflict get the latest
+// version from the API server.
+func (r *StatusRecorder) updateStatus(namespace, name string, mutate func(*backendconfigv1.BackendConfig)) error {
+	fromStore := true
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		var beConfig *backendconfigv1.BackendConfig
+		if fromStore {
+			fromStore = false
+			obj, exists, err := r.store.GetByKey(namespace + "/" + name)
+			if err != nil {
+				return err
+			}
+			if !exists {
+				// The BackendConfig was deleted, there is nothing to record.
+				return nil
+			}
+			beConfig = obj.(*backendconfigv1.BackendConfig)
+		} else {
+			var err error
+			beConfig, err = r.client.CloudV1().BackendConfigs(namespace).Get(name, metav1.GetOptions{})
+			if err != nil {
+				return err
+			}
+		}
+
+		updated := beConfig.DeepCopy()
+		mutate(updated)
+		if reflect.DeepEqual(beConfig.Status, updated.Status) {
+			return nil
+		}
+		klog.V(3).Infof("Updating status of BackendConfig %s/%s", namespace, name)
+		_, err := r.client.CloudV1().BackendConfigs(namespace).UpdateStatus(updated)
+		return err
+	})
+}
+
+func backendStatusKey(namespace, name, svcName, svcPort string) string {
+	return fmt.Sprintf("%s/%s/%s/%s", namespace, name, svcName, svcPort)
+}
+
+// setBackendStatus adds or replaces the entry for the Service port of entry.
+func setBackendStatus(status *backendconfigv1.BackendConfigStatus, entry backendconfigv1.BackendStatus) {
+	for i, bs := range status.Backends {
+		if bs.ServiceName == entry.ServiceName && bs.ServicePort == entry.ServicePort {
+			status.Backends[i] = entry
+			return
+		}
+	}
+	status.Backends = append(status.Backends, entry)
+}
+
+// removeBackendStatuses removes all entries for which remove returns true.
+func removeBackendStatuses(status *backendconfigv1.BackendConfigStatus, remove func(backendconfigv1.BackendStatus) bool) {
+	var backends []backendconfigv1.BackendStatus
+	for _, bs := range status.Backends {
+		if !remove(bs) {
+			backends = append(backends, bs)
+		}
+	}
+	status.Backends = backends
+}
+
+// updateConditions recomputes the Ready and Error conditions from the backend
+// entries in status. The transition time of a condition is only changed when
+// its status changes.
+func updateConditions(status *backendconfigv1.BackendConfigStatus, now metav1.Time) {
+	if len(status.Backends) == 0 {
+		status.Conditions = nil
+		return
+	}
+
+	var errs []string
+	for _, bs := range status.Backends {
+		if bs.Error != "" {
+			errs = append(errs, fmt.Sprintf("%s: %s", bs.BackendServiceName, bs.Error))
+		}
+	}
+
+	ready := backendconfigv1.BackendConfigCondition{
+		Type:   backendconfigv1.BackendConfigReady,
+		Status: apiv1.ConditionTrue,
+		Reason: reasonBackendsSynced,
+	}
+	errored := backendconfigv1.BackendConfigCondition{
+		Type:   backendconfigv1.BackendConfigError,
+		Status: apiv1.ConditionFalse,
+		Reason: reasonBackendsSynced,
+	}
+	if len(errs) > 0 {
+		ready.Status = apiv1.ConditionFalse
+		ready.Reason = reasonSyncFailed
+		ready.Message = fmt.Sprintf("BackendConfig could not be applied to %d of %d backend services", len(errs), len(status.Backends))
+		errored.Status = apiv1.ConditionTrue
+		errored.Reason = reasonSyncFailed
+		errored.Message = strings.Join(errs, "; ")
+	}
+	status.Conditions = []backendconfigv1.BackendConfigCondition{
+		withTransitionTime(ready, status.Conditions, now),
+		withTransitionTime(errored, status.Conditions, now),
+	}
+}
+
+// withTransitionTime sets the transition time of cond to the one of the
+// existing condition of the same type if its status did not change, or to
+// now otherwise.
+func withTransitionTime(cond backendconfigv1.BackendConfigCondition, existing []backendconfigv1.BackendConfigCondition, now metav1.Time) backendconfigv1.BackendConfigCondition {
+	cond.LastTransitionTime = now
+	for _, c := range existing {
+		if c.Type == cond.Type && c.Status == cond.Status {
+			cond.LastTransitionTime = c.LastTransitionTime
+		}
+	}
+	return cond
+}
@@ -0,0 +1,208 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backendconfig
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/cache"
+
+	backendconfigv1 "k8s.io/ingress-gce/pkg/apis/backendconfig/v1"
+	backendconfigclient "k8s.io/ingress-gce/pkg/backendconfig/client/clientset/versioned/fake"
+	"k8s.io/ingress-gce/pkg/utils"
+)
+
+func TestUpdateConditions(t *testing.T) {
+	earlier := metav1.NewTime(time.Unix(100, 0))
+	now := metav1.NewTime(time.Unix(200, 0))
+
+	testCases := []struct {
+		desc       string
+		status     backendconfigv1.BackendConfigStatus
+		wantReady  apiv1.ConditionStatus
+		wantError  apiv1.ConditionStatus
+		wantMsg    string
+		wantTime   metav1.Time
+		wantNoCond bool
+	}{
+		{
+			desc:       "no backends",
+			status:     backendconfigv1.BackendConfigStatus{},
+			wantNoCond: true,
+		},
+		{
+			desc: "all backends synced",
+			status: backendconfigv1.BackendConfigStatus{
+				Backends: []backendconfigv1.BackendStatus{
+					{ServiceName: "svc", ServicePort: "80", BackendServiceName: "k8s-be-30000--uid"},
+				},
+			},
+			wantReady: apiv1.ConditionTrue,
+			wantError: apiv1.ConditionFalse,
+			wantTime:  now,
+		},
+		{
+			desc: "one backend failed",
+			status: backendconfigv1.BackendConfigStatus{
+				Backends: []backendconfigv1.BackendStatus{
+					{ServiceName: "svc", ServicePort: "80", BackendServiceName: "k8s-be-30000--uid"},
+					{ServiceName: "svc", ServicePort: "443", BackendServiceName: "k8s-be-30001--uid", Error: "securityPolicy not found"},
+				},
+			},
+			wantReady: apiv1.ConditionFalse,
+			wantError: apiv1.ConditionTrue,
+			wantMsg:   "k8s-be-30001--uid: securityPolicy not found",
+			wantTime:  now,
+		},
+		{
+			desc: "unchanged condition keeps transition time",
+			status: backendconfigv1.BackendConfigStatus{
+				Backends: []backendconfigv1.BackendStatus{
+					{ServiceName: "svc", ServicePort: "80", BackendServiceName: "k8s-be-30000--uid"},
+				},
+				Conditions: []backendconfigv1.BackendConfigCondition{
+					{Type: backendconfigv1.BackendConfigReady, Status: apiv1.ConditionTrue, LastTransitionTime: earlier},
+					{Type: backendconfigv1.BackendConfigError, Status: apiv1.ConditionFalse, LastTransitionTime: earlier},
+				},
+			},
+			wantReady: apiv1.ConditionTrue,
+			wantError: apiv1.ConditionFalse,
+			wantTime:  earlier,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			status := tc.status.DeepCopy()
+			updateConditions(status, now)
+			if tc.wantNoCond {
+				if len(status.Conditions) != 0 {
+					t.Errorf("got conditions %+v, want none", status.Conditions)
+				}
+				return
+			}
+			ready := findCondition(status.Conditions, backendconfigv1.BackendConfigReady)
+			errored := findCondition(status.Conditions, backendconfigv1.BackendConfigError)
+			if ready == nil || errored == nil {
+				t.Fatalf("got conditions %+v, want Ready and Error conditions", status.Conditions)
+			}
+			if ready.Status != tc.wantReady {
+				t.Errorf("got Ready status %q, want %q", ready.Status, tc.wantReady)
+			}
+			if errored.Status != tc.wantError {
+				t.Errorf("got Error status %q, want %q", errored.Status, tc.wantError)
+			}
+			if errored.Message != tc.wantMsg {
+				t.Errorf("got Error message %q, want %q", errored.Message, tc.wantMsg)
+			}
+			if !ready.LastTransitionTime.Equal(&tc.wantTime) {
+				t.Errorf("got Ready transition time %v, want %v", ready.LastTransitionTime, tc.wantTime)
+			}
+		})
+	}
+}
+
+func TestStatusRecorder(t *testing.T) {
+	beConfig := &backendconfigv1.BackendConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "config-test",
+			Namespace:  "test",
+			Generation: 3,
+		},
+	}
+	client := backendconfigclient.NewSimpleClientset(beConfig)
+	store := cache.NewStore(cache.MetaNamespaceKeyFunc)
+	recorder := NewStatusRecorder(client, store)
+
+	// refreshStore mimics the informer picking up the latest BackendConfig.
+	refreshStore := func() *backendconfigv1.BackendConfig {
+		current, err := client.CloudV1().BackendConfigs("test").Get("config-test", metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("Get() = %v, want nil", err)
+		}
+		store.Update(current)
+		return current
+	}
+	refreshStore()
+
+	svcPort := func(port int32) utils.ServicePort {
+		return utils.ServicePort{
+			ID: utils.ServicePortID{
+				Service: types.NamespacedName{Namespace: "test", Name: "svc"},
+				Port:    intstr.FromInt(int(port)),
+			},
+			BackendConfig: beConfig,
+		}
+	}
+
+	if err := recorder.Record(svcPort(80), "k8s-be-30000--uid", nil); err != nil {
+		t.Fatalf("Record() = %v, want nil", err)
+	}
+	got := refreshStore()
+	wantBackends := []backendconfigv1.BackendStatus{
+		{ServiceName: "svc", ServicePort: "80", BackendServiceName: "k8s-be-30000--uid"},
+	}
+	if !reflect.DeepEqual(got.Status.Backends, wantBackends) {
+		t.Errorf("got backends %+v, want %+v", got.Status.Backends, wantBackends)
+	}
+	if got.Status.ObservedGeneration != 3 {
+		t.Errorf("got observedGeneration %d, want 3", got.Status.ObservedGeneration)
+	}
+	if c := findCondition(got.Status.Conditions, backendconfigv1.BackendConfigReady); c == nil || c.Status != apiv1.ConditionTrue {
+		t.Errorf("got Ready condition %+v, want status True", c)
+	}
+
+	if err := recorder.Record(svcPort(443), "k8s-be-30001--uid", errors.New("securityPolicy not found")); err != nil {
+		t.Fatalf("Record() = %v, want nil", err)
+	}
+	got = refreshStore()
+	if len(got.Status.Backends) != 2 {
+		t.Errorf("got backends %+v, want 2 entries", got.Status.Backends)
+	}
+	c := findCondition(got.Status.Conditions, backendconfigv1.BackendConfigError)
+	if c == nil || c.Status != apiv1.ConditionTrue || c.Message != "k8s-be-30001--uid: securityPolicy not found" {
+		t.Errorf("got Error condition %+v, want status True with the GCE error", c)
+	}
+
+	// Only port 80 is still in use, the entry for port 443 is pruned.
+	if err := recorder.Prune([]utils.ServicePort{svcPort(80)}); err != nil {
+		t.Fatalf("Prune() = %v, want nil", err)
+	}
+	got = refreshStore()
+	if !reflect.DeepEqual(got.Status.Backends, wantBackends) {
+		t.Errorf("got backends %+v, want %+v", got.Status.Backends, wantBackends)
+	}
+	if c := findCondition(got.Status.Conditions, backendconfigv1.BackendConfigError); c == nil || c.Status != apiv1.ConditionFalse {
+		t.Errorf("got Error condition %+v, want status False", c)
+	}
+}
+
+func findCondition(conditions []backendconfigv1.BackendConfigCondition, condType backendconfigv1.BackendConfigConditionType) *backendconfigv1.BackendConfigCondition {
+	for i := range conditions {
+		if conditions[i].Type == condType {
+			return &conditions[i]
+		}
+	}
+	return nil
+}
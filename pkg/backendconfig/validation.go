@@ -17,16 +17,55 @@ limitations under the License.
 package backendconfig
 
 import (
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
+	"strings"
 
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/client-go/kubernetes"
-	backendconfigv1beta1 "k8s.io/ingress-gce/pkg/apis/backendconfig/v1beta1"
+	backendconfigv1 "k8s.io/ingress-gce/pkg/apis/backendconfig/v1"
 )
 
 const (
 	OAuthClientIDKey     = "client_id"
 	OAuthClientSecretKey = "client_secret"
+	SignedURLKeyValueKey = "key_value"
+)
+
+var supportedCacheModes = map[string]bool{
+	"USE_ORIGIN_HEADERS": true,
+	"FORCE_CACHE_ALL":    true,
+	"CACHE_ALL_STATIC":   true,
+}
+
+var supportedNegativeCachingCodes = map[int64]bool{
+	300: true,
+	301: true,
+	308: true,
+	404: true,
+	405: true,
+	410: true,
+	421: true,
+	451: true,
+	501: true,
+}
+
+const (
+	// maxCDNTtl is the maximum TTL, in seconds, accepted for CDN cached content.
+	maxCDNTtl = 31622400
+	// maxNegativeCachingTtl is the maximum TTL, in seconds, of a negative caching policy.
+	maxNegativeCachingTtl = 1800
+	// signedURLKeyLength is the length, in bytes, of a decoded signed URL key.
+	signedURLKeyLength = 16
+	// maxDurationSeconds is the maximum number of seconds of a Duration.
+	maxDurationSeconds = 315576000000
+	// maxDurationNanos is the maximum number of nanoseconds of a Duration.
+	maxDurationNanos = 999999999
 )
 
 var supportedAffinities = map[string]bool{
@@ -35,35 +74,146 @@ var supportedAffinities = map[string]bool{
 	"GENERATED_COOKIE": true,
 }
 
-func Validate(kubeClient kubernetes.Interface, beConfig *backendconfigv1beta1.BackendConfig) error {
+var supportedBalancingModes = map[string]bool{
+	"RATE":        true,
+	"UTILIZATION": true,
+}
+
+var supportedLocalityLbPolicies = map[string]bool{
+	"ROUND_ROBIN":          true,
+	"LEAST_REQUEST":        true,
+	"RING_HASH":            true,
+	"RANDOM":               true,
+	"ORIGINAL_DESTINATION": true,
+	"MAGLEV":               true,
+}
+
+var supportedHealthCheckTypes = map[string]bool{
+	"HTTP":  true,
+	"HTTPS": true,
+	"HTTP2": true,
+}
+
+// specValidator validates a single field of a BackendConfig spec.
+type specValidator struct {
+	field    string
+	validate func(kubernetes.Interface, *backendconfigv1.BackendConfig) error
+}
+
+// specValidators lists the checks run against a BackendConfig, in the order
+// in which Validate applies them.
+var specValidators = []specValidator{
+	{"iap", validateIAP},
+	{"cdn", validateCDN},
+	{"sessionAffinity", validateSessionAffinity},
+	{"healthCheck", withoutClient(validateHealthCheck)},
+	{"customRequestHeaders", withoutClient(validateCustomRequestHeaders)},
+	{"customResponseHeaders", withoutClient(validateCustomResponseHeaders)},
+	{"logging", withoutClient(validateLogging)},
+	{"balancing", withoutClient(validateBalancing)},
+	{"outlierDetection", withoutClient(validateOutlierDetection)},
+	{"circuitBreakers", withoutClient(validateCircuitBreakers)},
+	{"localityLbPolicy", withoutClient(validateLocalityLbPolicy)},
+}
+
+func withoutClient(fn func(*backendconfigv1.BackendConfig) error) func(kubernetes.Interface, *backendconfigv1.BackendConfig) error {
+	return func(_ kubernetes.Interface, beConfig *backendconfigv1.BackendConfig) error {
+		return fn(beConfig)
+	}
+}
+
+func Validate(kubeClient kubernetes.Interface, beConfig *backendconfigv1.BackendConfig) error {
 	if beConfig == nil {
 		return nil
 	}
 
-	if err := validateIAP(kubeClient, beConfig); err != nil {
-		return err
+	for _, v := range specValidators {
+		if err := v.validate(kubeClient, beConfig); err != nil {
+			return err
+		}
 	}
+	return nil
+}
 
-	if err := validateSessionAffinity(kubeClient, beConfig); err != nil {
-		return err
+// ValidateFields runs the same checks as Validate but does not stop at the
+// first failure. Each error is attributed to the spec field it concerns.
+// Failures to retrieve a referenced Secret for reasons other than it not
+// existing are reported as field.ErrorTypeInternal. If kubeClient is nil,
+// the checks which require reading Secrets are skipped.
+func ValidateFields(kubeClient kubernetes.Interface, beConfig *backendconfigv1.BackendConfig) field.ErrorList {
+	var errs field.ErrorList
+	if beConfig == nil {
+		return errs
 	}
 
-	return nil
+	specPath := field.NewPath("spec")
+	for _, v := range specValidators {
+		err := v.validate(kubeClient, beConfig)
+		if err == nil {
+			continue
+		}
+		if _, ok := err.(*SecretUnavailableError); ok {
+			errs = append(errs, field.InternalError(specPath.Child(v.field), err))
+			continue
+		}
+		errs = append(errs, field.Invalid(specPath.Child(v.field), specFieldValue(beConfig, v.field), err.Error()))
+	}
+	return errs
+}
+
+// SecretUnavailableError is returned when a Secret referenced by a
+// BackendConfig could not be retrieved, e.g. because the API server could
+// not be reached or access to Secrets is denied.
+type SecretUnavailableError struct {
+	SecretName string
+	Err        error
+}
+
+func (e *SecretUnavailableError) Error() string {
+	return fmt.Sprintf("error retrieving secret %v: %v", e.SecretName, e.Err)
+}
+
+// getSecret retrieves the named Secret from the namespace of beConfig.
+func getSecret(kubeClient kubernetes.Interface, beConfig *backendconfigv1.BackendConfig, secretName string) (*apiv1.Secret, error) {
+	secret, err := kubeClient.Core().Secrets(beConfig.Namespace).Get(secretName, meta_v1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, fmt.Errorf("error retrieving secret %v: %v", secretName, err)
+		}
+		return nil, &SecretUnavailableError{SecretName: secretName, Err: err}
+	}
+	return secret, nil
+}
+
+// jsonValue is a JSON encoded value which field errors print verbatim.
+type jsonValue string
+
+func (v jsonValue) String() string {
+	return string(v)
+}
+
+// specFieldValue returns the JSON encoding of the given spec field.
+func specFieldValue(beConfig *backendconfigv1.BackendConfig, name string) jsonValue {
+	var spec map[string]json.RawMessage
+	if data, err := json.Marshal(beConfig.Spec); err == nil {
+		json.Unmarshal(data, &spec)
+	}
+	return jsonValue(spec[name])
 }
 
 // TODO(rramkumar): Return errors as constants so that the unit tests can distinguish
 // between which error is returned.
-func validateIAP(kubeClient kubernetes.Interface, beConfig *backendconfigv1beta1.BackendConfig) error {
+func validateIAP(kubeClient kubernetes.Interface, beConfig *backendconfigv1.BackendConfig) error {
 	// If IAP settings are not found or IAP is not enabled then don't bother continuing.
 	if beConfig.Spec.Iap == nil || beConfig.Spec.Iap.Enabled == false {
 		return nil
 	}
 	// If necessary, get the OAuth credentials stored in the K8s secret.
-	if beConfig.Spec.Iap.OAuthClientCredentials != nil && beConfig.Spec.Iap.OAuthClientCredentials.SecretName != "" {
+	if kubeClient != nil && beConfig.Spec.Iap.OAuthClientCredentials != nil && beConfig.Spec.Iap.OAuthClientCredentials.SecretName != "" {
 		secretName := beConfig.Spec.Iap.OAuthClientCredentials.SecretName
-		secret, err := kubeClient.Core().Secrets(beConfig.Namespace).Get(secretName, meta_v1.GetOptions{})
+		secret, err := getSecret(kubeClient, beConfig, secretName)
 		if err != nil {
-			return fmt.Errorf("error retrieving secret %v: %v", secretName, err)
+			return err
 		}
 		clientID, ok := secret.Data[OAuthClientIDKey]
 		if !ok {
@@ -83,7 +233,95 @@ func validateIAP(kubeClient kubernetes.Interface, beConfig *backendconfigv1beta1
 	return nil
 }
 
-func validateSessionAffinity(kubeClient kubernetes.Interface, beConfig *backendconfigv1beta1.BackendConfig) error {
+func validateCDN(kubeClient kubernetes.Interface, beConfig *backendconfigv1.BackendConfig) error {
+	cdn := beConfig.Spec.Cdn
+	if cdn == nil {
+		return nil
+	}
+
+	if cdn.CacheMode != nil {
+		if _, ok := supportedCacheModes[*cdn.CacheMode]; !ok {
+			return fmt.Errorf("unsupported CDN CacheMode: %s, should be one of USE_ORIGIN_HEADERS, FORCE_CACHE_ALL, or CACHE_ALL_STATIC", *cdn.CacheMode)
+		}
+	}
+	for _, field := range []struct {
+		name string
+		val  *int64
+	}{
+		{"ClientTtl", cdn.ClientTtl},
+		{"DefaultTtl", cdn.DefaultTtl},
+		{"MaxTtl", cdn.MaxTtl},
+		{"SignedUrlCacheMaxAgeSec", cdn.SignedUrlCacheMaxAgeSec},
+	} {
+		if field.val != nil && (*field.val < 0 || *field.val > maxCDNTtl) {
+			return fmt.Errorf("unsupported CDN %s: %d, should be between 0 and %d", field.name, *field.val, maxCDNTtl)
+		}
+	}
+	if cdn.CacheMode != nil && *cdn.CacheMode == "USE_ORIGIN_HEADERS" && (cdn.ClientTtl != nil || cdn.DefaultTtl != nil || cdn.MaxTtl != nil) {
+		return fmt.Errorf("CDN ClientTtl, DefaultTtl and MaxTtl cannot be specified with CacheMode USE_ORIGIN_HEADERS")
+	}
+	if cdn.DefaultTtl != nil && cdn.MaxTtl != nil && *cdn.DefaultTtl > *cdn.MaxTtl {
+		return fmt.Errorf("unsupported CDN DefaultTtl: %d, should not be greater than MaxTtl (%d)", *cdn.DefaultTtl, *cdn.MaxTtl)
+	}
+
+	if len(cdn.NegativeCachingPolicy) > 0 && (cdn.NegativeCaching == nil || !*cdn.NegativeCaching) {
+		return fmt.Errorf("CDN NegativeCachingPolicy requires NegativeCaching to be enabled")
+	}
+	seenCodes := make(map[int64]bool)
+	for _, policy := range cdn.NegativeCachingPolicy {
+		if policy == nil {
+			continue
+		}
+		if _, ok := supportedNegativeCachingCodes[policy.Code]; !ok {
+			return fmt.Errorf("unsupported CDN NegativeCachingPolicy Code: %d, should be one of 300, 301, 308, 404, 405, 410, 421, 451, or 501", policy.Code)
+		}
+		if seenCodes[policy.Code] {
+			return fmt.Errorf("duplicate CDN NegativeCachingPolicy Code: %d", policy.Code)
+		}
+		seenCodes[policy.Code] = true
+		if policy.Ttl < 0 || policy.Ttl > maxNegativeCachingTtl {
+			return fmt.Errorf("unsupported CDN NegativeCachingPolicy Ttl: %d, should be between 0 and %d", policy.Ttl, maxNegativeCachingTtl)
+		}
+	}
+
+	seenKeys := make(map[string]bool)
+	for _, key := range cdn.SignedUrlKeys {
+		if key == nil {
+			continue
+		}
+		if errs := validation.IsDNS1035Label(key.KeyName); len(errs) > 0 {
+			return fmt.Errorf("unsupported CDN SignedUrlKey KeyName: %q, %s", key.KeyName, strings.Join(errs, ", "))
+		}
+		if seenKeys[key.KeyName] {
+			return fmt.Errorf("duplicate CDN SignedUrlKey KeyName: %q", key.KeyName)
+		}
+		seenKeys[key.KeyName] = true
+		if (key.KeyValue == "") == (key.SecretName == "") {
+			return fmt.Errorf("CDN SignedUrlKey %q should specify exactly one of KeyValue or SecretName", key.KeyName)
+		}
+		// If necessary, get the key value stored in the K8s secret.
+		if key.SecretName != "" {
+			if kubeClient == nil {
+				continue
+			}
+			secret, err := getSecret(kubeClient, beConfig, key.SecretName)
+			if err != nil {
+				return err
+			}
+			keyValue, ok := secret.Data[SignedURLKeyValueKey]
+			if !ok {
+				return fmt.Errorf("secret %v missing %v data", key.SecretName, SignedURLKeyValueKey)
+			}
+			key.KeyValue = strings.TrimSpace(string(keyValue))
+		}
+		if decoded, err := base64.URLEncoding.DecodeString(key.KeyValue); err != nil || len(decoded) != signedURLKeyLength {
+			return fmt.Errorf("unsupported CDN SignedUrlKey %q value, should be a %d-byte key encoded with base64url", key.KeyName, signedURLKeyLength)
+		}
+	}
+	return nil
+}
+
+func validateSessionAffinity(kubeClient kubernetes.Interface, beConfig *backendconfigv1.BackendConfig) error {
 	if beConfig.Spec.SessionAffinity == nil {
 		return nil
 	}
@@ -104,3 +342,221 @@ func validateSessionAffinity(kubeClient kubernetes.Interface, beConfig *backendc
 
 	return nil
 }
+
+func validateHealthCheck(beConfig *backendconfigv1.BackendConfig) error {
+	hc := beConfig.Spec.HealthCheck
+	if hc == nil {
+		return nil
+	}
+
+	if hc.Type != nil {
+		if _, ok := supportedHealthCheckTypes[*hc.Type]; !ok {
+			return fmt.Errorf("unsupported health check Type: %s, should be one of HTTP, HTTPS, or HTTP2", *hc.Type)
+		}
+	}
+	if hc.RequestPath != nil && !strings.HasPrefix(*hc.RequestPath, "/") {
+		return fmt.Errorf("unsupported health check RequestPath: %q, should start with \"/\"", *hc.RequestPath)
+	}
+	if hc.Port != nil && (*hc.Port < 1 || *hc.Port > 65535) {
+		return fmt.Errorf("unsupported health check Port: %d, should be between 1 and 65535", *hc.Port)
+	}
+	for _, field := range []struct {
+		name string
+		val  *int64
+	}{
+		{"CheckIntervalSec", hc.CheckIntervalSec},
+		{"TimeoutSec", hc.TimeoutSec},
+		{"HealthyThreshold", hc.HealthyThreshold},
+		{"UnhealthyThreshold", hc.UnhealthyThreshold},
+	} {
+		if field.val != nil && *field.val <= 0 {
+			return fmt.Errorf("unsupported health check %s: %d, should be greater than 0", field.name, *field.val)
+		}
+	}
+	if hc.CheckIntervalSec != nil && hc.TimeoutSec != nil && *hc.TimeoutSec > *hc.CheckIntervalSec {
+		return fmt.Errorf("unsupported health check TimeoutSec: %d, should not be greater than CheckIntervalSec (%d)", *hc.TimeoutSec, *hc.CheckIntervalSec)
+	}
+	return nil
+}
+
+func validateCustomRequestHeaders(beConfig *backendconfigv1.BackendConfig) error {
+	if beConfig.Spec.CustomRequestHeaders == nil {
+		return nil
+	}
+	return validateCustomHeaders(beConfig.Spec.CustomRequestHeaders.Headers)
+}
+
+func validateCustomResponseHeaders(beConfig *backendconfigv1.BackendConfig) error {
+	if beConfig.Spec.CustomResponseHeaders == nil {
+		return nil
+	}
+	return validateCustomHeaders(beConfig.Spec.CustomResponseHeaders.Headers)
+}
+
+func validateCustomHeaders(headers []string) error {
+	for _, header := range headers {
+		parts := strings.SplitN(header, ":", 2)
+		if len(parts) != 2 || parts[0] == "" || strings.ContainsAny(parts[0], " \t") {
+			return fmt.Errorf("unsupported custom header: %q, should be of the form \"Header-Name: value\"", header)
+		}
+	}
+	return nil
+}
+
+func validateLogging(beConfig *backendconfigv1.BackendConfig) error {
+	if beConfig.Spec.Logging == nil || beConfig.Spec.Logging.SampleRate == nil {
+		return nil
+	}
+	if sampleRate := *beConfig.Spec.Logging.SampleRate; sampleRate < 0 || sampleRate > 1 {
+		return fmt.Errorf("unsupported logging SampleRate: %v, should be between 0 and 1", sampleRate)
+	}
+	return nil
+}
+
+func validateBalancing(beConfig *backendconfigv1.BackendConfig) error {
+	balancing := beConfig.Spec.Balancing
+	if balancing == nil {
+		return nil
+	}
+
+	mode := "RATE"
+	if balancing.Mode != nil {
+		if _, ok := supportedBalancingModes[*balancing.Mode]; !ok {
+			return fmt.Errorf("unsupported balancing Mode: %s, should be one of RATE or UTILIZATION", *balancing.Mode)
+		}
+		mode = *balancing.Mode
+	}
+	for _, field := range []struct {
+		name string
+		mode string
+		set  bool
+	}{
+		{"MaxRatePerInstance", "RATE", balancing.MaxRatePerInstance != nil},
+		{"MaxRatePerEndpoint", "RATE", balancing.MaxRatePerEndpoint != nil},
+		{"MaxUtilization", "UTILIZATION", balancing.MaxUtilization != nil},
+	} {
+		if field.set && field.mode != mode {
+			return fmt.Errorf("balancing %s can only be specified with Mode %s", field.name, field.mode)
+		}
+	}
+
+	for _, field := range []struct {
+		name string
+		val  *float64
+	}{
+		{"MaxRatePerInstance", balancing.MaxRatePerInstance},
+		{"MaxRatePerEndpoint", balancing.MaxRatePerEndpoint},
+	} {
+		if field.val != nil && *field.val <= 0 {
+			return fmt.Errorf("unsupported balancing %s: %v, should be greater than 0", field.name, *field.val)
+		}
+	}
+	for _, field := range []struct {
+		name string
+		val  *float64
+	}{
+		{"MaxUtilization", balancing.MaxUtilization},
+		{"CapacityScaler", balancing.CapacityScaler},
+	} {
+		if field.val != nil && (*field.val < 0 || *field.val > 1) {
+			return fmt.Errorf("unsupported balancing %s: %v, should be between 0 and 1", field.name, *field.val)
+		}
+	}
+	return nil
+}
+
+func validateOutlierDetection(beConfig *backendconfigv1.BackendConfig) error {
+	od := beConfig.Spec.OutlierDetection
+	if od == nil {
+		return nil
+	}
+
+	for _, field := range []struct {
+		name string
+		val  *backendconfigv1.Duration
+	}{
+		{"BaseEjectionTime", od.BaseEjectionTime},
+		{"Interval", od.Interval},
+	} {
+		if err := validateDuration("outlier detection "+field.name, field.val); err != nil {
+			return err
+		}
+	}
+	for _, field := range []struct {
+		name string
+		val  *int64
+	}{
+		{"EnforcingConsecutiveErrors", od.EnforcingConsecutiveErrors},
+		{"EnforcingConsecutiveGatewayFailure", od.EnforcingConsecutiveGatewayFailure},
+		{"EnforcingSuccessRate", od.EnforcingSuccessRate},
+		{"MaxEjectionPercent", od.MaxEjectionPercent},
+	} {
+		if field.val != nil && (*field.val < 0 || *field.val > 100) {
+			return fmt.Errorf("unsupported outlier detection %s: %d, should be between 0 and 100", field.name, *field.val)
+		}
+	}
+	for _, field := range []struct {
+		name string
+		val  *int64
+	}{
+		{"ConsecutiveErrors", od.ConsecutiveErrors},
+		{"ConsecutiveGatewayFailure", od.ConsecutiveGatewayFailure},
+		{"SuccessRateMinimumHosts", od.SuccessRateMinimumHosts},
+		{"SuccessRateRequestVolume", od.SuccessRateRequestVolume},
+		{"SuccessRateStdevFactor", od.SuccessRateStdevFactor},
+	} {
+		if field.val != nil && *field.val < 0 {
+			return fmt.Errorf("unsupported outlier detection %s: %d, should not be negative", field.name, *field.val)
+		}
+	}
+	return nil
+}
+
+func validateCircuitBreakers(beConfig *backendconfigv1.BackendConfig) error {
+	cb := beConfig.Spec.CircuitBreakers
+	if cb == nil {
+		return nil
+	}
+
+	if err := validateDuration("circuit breakers ConnectTimeout", cb.ConnectTimeout); err != nil {
+		return err
+	}
+	for _, field := range []struct {
+		name string
+		val  *int64
+	}{
+		{"MaxConnections", cb.MaxConnections},
+		{"MaxPendingRequests", cb.MaxPendingRequests},
+		{"MaxRequests", cb.MaxRequests},
+		{"MaxRequestsPerConnection", cb.MaxRequestsPerConnection},
+		{"MaxRetries", cb.MaxRetries},
+	} {
+		if field.val != nil && *field.val < 0 {
+			return fmt.Errorf("unsupported circuit breakers %s: %d, should not be negative", field.name, *field.val)
+		}
+	}
+	return nil
+}
+
+func validateLocalityLbPolicy(beConfig *backendconfigv1.BackendConfig) error {
+	if beConfig.Spec.LocalityLbPolicy == nil {
+		return nil
+	}
+	if _, ok := supportedLocalityLbPolicies[*beConfig.Spec.LocalityLbPolicy]; !ok {
+		return fmt.Errorf("unsupported LocalityLbPolicy: %s, should be one of ROUND_ROBIN, LEAST_REQUEST, RING_HASH, RANDOM, ORIGINAL_DESTINATION, or MAGLEV", *beConfig.Spec.LocalityLbPolicy)
+	}
+	return nil
+}
+
+func validateDuration(name string, d *backendconfigv1.Duration) error {
+	if d == nil {
+		return nil
+	}
+	if d.Seconds < 0 || d.Seconds > maxDurationSeconds {
+		return fmt.Errorf("unsupported %s seconds: %d, should be between 0 and %d", name, d.Seconds, int64(maxDurationSeconds))
+	}
+	if d.Nanos < 0 || d.Nanos > maxDurationNanos {
+		return fmt.Errorf("unsupported %s nanos: %d, should be between 0 and %d", name, d.Nanos, maxDurationNanos)
+	}
+	return nil
+}
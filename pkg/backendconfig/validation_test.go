@@ -17,27 +17,31 @@ limitations under the License.
 package backendconfig
 
 import (
+	"fmt"
 	"testing"
 
 	v1 "k8s.io/api/core/v1"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/fake"
-	backendconfigv1beta1 "k8s.io/ingress-gce/pkg/apis/backendconfig/v1beta1"
+	core "k8s.io/client-go/testing"
+	backendconfigv1 "k8s.io/ingress-gce/pkg/apis/backendconfig/v1"
 )
 
 var (
 	goodTTL int64 = 86400
 	badTTL  int64 = 86400 + 1
 
-	defaultBeConfig = &backendconfigv1beta1.BackendConfig{
+	defaultBeConfig = &backendconfigv1.BackendConfig{
 		ObjectMeta: meta_v1.ObjectMeta{
 			Namespace: "default",
 		},
-		Spec: backendconfigv1beta1.BackendConfigSpec{
-			Iap: &backendconfigv1beta1.IAPConfig{
+		Spec: backendconfigv1.BackendConfigSpec{
+			Iap: &backendconfigv1.IAPConfig{
 				Enabled: true,
-				OAuthClientCredentials: &backendconfigv1beta1.OAuthClientCredentials{
+				OAuthClientCredentials: &backendconfigv1.OAuthClientCredentials{
 					SecretName: "foo",
 				},
 			},
@@ -49,7 +53,7 @@ func TestValidateIAP(t *testing.T) {
 	testCases := []struct {
 		desc        string
 		init        func(kubeClient kubernetes.Interface)
-		beConfig    *backendconfigv1beta1.BackendConfig
+		beConfig    *backendconfigv1.BackendConfig
 		expectError bool
 	}{
 		{
@@ -120,15 +124,15 @@ func TestValidateIAP(t *testing.T) {
 		},
 		{
 			desc: "iap and cdn enabled at the same time",
-			beConfig: &backendconfigv1beta1.BackendConfig{
+			beConfig: &backendconfigv1.BackendConfig{
 				ObjectMeta: meta_v1.ObjectMeta{
 					Namespace: "default",
 				},
-				Spec: backendconfigv1beta1.BackendConfigSpec{
-					Iap: &backendconfigv1beta1.IAPConfig{
+				Spec: backendconfigv1.BackendConfigSpec{
+					Iap: &backendconfigv1.IAPConfig{
 						Enabled: true,
 					},
-					Cdn: &backendconfigv1beta1.CDNConfig{
+					Cdn: &backendconfigv1.CDNConfig{
 						Enabled: true,
 					},
 				},
@@ -166,18 +170,18 @@ func TestValidateIAP(t *testing.T) {
 func TestValidateSessionAffinity(t *testing.T) {
 	testCases := []struct {
 		desc        string
-		beConfig    *backendconfigv1beta1.BackendConfig
+		beConfig    *backendconfigv1.BackendConfig
 		expectError bool
 	}{
 
 		{
 			desc: "unsupported affinity type",
-			beConfig: &backendconfigv1beta1.BackendConfig{
+			beConfig: &backendconfigv1.BackendConfig{
 				ObjectMeta: meta_v1.ObjectMeta{
 					Namespace: "default",
 				},
-				Spec: backendconfigv1beta1.BackendConfigSpec{
-					SessionAffinity: &backendconfigv1beta1.SessionAffinityConfig{
+				Spec: backendconfigv1.BackendConfigSpec{
+					SessionAffinity: &backendconfigv1.SessionAffinityConfig{
 						AffinityType: "WRONG_TYPE",
 					},
 				},
@@ -186,12 +190,12 @@ func TestValidateSessionAffinity(t *testing.T) {
 		},
 		{
 			desc: "supported affinity type",
-			beConfig: &backendconfigv1beta1.BackendConfig{
+			beConfig: &backendconfigv1.BackendConfig{
 				ObjectMeta: meta_v1.ObjectMeta{
 					Namespace: "default",
 				},
-				Spec: backendconfigv1beta1.BackendConfigSpec{
-					SessionAffinity: &backendconfigv1beta1.SessionAffinityConfig{
+				Spec: backendconfigv1.BackendConfigSpec{
+					SessionAffinity: &backendconfigv1.SessionAffinityConfig{
 						AffinityType: "CLIENT_IP",
 					},
 				},
@@ -200,12 +204,12 @@ func TestValidateSessionAffinity(t *testing.T) {
 		},
 		{
 			desc: "unsupported ttl value",
-			beConfig: &backendconfigv1beta1.BackendConfig{
+			beConfig: &backendconfigv1.BackendConfig{
 				ObjectMeta: meta_v1.ObjectMeta{
 					Namespace: "default",
 				},
-				Spec: backendconfigv1beta1.BackendConfigSpec{
-					SessionAffinity: &backendconfigv1beta1.SessionAffinityConfig{
+				Spec: backendconfigv1.BackendConfigSpec{
+					SessionAffinity: &backendconfigv1.SessionAffinityConfig{
 						AffinityCookieTtlSec: &badTTL,
 					},
 				},
@@ -214,12 +218,12 @@ func TestValidateSessionAffinity(t *testing.T) {
 		},
 		{
 			desc: "supported ttl value",
-			beConfig: &backendconfigv1beta1.BackendConfig{
+			beConfig: &backendconfigv1.BackendConfig{
 				ObjectMeta: meta_v1.ObjectMeta{
 					Namespace: "default",
 				},
-				Spec: backendconfigv1beta1.BackendConfigSpec{
-					SessionAffinity: &backendconfigv1beta1.SessionAffinityConfig{
+				Spec: backendconfigv1.BackendConfigSpec{
+					SessionAffinity: &backendconfigv1.SessionAffinityConfig{
 						AffinityCookieTtlSec: &goodTTL,
 					},
 				},
@@ -239,3 +243,832 @@ func TestValidateSessionAffinity(t *testing.T) {
 		}
 	}
 }
+
+func TestValidateHealthCheck(t *testing.T) {
+	var (
+		interval    int64 = 10
+		timeout     int64 = 5
+		negative    int64 = -1
+		badPort     int64 = 70000
+		goodPort    int64 = 8080
+		goodType          = "HTTPS"
+		badType           = "TCP"
+		goodPath          = "/healthz"
+		badPath           = "healthz"
+		longTimeout int64 = 20
+	)
+	testCases := []struct {
+		desc        string
+		hc          *backendconfigv1.HealthCheckConfig
+		expectError bool
+	}{
+		{
+			desc:        "no health check settings",
+			hc:          nil,
+			expectError: false,
+		},
+		{
+			desc: "all settings valid",
+			hc: &backendconfigv1.HealthCheckConfig{
+				CheckIntervalSec: &interval,
+				TimeoutSec:       &timeout,
+				Type:             &goodType,
+				Port:             &goodPort,
+				RequestPath:      &goodPath,
+			},
+			expectError: false,
+		},
+		{
+			desc:        "unsupported type",
+			hc:          &backendconfigv1.HealthCheckConfig{Type: &badType},
+			expectError: true,
+		},
+		{
+			desc:        "request path without leading slash",
+			hc:          &backendconfigv1.HealthCheckConfig{RequestPath: &badPath},
+			expectError: true,
+		},
+		{
+			desc:        "port out of range",
+			hc:          &backendconfigv1.HealthCheckConfig{Port: &badPort},
+			expectError: true,
+		},
+		{
+			desc:        "negative threshold",
+			hc:          &backendconfigv1.HealthCheckConfig{UnhealthyThreshold: &negative},
+			expectError: true,
+		},
+		{
+			desc: "timeout greater than interval",
+			hc: &backendconfigv1.HealthCheckConfig{
+				CheckIntervalSec: &interval,
+				TimeoutSec:       &longTimeout,
+			},
+			expectError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		beConfig := &backendconfigv1.BackendConfig{
+			ObjectMeta: meta_v1.ObjectMeta{
+				Namespace: "default",
+			},
+			Spec: backendconfigv1.BackendConfigSpec{
+				HealthCheck: testCase.hc,
+			},
+		}
+		kubeClient := fake.NewSimpleClientset()
+		err := Validate(kubeClient, beConfig)
+		if testCase.expectError && err == nil {
+			t.Errorf("%v: Expected error but got nil", testCase.desc)
+		}
+		if !testCase.expectError && err != nil {
+			t.Errorf("%v: Did not expect error but got: %v", testCase.desc, err)
+		}
+	}
+}
+
+func TestValidateCustomHeaders(t *testing.T) {
+	testCases := []struct {
+		desc            string
+		requestHeaders  *backendconfigv1.CustomRequestHeadersConfig
+		responseHeaders *backendconfigv1.CustomResponseHeadersConfig
+		expectError     bool
+	}{
+		{
+			desc:        "no custom headers",
+			expectError: false,
+		},
+		{
+			desc: "valid request and response headers",
+			requestHeaders: &backendconfigv1.CustomRequestHeadersConfig{
+				Headers: []string{"X-Client-Region: {client_region}", "X-Empty:"},
+			},
+			responseHeaders: &backendconfigv1.CustomResponseHeadersConfig{
+				Headers: []string{"Strict-Transport-Security: max-age=31536000"},
+			},
+			expectError: false,
+		},
+		{
+			desc: "request header without separator",
+			requestHeaders: &backendconfigv1.CustomRequestHeadersConfig{
+				Headers: []string{"X-Client-Region"},
+			},
+			expectError: true,
+		},
+		{
+			desc: "response header with empty name",
+			responseHeaders: &backendconfigv1.CustomResponseHeadersConfig{
+				Headers: []string{": value"},
+			},
+			expectError: true,
+		},
+		{
+			desc: "response header name with whitespace",
+			responseHeaders: &backendconfigv1.CustomResponseHeadersConfig{
+				Headers: []string{"X Frame Options: DENY"},
+			},
+			expectError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		beConfig := &backendconfigv1.BackendConfig{
+			ObjectMeta: meta_v1.ObjectMeta{
+				Namespace: "default",
+			},
+			Spec: backendconfigv1.BackendConfigSpec{
+				CustomRequestHeaders:  testCase.requestHeaders,
+				CustomResponseHeaders: testCase.responseHeaders,
+			},
+		}
+		kubeClient := fake.NewSimpleClientset()
+		err := Validate(kubeClient, beConfig)
+		if testCase.expectError && err == nil {
+			t.Errorf("%v: Expected error but got nil", testCase.desc)
+		}
+		if !testCase.expectError && err != nil {
+			t.Errorf("%v: Did not expect error but got: %v", testCase.desc, err)
+		}
+	}
+}
+
+func TestValidateLogging(t *testing.T) {
+	var (
+		goodRate    = 0.5
+		zeroRate    = 0.0
+		fullRate    = 1.0
+		tooHighRate = 1.5
+		negRate     = -0.1
+	)
+	testCases := []struct {
+		desc        string
+		logging     *backendconfigv1.LogConfig
+		expectError bool
+	}{
+		{
+			desc:        "no logging settings",
+			logging:     nil,
+			expectError: false,
+		},
+		{
+			desc:        "logging enabled without sample rate",
+			logging:     &backendconfigv1.LogConfig{Enable: true},
+			expectError: false,
+		},
+		{
+			desc:        "valid sample rate",
+			logging:     &backendconfigv1.LogConfig{Enable: true, SampleRate: &goodRate},
+			expectError: false,
+		},
+		{
+			desc:        "zero sample rate",
+			logging:     &backendconfigv1.LogConfig{Enable: true, SampleRate: &zeroRate},
+			expectError: false,
+		},
+		{
+			desc:        "full sample rate",
+			logging:     &backendconfigv1.LogConfig{Enable: true, SampleRate: &fullRate},
+			expectError: false,
+		},
+		{
+			desc:        "sample rate greater than 1",
+			logging:     &backendconfigv1.LogConfig{Enable: true, SampleRate: &tooHighRate},
+			expectError: true,
+		},
+		{
+			desc:        "negative sample rate",
+			logging:     &backendconfigv1.LogConfig{Enable: true, SampleRate: &negRate},
+			expectError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		beConfig := &backendconfigv1.BackendConfig{
+			ObjectMeta: meta_v1.ObjectMeta{
+				Namespace: "default",
+			},
+			Spec: backendconfigv1.BackendConfigSpec{
+				Logging: testCase.logging,
+			},
+		}
+		kubeClient := fake.NewSimpleClientset()
+		err := Validate(kubeClient, beConfig)
+		if testCase.expectError && err == nil {
+			t.Errorf("%v: Expected error but got nil", testCase.desc)
+		}
+		if !testCase.expectError && err != nil {
+			t.Errorf("%v: Did not expect error but got: %v", testCase.desc, err)
+		}
+	}
+}
+
+func TestValidateCDN(t *testing.T) {
+	var (
+		cacheAllStatic   = "CACHE_ALL_STATIC"
+		useOriginHeaders = "USE_ORIGIN_HEADERS"
+		badCacheMode     = "CACHE_EVERYTHING"
+		highTTL          = int64(3600)
+		lowTTL           = int64(60)
+		negTTL           = int64(-1)
+		enabled          = true
+		validKey         = "AAECAwQFBgcICQoLDA0ODw=="
+	)
+	testCases := []struct {
+		desc        string
+		init        func(kubeClient kubernetes.Interface)
+		cdn         *backendconfigv1.CDNConfig
+		expectError bool
+		expectKey   string
+	}{
+		{
+			desc: "cache mode and ttls",
+			cdn: &backendconfigv1.CDNConfig{
+				Enabled:    true,
+				CacheMode:  &cacheAllStatic,
+				DefaultTtl: &lowTTL,
+				MaxTtl:     &highTTL,
+				ClientTtl:  &lowTTL,
+			},
+			expectError: false,
+		},
+		{
+			desc: "unsupported cache mode",
+			cdn: &backendconfigv1.CDNConfig{
+				Enabled:   true,
+				CacheMode: &badCacheMode,
+			},
+			expectError: true,
+		},
+		{
+			desc: "negative ttl",
+			cdn: &backendconfigv1.CDNConfig{
+				Enabled:    true,
+				DefaultTtl: &negTTL,
+			},
+			expectError: true,
+		},
+		{
+			desc: "ttls with origin headers cache mode",
+			cdn: &backendconfigv1.CDNConfig{
+				Enabled:    true,
+				CacheMode:  &useOriginHeaders,
+				DefaultTtl: &highTTL,
+			},
+			expectError: true,
+		},
+		{
+			desc: "default ttl greater than max ttl",
+			cdn: &backendconfigv1.CDNConfig{
+				Enabled:    true,
+				DefaultTtl: &highTTL,
+				MaxTtl:     &lowTTL,
+			},
+			expectError: true,
+		},
+		{
+			desc: "negative caching policy",
+			cdn: &backendconfigv1.CDNConfig{
+				Enabled:         true,
+				NegativeCaching: &enabled,
+				NegativeCachingPolicy: []*backendconfigv1.NegativeCachingPolicy{
+					{Code: 404, Ttl: 120},
+					{Code: 410, Ttl: 1800},
+				},
+			},
+			expectError: false,
+		},
+		{
+			desc: "negative caching policy without negative caching",
+			cdn: &backendconfigv1.CDNConfig{
+				Enabled: true,
+				NegativeCachingPolicy: []*backendconfigv1.NegativeCachingPolicy{
+					{Code: 404, Ttl: 120},
+				},
+			},
+			expectError: true,
+		},
+		{
+			desc: "negative caching policy with unsupported code",
+			cdn: &backendconfigv1.CDNConfig{
+				Enabled:         true,
+				NegativeCaching: &enabled,
+				NegativeCachingPolicy: []*backendconfigv1.NegativeCachingPolicy{
+					{Code: 500, Ttl: 120},
+				},
+			},
+			expectError: true,
+		},
+		{
+			desc: "negative caching policy with duplicate code",
+			cdn: &backendconfigv1.CDNConfig{
+				Enabled:         true,
+				NegativeCaching: &enabled,
+				NegativeCachingPolicy: []*backendconfigv1.NegativeCachingPolicy{
+					{Code: 404, Ttl: 120},
+					{Code: 404, Ttl: 60},
+				},
+			},
+			expectError: true,
+		},
+		{
+			desc: "negative caching policy ttl too high",
+			cdn: &backendconfigv1.CDNConfig{
+				Enabled:         true,
+				NegativeCaching: &enabled,
+				NegativeCachingPolicy: []*backendconfigv1.NegativeCachingPolicy{
+					{Code: 404, Ttl: 1801},
+				},
+			},
+			expectError: true,
+		},
+		{
+			desc: "signed url key with inline value",
+			cdn: &backendconfigv1.CDNConfig{
+				Enabled: true,
+				SignedUrlKeys: []*backendconfigv1.SignedUrlKey{
+					{KeyName: "key-1", KeyValue: validKey},
+				},
+			},
+			expectError: false,
+			expectKey:   validKey,
+		},
+		{
+			desc: "signed url key from secret",
+			init: func(kubeClient kubernetes.Interface) {
+				secret := &v1.Secret{
+					ObjectMeta: meta_v1.ObjectMeta{
+						Namespace: "default",
+						Name:      "foo",
+					},
+					Data: map[string][]byte{
+						"key_value": []byte(validKey + "\n"),
+					},
+				}
+				kubeClient.Core().Secrets("default").Create(secret)
+			},
+			cdn: &backendconfigv1.CDNConfig{
+				Enabled: true,
+				SignedUrlKeys: []*backendconfigv1.SignedUrlKey{
+					{KeyName: "key-1", SecretName: "foo"},
+				},
+			},
+			expectError: false,
+			expectKey:   validKey,
+		},
+		{
+			desc: "signed url key secret does not exist",
+			cdn: &backendconfigv1.CDNConfig{
+				Enabled: true,
+				SignedUrlKeys: []*backendconfigv1.SignedUrlKey{
+					{KeyName: "key-1", SecretName: "foo"},
+				},
+			},
+			expectError: true,
+		},
+		{
+			desc: "signed url key secret does not contain key_value",
+			init: func(kubeClient kubernetes.Interface) {
+				secret := &v1.Secret{
+					ObjectMeta: meta_v1.ObjectMeta{
+						Namespace: "default",
+						Name:      "foo",
+					},
+					Data: map[string][]byte{
+						"client_id": []byte("my-id"),
+					},
+				}
+				kubeClient.Core().Secrets("default").Create(secret)
+			},
+			cdn: &backendconfigv1.CDNConfig{
+				Enabled: true,
+				SignedUrlKeys: []*backendconfigv1.SignedUrlKey{
+					{KeyName: "key-1", SecretName: "foo"},
+				},
+			},
+			expectError: true,
+		},
+		{
+			desc: "signed url key with both value and secret",
+			cdn: &backendconfigv1.CDNConfig{
+				Enabled: true,
+				SignedUrlKeys: []*backendconfigv1.SignedUrlKey{
+					{KeyName: "key-1", KeyValue: validKey, SecretName: "foo"},
+				},
+			},
+			expectError: true,
+		},
+		{
+			desc: "signed url key with invalid name",
+			cdn: &backendconfigv1.CDNConfig{
+				Enabled: true,
+				SignedUrlKeys: []*backendconfigv1.SignedUrlKey{
+					{KeyName: "Key_1", KeyValue: validKey},
+				},
+			},
+			expectError: true,
+		},
+		{
+			desc: "signed url key with duplicate name",
+			cdn: &backendconfigv1.CDNConfig{
+				Enabled: true,
+				SignedUrlKeys: []*backendconfigv1.SignedUrlKey{
+					{KeyName: "key-1", KeyValue: validKey},
+					{KeyName: "key-1", KeyValue: validKey},
+				},
+			},
+			expectError: true,
+		},
+		{
+			desc: "signed url key with short value",
+			cdn: &backendconfigv1.CDNConfig{
+				Enabled: true,
+				SignedUrlKeys: []*backendconfigv1.SignedUrlKey{
+					{KeyName: "key-1", KeyValue: "AAECAwQFBgc="},
+				},
+			},
+			expectError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		beConfig := &backendconfigv1.BackendConfig{
+			ObjectMeta: meta_v1.ObjectMeta{
+				Namespace: "default",
+			},
+			Spec: backendconfigv1.BackendConfigSpec{
+				Cdn: testCase.cdn,
+			},
+		}
+		kubeClient := fake.NewSimpleClientset()
+		if testCase.init != nil {
+			testCase.init(kubeClient)
+		}
+		err := Validate(kubeClient, beConfig)
+		if testCase.expectError && err == nil {
+			t.Errorf("%v: Expected error but got nil", testCase.desc)
+		}
+		if !testCase.expectError && err != nil {
+			t.Errorf("%v: Did not expect error but got: %v", testCase.desc, err)
+		}
+		if testCase.expectKey != "" && beConfig.Spec.Cdn.SignedUrlKeys[0].KeyValue != testCase.expectKey {
+			t.Errorf("%v: Expected key value %q but got %q", testCase.desc, testCase.expectKey, beConfig.Spec.Cdn.SignedUrlKeys[0].KeyValue)
+		}
+	}
+}
+
+func TestValidateBalancing(t *testing.T) {
+	var (
+		rate         = "RATE"
+		utilization  = "UTILIZATION"
+		connection   = "CONNECTION"
+		badMode      = "ROUND_ROBIN"
+		goodRate     = 100.0
+		zeroRate     = 0.0
+		goodFraction = 0.5
+		badFraction  = 1.5
+	)
+	testCases := []struct {
+		desc        string
+		balancing   *backendconfigv1.BalancingConfig
+		expectError bool
+	}{
+		{
+			desc:        "no balancing settings",
+			balancing:   nil,
+			expectError: false,
+		},
+		{
+			desc:        "rate settings without mode",
+			balancing:   &backendconfigv1.BalancingConfig{MaxRatePerInstance: &goodRate, MaxRatePerEndpoint: &goodRate},
+			expectError: false,
+		},
+		{
+			desc:        "utilization settings",
+			balancing:   &backendconfigv1.BalancingConfig{Mode: &utilization, MaxUtilization: &goodFraction, CapacityScaler: &goodFraction},
+			expectError: false,
+		},
+		{
+			desc:        "unsupported mode",
+			balancing:   &backendconfigv1.BalancingConfig{Mode: &badMode},
+			expectError: true,
+		},
+		{
+			desc:        "rate setting with utilization mode",
+			balancing:   &backendconfigv1.BalancingConfig{Mode: &utilization, MaxRatePerInstance: &goodRate},
+			expectError: true,
+		},
+		{
+			desc:        "utilization setting with rate mode",
+			balancing:   &backendconfigv1.BalancingConfig{Mode: &rate, MaxUtilization: &goodFraction},
+			expectError: true,
+		},
+		{
+			// HTTP(S) load balancers do not support CONNECTION.
+			desc:        "connection mode",
+			balancing:   &backendconfigv1.BalancingConfig{Mode: &connection},
+			expectError: true,
+		},
+		{
+			desc:        "zero max rate",
+			balancing:   &backendconfigv1.BalancingConfig{MaxRatePerEndpoint: &zeroRate},
+			expectError: true,
+		},
+		{
+			desc:        "max utilization greater than 1",
+			balancing:   &backendconfigv1.BalancingConfig{Mode: &utilization, MaxUtilization: &badFraction},
+			expectError: true,
+		},
+		{
+			desc:        "capacity scaler greater than 1",
+			balancing:   &backendconfigv1.BalancingConfig{CapacityScaler: &badFraction},
+			expectError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		beConfig := &backendconfigv1.BackendConfig{
+			ObjectMeta: meta_v1.ObjectMeta{
+				Namespace: "default",
+			},
+			Spec: backendconfigv1.BackendConfigSpec{
+				Balancing: testCase.balancing,
+			},
+		}
+		kubeClient := fake.NewSimpleClientset()
+		err := Validate(kubeClient, beConfig)
+		if testCase.expectError && err == nil {
+			t.Errorf("%v: Expected error but got nil", testCase.desc)
+		}
+		if !testCase.expectError && err != nil {
+			t.Errorf("%v: Did not expect error but got: %v", testCase.desc, err)
+		}
+	}
+}
+
+func TestValidateOutlierDetection(t *testing.T) {
+	var (
+		percent    = int64(50)
+		badPercent = int64(101)
+		count      = int64(5)
+		negCount   = int64(-1)
+	)
+	testCases := []struct {
+		desc             string
+		outlierDetection *backendconfigv1.OutlierDetectionConfig
+		expectError      bool
+	}{
+		{
+			desc:             "no outlier detection settings",
+			outlierDetection: nil,
+			expectError:      false,
+		},
+		{
+			desc: "valid settings",
+			outlierDetection: &backendconfigv1.OutlierDetectionConfig{
+				BaseEjectionTime:   &backendconfigv1.Duration{Seconds: 30},
+				Interval:           &backendconfigv1.Duration{Seconds: 1, Nanos: 500000000},
+				ConsecutiveErrors:  &count,
+				MaxEjectionPercent: &percent,
+			},
+			expectError: false,
+		},
+		{
+			desc:             "enforcing percentage greater than 100",
+			outlierDetection: &backendconfigv1.OutlierDetectionConfig{EnforcingSuccessRate: &badPercent},
+			expectError:      true,
+		},
+		{
+			desc:             "negative consecutive errors",
+			outlierDetection: &backendconfigv1.OutlierDetectionConfig{ConsecutiveErrors: &negCount},
+			expectError:      true,
+		},
+		{
+			desc:             "negative interval",
+			outlierDetection: &backendconfigv1.OutlierDetectionConfig{Interval: &backendconfigv1.Duration{Seconds: -1}},
+			expectError:      true,
+		},
+		{
+			desc:             "interval nanos out of range",
+			outlierDetection: &backendconfigv1.OutlierDetectionConfig{Interval: &backendconfigv1.Duration{Nanos: 1000000000}},
+			expectError:      true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		beConfig := &backendconfigv1.BackendConfig{
+			ObjectMeta: meta_v1.ObjectMeta{
+				Namespace: "default",
+			},
+			Spec: backendconfigv1.BackendConfigSpec{
+				OutlierDetection: testCase.outlierDetection,
+			},
+		}
+		kubeClient := fake.NewSimpleClientset()
+		err := Validate(kubeClient, beConfig)
+		if testCase.expectError && err == nil {
+			t.Errorf("%v: Expected error but got nil", testCase.desc)
+		}
+		if !testCase.expectError && err != nil {
+			t.Errorf("%v: Did not expect error but got: %v", testCase.desc, err)
+		}
+	}
+}
+
+func TestValidateCircuitBreakers(t *testing.T) {
+	var (
+		count    = int64(100)
+		negCount = int64(-1)
+	)
+	testCases := []struct {
+		desc            string
+		circuitBreakers *backendconfigv1.CircuitBreakersConfig
+		expectError     bool
+	}{
+		{
+			desc:            "no circuit breakers settings",
+			circuitBreakers: nil,
+			expectError:     false,
+		},
+		{
+			desc: "valid settings",
+			circuitBreakers: &backendconfigv1.CircuitBreakersConfig{
+				ConnectTimeout: &backendconfigv1.Duration{Seconds: 5},
+				MaxConnections: &count,
+				MaxRequests:    &count,
+				MaxRetries:     &count,
+			},
+			expectError: false,
+		},
+		{
+			desc:            "negative max pending requests",
+			circuitBreakers: &backendconfigv1.CircuitBreakersConfig{MaxPendingRequests: &negCount},
+			expectError:     true,
+		},
+		{
+			desc:            "negative connect timeout",
+			circuitBreakers: &backendconfigv1.CircuitBreakersConfig{ConnectTimeout: &backendconfigv1.Duration{Seconds: -5}},
+			expectError:     true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		beConfig := &backendconfigv1.BackendConfig{
+			ObjectMeta: meta_v1.ObjectMeta{
+				Namespace: "default",
+			},
+			Spec: backendconfigv1.BackendConfigSpec{
+				CircuitBreakers: testCase.circuitBreakers,
+			},
+		}
+		kubeClient := fake.NewSimpleClientset()
+		err := Validate(kubeClient, beConfig)
+		if testCase.expectError && err == nil {
+			t.Errorf("%v: Expected error but got nil", testCase.desc)
+		}
+		if !testCase.expectError && err != nil {
+			t.Errorf("%v: Did not expect error but got: %v", testCase.desc, err)
+		}
+	}
+}
+
+func TestValidateLocalityLbPolicy(t *testing.T) {
+	var (
+		leastRequest = "LEAST_REQUEST"
+		badPolicy    = "WEIGHTED"
+	)
+	testCases := []struct {
+		desc        string
+		policy      *string
+		expectError bool
+	}{
+		{
+			desc:        "no locality lb policy",
+			policy:      nil,
+			expectError: false,
+		},
+		{
+			desc:        "supported policy",
+			policy:      &leastRequest,
+			expectError: false,
+		},
+		{
+			desc:        "unsupported policy",
+			policy:      &badPolicy,
+			expectError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		beConfig := &backendconfigv1.BackendConfig{
+			ObjectMeta: meta_v1.ObjectMeta{
+				Namespace: "default",
+			},
+			Spec: backendconfigv1.BackendConfigSpec{
+				LocalityLbPolicy: testCase.policy,
+			},
+		}
+		kubeClient := fake.NewSimpleClientset()
+		err := Validate(kubeClient, beConfig)
+		if testCase.expectError && err == nil {
+			t.Errorf("%v: Expected error but got nil", testCase.desc)
+		}
+		if !testCase.expectError && err != nil {
+			t.Errorf("%v: Did not expect error but got: %v", testCase.desc, err)
+		}
+	}
+}
+
+func TestValidateFields(t *testing.T) {
+	badAffinity := "WRONG_AFFINITY"
+	badPolicy := "FASTEST"
+	unreachable := func(kubeClient *fake.Clientset) {
+		kubeClient.PrependReactor("get", "secrets", func(action core.Action) (bool, runtime.Object, error) {
+			return true, nil, fmt.Errorf("connection refused")
+		})
+	}
+
+	testCases := []struct {
+		desc       string
+		init       func(kubeClient *fake.Clientset)
+		nilClient  bool
+		spec       backendconfigv1.BackendConfigSpec
+		wantFields []string
+		wantTypes  []field.ErrorType
+	}{
+		{
+			desc: "valid config",
+			init: func(*fake.Clientset) {},
+			spec: backendconfigv1.BackendConfigSpec{
+				SessionAffinity: &backendconfigv1.SessionAffinityConfig{AffinityType: "CLIENT_IP"},
+			},
+		},
+		{
+			desc: "every invalid field is reported",
+			init: func(*fake.Clientset) {},
+			spec: backendconfigv1.BackendConfigSpec{
+				SessionAffinity:       &backendconfigv1.SessionAffinityConfig{AffinityType: badAffinity},
+				CustomResponseHeaders: &backendconfigv1.CustomResponseHeadersConfig{Headers: []string{"bad header"}},
+				LocalityLbPolicy:      &badPolicy,
+			},
+			wantFields: []string{"spec.sessionAffinity", "spec.customResponseHeaders", "spec.localityLbPolicy"},
+			wantTypes:  []field.ErrorType{field.ErrorTypeInvalid, field.ErrorTypeInvalid, field.ErrorTypeInvalid},
+		},
+		{
+			desc:       "missing secret is invalid",
+			init:       func(*fake.Clientset) {},
+			spec:       defaultBeConfig.Spec,
+			wantFields: []string{"spec.iap"},
+			wantTypes:  []field.ErrorType{field.ErrorTypeInvalid},
+		},
+		{
+			desc:       "unreachable secret is an internal error",
+			init:       unreachable,
+			spec:       defaultBeConfig.Spec,
+			wantFields: []string{"spec.iap"},
+			wantTypes:  []field.ErrorType{field.ErrorTypeInternal},
+		},
+		{
+			desc:      "secret checks are skipped without a client",
+			init:      unreachable,
+			nilClient: true,
+			spec:      defaultBeConfig.Spec,
+		},
+		{
+			desc:      "checks not needing secrets still run without a client",
+			init:      func(*fake.Clientset) {},
+			nilClient: true,
+			spec: backendconfigv1.BackendConfigSpec{
+				Iap: &backendconfigv1.IAPConfig{Enabled: true},
+				Cdn: &backendconfigv1.CDNConfig{Enabled: true},
+			},
+			wantFields: []string{"spec.iap"},
+			wantTypes:  []field.ErrorType{field.ErrorTypeInvalid},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			fakeClient := fake.NewSimpleClientset()
+			tc.init(fakeClient)
+			var kubeClient kubernetes.Interface = fakeClient
+			if tc.nilClient {
+				kubeClient = nil
+			}
+			beConfig := &backendconfigv1.BackendConfig{
+				ObjectMeta: meta_v1.ObjectMeta{Namespace: "default"},
+				Spec:       *tc.spec.DeepCopy(),
+			}
+
+			errs := ValidateFields(kubeClient, beConfig)
+			if len(errs) != len(tc.wantFields) {
+				t.Fatalf("ValidateFields() = %v, want errors for %v", errs, tc.wantFields)
+			}
+			for i, err := range errs {
+				if err.Field != tc.wantFields[i] || err.Type != tc.wantTypes[i] {
+					t.Errorf("ValidateFields()[%d] = %v, want %v error for %q", i, err, tc.wantTypes[i], tc.wantFields[i])
+				}
+			}
+		})
+	}
+}
@@ -16,6 +16,7 @@ package backends
 import (
 	"net/http"
 
+	computebeta "google.golang.org/api/compute/v0.beta"
 	compute "google.golang.org/api/compute/v1"
 	"k8s.io/ingress-gce/pkg/backends/features"
 	"k8s.io/ingress-gce/pkg/composite"
@@ -56,31 +57,41 @@ func ensureDescription(be *composite.BackendService, sp *utils.ServicePort) (nee
 	return true
 }
 
-// Create implements Pool.
-func (b *Backends) Create(sp utils.ServicePort, hcLink string) (*composite.BackendService, error) {
-	name := sp.BackendName(b.namer)
+// NewBackendService returns the BackendService which a Pool creates for sp,
+// with the given health check.
+func NewBackendService(sp utils.ServicePort, hcLink string, namer *utils.Namer) *composite.BackendService {
 	namedPort := &compute.NamedPort{
-		Name: b.namer.NamedPort(sp.NodePort),
+		Name: namer.NamedPort(sp.NodePort),
 		Port: sp.NodePort,
 	}
 
-	version := features.VersionFromServicePort(&sp)
+	scope := features.ScopeFromServicePort(&sp)
 	be := &composite.BackendService{
-		Version:      version,
-		Name:         name,
+		Version:      features.VersionFromServicePort(&sp),
+		Scope:        scope,
+		Name:         sp.BackendName(namer),
 		Protocol:     string(sp.Protocol),
 		Port:         namedPort.Port,
 		PortName:     namedPort.Name,
 		HealthChecks: []string{hcLink},
 	}
+	if scope == meta.Regional {
+		be.LoadBalancingScheme = "INTERNAL_MANAGED"
+	}
 	ensureDescription(be, &sp)
+	return be
+}
+
+// Create implements Pool.
+func (b *Backends) Create(sp utils.ServicePort, hcLink string) (*composite.BackendService, error) {
+	be := NewBackendService(sp, hcLink, b.namer)
 	if err := composite.CreateBackendService(be, b.cloud); err != nil {
 		return nil, err
 	}
 	// Note: We need to perform a GCE call to re-fetch the object we just created
 	// so that the "Fingerprint" field is filled in. This is needed to update the
 	// object without error.
-	return b.Get(name, version)
+	return b.Get(be.Name, be.Version, be.Scope)
 }
 
 // Update implements Pool.
@@ -94,8 +105,8 @@ func (b *Backends) Update(be *composite.BackendService) error {
 }
 
 // Get implements Pool.
-func (b *Backends) Get(name string, version meta.Version) (*composite.BackendService, error) {
-	be, err := composite.GetBackendService(name, version, b.cloud)
+func (b *Backends) Get(name string, version meta.Version, scope meta.KeyType) (*composite.BackendService, error) {
+	be, err := composite.GetBackendService(name, version, scope, b.cloud)
 	if err != nil {
 		return nil, err
 	}
@@ -104,7 +115,7 @@ func (b *Backends) Get(name string, version meta.Version) (*composite.BackendSer
 	// the existing backend service.
 	versionRequired := features.VersionFromDescription(be.Description)
 	if features.IsLowerVersion(versionRequired, version) {
-		be, err = composite.GetBackendService(name, versionRequired, b.cloud)
+		be, err = composite.GetBackendService(name, versionRequired, scope, b.cloud)
 		if err != nil {
 			return nil, err
 		}
@@ -113,25 +124,55 @@ func (b *Backends) Get(name string, version meta.Version) (*composite.BackendSer
 }
 
 // Delete implements Pool.
-func (b *Backends) Delete(name string) (err error) {
+func (b *Backends) Delete(name string, scope meta.KeyType) (err error) {
 	defer func() {
 		if utils.IsHTTPErrorCode(err, http.StatusNotFound) {
 			err = nil
 		}
 	}()
 
-	klog.V(2).Infof("Deleting backend service %v", name)
+	klog.V(2).Infof("Deleting %s backend service %v", scope, name)
 
 	// Try deleting health checks even if a backend is not found.
-	if err = b.cloud.DeleteGlobalBackendService(name); err != nil && !utils.IsHTTPErrorCode(err, http.StatusNotFound) {
+	if err = composite.DeleteBackendService(name, scope, b.cloud); err != nil && !utils.IsHTTPErrorCode(err, http.StatusNotFound) {
 		return err
 	}
 	return
 }
 
+// ProjectID implements Pool.
+func (b *Backends) ProjectID() string {
+	return b.cloud.ProjectID()
+}
+
+// SetSecurityPolicyForBetaGlobalBackendService implements Pool.
+func (b *Backends) SetSecurityPolicyForBetaGlobalBackendService(name string, policyRef *computebeta.SecurityPolicyReference) error {
+	return b.cloud.SetSecurityPolicyForBetaGlobalBackendService(name, policyRef)
+}
+
+// AddSignedURLKey implements Pool.
+func (b *Backends) AddSignedURLKey(name string, key *composite.SignedUrlKey) error {
+	return composite.AddSignedUrlKey(name, key, b.cloud)
+}
+
+// DeleteSignedURLKey implements Pool.
+func (b *Backends) DeleteSignedURLKey(name, keyName string) error {
+	return composite.DeleteSignedUrlKey(name, keyName, b.cloud)
+}
+
+// GetBetaGlobalBackendService implements Pool.
+func (b *Backends) GetBetaGlobalBackendService(name string) (*computebeta.BackendService, error) {
+	return b.cloud.GetBetaGlobalBackendService(name)
+}
+
+// UpdateBetaGlobalBackendService implements Pool.
+func (b *Backends) UpdateBetaGlobalBackendService(be *computebeta.BackendService) error {
+	return b.cloud.UpdateBetaGlobalBackendService(be)
+}
+
 // Health implements Pool.
 func (b *Backends) Health(name string) string {
-	be, err := b.Get(name, meta.VersionGA)
+	be, err := b.Get(name, meta.VersionGA, meta.Global)
 	if err != nil || len(be.Backends) == 0 {
 		return "Unknown"
 	}
@@ -146,20 +187,20 @@ func (b *Backends) Health(name string) string {
 	return hs.HealthStatus[0].HealthState
 }
 
-// List lists all backends managed by this controller.
-func (b *Backends) List() ([]string, error) {
+// List lists all backends of the given scope managed by this controller.
+func (b *Backends) List(scope meta.KeyType) ([]string, error) {
 	// TODO: for consistency with the rest of this sub-package this method
 	// should return a list of backend ports.
-	backends, err := b.cloud.ListGlobalBackendServices()
+	backends, err := composite.ListBackendServiceNames(scope, b.cloud)
 	if err != nil {
 		return nil, err
 	}
 
 	var names []string
 
-	for _, bs := range backends {
-		if b.namer.NameBelongsToCluster(bs.Name) {
-			names = append(names, bs.Name)
+	for _, name := range backends {
+		if b.namer.NameBelongsToCluster(name) {
+			names = append(names, name)
 		}
 	}
 	return names, nil
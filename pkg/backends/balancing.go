@@ -0,0 +1,221 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backends
+
+import (
+	computebeta "google.golang.org/api/compute/v0.beta"
+	"k8s.io/ingress-gce/pkg/composite"
+	"k8s.io/ingress-gce/pkg/utils"
+)
+
+const (
+	// defaultMaxUtilization is the utilization target GCE applies to
+	// Backends with BalancingMode UTILIZATION if none is specified.
+	defaultMaxUtilization = 0.8
+	// defaultCapacityScaler is the capacity scaler GCE applies to Backends
+	// if none is specified.
+	defaultCapacityScaler = 1.0
+)
+
+// balancingSettings are the balancing settings applied to all Backends of
+// a BackendService, as specified in the BackendConfig of a ServicePort.
+type balancingSettings struct {
+	mode               BalancingMode
+	maxRatePerInstance float64
+	maxRatePerEndpoint float64
+	maxUtilization     float64
+	capacityScaler     float64
+}
+
+// defaultBalancingSettings returns the balancing settings of Backends whose
+// BackendConfig does not specify any.
+func defaultBalancingSettings() *balancingSettings {
+	return &balancingSettings{
+		mode:               Rate,
+		maxRatePerInstance: maxRPS,
+		maxRatePerEndpoint: maxRPS,
+		maxUtilization:     defaultMaxUtilization,
+		capacityScaler:     defaultCapacityScaler,
+	}
+}
+
+// balancingSettingsFromServicePort returns the balancing settings specified
+// in the BackendConfig of the given ServicePort, with defaults filled in.
+// It returns nil if the BackendConfig does not specify any.
+func balancingSettingsFromServicePort(sp utils.ServicePort) *balancingSettings {
+	if sp.BackendConfig == nil || sp.BackendConfig.Spec.Balancing == nil {
+		return nil
+	}
+	config := sp.BackendConfig.Spec.Balancing
+	s := defaultBalancingSettings()
+	if config.Mode != nil {
+		s.mode = BalancingMode(*config.Mode)
+	}
+	if config.MaxRatePerInstance != nil {
+		s.maxRatePerInstance = *config.MaxRatePerInstance
+	}
+	if config.MaxRatePerEndpoint != nil {
+		s.maxRatePerEndpoint = *config.MaxRatePerEndpoint
+	}
+	if config.MaxUtilization != nil {
+		s.maxUtilization = *config.MaxUtilization
+	}
+	if config.CapacityScaler != nil {
+		s.capacityScaler = *config.CapacityScaler
+	}
+	return s
+}
+
+// withMode returns a copy of the settings which uses the given mode instead.
+func (s *balancingSettings) withMode(mode BalancingMode) *balancingSettings {
+	copy := *s
+	copy.mode = mode
+	return &copy
+}
+
+// applyToInstanceGroupBackend applies the settings to a Backend pointing to
+// an instance group. Only the settings valid for the mode are set.
+func (s *balancingSettings) applyToInstanceGroupBackend(b *composite.Backend) {
+	b.BalancingMode = string(s.mode)
+	b.CapacityScaler = s.capacityScaler
+	b.MaxRatePerInstance = 0
+	b.MaxConnectionsPerInstance = 0
+	b.MaxUtilization = 0
+	switch s.mode {
+	case Rate:
+		b.MaxRatePerInstance = s.maxRatePerInstance
+	case Utilization:
+		b.MaxUtilization = s.maxUtilization
+	}
+}
+
+// applyToNEGBackend applies the settings to a Backend pointing to a network
+// endpoint group. NEGs do not support UTILIZATION, so they always use RATE.
+func (s *balancingSettings) applyToNEGBackend(b *computebeta.Backend) {
+	b.BalancingMode = string(Rate)
+	b.CapacityScaler = s.capacityScaler
+	b.MaxRatePerEndpoint = s.maxRatePerEndpoint
+	b.MaxConnectionsPerEndpoint = 0
+}
+
+// instanceGroupBackendsEqual returns true if the given Backends point to the
+// same instance groups with the same balancing settings.
+func instanceGroupBackendsEqual(a, b []*composite.Backend) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	backends := make(map[string]*composite.Backend)
+	for _, backend := range a {
+		backends[relativeGroupName(backend.Group)] = backend
+	}
+	for _, backend := range b {
+		other, ok := backends[relativeGroupName(backend.Group)]
+		if !ok {
+			return false
+		}
+		if other.BalancingMode != backend.BalancingMode ||
+			other.CapacityScaler != backend.CapacityScaler ||
+			other.MaxRatePerInstance != backend.MaxRatePerInstance ||
+			other.MaxConnectionsPerInstance != backend.MaxConnectionsPerInstance ||
+			other.MaxUtilization != backend.MaxUtilization {
+			return false
+		}
+	}
+	return true
+}
+
+// instanceGroupBackendsDefault returns true if the given Backends pointing
+// to instance groups use the default balancing settings for their mode.
+// Backends in UTILIZATION are left alone since the instance groups may be
+// used in that mode by other BackendServices. GCE fills in the default
+// capacity scaler and utilization target of Backends which do not set them.
+func instanceGroupBackendsDefault(backends []*composite.Backend) bool {
+	for _, b := range backends {
+		if !isDefaultCapacityScaler(b.CapacityScaler) {
+			return false
+		}
+		switch BalancingMode(b.BalancingMode) {
+		case Rate:
+			if b.MaxRatePerInstance != maxRPS {
+				return false
+			}
+		case Utilization:
+			if b.MaxUtilization != 0 && b.MaxUtilization != defaultMaxUtilization {
+				return false
+			}
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// negBackendsBalancingUpToDate returns true if the balancing settings of the
+// existing Backends pointing to network endpoint groups need not be updated
+// to those of the target Backends. If settings is nil, the existing Backends
+// are only updated if they do not use the default settings.
+func negBackendsBalancingUpToDate(existing, target []*computebeta.Backend, settings *balancingSettings) bool {
+	if settings != nil {
+		return negBackendsBalancingEqual(existing, target)
+	}
+	for _, b := range existing {
+		if b.BalancingMode != string(Rate) || b.MaxRatePerEndpoint != maxRPS || !isDefaultCapacityScaler(b.CapacityScaler) {
+			return false
+		}
+	}
+	return true
+}
+
+func isDefaultCapacityScaler(capacityScaler float64) bool {
+	return capacityScaler == 0 || capacityScaler == defaultCapacityScaler
+}
+
+// negBackendsBalancingEqual returns true if the given Backends pointing to
+// network endpoint groups have the same balancing settings. The groups
+// themselves are compared separately.
+func negBackendsBalancingEqual(a, b []*computebeta.Backend) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	backends := make(map[string]*computebeta.Backend)
+	for _, backend := range a {
+		backends[backend.Group] = backend
+	}
+	for _, backend := range b {
+		other, ok := backends[backend.Group]
+		if !ok {
+			return false
+		}
+		if other.BalancingMode != backend.BalancingMode ||
+			other.CapacityScaler != backend.CapacityScaler ||
+			other.MaxRatePerEndpoint != backend.MaxRatePerEndpoint ||
+			other.MaxConnectionsPerEndpoint != backend.MaxConnectionsPerEndpoint {
+			return false
+		}
+	}
+	return true
+}
+
+// relativeGroupName returns the relative resource name of the given group
+// link, so that links using different API versions compare equal.
+func relativeGroupName(link string) string {
+	name, err := utils.RelativeResourceName(link)
+	if err != nil {
+		return link
+	}
+	return name
+}
@@ -17,11 +17,18 @@ limitations under the License.
 package features
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
 	"reflect"
+	"sort"
 
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog"
+
+	backendconfigv1 "k8s.io/ingress-gce/pkg/apis/backendconfig/v1"
 	"k8s.io/ingress-gce/pkg/composite"
 	"k8s.io/ingress-gce/pkg/utils"
-	"k8s.io/klog"
 )
 
 // EnsureCDN reads the CDN configuration specified in the ServicePort.BackendConfig
@@ -31,10 +38,9 @@ func EnsureCDN(sp utils.ServicePort, be *composite.BackendService) bool {
 	if sp.BackendConfig.Spec.Cdn == nil {
 		return false
 	}
-	beTemp := &composite.BackendService{}
+	beTemp := &composite.BackendService{EnableCDN: be.EnableCDN, CdnPolicy: be.CdnPolicy}
 	applyCDNSettings(sp, beTemp)
-	// Only compare CdnPolicy if it was specified.
-	if (beTemp.CdnPolicy != nil && !reflect.DeepEqual(beTemp.CdnPolicy, be.CdnPolicy)) || beTemp.EnableCDN != be.EnableCDN {
+	if !reflect.DeepEqual(beTemp.CdnPolicy, be.CdnPolicy) || beTemp.EnableCDN != be.EnableCDN {
 		applyCDNSettings(sp, be)
 		klog.V(2).Infof("Updated CDN settings for service %v/%v.", sp.ID.Service.Namespace, sp.ID.Service.Name)
 		return true
@@ -46,20 +52,168 @@ func EnsureCDN(sp utils.ServicePort, be *composite.BackendService) bool {
 // to the passed in compute.BackendService. A GCE API call still needs to be
 // made to actually persist the changes.
 func applyCDNSettings(sp utils.ServicePort, be *composite.BackendService) {
-	beConfig := sp.BackendConfig
+	cdnConfig := sp.BackendConfig.Spec.Cdn
 	// Apply the boolean switch
-	be.EnableCDN = beConfig.Spec.Cdn.Enabled
-	cacheKeyPolicy := beConfig.Spec.Cdn.CachePolicy
+	be.EnableCDN = cdnConfig.Enabled
+	if !hasCDNPolicySettings(cdnConfig) {
+		return
+	}
+	// Only the settings specified in the BackendConfig are overwritten so
+	// that values defaulted by GCE do not cause perpetual updates.
+	cdnPolicy := &composite.BackendServiceCdnPolicy{}
+	if be.CdnPolicy != nil {
+		*cdnPolicy = *be.CdnPolicy
+	}
 	// Apply the cache key policies if the BackendConfig contains them.
-	if cacheKeyPolicy != nil {
-		be.CdnPolicy = &composite.BackendServiceCdnPolicy{CacheKeyPolicy: &composite.CacheKeyPolicy{}}
-		be.CdnPolicy.CacheKeyPolicy.IncludeHost = cacheKeyPolicy.IncludeHost
-		be.CdnPolicy.CacheKeyPolicy.IncludeProtocol = cacheKeyPolicy.IncludeProtocol
-		be.CdnPolicy.CacheKeyPolicy.IncludeQueryString = cacheKeyPolicy.IncludeQueryString
-		be.CdnPolicy.CacheKeyPolicy.QueryStringBlacklist = cacheKeyPolicy.QueryStringBlacklist
-		be.CdnPolicy.CacheKeyPolicy.QueryStringWhitelist = cacheKeyPolicy.QueryStringWhitelist
+	if cacheKeyPolicy := cdnConfig.CachePolicy; cacheKeyPolicy != nil {
+		cdnPolicy.CacheKeyPolicy = &composite.CacheKeyPolicy{
+			IncludeHost:          cacheKeyPolicy.IncludeHost,
+			IncludeProtocol:      cacheKeyPolicy.IncludeProtocol,
+			IncludeQueryString:   cacheKeyPolicy.IncludeQueryString,
+			QueryStringBlacklist: cacheKeyPolicy.QueryStringBlacklist,
+			QueryStringWhitelist: cacheKeyPolicy.QueryStringWhitelist,
+		}
 	}
 	// Note that upon creation of a BackendServices, the fields 'IncludeHost',
 	// 'IncludeProtocol' and 'IncludeQueryString' all default to true if not
 	// explicitly specified.
+	if cdnConfig.CacheMode != nil {
+		cdnPolicy.CacheMode = *cdnConfig.CacheMode
+		// TTLs cannot be set when the cache mode defers to the origin.
+		if cdnPolicy.CacheMode == "USE_ORIGIN_HEADERS" {
+			cdnPolicy.ClientTtl, cdnPolicy.DefaultTtl, cdnPolicy.MaxTtl = 0, 0, 0
+		}
+	}
+	if cdnConfig.ClientTtl != nil {
+		cdnPolicy.ClientTtl = *cdnConfig.ClientTtl
+	}
+	if cdnConfig.DefaultTtl != nil {
+		cdnPolicy.DefaultTtl = *cdnConfig.DefaultTtl
+	}
+	if cdnConfig.MaxTtl != nil {
+		cdnPolicy.MaxTtl = *cdnConfig.MaxTtl
+	}
+	if cdnConfig.NegativeCaching != nil {
+		cdnPolicy.NegativeCaching = *cdnConfig.NegativeCaching
+		if !cdnPolicy.NegativeCaching {
+			cdnPolicy.NegativeCachingPolicy = nil
+		}
+	}
+	if len(cdnConfig.NegativeCachingPolicy) > 0 {
+		cdnPolicy.NegativeCachingPolicy = nil
+		for _, policy := range cdnConfig.NegativeCachingPolicy {
+			if policy == nil {
+				continue
+			}
+			cdnPolicy.NegativeCachingPolicy = append(cdnPolicy.NegativeCachingPolicy, &composite.BackendServiceCdnPolicyNegativeCachingPolicy{
+				Code: policy.Code,
+				Ttl:  policy.Ttl,
+			})
+		}
+	}
+	if cdnConfig.SignedUrlCacheMaxAgeSec != nil {
+		cdnPolicy.SignedUrlCacheMaxAgeSec = *cdnConfig.SignedUrlCacheMaxAgeSec
+	}
+	be.CdnPolicy = cdnPolicy
+}
+
+// hasCDNPolicySettings returns true if the CDNConfig specifies any setting
+// which is stored in the CdnPolicy of a BackendService.
+func hasCDNPolicySettings(cdnConfig *backendconfigv1.CDNConfig) bool {
+	return cdnConfig.CachePolicy != nil || cdnConfig.SignedUrlCacheMaxAgeSec != nil || usesCDNCacheMode(cdnConfig)
+}
+
+// usesCDNCacheMode returns true if the CDNConfig specifies a cache mode,
+// TTLs or negative caching.
+func usesCDNCacheMode(cdnConfig *backendconfigv1.CDNConfig) bool {
+	if cdnConfig == nil {
+		return false
+	}
+	return cdnConfig.CacheMode != nil || cdnConfig.ClientTtl != nil || cdnConfig.DefaultTtl != nil || cdnConfig.MaxTtl != nil ||
+		cdnConfig.NegativeCaching != nil || len(cdnConfig.NegativeCachingPolicy) > 0
+}
+
+// SignedURLKeys adds and deletes the CDN signed URL keys of global backend
+// services.
+type SignedURLKeys interface {
+	AddSignedURLKey(beName string, key *composite.SignedUrlKey) error
+	DeleteSignedURLKey(beName, keyName string) error
+}
+
+// EnsureSignedURLKeys reconciles the CDN signed URL keys specified in the
+// ServicePort.BackendConfig with the keys on the BackendService. GCE never
+// returns key values, so a key is rotated by deleting and re-adding it when
+// the fingerprint recorded in the BackendService description changes. Only
+// keys recorded in the description are ever deleted.
+// This must be called before the description of the BackendService is updated.
+func EnsureSignedURLKeys(keys SignedURLKeys, sp utils.ServicePort, be *composite.BackendService, beName string) error {
+	if sp.BackendConfig.Spec.Cdn == nil {
+		return nil
+	}
+
+	toDelete, toAdd := signedURLKeysToSync(sp, be)
+	for _, keyName := range toDelete {
+		klog.V(2).Infof("Deleting signed url key %q from backend service %s (%s:%s)", keyName, beName, sp.ID.Service.String(), sp.ID.Port.String())
+		if err := keys.DeleteSignedURLKey(beName, keyName); err != nil && !utils.IsNotFoundError(err) {
+			return fmt.Errorf("failed to delete signed url key %q from backend service %s (%s:%s): %v", keyName, beName, sp.ID.Service.String(), sp.ID.Port.String(), err)
+		}
+	}
+	for _, key := range toAdd {
+		klog.V(2).Infof("Adding signed url key %q to backend service %s (%s:%s)", key.KeyName, beName, sp.ID.Service.String(), sp.ID.Port.String())
+		if err := keys.AddSignedURLKey(beName, key); err != nil {
+			return fmt.Errorf("failed to add signed url key %q to backend service %s (%s:%s): %v", key.KeyName, beName, sp.ID.Service.String(), sp.ID.Port.String(), err)
+		}
+	}
+	return nil
+}
+
+// signedURLKeysToSync returns the names of the signed URL keys that need to
+// be deleted from the BackendService and the keys that need to be added to it.
+func signedURLKeysToSync(sp utils.ServicePort, be *composite.BackendService) ([]string, []*composite.SignedUrlKey) {
+	existing := sets.NewString()
+	if be.CdnPolicy != nil {
+		existing.Insert(be.CdnPolicy.SignedUrlKeyNames...)
+	}
+	recorded := utils.DescriptionFromString(be.Description).SignedURLKeys
+	desired := signedURLKeyFingerprints(&sp)
+
+	var toDelete []string
+	var toAdd []*composite.SignedUrlKey
+	for keyName := range recorded {
+		if _, ok := desired[keyName]; !ok && existing.Has(keyName) {
+			toDelete = append(toDelete, keyName)
+		}
+	}
+	for _, key := range sp.BackendConfig.Spec.Cdn.SignedUrlKeys {
+		if key == nil {
+			continue
+		}
+		if existing.Has(key.KeyName) {
+			if recorded[key.KeyName] == desired[key.KeyName] {
+				continue
+			}
+			// The key value changed or is unknown, so it has to be replaced.
+			toDelete = append(toDelete, key.KeyName)
+		}
+		toAdd = append(toAdd, &composite.SignedUrlKey{KeyName: key.KeyName, KeyValue: key.KeyValue})
+	}
+	sort.Strings(toDelete)
+	return toDelete, toAdd
+}
+
+// signedURLKeyFingerprints returns a map from the name of each signed URL
+// key specified for the given ServicePort to a fingerprint of its value.
+func signedURLKeyFingerprints(sp *utils.ServicePort) map[string]string {
+	if sp.BackendConfig == nil || sp.BackendConfig.Spec.Cdn == nil || len(sp.BackendConfig.Spec.Cdn.SignedUrlKeys) == 0 {
+		return nil
+	}
+	fingerprints := make(map[string]string)
+	for _, key := range sp.BackendConfig.Spec.Cdn.SignedUrlKeys {
+		if key == nil {
+			continue
+		}
+		sum := sha256.Sum256([]byte(key.KeyValue))
+		fingerprints[key.KeyName] = hex.EncodeToString(sum[:8])
+	}
+	return fingerprints
 }
@@ -17,25 +17,34 @@ limitations under the License.
 package features
 
 import (
+	"reflect"
 	"testing"
 
-	backendconfigv1beta1 "k8s.io/ingress-gce/pkg/apis/backendconfig/v1beta1"
+	backendconfigv1 "k8s.io/ingress-gce/pkg/apis/backendconfig/v1"
 	"k8s.io/ingress-gce/pkg/composite"
 	"k8s.io/ingress-gce/pkg/utils"
 )
 
 func TestEnsureCDN(t *testing.T) {
+	var (
+		cacheAllStatic   = "CACHE_ALL_STATIC"
+		useOriginHeaders = "USE_ORIGIN_HEADERS"
+		ttl              = int64(60)
+		enabled          = true
+		disabled         = false
+	)
 	testCases := []struct {
 		desc           string
 		sp             utils.ServicePort
 		be             *composite.BackendService
 		updateExpected bool
+		expectedPolicy *composite.BackendServiceCdnPolicy
 	}{
 		{
 			desc: "cdn setting are missing from spec, no update needed",
 			sp: utils.ServicePort{
-				BackendConfig: &backendconfigv1beta1.BackendConfig{
-					Spec: backendconfigv1beta1.BackendConfigSpec{
+				BackendConfig: &backendconfigv1.BackendConfig{
+					Spec: backendconfigv1.BackendConfigSpec{
 						Cdn: nil,
 					},
 				},
@@ -53,9 +62,9 @@ func TestEnsureCDN(t *testing.T) {
 		{
 			desc: "cache policies are missing from spec, no update needed",
 			sp: utils.ServicePort{
-				BackendConfig: &backendconfigv1beta1.BackendConfig{
-					Spec: backendconfigv1beta1.BackendConfigSpec{
-						Cdn: &backendconfigv1beta1.CDNConfig{
+				BackendConfig: &backendconfigv1.BackendConfig{
+					Spec: backendconfigv1.BackendConfigSpec{
+						Cdn: &backendconfigv1.CDNConfig{
 							Enabled: true,
 						},
 					},
@@ -74,11 +83,11 @@ func TestEnsureCDN(t *testing.T) {
 		{
 			desc: "settings are identical, no update needed",
 			sp: utils.ServicePort{
-				BackendConfig: &backendconfigv1beta1.BackendConfig{
-					Spec: backendconfigv1beta1.BackendConfigSpec{
-						Cdn: &backendconfigv1beta1.CDNConfig{
+				BackendConfig: &backendconfigv1.BackendConfig{
+					Spec: backendconfigv1.BackendConfigSpec{
+						Cdn: &backendconfigv1.CDNConfig{
 							Enabled: true,
-							CachePolicy: &backendconfigv1beta1.CacheKeyPolicy{
+							CachePolicy: &backendconfigv1.CacheKeyPolicy{
 								IncludeHost: true,
 							},
 						},
@@ -98,11 +107,11 @@ func TestEnsureCDN(t *testing.T) {
 		{
 			desc: "cache settings are different, update needed",
 			sp: utils.ServicePort{
-				BackendConfig: &backendconfigv1beta1.BackendConfig{
-					Spec: backendconfigv1beta1.BackendConfigSpec{
-						Cdn: &backendconfigv1beta1.CDNConfig{
+				BackendConfig: &backendconfigv1.BackendConfig{
+					Spec: backendconfigv1.BackendConfigSpec{
+						Cdn: &backendconfigv1.CDNConfig{
 							Enabled: true,
-							CachePolicy: &backendconfigv1beta1.CacheKeyPolicy{
+							CachePolicy: &backendconfigv1.CacheKeyPolicy{
 								IncludeHost:        true,
 								IncludeQueryString: false,
 								IncludeProtocol:    false,
@@ -126,9 +135,9 @@ func TestEnsureCDN(t *testing.T) {
 		{
 			desc: "enabled setting is different, update needed",
 			sp: utils.ServicePort{
-				BackendConfig: &backendconfigv1beta1.BackendConfig{
-					Spec: backendconfigv1beta1.BackendConfigSpec{
-						Cdn: &backendconfigv1beta1.CDNConfig{
+				BackendConfig: &backendconfigv1.BackendConfig{
+					Spec: backendconfigv1.BackendConfigSpec{
+						Cdn: &backendconfigv1.CDNConfig{
 							Enabled: true,
 						},
 					},
@@ -139,6 +148,139 @@ func TestEnsureCDN(t *testing.T) {
 			},
 			updateExpected: true,
 		},
+		{
+			desc: "cache mode and ttls are different, update needed",
+			sp: utils.ServicePort{
+				BackendConfig: &backendconfigv1.BackendConfig{
+					Spec: backendconfigv1.BackendConfigSpec{
+						Cdn: &backendconfigv1.CDNConfig{
+							Enabled:    true,
+							CacheMode:  &cacheAllStatic,
+							DefaultTtl: &ttl,
+						},
+					},
+				},
+			},
+			be: &composite.BackendService{
+				EnableCDN: true,
+				CdnPolicy: &composite.BackendServiceCdnPolicy{
+					CacheMode:  "USE_ORIGIN_HEADERS",
+					DefaultTtl: 0,
+				},
+			},
+			updateExpected: true,
+			expectedPolicy: &composite.BackendServiceCdnPolicy{
+				CacheMode:  "CACHE_ALL_STATIC",
+				DefaultTtl: 60,
+			},
+		},
+		{
+			desc: "settings defaulted by GCE are preserved, no update needed",
+			sp: utils.ServicePort{
+				BackendConfig: &backendconfigv1.BackendConfig{
+					Spec: backendconfigv1.BackendConfigSpec{
+						Cdn: &backendconfigv1.CDNConfig{
+							Enabled:   true,
+							CacheMode: &cacheAllStatic,
+						},
+					},
+				},
+			},
+			be: &composite.BackendService{
+				EnableCDN: true,
+				CdnPolicy: &composite.BackendServiceCdnPolicy{
+					CacheMode:               "CACHE_ALL_STATIC",
+					ClientTtl:               3600,
+					DefaultTtl:              3600,
+					MaxTtl:                  86400,
+					SignedUrlCacheMaxAgeSec: 3600,
+					SignedUrlKeyNames:       []string{"key-1"},
+				},
+			},
+			updateExpected: false,
+		},
+		{
+			desc: "switching to origin headers clears ttls, update needed",
+			sp: utils.ServicePort{
+				BackendConfig: &backendconfigv1.BackendConfig{
+					Spec: backendconfigv1.BackendConfigSpec{
+						Cdn: &backendconfigv1.CDNConfig{
+							Enabled:   true,
+							CacheMode: &useOriginHeaders,
+						},
+					},
+				},
+			},
+			be: &composite.BackendService{
+				EnableCDN: true,
+				CdnPolicy: &composite.BackendServiceCdnPolicy{
+					CacheMode:  "CACHE_ALL_STATIC",
+					ClientTtl:  3600,
+					DefaultTtl: 3600,
+					MaxTtl:     86400,
+				},
+			},
+			updateExpected: true,
+			expectedPolicy: &composite.BackendServiceCdnPolicy{
+				CacheMode: "USE_ORIGIN_HEADERS",
+			},
+		},
+		{
+			desc: "negative caching policy is different, update needed",
+			sp: utils.ServicePort{
+				BackendConfig: &backendconfigv1.BackendConfig{
+					Spec: backendconfigv1.BackendConfigSpec{
+						Cdn: &backendconfigv1.CDNConfig{
+							Enabled:         true,
+							NegativeCaching: &enabled,
+							NegativeCachingPolicy: []*backendconfigv1.NegativeCachingPolicy{
+								{Code: 404, Ttl: 120},
+							},
+						},
+					},
+				},
+			},
+			be: &composite.BackendService{
+				EnableCDN: true,
+				CdnPolicy: &composite.BackendServiceCdnPolicy{
+					NegativeCaching: true,
+					NegativeCachingPolicy: []*composite.BackendServiceCdnPolicyNegativeCachingPolicy{
+						{Code: 404, Ttl: 60},
+					},
+				},
+			},
+			updateExpected: true,
+			expectedPolicy: &composite.BackendServiceCdnPolicy{
+				NegativeCaching: true,
+				NegativeCachingPolicy: []*composite.BackendServiceCdnPolicyNegativeCachingPolicy{
+					{Code: 404, Ttl: 120},
+				},
+			},
+		},
+		{
+			desc: "negative caching is disabled, update needed",
+			sp: utils.ServicePort{
+				BackendConfig: &backendconfigv1.BackendConfig{
+					Spec: backendconfigv1.BackendConfigSpec{
+						Cdn: &backendconfigv1.CDNConfig{
+							Enabled:         true,
+							NegativeCaching: &disabled,
+						},
+					},
+				},
+			},
+			be: &composite.BackendService{
+				EnableCDN: true,
+				CdnPolicy: &composite.BackendServiceCdnPolicy{
+					NegativeCaching: true,
+					NegativeCachingPolicy: []*composite.BackendServiceCdnPolicyNegativeCachingPolicy{
+						{Code: 404, Ttl: 60},
+					},
+				},
+			},
+			updateExpected: true,
+			expectedPolicy: &composite.BackendServiceCdnPolicy{},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -147,6 +289,115 @@ func TestEnsureCDN(t *testing.T) {
 			if result != tc.updateExpected {
 				t.Errorf("%v: expected %v but got %v", tc.desc, tc.updateExpected, result)
 			}
+			if tc.expectedPolicy != nil && !reflect.DeepEqual(tc.be.CdnPolicy, tc.expectedPolicy) {
+				t.Errorf("%v: expected CdnPolicy %+v but got %+v", tc.desc, tc.expectedPolicy, tc.be.CdnPolicy)
+			}
+		})
+	}
+}
+
+func TestSignedURLKeysToSync(t *testing.T) {
+	const (
+		value1 = "AAECAwQFBgcICQoLDA0ODw=="
+		value2 = "Dw4NDAsKCQgHBgUEAwIBAA=="
+	)
+	fingerprint := func(keys ...*backendconfigv1.SignedUrlKey) map[string]string {
+		return signedURLKeyFingerprints(&utils.ServicePort{
+			BackendConfig: &backendconfigv1.BackendConfig{
+				Spec: backendconfigv1.BackendConfigSpec{
+					Cdn: &backendconfigv1.CDNConfig{SignedUrlKeys: keys},
+				},
+			},
+		})
+	}
+	description := func(fingerprints map[string]string) string {
+		return utils.Description{ServiceName: "my-service", ServicePort: "80", SignedURLKeys: fingerprints}.String()
+	}
+
+	testCases := []struct {
+		desc           string
+		keys           []*backendconfigv1.SignedUrlKey
+		be             *composite.BackendService
+		expectedDelete []string
+		expectedAdd    []string
+	}{
+		{
+			desc: "no keys, nothing to do",
+			be:   &composite.BackendService{},
+		},
+		{
+			desc:        "new key is added",
+			keys:        []*backendconfigv1.SignedUrlKey{{KeyName: "key-1", KeyValue: value1}},
+			be:          &composite.BackendService{},
+			expectedAdd: []string{"key-1"},
+		},
+		{
+			desc: "key is in sync, nothing to do",
+			keys: []*backendconfigv1.SignedUrlKey{{KeyName: "key-1", KeyValue: value1}},
+			be: &composite.BackendService{
+				CdnPolicy:   &composite.BackendServiceCdnPolicy{SignedUrlKeyNames: []string{"key-1"}},
+				Description: description(fingerprint(&backendconfigv1.SignedUrlKey{KeyName: "key-1", KeyValue: value1})),
+			},
+		},
+		{
+			desc: "key value changed, key is replaced",
+			keys: []*backendconfigv1.SignedUrlKey{{KeyName: "key-1", KeyValue: value2}},
+			be: &composite.BackendService{
+				CdnPolicy:   &composite.BackendServiceCdnPolicy{SignedUrlKeyNames: []string{"key-1"}},
+				Description: description(fingerprint(&backendconfigv1.SignedUrlKey{KeyName: "key-1", KeyValue: value1})),
+			},
+			expectedDelete: []string{"key-1"},
+			expectedAdd:    []string{"key-1"},
+		},
+		{
+			desc: "unrecorded key with the same name is replaced",
+			keys: []*backendconfigv1.SignedUrlKey{{KeyName: "key-1", KeyValue: value1}},
+			be: &composite.BackendService{
+				CdnPolicy: &composite.BackendServiceCdnPolicy{SignedUrlKeyNames: []string{"key-1"}},
+			},
+			expectedDelete: []string{"key-1"},
+			expectedAdd:    []string{"key-1"},
+		},
+		{
+			desc: "removed key is deleted",
+			keys: []*backendconfigv1.SignedUrlKey{{KeyName: "key-2", KeyValue: value2}},
+			be: &composite.BackendService{
+				CdnPolicy: &composite.BackendServiceCdnPolicy{SignedUrlKeyNames: []string{"key-1", "key-2"}},
+				Description: description(fingerprint(
+					&backendconfigv1.SignedUrlKey{KeyName: "key-1", KeyValue: value1},
+					&backendconfigv1.SignedUrlKey{KeyName: "key-2", KeyValue: value2},
+				)),
+			},
+			expectedDelete: []string{"key-1"},
+		},
+		{
+			desc: "keys not added by the controller are kept",
+			be: &composite.BackendService{
+				CdnPolicy: &composite.BackendServiceCdnPolicy{SignedUrlKeyNames: []string{"manual-key"}},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			sp := utils.ServicePort{
+				BackendConfig: &backendconfigv1.BackendConfig{
+					Spec: backendconfigv1.BackendConfigSpec{
+						Cdn: &backendconfigv1.CDNConfig{Enabled: true, SignedUrlKeys: tc.keys},
+					},
+				},
+			}
+			toDelete, toAdd := signedURLKeysToSync(sp, tc.be)
+			if !reflect.DeepEqual(toDelete, tc.expectedDelete) {
+				t.Errorf("%v: expected keys to delete %v but got %v", tc.desc, tc.expectedDelete, toDelete)
+			}
+			var added []string
+			for _, key := range toAdd {
+				added = append(added, key.KeyName)
+			}
+			if !reflect.DeepEqual(added, tc.expectedAdd) {
+				t.Errorf("%v: expected keys to add %v but got %v", tc.desc, tc.expectedAdd, added)
+			}
 		})
 	}
 }
@@ -0,0 +1,74 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package features
+
+import (
+	"reflect"
+
+	"k8s.io/ingress-gce/pkg/composite"
+	"k8s.io/ingress-gce/pkg/utils"
+	"k8s.io/klog"
+)
+
+// EnsureCircuitBreakers reads the CircuitBreakers configuration specified in
+// the ServicePort.BackendConfig and applies it to the BackendService.
+// It returns true if there were existing settings on the BackendService
+// that were overwritten.
+func EnsureCircuitBreakers(sp utils.ServicePort, be *composite.BackendService) bool {
+	if sp.BackendConfig.Spec.CircuitBreakers == nil {
+		return false
+	}
+	beTemp := &composite.BackendService{CircuitBreakers: be.CircuitBreakers}
+	applyCircuitBreakersSettings(sp, beTemp)
+	if !reflect.DeepEqual(beTemp.CircuitBreakers, be.CircuitBreakers) {
+		applyCircuitBreakersSettings(sp, be)
+		klog.V(2).Infof("Updated CircuitBreakers settings for service %v/%v.", sp.ID.Service.Namespace, sp.ID.Service.Name)
+		return true
+	}
+	return false
+}
+
+// applyCircuitBreakersSettings applies the CircuitBreakers settings
+// specified in the BackendConfig to the passed in composite.BackendService.
+// Only the specified settings are overwritten so that values defaulted by
+// GCE do not cause perpetual updates. A GCE API call still needs to be made
+// to actually persist the changes.
+func applyCircuitBreakersSettings(sp utils.ServicePort, be *composite.BackendService) {
+	config := sp.BackendConfig.Spec.CircuitBreakers
+	circuitBreakers := &composite.CircuitBreakers{}
+	if be.CircuitBreakers != nil {
+		*circuitBreakers = *be.CircuitBreakers
+	}
+	if config.ConnectTimeout != nil {
+		circuitBreakers.ConnectTimeout = toCompositeDuration(config.ConnectTimeout)
+	}
+	for _, field := range []struct {
+		val *int64
+		dst *int64
+	}{
+		{config.MaxConnections, &circuitBreakers.MaxConnections},
+		{config.MaxPendingRequests, &circuitBreakers.MaxPendingRequests},
+		{config.MaxRequests, &circuitBreakers.MaxRequests},
+		{config.MaxRequestsPerConnection, &circuitBreakers.MaxRequestsPerConnection},
+		{config.MaxRetries, &circuitBreakers.MaxRetries},
+	} {
+		if field.val != nil {
+			*field.dst = *field.val
+		}
+	}
+	be.CircuitBreakers = circuitBreakers
+}
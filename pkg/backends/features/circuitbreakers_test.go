@@ -0,0 +1,121 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package features
+
+import (
+	"reflect"
+	"testing"
+
+	backendconfigv1 "k8s.io/ingress-gce/pkg/apis/backendconfig/v1"
+	"k8s.io/ingress-gce/pkg/composite"
+	"k8s.io/ingress-gce/pkg/utils"
+)
+
+func TestEnsureCircuitBreakers(t *testing.T) {
+	var (
+		maxRequests = int64(1000)
+		maxRetries  = int64(3)
+	)
+	testCases := []struct {
+		desc           string
+		sp             utils.ServicePort
+		be             *composite.BackendService
+		updateExpected bool
+		expected       *composite.CircuitBreakers
+	}{
+		{
+			desc:           "circuit breakers settings missing from spec, no update needed",
+			sp:             utils.ServicePort{BackendConfig: &backendconfigv1.BackendConfig{}},
+			be:             &composite.BackendService{CircuitBreakers: &composite.CircuitBreakers{MaxRequests: 10}},
+			updateExpected: false,
+		},
+		{
+			desc: "settings are identical, no update needed",
+			sp: utils.ServicePort{
+				BackendConfig: &backendconfigv1.BackendConfig{
+					Spec: backendconfigv1.BackendConfigSpec{
+						CircuitBreakers: &backendconfigv1.CircuitBreakersConfig{
+							MaxRequests:    &maxRequests,
+							ConnectTimeout: &backendconfigv1.Duration{Seconds: 5},
+						},
+					},
+				},
+			},
+			be: &composite.BackendService{
+				CircuitBreakers: &composite.CircuitBreakers{
+					MaxRequests:        1000,
+					MaxPendingRequests: 1024,
+					ConnectTimeout:     &composite.Duration{Seconds: 5},
+				},
+			},
+			updateExpected: false,
+		},
+		{
+			desc: "circuit breakers missing from backend service, update needed",
+			sp: utils.ServicePort{
+				BackendConfig: &backendconfigv1.BackendConfig{
+					Spec: backendconfigv1.BackendConfigSpec{
+						CircuitBreakers: &backendconfigv1.CircuitBreakersConfig{
+							MaxRetries: &maxRetries,
+						},
+					},
+				},
+			},
+			be:             &composite.BackendService{},
+			updateExpected: true,
+			expected:       &composite.CircuitBreakers{MaxRetries: 3},
+		},
+		{
+			desc: "settings are different, update needed",
+			sp: utils.ServicePort{
+				BackendConfig: &backendconfigv1.BackendConfig{
+					Spec: backendconfigv1.BackendConfigSpec{
+						CircuitBreakers: &backendconfigv1.CircuitBreakersConfig{
+							MaxRequests:    &maxRequests,
+							ConnectTimeout: &backendconfigv1.Duration{Seconds: 2},
+						},
+					},
+				},
+			},
+			be: &composite.BackendService{
+				CircuitBreakers: &composite.CircuitBreakers{
+					MaxRequests:    10,
+					MaxRetries:     3,
+					ConnectTimeout: &composite.Duration{Seconds: 5},
+				},
+			},
+			updateExpected: true,
+			expected: &composite.CircuitBreakers{
+				MaxRequests:    1000,
+				MaxRetries:     3,
+				ConnectTimeout: &composite.Duration{Seconds: 2},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			result := EnsureCircuitBreakers(tc.sp, tc.be)
+			if result != tc.updateExpected {
+				t.Errorf("%v: expected %v but got %v", tc.desc, tc.updateExpected, result)
+			}
+			if tc.expected != nil && !reflect.DeepEqual(tc.be.CircuitBreakers, tc.expected) {
+				t.Errorf("%v: expected CircuitBreakers %+v but got %+v", tc.desc, tc.expected, tc.be.CircuitBreakers)
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package features
+
+import (
+	"reflect"
+
+	"k8s.io/ingress-gce/pkg/composite"
+	"k8s.io/ingress-gce/pkg/utils"
+	"k8s.io/klog"
+)
+
+// EnsureCustomRequestHeaders reads the CustomRequestHeaders configuration specified
+// in the ServicePort.BackendConfig and applies it to the BackendService. It returns
+// true if there were existing settings on the BackendService that were overwritten.
+func EnsureCustomRequestHeaders(sp utils.ServicePort, be *composite.BackendService) bool {
+	if sp.BackendConfig.Spec.CustomRequestHeaders == nil {
+		return false
+	}
+	beTemp := &composite.BackendService{}
+	applyCustomRequestHeadersSettings(sp, beTemp)
+	if !headersEqual(beTemp.CustomRequestHeaders, be.CustomRequestHeaders) {
+		applyCustomRequestHeadersSettings(sp, be)
+		klog.V(2).Infof("Updated CustomRequestHeaders settings for service %v/%v.", sp.ID.Service.Namespace, sp.ID.Service.Name)
+		return true
+	}
+	return false
+}
+
+// EnsureCustomResponseHeaders reads the CustomResponseHeaders configuration specified
+// in the ServicePort.BackendConfig and applies it to the BackendService. It returns
+// true if there were existing settings on the BackendService that were overwritten.
+func EnsureCustomResponseHeaders(sp utils.ServicePort, be *composite.BackendService) bool {
+	if sp.BackendConfig.Spec.CustomResponseHeaders == nil {
+		return false
+	}
+	beTemp := &composite.BackendService{}
+	applyCustomResponseHeadersSettings(sp, beTemp)
+	if !headersEqual(beTemp.CustomResponseHeaders, be.CustomResponseHeaders) {
+		applyCustomResponseHeadersSettings(sp, be)
+		klog.V(2).Infof("Updated CustomResponseHeaders settings for service %v/%v.", sp.ID.Service.Namespace, sp.ID.Service.Name)
+		return true
+	}
+	return false
+}
+
+// applyCustomRequestHeadersSettings applies the CustomRequestHeaders settings
+// specified in the BackendConfig to the passed in composite.BackendService.
+// A GCE API call still needs to be made to actually persist the changes.
+func applyCustomRequestHeadersSettings(sp utils.ServicePort, be *composite.BackendService) {
+	be.CustomRequestHeaders = sp.BackendConfig.Spec.CustomRequestHeaders.Headers
+}
+
+// applyCustomResponseHeadersSettings applies the CustomResponseHeaders settings
+// specified in the BackendConfig to the passed in composite.BackendService.
+// A GCE API call still needs to be made to actually persist the changes.
+func applyCustomResponseHeadersSettings(sp utils.ServicePort, be *composite.BackendService) {
+	be.CustomResponseHeaders = sp.BackendConfig.Spec.CustomResponseHeaders.Headers
+}
+
+// headersEqual returns true if the two header lists are identical. The order
+// of headers is significant. A nil list is considered equal to an empty one
+// since GCE does not distinguish between the two.
+func headersEqual(a, b []string) bool {
+	if len(a) == 0 && len(b) == 0 {
+		return true
+	}
+	return reflect.DeepEqual(a, b)
+}
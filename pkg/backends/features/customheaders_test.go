@@ -0,0 +1,173 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package features
+
+import (
+	"testing"
+
+	backendconfigv1 "k8s.io/ingress-gce/pkg/apis/backendconfig/v1"
+	"k8s.io/ingress-gce/pkg/composite"
+	"k8s.io/ingress-gce/pkg/utils"
+)
+
+func TestEnsureCustomRequestHeaders(t *testing.T) {
+	testCases := []struct {
+		desc           string
+		sp             utils.ServicePort
+		be             *composite.BackendService
+		updateExpected bool
+	}{
+		{
+			desc:           "custom request headers missing from both ends, no update needed",
+			sp:             utils.ServicePort{BackendConfig: &backendconfigv1.BackendConfig{}},
+			be:             &composite.BackendService{},
+			updateExpected: false,
+		},
+		{
+			desc: "settings are identical, no update needed",
+			sp: utils.ServicePort{
+				BackendConfig: &backendconfigv1.BackendConfig{
+					Spec: backendconfigv1.BackendConfigSpec{
+						CustomRequestHeaders: &backendconfigv1.CustomRequestHeadersConfig{
+							Headers: []string{"X-Client-Region: {client_region}"},
+						},
+					},
+				},
+			},
+			be: &composite.BackendService{
+				CustomRequestHeaders: []string{"X-Client-Region: {client_region}"},
+			},
+			updateExpected: false,
+		},
+		{
+			desc: "empty headers and no existing headers, no update needed",
+			sp: utils.ServicePort{
+				BackendConfig: &backendconfigv1.BackendConfig{
+					Spec: backendconfigv1.BackendConfigSpec{
+						CustomRequestHeaders: &backendconfigv1.CustomRequestHeadersConfig{
+							Headers: []string{},
+						},
+					},
+				},
+			},
+			be:             &composite.BackendService{},
+			updateExpected: false,
+		},
+		{
+			desc: "settings are different, update needed",
+			sp: utils.ServicePort{
+				BackendConfig: &backendconfigv1.BackendConfig{
+					Spec: backendconfigv1.BackendConfigSpec{
+						CustomRequestHeaders: &backendconfigv1.CustomRequestHeadersConfig{
+							Headers: []string{"X-Client-Region: {client_region}"},
+						},
+					},
+				},
+			},
+			be: &composite.BackendService{
+				CustomRequestHeaders: []string{"X-Client-City: {client_city}"},
+			},
+			updateExpected: true,
+		},
+		{
+			desc: "headers removed, update needed",
+			sp: utils.ServicePort{
+				BackendConfig: &backendconfigv1.BackendConfig{
+					Spec: backendconfigv1.BackendConfigSpec{
+						CustomRequestHeaders: &backendconfigv1.CustomRequestHeadersConfig{},
+					},
+				},
+			},
+			be: &composite.BackendService{
+				CustomRequestHeaders: []string{"X-Client-City: {client_city}"},
+			},
+			updateExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			result := EnsureCustomRequestHeaders(tc.sp, tc.be)
+			if result != tc.updateExpected {
+				t.Errorf("%v: expected %v but got %v", tc.desc, tc.updateExpected, result)
+			}
+			if tc.sp.BackendConfig.Spec.CustomRequestHeaders != nil && !headersEqual(tc.be.CustomRequestHeaders, tc.sp.BackendConfig.Spec.CustomRequestHeaders.Headers) {
+				t.Errorf("%v: got headers %v, want %v", tc.desc, tc.be.CustomRequestHeaders, tc.sp.BackendConfig.Spec.CustomRequestHeaders.Headers)
+			}
+		})
+	}
+}
+
+func TestEnsureCustomResponseHeaders(t *testing.T) {
+	testCases := []struct {
+		desc           string
+		sp             utils.ServicePort
+		be             *composite.BackendService
+		updateExpected bool
+	}{
+		{
+			desc:           "custom response headers missing from both ends, no update needed",
+			sp:             utils.ServicePort{BackendConfig: &backendconfigv1.BackendConfig{}},
+			be:             &composite.BackendService{},
+			updateExpected: false,
+		},
+		{
+			desc: "settings are identical, no update needed",
+			sp: utils.ServicePort{
+				BackendConfig: &backendconfigv1.BackendConfig{
+					Spec: backendconfigv1.BackendConfigSpec{
+						CustomResponseHeaders: &backendconfigv1.CustomResponseHeadersConfig{
+							Headers: []string{"Strict-Transport-Security: max-age=31536000"},
+						},
+					},
+				},
+			},
+			be: &composite.BackendService{
+				CustomResponseHeaders: []string{"Strict-Transport-Security: max-age=31536000"},
+			},
+			updateExpected: false,
+		},
+		{
+			desc: "header order changed, update needed",
+			sp: utils.ServicePort{
+				BackendConfig: &backendconfigv1.BackendConfig{
+					Spec: backendconfigv1.BackendConfigSpec{
+						CustomResponseHeaders: &backendconfigv1.CustomResponseHeadersConfig{
+							Headers: []string{"X-Frame-Options: DENY", "Strict-Transport-Security: max-age=31536000"},
+						},
+					},
+				},
+			},
+			be: &composite.BackendService{
+				CustomResponseHeaders: []string{"Strict-Transport-Security: max-age=31536000", "X-Frame-Options: DENY"},
+			},
+			updateExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			result := EnsureCustomResponseHeaders(tc.sp, tc.be)
+			if result != tc.updateExpected {
+				t.Errorf("%v: expected %v but got %v", tc.desc, tc.updateExpected, result)
+			}
+			if tc.sp.BackendConfig.Spec.CustomResponseHeaders != nil && !headersEqual(tc.be.CustomResponseHeaders, tc.sp.BackendConfig.Spec.CustomResponseHeaders.Headers) {
+				t.Errorf("%v: got headers %v, want %v", tc.desc, tc.be.CustomResponseHeaders, tc.sp.BackendConfig.Spec.CustomResponseHeaders.Headers)
+			}
+		})
+	}
+}
@@ -33,20 +33,40 @@ const (
 	FeatureSecurityPolicy = "SecurityPolicy"
 	// FeatureNEG defines the feature name of NEG.
 	FeatureNEG = "NEG"
+	// FeatureCustomRequestHeaders defines the feature name of CustomRequestHeaders.
+	FeatureCustomRequestHeaders = "CustomRequestHeaders"
+	// FeatureCustomResponseHeaders defines the feature name of CustomResponseHeaders.
+	FeatureCustomResponseHeaders = "CustomResponseHeaders"
+	// FeatureLogging defines the feature name of Logging.
+	FeatureLogging = "Logging"
+	// FeatureCDNCacheMode defines the feature name of CDN cache mode, TTLs
+	// and negative caching.
+	FeatureCDNCacheMode = "CDNCacheMode"
+	// FeatureOutlierDetection defines the feature name of OutlierDetection.
+	FeatureOutlierDetection = "OutlierDetection"
+	// FeatureCircuitBreakers defines the feature name of CircuitBreakers.
+	FeatureCircuitBreakers = "CircuitBreakers"
+	// FeatureLocalityLbPolicy defines the feature name of LocalityLbPolicy.
+	FeatureLocalityLbPolicy = "LocalityLbPolicy"
+	// FeatureL7ILB defines the feature name of internal HTTP(S) load
+	// balancing, whose regional backend services require the alpha API.
+	FeatureL7ILB = "L7ILB"
 )
 
 var (
 	// versionToFeatures stores the mapping from the required API
 	// version to feature names.
 	versionToFeatures = map[meta.Version][]string{
-		meta.VersionAlpha: []string{},
-		meta.VersionBeta:  []string{FeatureSecurityPolicy, FeatureNEG, FeatureHTTP2},
+		meta.VersionAlpha: []string{FeatureLogging, FeatureL7ILB},
+		meta.VersionBeta:  []string{FeatureSecurityPolicy, FeatureNEG, FeatureHTTP2, FeatureCustomRequestHeaders, FeatureCustomResponseHeaders, FeatureCDNCacheMode, FeatureOutlierDetection, FeatureCircuitBreakers, FeatureLocalityLbPolicy},
 	}
 )
 
-// SetDescription sets the XFeatures field for the given Description.
+// SetDescription sets the XFeatures and SignedURLKeys fields for the given
+// Description.
 func SetDescription(desc *utils.Description, sp *utils.ServicePort) {
 	desc.XFeatures = featuresFromServicePort(sp)
+	desc.SignedURLKeys = signedURLKeyFingerprints(sp)
 }
 
 // featuresFromServicePort returns a list of features used by the
@@ -62,6 +82,30 @@ func featuresFromServicePort(sp *utils.ServicePort) []string {
 	if sp.NEGEnabled {
 		features = append(features, FeatureNEG)
 	}
+	if sp.BackendConfig != nil && sp.BackendConfig.Spec.CustomRequestHeaders != nil {
+		features = append(features, FeatureCustomRequestHeaders)
+	}
+	if sp.BackendConfig != nil && sp.BackendConfig.Spec.CustomResponseHeaders != nil {
+		features = append(features, FeatureCustomResponseHeaders)
+	}
+	if sp.BackendConfig != nil && sp.BackendConfig.Spec.Logging != nil {
+		features = append(features, FeatureLogging)
+	}
+	if sp.BackendConfig != nil && usesCDNCacheMode(sp.BackendConfig.Spec.Cdn) {
+		features = append(features, FeatureCDNCacheMode)
+	}
+	if sp.BackendConfig != nil && sp.BackendConfig.Spec.OutlierDetection != nil {
+		features = append(features, FeatureOutlierDetection)
+	}
+	if sp.BackendConfig != nil && sp.BackendConfig.Spec.CircuitBreakers != nil {
+		features = append(features, FeatureCircuitBreakers)
+	}
+	if sp.BackendConfig != nil && sp.BackendConfig.Spec.LocalityLbPolicy != nil {
+		features = append(features, FeatureLocalityLbPolicy)
+	}
+	if sp.L7ILBEnabled {
+		features = append(features, FeatureL7ILB)
+	}
 	// Keep feature names sorted to be consistent.
 	sort.Strings(features)
 	return features
@@ -73,6 +117,16 @@ func VersionFromServicePort(sp *utils.ServicePort) meta.Version {
 	return VersionFromFeatures(featuresFromServicePort(sp))
 }
 
+// ScopeFromServicePort returns the scope of the backend that this
+// ServicePort is associated with. Backends of internal HTTP(S) load
+// balancers are regional, all others are global.
+func ScopeFromServicePort(sp *utils.ServicePort) meta.KeyType {
+	if sp.L7ILBEnabled {
+		return meta.Regional
+	}
+	return meta.Global
+}
+
 // VersionFromDescription returns the meta.Version required for the given
 // description.
 func VersionFromDescription(desc string) meta.Version {
@@ -25,7 +25,7 @@ import (
 	"k8s.io/kubernetes/pkg/cloudprovider/providers/gce/cloud/meta"
 
 	"k8s.io/ingress-gce/pkg/annotations"
-	backendconfigv1beta1 "k8s.io/ingress-gce/pkg/apis/backendconfig/v1beta1"
+	backendconfigv1 "k8s.io/ingress-gce/pkg/apis/backendconfig/v1"
 	"k8s.io/ingress-gce/pkg/utils"
 )
 
@@ -49,9 +49,9 @@ var (
 
 	svcPortWithSecurityPolicy = utils.ServicePort{
 		ID: fakeSvcPortID,
-		BackendConfig: &backendconfigv1beta1.BackendConfig{
-			Spec: backendconfigv1beta1.BackendConfigSpec{
-				SecurityPolicy: &backendconfigv1beta1.SecurityPolicyConfig{
+		BackendConfig: &backendconfigv1.BackendConfig{
+			Spec: backendconfigv1.BackendConfigSpec{
+				SecurityPolicy: &backendconfigv1.SecurityPolicyConfig{
 					Name: "policy-test",
 				},
 			},
@@ -61,9 +61,9 @@ var (
 	svcPortWithHTTP2SecurityPolicy = utils.ServicePort{
 		ID:       fakeSvcPortID,
 		Protocol: annotations.ProtocolHTTP2,
-		BackendConfig: &backendconfigv1beta1.BackendConfig{
-			Spec: backendconfigv1beta1.BackendConfigSpec{
-				SecurityPolicy: &backendconfigv1beta1.SecurityPolicyConfig{
+		BackendConfig: &backendconfigv1.BackendConfig{
+			Spec: backendconfigv1.BackendConfigSpec{
+				SecurityPolicy: &backendconfigv1.SecurityPolicyConfig{
 					Name: "policy-test",
 				},
 			},
@@ -74,6 +74,65 @@ var (
 		ID:         fakeSvcPortID,
 		NEGEnabled: true,
 	}
+
+	svcPortWithL7ILB = utils.ServicePort{
+		ID:           fakeSvcPortID,
+		NEGEnabled:   true,
+		L7ILBEnabled: true,
+	}
+
+	svcPortWithCustomHeaders = utils.ServicePort{
+		ID: fakeSvcPortID,
+		BackendConfig: &backendconfigv1.BackendConfig{
+			Spec: backendconfigv1.BackendConfigSpec{
+				CustomRequestHeaders: &backendconfigv1.CustomRequestHeadersConfig{
+					Headers: []string{"X-Client-Region: {client_region}"},
+				},
+				CustomResponseHeaders: &backendconfigv1.CustomResponseHeadersConfig{
+					Headers: []string{"Strict-Transport-Security: max-age=31536000"},
+				},
+			},
+		},
+	}
+
+	svcPortWithHTTP2Logging = utils.ServicePort{
+		ID:       fakeSvcPortID,
+		Protocol: annotations.ProtocolHTTP2,
+		BackendConfig: &backendconfigv1.BackendConfig{
+			Spec: backendconfigv1.BackendConfigSpec{
+				Logging: &backendconfigv1.LogConfig{
+					Enable: true,
+				},
+			},
+		},
+	}
+
+	cdnCacheMode = "CACHE_ALL_STATIC"
+
+	localityLbPolicy = "LEAST_REQUEST"
+
+	svcPortWithTrafficPolicies = utils.ServicePort{
+		ID: fakeSvcPortID,
+		BackendConfig: &backendconfigv1.BackendConfig{
+			Spec: backendconfigv1.BackendConfigSpec{
+				OutlierDetection: &backendconfigv1.OutlierDetectionConfig{},
+				CircuitBreakers:  &backendconfigv1.CircuitBreakersConfig{},
+				LocalityLbPolicy: &localityLbPolicy,
+			},
+		},
+	}
+
+	svcPortWithCDNCacheMode = utils.ServicePort{
+		ID: fakeSvcPortID,
+		BackendConfig: &backendconfigv1.BackendConfig{
+			Spec: backendconfigv1.BackendConfigSpec{
+				Cdn: &backendconfigv1.CDNConfig{
+					Enabled:   true,
+					CacheMode: &cdnCacheMode,
+				},
+			},
+		},
+	}
 )
 
 func TestFeaturesFromServicePort(t *testing.T) {
@@ -102,11 +161,36 @@ func TestFeaturesFromServicePort(t *testing.T) {
 			svcPort:          svcPortWithNEG,
 			expectedFeatures: []string{"NEG"},
 		},
+		{
+			desc:             "NEG + L7ILB",
+			svcPort:          svcPortWithL7ILB,
+			expectedFeatures: []string{"L7ILB", "NEG"},
+		},
 		{
 			desc:             "HTTP2 + SecurityPolicy",
 			svcPort:          svcPortWithHTTP2SecurityPolicy,
 			expectedFeatures: []string{"HTTP2", "SecurityPolicy"},
 		},
+		{
+			desc:             "CustomRequestHeaders + CustomResponseHeaders",
+			svcPort:          svcPortWithCustomHeaders,
+			expectedFeatures: []string{"CustomRequestHeaders", "CustomResponseHeaders"},
+		},
+		{
+			desc:             "HTTP2 + Logging",
+			svcPort:          svcPortWithHTTP2Logging,
+			expectedFeatures: []string{"HTTP2", "Logging"},
+		},
+		{
+			desc:             "CDNCacheMode",
+			svcPort:          svcPortWithCDNCacheMode,
+			expectedFeatures: []string{"CDNCacheMode"},
+		},
+		{
+			desc:             "OutlierDetection + CircuitBreakers + LocalityLbPolicy",
+			svcPort:          svcPortWithTrafficPolicies,
+			expectedFeatures: []string{"CircuitBreakers", "LocalityLbPolicy", "OutlierDetection"},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -148,6 +232,46 @@ func TestVersionFromFeatures(t *testing.T) {
 			features:        []string{FeatureHTTP2, FeatureSecurityPolicy},
 			expectedVersion: meta.VersionBeta,
 		},
+		{
+			desc:            "CustomRequestHeaders",
+			features:        []string{FeatureCustomRequestHeaders},
+			expectedVersion: meta.VersionBeta,
+		},
+		{
+			desc:            "CustomResponseHeaders",
+			features:        []string{FeatureCustomResponseHeaders},
+			expectedVersion: meta.VersionBeta,
+		},
+		{
+			desc:            "Logging",
+			features:        []string{FeatureLogging},
+			expectedVersion: meta.VersionAlpha,
+		},
+		{
+			desc:            "HTTP2 + Logging",
+			features:        []string{FeatureHTTP2, FeatureLogging},
+			expectedVersion: meta.VersionAlpha,
+		},
+		{
+			desc:            "CDNCacheMode",
+			features:        []string{FeatureCDNCacheMode},
+			expectedVersion: meta.VersionBeta,
+		},
+		{
+			desc:            "OutlierDetection",
+			features:        []string{FeatureOutlierDetection},
+			expectedVersion: meta.VersionBeta,
+		},
+		{
+			desc:            "CircuitBreakers",
+			features:        []string{FeatureCircuitBreakers},
+			expectedVersion: meta.VersionBeta,
+		},
+		{
+			desc:            "LocalityLbPolicy",
+			features:        []string{FeatureLocalityLbPolicy},
+			expectedVersion: meta.VersionBeta,
+		},
 		{
 			desc:            "unknown feature",
 			features:        []string{"whatisthis"},
@@ -233,6 +357,31 @@ func TestVersionFromServicePort(t *testing.T) {
 			svcPort:         svcPortWithHTTP2SecurityPolicy,
 			expectedVersion: meta.VersionBeta,
 		},
+		{
+			desc:            "enabled custom headers",
+			svcPort:         svcPortWithCustomHeaders,
+			expectedVersion: meta.VersionBeta,
+		},
+		{
+			desc:            "enabled http2 + logging",
+			svcPort:         svcPortWithHTTP2Logging,
+			expectedVersion: meta.VersionAlpha,
+		},
+		{
+			desc:            "enabled l7 ilb",
+			svcPort:         svcPortWithL7ILB,
+			expectedVersion: meta.VersionAlpha,
+		},
+		{
+			desc:            "enabled cdn cache mode",
+			svcPort:         svcPortWithCDNCacheMode,
+			expectedVersion: meta.VersionBeta,
+		},
+		{
+			desc:            "enabled outlier detection, circuit breakers and locality lb policy",
+			svcPort:         svcPortWithTrafficPolicies,
+			expectedVersion: meta.VersionBeta,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -242,6 +391,15 @@ func TestVersionFromServicePort(t *testing.T) {
 	}
 }
 
+func TestScopeFromServicePort(t *testing.T) {
+	if scope := ScopeFromServicePort(&svcPortWithNEG); scope != meta.Global {
+		t.Errorf("ScopeFromServicePort(%+v)=%s, want %s", svcPortWithNEG, scope, meta.Global)
+	}
+	if scope := ScopeFromServicePort(&svcPortWithL7ILB); scope != meta.Regional {
+		t.Errorf("ScopeFromServicePort(%+v)=%s, want %s", svcPortWithL7ILB, scope, meta.Regional)
+	}
+}
+
 func TestIsLowerVersion(t *testing.T) {
 	testCases := []struct {
 		desc   string
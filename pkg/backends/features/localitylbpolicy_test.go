@@ -0,0 +1,78 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package features
+
+import (
+	"testing"
+
+	backendconfigv1 "k8s.io/ingress-gce/pkg/apis/backendconfig/v1"
+	"k8s.io/ingress-gce/pkg/composite"
+	"k8s.io/ingress-gce/pkg/utils"
+)
+
+func TestEnsureLocalityLbPolicy(t *testing.T) {
+	leastRequest := "LEAST_REQUEST"
+	testCases := []struct {
+		desc           string
+		sp             utils.ServicePort
+		be             *composite.BackendService
+		updateExpected bool
+	}{
+		{
+			desc:           "locality lb policy missing from spec, no update needed",
+			sp:             utils.ServicePort{BackendConfig: &backendconfigv1.BackendConfig{}},
+			be:             &composite.BackendService{LocalityLbPolicy: "ROUND_ROBIN"},
+			updateExpected: false,
+		},
+		{
+			desc: "policies are identical, no update needed",
+			sp: utils.ServicePort{
+				BackendConfig: &backendconfigv1.BackendConfig{
+					Spec: backendconfigv1.BackendConfigSpec{
+						LocalityLbPolicy: &leastRequest,
+					},
+				},
+			},
+			be:             &composite.BackendService{LocalityLbPolicy: "LEAST_REQUEST"},
+			updateExpected: false,
+		},
+		{
+			desc: "policies are different, update needed",
+			sp: utils.ServicePort{
+				BackendConfig: &backendconfigv1.BackendConfig{
+					Spec: backendconfigv1.BackendConfigSpec{
+						LocalityLbPolicy: &leastRequest,
+					},
+				},
+			},
+			be:             &composite.BackendService{LocalityLbPolicy: "ROUND_ROBIN"},
+			updateExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			result := EnsureLocalityLbPolicy(tc.sp, tc.be)
+			if result != tc.updateExpected {
+				t.Errorf("%v: expected %v but got %v", tc.desc, tc.updateExpected, result)
+			}
+			if tc.updateExpected && tc.be.LocalityLbPolicy != leastRequest {
+				t.Errorf("%v: expected LocalityLbPolicy %v but got %v", tc.desc, leastRequest, tc.be.LocalityLbPolicy)
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package features
+
+import (
+	"k8s.io/ingress-gce/pkg/composite"
+	"k8s.io/ingress-gce/pkg/utils"
+	"k8s.io/klog"
+)
+
+// defaultLogSampleRate is the sample rate GCE applies when logging is
+// enabled without an explicit sample rate.
+const defaultLogSampleRate = 1.0
+
+// EnsureLogging reads the Logging configuration specified in the
+// ServicePort.BackendConfig and applies it to the BackendService.
+// It returns true if there were existing settings on the BackendService
+// that were overwritten.
+func EnsureLogging(sp utils.ServicePort, be *composite.BackendService) bool {
+	if sp.BackendConfig.Spec.Logging == nil {
+		return false
+	}
+	beTemp := &composite.BackendService{}
+	applyLoggingSettings(sp, beTemp)
+	if !logConfigEqual(beTemp.LogConfig, be.LogConfig) {
+		applyLoggingSettings(sp, be)
+		klog.V(2).Infof("Updated Logging settings for service %v/%v.", sp.ID.Service.Namespace, sp.ID.Service.Name)
+		return true
+	}
+	return false
+}
+
+// applyLoggingSettings applies the Logging settings specified in the
+// BackendConfig to the passed in composite.BackendService. A GCE API call
+// still needs to be made to actually persist the changes.
+func applyLoggingSettings(sp utils.ServicePort, be *composite.BackendService) {
+	logConfig := sp.BackendConfig.Spec.Logging
+	be.LogConfig = &composite.BackendServiceLogConfig{
+		Enable: logConfig.Enable,
+	}
+	// The sample rate can only be specified if logging is enabled.
+	if logConfig.Enable {
+		be.LogConfig.SampleRate = defaultLogSampleRate
+		if logConfig.SampleRate != nil {
+			be.LogConfig.SampleRate = *logConfig.SampleRate
+		}
+	}
+}
+
+// logConfigEqual returns true if the desired log config matches the existing
+// one. A missing log config is equivalent to logging being disabled, and the
+// sample rate is ignored when logging is disabled.
+func logConfigEqual(desired, existing *composite.BackendServiceLogConfig) bool {
+	if existing == nil {
+		existing = &composite.BackendServiceLogConfig{}
+	}
+	if desired.Enable != existing.Enable {
+		return false
+	}
+	return !desired.Enable || desired.SampleRate == existing.SampleRate
+}
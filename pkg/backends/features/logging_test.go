@@ -0,0 +1,120 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package features
+
+import (
+	"testing"
+
+	backendconfigv1 "k8s.io/ingress-gce/pkg/apis/backendconfig/v1"
+	"k8s.io/ingress-gce/pkg/composite"
+	"k8s.io/ingress-gce/pkg/utils"
+)
+
+func TestEnsureLogging(t *testing.T) {
+	halfRate := 0.5
+	testCases := []struct {
+		desc           string
+		sp             utils.ServicePort
+		be             *composite.BackendService
+		updateExpected bool
+	}{
+		{
+			desc:           "logging setting missing from both ends, no update needed",
+			sp:             utils.ServicePort{BackendConfig: &backendconfigv1.BackendConfig{}},
+			be:             &composite.BackendService{},
+			updateExpected: false,
+		},
+		{
+			desc: "logging disabled and no existing log config, no update needed",
+			sp: utils.ServicePort{
+				BackendConfig: &backendconfigv1.BackendConfig{
+					Spec: backendconfigv1.BackendConfigSpec{
+						Logging: &backendconfigv1.LogConfig{Enable: false},
+					},
+				},
+			},
+			be:             &composite.BackendService{},
+			updateExpected: false,
+		},
+		{
+			desc: "logging enabled with default sample rate, no update needed",
+			sp: utils.ServicePort{
+				BackendConfig: &backendconfigv1.BackendConfig{
+					Spec: backendconfigv1.BackendConfigSpec{
+						Logging: &backendconfigv1.LogConfig{Enable: true},
+					},
+				},
+			},
+			be: &composite.BackendService{
+				LogConfig: &composite.BackendServiceLogConfig{Enable: true, SampleRate: 1.0},
+			},
+			updateExpected: false,
+		},
+		{
+			desc: "logging enabled by hand but disabled in config, update needed",
+			sp: utils.ServicePort{
+				BackendConfig: &backendconfigv1.BackendConfig{
+					Spec: backendconfigv1.BackendConfigSpec{
+						Logging: &backendconfigv1.LogConfig{Enable: false},
+					},
+				},
+			},
+			be: &composite.BackendService{
+				LogConfig: &composite.BackendServiceLogConfig{Enable: true, SampleRate: 1.0},
+			},
+			updateExpected: true,
+		},
+		{
+			desc: "logging not enabled yet, update needed",
+			sp: utils.ServicePort{
+				BackendConfig: &backendconfigv1.BackendConfig{
+					Spec: backendconfigv1.BackendConfigSpec{
+						Logging: &backendconfigv1.LogConfig{Enable: true, SampleRate: &halfRate},
+					},
+				},
+			},
+			be:             &composite.BackendService{},
+			updateExpected: true,
+		},
+		{
+			desc: "sample rate is different, update needed",
+			sp: utils.ServicePort{
+				BackendConfig: &backendconfigv1.BackendConfig{
+					Spec: backendconfigv1.BackendConfigSpec{
+						Logging: &backendconfigv1.LogConfig{Enable: true, SampleRate: &halfRate},
+					},
+				},
+			},
+			be: &composite.BackendService{
+				LogConfig: &composite.BackendServiceLogConfig{Enable: true, SampleRate: 1.0},
+			},
+			updateExpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			result := EnsureLogging(tc.sp, tc.be)
+			if result != tc.updateExpected {
+				t.Errorf("%v: expected %v but got %v", tc.desc, tc.updateExpected, result)
+			}
+			if tc.updateExpected && tc.be.LogConfig.Enable != tc.sp.BackendConfig.Spec.Logging.Enable {
+				t.Errorf("%v: got LogConfig.Enable = %v, want %v", tc.desc, tc.be.LogConfig.Enable, tc.sp.BackendConfig.Spec.Logging.Enable)
+			}
+		})
+	}
+}
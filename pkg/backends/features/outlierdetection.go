@@ -0,0 +1,88 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package features
+
+import (
+	"reflect"
+
+	backendconfigv1 "k8s.io/ingress-gce/pkg/apis/backendconfig/v1"
+	"k8s.io/ingress-gce/pkg/composite"
+	"k8s.io/ingress-gce/pkg/utils"
+	"k8s.io/klog"
+)
+
+// EnsureOutlierDetection reads the OutlierDetection configuration specified
+// in the ServicePort.BackendConfig and applies it to the BackendService.
+// It returns true if there were existing settings on the BackendService
+// that were overwritten.
+func EnsureOutlierDetection(sp utils.ServicePort, be *composite.BackendService) bool {
+	if sp.BackendConfig.Spec.OutlierDetection == nil {
+		return false
+	}
+	beTemp := &composite.BackendService{OutlierDetection: be.OutlierDetection}
+	applyOutlierDetectionSettings(sp, beTemp)
+	if !reflect.DeepEqual(beTemp.OutlierDetection, be.OutlierDetection) {
+		applyOutlierDetectionSettings(sp, be)
+		klog.V(2).Infof("Updated OutlierDetection settings for service %v/%v.", sp.ID.Service.Namespace, sp.ID.Service.Name)
+		return true
+	}
+	return false
+}
+
+// applyOutlierDetectionSettings applies the OutlierDetection settings
+// specified in the BackendConfig to the passed in composite.BackendService.
+// Only the specified settings are overwritten so that values defaulted by
+// GCE do not cause perpetual updates. A GCE API call still needs to be made
+// to actually persist the changes.
+func applyOutlierDetectionSettings(sp utils.ServicePort, be *composite.BackendService) {
+	config := sp.BackendConfig.Spec.OutlierDetection
+	outlierDetection := &composite.OutlierDetection{}
+	if be.OutlierDetection != nil {
+		*outlierDetection = *be.OutlierDetection
+	}
+	if config.BaseEjectionTime != nil {
+		outlierDetection.BaseEjectionTime = toCompositeDuration(config.BaseEjectionTime)
+	}
+	if config.Interval != nil {
+		outlierDetection.Interval = toCompositeDuration(config.Interval)
+	}
+	for _, field := range []struct {
+		val *int64
+		dst *int64
+	}{
+		{config.ConsecutiveErrors, &outlierDetection.ConsecutiveErrors},
+		{config.ConsecutiveGatewayFailure, &outlierDetection.ConsecutiveGatewayFailure},
+		{config.EnforcingConsecutiveErrors, &outlierDetection.EnforcingConsecutiveErrors},
+		{config.EnforcingConsecutiveGatewayFailure, &outlierDetection.EnforcingConsecutiveGatewayFailure},
+		{config.EnforcingSuccessRate, &outlierDetection.EnforcingSuccessRate},
+		{config.MaxEjectionPercent, &outlierDetection.MaxEjectionPercent},
+		{config.SuccessRateMinimumHosts, &outlierDetection.SuccessRateMinimumHosts},
+		{config.SuccessRateRequestVolume, &outlierDetection.SuccessRateRequestVolume},
+		{config.SuccessRateStdevFactor, &outlierDetection.SuccessRateStdevFactor},
+	} {
+		if field.val != nil {
+			*field.dst = *field.val
+		}
+	}
+	be.OutlierDetection = outlierDetection
+}
+
+// toCompositeDuration converts a BackendConfig Duration into the composite
+// type.
+func toCompositeDuration(d *backendconfigv1.Duration) *composite.Duration {
+	return &composite.Duration{Seconds: d.Seconds, Nanos: d.Nanos}
+}
@@ -0,0 +1,141 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package features
+
+import (
+	"reflect"
+	"testing"
+
+	backendconfigv1 "k8s.io/ingress-gce/pkg/apis/backendconfig/v1"
+	"k8s.io/ingress-gce/pkg/composite"
+	"k8s.io/ingress-gce/pkg/utils"
+)
+
+func TestEnsureOutlierDetection(t *testing.T) {
+	var (
+		consecutiveErrors  = int64(3)
+		maxEjectionPercent = int64(50)
+	)
+	testCases := []struct {
+		desc           string
+		sp             utils.ServicePort
+		be             *composite.BackendService
+		updateExpected bool
+		expected       *composite.OutlierDetection
+	}{
+		{
+			desc:           "outlier detection settings missing from spec, no update needed",
+			sp:             utils.ServicePort{BackendConfig: &backendconfigv1.BackendConfig{}},
+			be:             &composite.BackendService{OutlierDetection: &composite.OutlierDetection{ConsecutiveErrors: 5}},
+			updateExpected: false,
+		},
+		{
+			desc: "settings are identical, no update needed",
+			sp: utils.ServicePort{
+				BackendConfig: &backendconfigv1.BackendConfig{
+					Spec: backendconfigv1.BackendConfigSpec{
+						OutlierDetection: &backendconfigv1.OutlierDetectionConfig{
+							ConsecutiveErrors: &consecutiveErrors,
+							BaseEjectionTime:  &backendconfigv1.Duration{Seconds: 30},
+						},
+					},
+				},
+			},
+			be: &composite.BackendService{
+				OutlierDetection: &composite.OutlierDetection{
+					ConsecutiveErrors: 3,
+					BaseEjectionTime:  &composite.Duration{Seconds: 30},
+				},
+			},
+			updateExpected: false,
+		},
+		{
+			desc: "settings defaulted by GCE are preserved, no update needed",
+			sp: utils.ServicePort{
+				BackendConfig: &backendconfigv1.BackendConfig{
+					Spec: backendconfigv1.BackendConfigSpec{
+						OutlierDetection: &backendconfigv1.OutlierDetectionConfig{
+							ConsecutiveErrors: &consecutiveErrors,
+						},
+					},
+				},
+			},
+			be: &composite.BackendService{
+				OutlierDetection: &composite.OutlierDetection{
+					ConsecutiveErrors:  3,
+					MaxEjectionPercent: 10,
+					Interval:           &composite.Duration{Seconds: 10},
+				},
+			},
+			updateExpected: false,
+		},
+		{
+			desc: "outlier detection missing from backend service, update needed",
+			sp: utils.ServicePort{
+				BackendConfig: &backendconfigv1.BackendConfig{
+					Spec: backendconfigv1.BackendConfigSpec{
+						OutlierDetection: &backendconfigv1.OutlierDetectionConfig{
+							MaxEjectionPercent: &maxEjectionPercent,
+							Interval:           &backendconfigv1.Duration{Seconds: 1, Nanos: 500},
+						},
+					},
+				},
+			},
+			be:             &composite.BackendService{},
+			updateExpected: true,
+			expected: &composite.OutlierDetection{
+				MaxEjectionPercent: 50,
+				Interval:           &composite.Duration{Seconds: 1, Nanos: 500},
+			},
+		},
+		{
+			desc: "settings are different, update needed",
+			sp: utils.ServicePort{
+				BackendConfig: &backendconfigv1.BackendConfig{
+					Spec: backendconfigv1.BackendConfigSpec{
+						OutlierDetection: &backendconfigv1.OutlierDetectionConfig{
+							ConsecutiveErrors: &consecutiveErrors,
+						},
+					},
+				},
+			},
+			be: &composite.BackendService{
+				OutlierDetection: &composite.OutlierDetection{
+					ConsecutiveErrors:  5,
+					MaxEjectionPercent: 10,
+				},
+			},
+			updateExpected: true,
+			expected: &composite.OutlierDetection{
+				ConsecutiveErrors:  3,
+				MaxEjectionPercent: 10,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			result := EnsureOutlierDetection(tc.sp, tc.be)
+			if result != tc.updateExpected {
+				t.Errorf("%v: expected %v but got %v", tc.desc, tc.updateExpected, result)
+			}
+			if tc.expected != nil && !reflect.DeepEqual(tc.be.OutlierDetection, tc.expected) {
+				t.Errorf("%v: expected OutlierDetection %+v but got %+v", tc.desc, tc.expected, tc.be.OutlierDetection)
+			}
+		})
+	}
+}
@@ -23,7 +23,6 @@ import (
 
 	computebeta "google.golang.org/api/compute/v0.beta"
 
-	"k8s.io/kubernetes/pkg/cloudprovider/providers/gce"
 	gcecloud "k8s.io/kubernetes/pkg/cloudprovider/providers/gce/cloud"
 	"k8s.io/kubernetes/pkg/cloudprovider/providers/gce/cloud/meta"
 
@@ -31,9 +30,16 @@ import (
 	"k8s.io/ingress-gce/pkg/utils"
 )
 
+// SecurityPolicySetter sets the security policy of global backend services.
+// It is implemented by gce.Cloud.
+type SecurityPolicySetter interface {
+	ProjectID() string
+	SetSecurityPolicyForBetaGlobalBackendService(backendServiceName string, securityPolicyReference *computebeta.SecurityPolicyReference) error
+}
+
 // EnsureSecurityPolicy ensures the security policy link on backend service.
 // TODO(mrhohn): Emit event when attach/detach security policy to backend service.
-func EnsureSecurityPolicy(cloud *gce.Cloud, sp utils.ServicePort, be *composite.BackendService, beName string) error {
+func EnsureSecurityPolicy(cloud SecurityPolicySetter, sp utils.ServicePort, be *composite.BackendService, beName string) error {
 	if sp.BackendConfig.Spec.SecurityPolicy == nil {
 		return nil
 	}
@@ -52,7 +58,7 @@ func EnsureSecurityPolicy(cloud *gce.Cloud, sp utils.ServicePort, be *composite.
 
 // securityPolicyNeedsUpdate checks if security policy needs update and
 // returns the desired policy reference.
-func securityPolicyNeedsUpdate(cloud *gce.Cloud, currentLink, desiredName string) (bool, *computebeta.SecurityPolicyReference) {
+func securityPolicyNeedsUpdate(cloud SecurityPolicySetter, currentLink, desiredName string) (bool, *computebeta.SecurityPolicyReference) {
 	currentName, _ := utils.KeyName(currentLink)
 	if currentName == desiredName {
 		return false, nil
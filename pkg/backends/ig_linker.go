@@ -93,7 +93,7 @@ func (l *instanceGroupLinker) Link(sp utils.ServicePort, groups []GroupKey) erro
 		igLinks = append(igLinks, ig.SelfLink)
 	}
 
-	be, err := l.backendPool.Get(sp.BackendName(l.namer), meta.VersionGA)
+	be, err := l.backendPool.Get(sp.BackendName(l.namer), meta.VersionGA, meta.Global)
 	if err != nil {
 		return err
 	}
@@ -103,10 +103,6 @@ func (l *instanceGroupLinker) Link(sp utils.ServicePort, groups []GroupKey) erro
 		return err
 	}
 
-	if len(addIGs) == 0 {
-		return nil
-	}
-
 	originalIGBackends := []*composite.Backend{}
 	for _, backend := range be.Backends {
 		// Backend service is not able to point to NEG and IG at the same time.
@@ -116,6 +112,19 @@ func (l *instanceGroupLinker) Link(sp utils.ServicePort, groups []GroupKey) erro
 		}
 	}
 
+	if settings := balancingSettingsFromServicePort(sp); settings != nil {
+		return l.linkWithBalancingSettings(be, originalIGBackends, addIGs, settings)
+	}
+	// The settings of a BackendConfig which no longer specifies any are
+	// reset to the defaults.
+	if !instanceGroupBackendsDefault(originalIGBackends) {
+		return l.linkWithBalancingSettings(be, originalIGBackends, addIGs, defaultBalancingSettings())
+	}
+
+	if len(addIGs) == 0 {
+		return nil
+	}
+
 	// We first try to create the backend with balancingMode=RATE.  If this	+ return addIGs
 	// fails, it's mostly likely because there are existing backends with
 	// balancingMode=UTILIZATION. This failure mode throws a googleapi error
@@ -147,6 +156,55 @@ func (l *instanceGroupLinker) Link(sp utils.ServicePort, groups []GroupKey) erro
 	return fmt.Errorf("received errors when updating backend service: %v", strings.Join(errs, "\n"))
 }
 
+// linkWithBalancingSettings adds the given instance groups to the
+// BackendService and applies the balancing settings to all of its Backends
+// in a single update, since GCE requires all Backends of a BackendService to
+// share a balancing mode. Instance groups are also shared by every
+// BackendService in the cluster, and GCE may reject RATE on an instance group
+// used in UTILIZATION by others. If the desired mode is
+// rejected, the Backends are migrated to UTILIZATION instead and the desired
+// mode is retried on the next sync.
+func (l *instanceGroupLinker) linkWithBalancingSettings(be *composite.BackendService, originalIGBackends []*composite.Backend, addIGs []string, settings *balancingSettings) error {
+	var groups []string
+	for _, backend := range originalIGBackends {
+		groups = append(groups, backend.Group)
+	}
+	groups = append(groups, addIGs...)
+
+	modes := []BalancingMode{settings.mode}
+	if settings.mode != Utilization {
+		modes = append(modes, Utilization)
+	}
+	var errs []string
+	for _, bm := range modes {
+		var newBackends []*composite.Backend
+		for _, group := range groups {
+			b := &composite.Backend{Group: group}
+			settings.withMode(bm).applyToInstanceGroupBackend(b)
+			newBackends = append(newBackends, b)
+		}
+		if instanceGroupBackendsEqual(originalIGBackends, newBackends) {
+			return nil
+		}
+		be.Backends = newBackends
+
+		if err := l.backendPool.Update(be); err != nil {
+			if utils.IsHTTPErrorCode(err, http.StatusBadRequest) {
+				klog.V(2).Infof("Updating backend service backends with balancing mode %v failed, will try another mode. err:%v", bm, err)
+				errs = append(errs, err.Error())
+				continue
+			}
+			klog.V(2).Infof("Error updating backend service backends with balancing mode %v:%v", bm, err)
+			return err
+		}
+		if bm != settings.mode {
+			klog.Warningf("Backend service %q was migrated to balancing mode %v instead of %v, which will be retried on the next sync", be.Name, bm, settings.mode)
+		}
+		return nil
+	}
+	return fmt.Errorf("received errors when updating backend service: %v", strings.Join(errs, "\n"))
+}
+
 func getBackendsForIGs(igLinks []string, bm BalancingMode) []*composite.Backend {
 	var backends []*composite.Backend
 	for _, igLink := range igLinks {
@@ -22,6 +22,7 @@ import (
 	"google.golang.org/api/googleapi"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/ingress-gce/pkg/annotations"
+	backendconfigv1 "k8s.io/ingress-gce/pkg/apis/backendconfig/v1"
 	"k8s.io/ingress-gce/pkg/instances"
 	"k8s.io/ingress-gce/pkg/utils"
 	"k8s.io/kubernetes/pkg/cloudprovider/providers/gce"
@@ -122,6 +123,153 @@ func TestLinkWithCreationModeError(t *testing.T) {
 				t.Fatalf("Wrong balancing mode, expected %v got %v", modes[(i+1)%len(modes)], b.BalancingMode)
 			}
 		}
-		linker.backendPool.Delete(sp.BackendName(defaultNamer))
+		linker.backendPool.Delete(sp.BackendName(defaultNamer), meta.Global)
+	}
+}
+
+func TestLinkWithBalancingSettings(t *testing.T) {
+	fakeIGs := instances.NewFakeInstanceGroups(sets.NewString(), defaultNamer)
+	fakeNodePool := instances.NewNodePool(fakeIGs, defaultNamer)
+	fakeGCE := gce.NewFakeGCECloud(gce.DefaultTestClusterValues())
+	linker := newTestIGLinker(fakeGCE, fakeNodePool)
+
+	utilization := string(Utilization)
+	maxUtilization := 0.6
+	capacityScaler := 0.5
+	sp := utils.ServicePort{
+		NodePort: 8080,
+		Protocol: annotations.ProtocolHTTP,
+		BackendConfig: &backendconfigv1.BackendConfig{
+			Spec: backendconfigv1.BackendConfigSpec{
+				Balancing: &backendconfigv1.BalancingConfig{
+					Mode:           &utilization,
+					MaxUtilization: &maxUtilization,
+					CapacityScaler: &capacityScaler,
+				},
+			},
+		},
+	}
+
+	// Mimic the instance group being created
+	if _, err := linker.instancePool.EnsureInstanceGroupsAndPorts(defaultNamer.InstanceGroup(), []int64{sp.NodePort}); err != nil {
+		t.Fatalf("Did not expect error when ensuring IG for ServicePort %+v: %v", sp, err)
+	}
+
+	// Mimic the syncer creating the backend.
+	linker.backendPool.Create(sp, "fake-health-check-link")
+
+	if err := linker.Link(sp, []GroupKey{{Zone: defaultZone}}); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	be, err := fakeGCE.GetGlobalBackendService(sp.BackendName(defaultNamer))
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if len(be.Backends) != 1 {
+		t.Fatalf("Expected 1 backend, got %d", len(be.Backends))
+	}
+	if b := be.Backends[0]; b.BalancingMode != utilization || b.MaxUtilization != maxUtilization || b.CapacityScaler != capacityScaler {
+		t.Fatalf("Got backend %+v, want balancing mode %v, max utilization %v and capacity scaler %v", b, utilization, maxUtilization, capacityScaler)
+	}
+
+	// Switching the mode of an existing backend updates it.
+	maxRate := 100.0
+	sp.BackendConfig.Spec.Balancing = &backendconfigv1.BalancingConfig{MaxRatePerInstance: &maxRate}
+	if err := linker.Link(sp, []GroupKey{{Zone: defaultZone}}); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	be, err = fakeGCE.GetGlobalBackendService(sp.BackendName(defaultNamer))
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if len(be.Backends) != 1 {
+		t.Fatalf("Expected 1 backend, got %d", len(be.Backends))
+	}
+	if b := be.Backends[0]; b.BalancingMode != string(Rate) || b.MaxRatePerInstance != maxRate || b.MaxUtilization != 0 || b.CapacityScaler != defaultCapacityScaler {
+		t.Fatalf("Got backend %+v, want balancing mode %v and max rate per instance %v", b, Rate, maxRate)
+	}
+
+	// Removing the balancing settings resets the backend to the defaults,
+	// even though no instance group is added.
+	sp.BackendConfig.Spec.Balancing = nil
+	if err := linker.Link(sp, []GroupKey{{Zone: defaultZone}}); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	be, err = fakeGCE.GetGlobalBackendService(sp.BackendName(defaultNamer))
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if len(be.Backends) != 1 {
+		t.Fatalf("Expected 1 backend, got %d", len(be.Backends))
+	}
+	if b := be.Backends[0]; b.BalancingMode != string(Rate) || b.MaxRatePerInstance != maxRPS || b.CapacityScaler != defaultCapacityScaler {
+		t.Fatalf("Got backend %+v, want balancing mode %v and max rate per instance %v", b, Rate, maxRPS)
+	}
+}
+
+func TestLinkWithBalancingModeMigration(t *testing.T) {
+	fakeIGs := instances.NewFakeInstanceGroups(sets.NewString(), defaultNamer)
+	fakeNodePool := instances.NewNodePool(fakeIGs, defaultNamer)
+	fakeGCE := gce.NewFakeGCECloud(gce.DefaultTestClusterValues())
+	linker := newTestIGLinker(fakeGCE, fakeNodePool)
+
+	maxRate := 100.0
+	sp := utils.ServicePort{
+		NodePort: 8080,
+		Protocol: annotations.ProtocolHTTP,
+		BackendConfig: &backendconfigv1.BackendConfig{
+			Spec: backendconfigv1.BackendConfigSpec{
+				Balancing: &backendconfigv1.BalancingConfig{
+					MaxRatePerInstance: &maxRate,
+				},
+			},
+		},
+	}
+
+	// Reject RATE, as GCE would if the instance group was used by another
+	// backend service with balancing mode UTILIZATION.
+	rejectRate := true
+	(fakeGCE.Compute().(*cloud.MockGCE)).MockBackendServices.UpdateHook = func(ctx context.Context, key *meta.Key, be *compute.BackendService, m *cloud.MockBackendServices) error {
+		for _, b := range be.Backends {
+			if rejectRate && b.BalancingMode == string(Rate) {
+				return &googleapi.Error{Code: http.StatusBadRequest}
+			}
+		}
+		return mock.UpdateBackendServiceHook(ctx, key, be, m)
+	}
+
+	// Mimic the instance group being created
+	if _, err := linker.instancePool.EnsureInstanceGroupsAndPorts(defaultNamer.InstanceGroup(), []int64{sp.NodePort}); err != nil {
+		t.Fatalf("Did not expect error when ensuring IG for ServicePort %+v: %v", sp, err)
+	}
+
+	// Mimic the syncer creating the backend.
+	linker.backendPool.Create(sp, "fake-health-check-link")
+
+	if err := linker.Link(sp, []GroupKey{{Zone: defaultZone}}); err != nil {
+		t.Fatalf("%v", err)
+	}
+	be, err := fakeGCE.GetGlobalBackendService(sp.BackendName(defaultNamer))
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if len(be.Backends) != 1 || be.Backends[0].BalancingMode != string(Utilization) {
+		t.Fatalf("Got backends %+v, want a single backend with balancing mode %v", be.Backends, Utilization)
+	}
+
+	// Once the conflict is gone, the desired mode is applied.
+	rejectRate = false
+	if err := linker.Link(sp, []GroupKey{{Zone: defaultZone}}); err != nil {
+		t.Fatalf("%v", err)
+	}
+	be, err = fakeGCE.GetGlobalBackendService(sp.BackendName(defaultNamer))
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if len(be.Backends) != 1 || be.Backends[0].BalancingMode != string(Rate) || be.Backends[0].MaxRatePerInstance != maxRate {
+		t.Fatalf("Got backends %+v, want a single backend with balancing mode %v", be.Backends, Rate)
 	}
 }
@@ -57,7 +57,7 @@ func newTestJig(fakeGCE *gce.Cloud) *Jig {
 	return &Jig{
 		fakeInstancePool: fakeInstancePool,
 		linker:           NewInstanceGroupLinker(fakeInstancePool, fakeBackendPool, defaultNamer),
-		syncer:           NewBackendSyncer(fakeBackendPool, fakeHealthChecks, defaultNamer),
+		syncer:           NewBackendSyncer(fakeBackendPool, fakeHealthChecks, defaultNamer, nil),
 		pool:             fakeBackendPool,
 	}
 }
@@ -19,6 +19,7 @@ package backends
 import (
 	computebeta "google.golang.org/api/compute/v0.beta"
 	api_v1 "k8s.io/api/core/v1"
+	"k8s.io/ingress-gce/pkg/backends/features"
 	"k8s.io/ingress-gce/pkg/composite"
 	"k8s.io/ingress-gce/pkg/utils"
 	"k8s.io/kubernetes/pkg/cloudprovider/providers/gce/cloud/meta"
@@ -34,18 +35,27 @@ type GroupKey struct {
 // Pool is an interface to perform CRUD operations on a pool of GCE
 // Backend Services.
 type Pool interface {
-	// Get a composite BackendService given a required version.
-	Get(name string, version meta.Version) (*composite.BackendService, error)
+	// Get a composite BackendService given a required version and its
+	// scope.
+	Get(name string, version meta.Version, scope meta.KeyType) (*composite.BackendService, error)
 	// Create a composite BackendService and returns it.
 	Create(sp utils.ServicePort, hcLink string) (*composite.BackendService, error)
 	// Update a BackendService given the composite type.
 	Update(be *composite.BackendService) error
-	// Delete a BackendService given its name.
-	Delete(name string) error
-	// Get the health of a BackendService given its name.
+	// Delete a BackendService given its name and scope.
+	Delete(name string, scope meta.KeyType) error
+	// Get the health of a global BackendService given its name.
 	Health(name string) string
-	// Get a list of BackendService names that are managed by this pool.
-	List() ([]string, error)
+	// Get a list of BackendService names of the given scope that are
+	// managed by this pool.
+	List(scope meta.KeyType) ([]string, error)
+
+	// The following operate on global BackendServices, for the features
+	// which the composite BackendService does not cover.
+	features.SecurityPolicySetter
+	features.SignedURLKeys
+	GetBetaGlobalBackendService(name string) (*computebeta.BackendService, error)
+	UpdateBetaGlobalBackendService(be *computebeta.BackendService) error
 }
 
 // Syncer is an interface to sync Kubernetes services to GCE BackendServices.
@@ -57,12 +67,28 @@ type Syncer interface {
 	Sync(svcPorts []utils.ServicePort) error
 	// GC garbage collects unused BackendService's
 	GC(svcPorts []utils.ServicePort) error
+	// Delete deletes the BackendServices of the given ServicePorts, along
+	// with their health checks.
+	Delete(svcPorts []utils.ServicePort) error
+	// PruneStatus prunes the BackendConfig status recorded for ServicePorts
+	// which are not in svcPorts.
+	PruneStatus(svcPorts []utils.ServicePort)
 	// Status returns the status of a BackendService given its name.
 	Status(name string) string
 	// Shutdown cleans up all BackendService's previously synced.
 	Shutdown() error
 }
 
+// BackendConfigStatusRecorder is an interface to record the result of
+// applying BackendConfigs to BackendServices.
+type BackendConfigStatusRecorder interface {
+	// Record the result of syncing the BackendService named beName for a
+	// ServicePort which references a BackendConfig.
+	Record(sp utils.ServicePort, beName string, syncErr error) error
+	// Prune the results recorded for ServicePorts which are not in use.
+	Prune(svcPorts []utils.ServicePort) error
+}
+
 // Linker is an interface to link backends with their associated groups.
 type Linker interface {
 	// Link a BackendService to its groups.
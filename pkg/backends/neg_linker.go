@@ -14,9 +14,12 @@ limitations under the License.
 package backends
 
 import (
+	"encoding/json"
+
 	computebeta "google.golang.org/api/compute/v0.beta"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/ingress-gce/pkg/utils"
+	"k8s.io/kubernetes/pkg/cloudprovider/providers/gce/cloud/meta"
 )
 
 // negLinker handles linking backends to NEG's.
@@ -58,14 +61,17 @@ func (l *negLinker) Link(sp utils.ServicePort, groups []GroupKey) error {
 		negs = append(negs, neg)
 	}
 
-	cloud := l.backendPool.(*Backends).cloud
 	beName := sp.BackendName(l.namer)
-	backendService, err := cloud.GetBetaGlobalBackendService(beName)
+	settings := balancingSettingsFromServicePort(sp)
+	targetBackends := getBackendsForNEGs(negs, settings)
+	if sp.L7ILBEnabled {
+		return l.linkRegional(beName, targetBackends, settings)
+	}
+
+	backendService, err := l.backendPool.GetBetaGlobalBackendService(beName)
 	if err != nil {
 		return err
 	}
-
-	targetBackends := getBackendsForNEGs(negs)
 	oldBackends := sets.NewString()
 	newBackends := sets.NewString()
 
@@ -78,14 +84,71 @@ func (l *negLinker) Link(sp utils.ServicePort, groups []GroupKey) error {
 		newBackends.Insert(be.Group)
 	}
 
-	if !oldBackends.Equal(newBackends) {
+	// NEGs are not shared between backend services, so all backends can be
+	// switched to a different balancing mode in a single update.
+	if !oldBackends.Equal(newBackends) || !negBackendsBalancingUpToDate(backendService.Backends, targetBackends, settings) {
 		backendService.Backends = targetBackends
-		return cloud.UpdateBetaGlobalBackendService(backendService)
+		return l.backendPool.UpdateBetaGlobalBackendService(backendService)
 	}
 	return nil
 }
 
-func getBackendsForNEGs(negs []*computebeta.NetworkEndpointGroup) []*computebeta.Backend {
+// linkRegional sets the target Backends on the regional backend service
+// named beName. Regional backend services are only served by the alpha API,
+// so their Backends are compared by the relative names of their groups.
+func (l *negLinker) linkRegional(beName string, targetBackends []*computebeta.Backend, settings *balancingSettings) error {
+	be, err := l.backendPool.Get(beName, meta.VersionAlpha, meta.Regional)
+	if err != nil {
+		return err
+	}
+	var existingBackends []*computebeta.Backend
+	if err := convertBackends(&existingBackends, be.Backends); err != nil {
+		return err
+	}
+
+	oldBackends := sets.NewString()
+	newBackends := sets.NewString()
+	for _, b := range existingBackends {
+		oldBackends.Insert(relativeGroupName(b.Group))
+	}
+	for _, b := range targetBackends {
+		newBackends.Insert(relativeGroupName(b.Group))
+	}
+	if oldBackends.Equal(newBackends) && negBackendsBalancingUpToDate(withRelativeGroups(existingBackends), withRelativeGroups(targetBackends), settings) {
+		return nil
+	}
+	be.Backends = nil
+	if err := convertBackends(&be.Backends, targetBackends); err != nil {
+		return err
+	}
+	return l.backendPool.Update(be)
+}
+
+// convertBackends converts the Backends in src to the type of dst, which
+// are Backends of different versions.
+func convertBackends(dst, src interface{}) error {
+	bytes, err := json.Marshal(src)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(bytes, dst)
+}
+
+// withRelativeGroups returns copies of the given Backends which refer to
+// their groups by relative resource name.
+func withRelativeGroups(backends []*computebeta.Backend) []*computebeta.Backend {
+	var ret []*computebeta.Backend
+	for _, b := range backends {
+		c := *b
+		c.Group = relativeGroupName(b.Group)
+		ret = append(ret, &c)
+	}
+	return ret
+}
+
+// getBackendsForNEGs returns the Backends for the given NEGs. If settings is
+// nil, the Backends use BalancingMode RATE.
+func getBackendsForNEGs(negs []*computebeta.NetworkEndpointGroup, settings *balancingSettings) []*computebeta.Backend {
 	var backends []*computebeta.Backend
 	for _, neg := range negs {
 		b := &computebeta.Backend{
@@ -93,6 +156,9 @@ func getBackendsForNEGs(negs []*computebeta.NetworkEndpointGroup) []*computebeta
 			BalancingMode:      string(Rate),
 			MaxRatePerEndpoint: maxRPS,
 		}
+		if settings != nil {
+			settings.applyToNEGBackend(b)
+		}
 		backends = append(backends, b)
 	}
 	return backends
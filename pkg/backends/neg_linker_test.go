@@ -14,16 +14,23 @@ limitations under the License.
 package backends
 
 import (
+	"context"
+	"fmt"
+	"net/http"
 	"strings"
 	"testing"
 
+	computealpha "google.golang.org/api/compute/v0.alpha"
 	computebeta "google.golang.org/api/compute/v0.beta"
+	"google.golang.org/api/googleapi"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/ingress-gce/pkg/annotations"
+	backendconfigv1 "k8s.io/ingress-gce/pkg/apis/backendconfig/v1"
 	negtypes "k8s.io/ingress-gce/pkg/neg/types"
 	"k8s.io/ingress-gce/pkg/utils"
 	"k8s.io/kubernetes/pkg/cloudprovider/providers/gce"
 	"k8s.io/kubernetes/pkg/cloudprovider/providers/gce/cloud"
+	"k8s.io/kubernetes/pkg/cloudprovider/providers/gce/cloud/meta"
 	"k8s.io/kubernetes/pkg/cloudprovider/providers/gce/cloud/mock"
 )
 
@@ -34,10 +41,29 @@ func newTestNEGLinker(fakeNEG negtypes.NetworkEndpointGroupCloud, fakeGCE *gce.C
 	(fakeGCE.Compute().(*cloud.MockGCE)).MockAlphaBackendServices.UpdateHook = mock.UpdateAlphaBackendServiceHook
 	(fakeGCE.Compute().(*cloud.MockGCE)).MockBetaBackendServices.UpdateHook = mock.UpdateBetaBackendServiceHook
 	(fakeGCE.Compute().(*cloud.MockGCE)).MockBackendServices.UpdateHook = mock.UpdateBackendServiceHook
+	(fakeGCE.Compute().(*cloud.MockGCE)).MockAlphaRegionBackendServices.UpdateHook = updateAlphaRegionBackendServiceHook
 
 	return &negLinker{fakeBackendPool, fakeNEG, defaultNamer}
 }
 
+// updateAlphaRegionBackendServiceHook replaces the regional BackendService
+// with the same key in the mock with the updated object.
+func updateAlphaRegionBackendServiceHook(ctx context.Context, key *meta.Key, obj *computealpha.BackendService, m *cloud.MockAlphaRegionBackendServices) error {
+	if _, err := m.Get(ctx, key); err != nil {
+		return &googleapi.Error{
+			Code:    http.StatusNotFound,
+			Message: fmt.Sprintf("Key: %s was not found in RegionBackendServices", key.String()),
+		}
+	}
+
+	obj.Name = key.Name
+	projectID := m.ProjectRouter.ProjectID(ctx, "alpha", "backendServices")
+	obj.SelfLink = cloud.SelfLink(meta.VersionAlpha, projectID, "backendServices", key)
+
+	m.Objects[*key] = &cloud.MockRegionBackendServicesObj{Obj: obj}
+	return nil
+}
+
 func TestLinkBackendServiceToNEG(t *testing.T) {
 	fakeGCE := gce.NewFakeGCECloud(gce.DefaultTestClusterValues())
 	fakeNEG := negtypes.NewFakeNetworkEndpointGroupCloud("test-subnetwork", "test-network")
@@ -92,3 +118,163 @@ func TestLinkBackendServiceToNEG(t *testing.T) {
 		}
 	}
 }
+
+func TestLinkBackendServiceToNEGWithBalancingSettings(t *testing.T) {
+	fakeGCE := gce.NewFakeGCECloud(gce.DefaultTestClusterValues())
+	fakeNEG := negtypes.NewFakeNetworkEndpointGroupCloud("test-subnetwork", "test-network")
+	linker := newTestNEGLinker(fakeNEG, fakeGCE)
+
+	zones := []GroupKey{{Zone: "zone1"}, {Zone: "zone2"}}
+	namespace, name, port := "ns", "name", "port"
+
+	maxRate := 50.0
+	svcPort := utils.ServicePort{
+		ID: utils.ServicePortID{
+			Service: types.NamespacedName{
+				Namespace: namespace,
+				Name:      name,
+			},
+		},
+		Port:       80,
+		NodePort:   30001,
+		Protocol:   annotations.ProtocolHTTP,
+		TargetPort: port,
+		NEGEnabled: true,
+		BackendConfig: &backendconfigv1.BackendConfig{
+			Spec: backendconfigv1.BackendConfigSpec{
+				Balancing: &backendconfigv1.BalancingConfig{
+					MaxRatePerEndpoint: &maxRate,
+				},
+			},
+		},
+	}
+
+	// Mimic how the syncer would create the backend.
+	linker.backendPool.Create(svcPort, "fake-healthcheck-link")
+
+	for _, key := range zones {
+		err := fakeNEG.CreateNetworkEndpointGroup(&computebeta.NetworkEndpointGroup{
+			Name: defaultNamer.NEG(namespace, name, svcPort.Port),
+		}, key.Zone)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if err := linker.Link(svcPort, zones); err != nil {
+		t.Fatalf("Failed to link backend service to NEG: %v", err)
+	}
+
+	beName := svcPort.BackendName(defaultNamer)
+	bs, err := fakeGCE.GetBetaGlobalBackendService(beName)
+	if err != nil {
+		t.Fatalf("Failed to retrieve backend service: %v", err)
+	}
+	for _, be := range bs.Backends {
+		if be.BalancingMode != string(Rate) || be.MaxRatePerEndpoint != maxRate {
+			t.Errorf("Got backend %+v, want balancing mode %v and max rate per endpoint %v", be, Rate, maxRate)
+		}
+	}
+
+	// Changing the balancing settings updates the existing backends.
+	capacityScaler := 0.5
+	svcPort.BackendConfig.Spec.Balancing = &backendconfigv1.BalancingConfig{
+		MaxRatePerEndpoint: &maxRate,
+		CapacityScaler:     &capacityScaler,
+	}
+	if err := linker.Link(svcPort, zones); err != nil {
+		t.Fatalf("Failed to link backend service to NEG: %v", err)
+	}
+
+	bs, err = fakeGCE.GetBetaGlobalBackendService(beName)
+	if err != nil {
+		t.Fatalf("Failed to retrieve backend service: %v", err)
+	}
+	if len(bs.Backends) != len(zones) {
+		t.Errorf("Expect %v backends, but got %v.", len(zones), len(bs.Backends))
+	}
+	for _, be := range bs.Backends {
+		if be.BalancingMode != string(Rate) || be.MaxRatePerEndpoint != maxRate || be.CapacityScaler != capacityScaler {
+			t.Errorf("Got backend %+v, want balancing mode %v, max rate per endpoint %v and capacity scaler %v", be, Rate, maxRate, capacityScaler)
+		}
+	}
+
+	// Removing the balancing settings resets the backends to the defaults.
+	svcPort.BackendConfig.Spec.Balancing = nil
+	if err := linker.Link(svcPort, zones); err != nil {
+		t.Fatalf("Failed to link backend service to NEG: %v", err)
+	}
+
+	bs, err = fakeGCE.GetBetaGlobalBackendService(beName)
+	if err != nil {
+		t.Fatalf("Failed to retrieve backend service: %v", err)
+	}
+	for _, be := range bs.Backends {
+		if be.BalancingMode != string(Rate) || be.MaxRatePerEndpoint != maxRPS || !isDefaultCapacityScaler(be.CapacityScaler) {
+			t.Errorf("Got backend %+v, want the default balancing settings", be)
+		}
+	}
+}
+
+func TestLinkRegionalBackendServiceToNEG(t *testing.T) {
+	fakeGCE := gce.NewFakeGCECloud(gce.DefaultTestClusterValues())
+	fakeNEG := negtypes.NewFakeNetworkEndpointGroupCloud("test-subnetwork", "test-network")
+	linker := newTestNEGLinker(fakeNEG, fakeGCE)
+
+	zones := []GroupKey{{Zone: "zone1"}, {Zone: "zone2"}}
+	svcPort := utils.ServicePort{
+		ID: utils.ServicePortID{
+			Service: types.NamespacedName{
+				Namespace: "ns",
+				Name:      "name",
+			},
+		},
+		Port:         80,
+		NodePort:     30001,
+		Protocol:     annotations.ProtocolHTTP,
+		TargetPort:   "port",
+		NEGEnabled:   true,
+		L7ILBEnabled: true,
+	}
+
+	// Mimic how the syncer would create the backend.
+	if _, err := linker.backendPool.Create(svcPort, "fake-healthcheck-link"); err != nil {
+		t.Fatalf("Failed to create backend service: %v", err)
+	}
+
+	for _, key := range zones {
+		err := fakeNEG.CreateNetworkEndpointGroup(&computebeta.NetworkEndpointGroup{
+			Name: defaultNamer.NEG("ns", "name", svcPort.Port),
+		}, key.Zone)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if err := linker.Link(svcPort, zones); err != nil {
+		t.Fatalf("Failed to link backend service to NEG: %v", err)
+	}
+
+	beName := svcPort.BackendName(defaultNamer)
+	if _, err := fakeGCE.GetGlobalBackendService(beName); err == nil {
+		t.Errorf("Got global backend service %q, want none", beName)
+	}
+	bs, err := fakeGCE.Compute().AlphaRegionBackendServices().Get(context.Background(), meta.RegionalKey(beName, fakeGCE.Region()))
+	if err != nil {
+		t.Fatalf("Failed to retrieve regional backend service: %v", err)
+	}
+	if bs.LoadBalancingScheme != "INTERNAL_MANAGED" {
+		t.Errorf("Got load balancing scheme %q, want INTERNAL_MANAGED", bs.LoadBalancingScheme)
+	}
+	if len(bs.Backends) != len(zones) {
+		t.Errorf("Expect %v backends, but got %v.", len(zones), len(bs.Backends))
+	}
+
+	// Linking again must not update the backend service.
+	(fakeGCE.Compute().(*cloud.MockGCE)).MockAlphaRegionBackendServices.UpdateHook = func(context.Context, *meta.Key, *computealpha.BackendService, *cloud.MockAlphaRegionBackendServices) error {
+		return fmt.Errorf("unexpected update")
+	}
+	if err := linker.Link(svcPort, zones); err != nil {
+		t.Errorf("Failed to link backend service to NEG again: %v", err)
+	}
+}
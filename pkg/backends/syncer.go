@@ -20,11 +20,14 @@ import (
 
 	"k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
+	backendconfigv1 "k8s.io/ingress-gce/pkg/apis/backendconfig/v1"
 	"k8s.io/ingress-gce/pkg/backends/features"
 	"k8s.io/ingress-gce/pkg/composite"
+	"k8s.io/ingress-gce/pkg/flags"
 	"k8s.io/ingress-gce/pkg/healthchecks"
 	"k8s.io/ingress-gce/pkg/utils"
 	"k8s.io/klog"
+	"k8s.io/kubernetes/pkg/cloudprovider/providers/gce/cloud/meta"
 )
 
 // backendSyncer manages the lifecycle of backends.
@@ -33,6 +36,8 @@ type backendSyncer struct {
 	healthChecker healthchecks.HealthChecker
 	prober        ProbeProvider
 	namer         *utils.Namer
+	// statusRecorder is optional, it is nil if BackendConfig is disabled.
+	statusRecorder BackendConfigStatusRecorder
 }
 
 // backendSyncer is a Syncer
@@ -41,11 +46,13 @@ var _ Syncer = (*backendSyncer)(nil)
 func NewBackendSyncer(
 	backendPool Pool,
 	healthChecker healthchecks.HealthChecker,
-	namer *utils.Namer) Syncer {
+	namer *utils.Namer,
+	statusRecorder BackendConfigStatusRecorder) Syncer {
 	return &backendSyncer{
-		backendPool:   backendPool,
-		healthChecker: healthChecker,
-		namer:         namer,
+		backendPool:    backendPool,
+		healthChecker:  healthChecker,
+		namer:          namer,
+		statusRecorder: statusRecorder,
 	}
 }
 
@@ -58,7 +65,9 @@ func (s *backendSyncer) Init(pp ProbeProvider) {
 func (s *backendSyncer) Sync(svcPorts []utils.ServicePort) error {
 	for _, sp := range svcPorts {
 		klog.V(3).Infof("Sync: backend %+v", sp)
-		if err := s.ensureBackendService(sp); err != nil {
+		err := s.ensureBackendService(sp)
+		s.recordBackendConfigStatus(sp, err)
+		if err != nil {
 			return err
 		}
 	}
@@ -66,6 +75,17 @@ func (s *backendSyncer) Sync(svcPorts []utils.ServicePort) error {
 
 }
 
+// recordBackendConfigStatus records the result of syncing the BackendService
+// for the given port in the status of the BackendConfig it references.
+func (s *backendSyncer) recordBackendConfigStatus(sp utils.ServicePort, syncErr error) {
+	if s.statusRecorder == nil || sp.BackendConfig == nil {
+		return
+	}
+	if err := s.statusRecorder.Record(sp, sp.BackendName(s.namer), syncErr); err != nil {
+		klog.Warningf("Failed to record status of BackendConfig %s/%s: %v", sp.BackendConfig.Namespace, sp.BackendConfig.Name, err)
+	}
+}
+
 // ensureBackendService will update or create a BackendService for the given port.
 func (s *backendSyncer) ensureBackendService(sp utils.ServicePort) error {
 	// We must track the ports even if creating the backends failed, because
@@ -73,8 +93,14 @@ func (s *backendSyncer) ensureBackendService(sp utils.ServicePort) error {
 	be := &composite.BackendService{}
 	beName := sp.BackendName(s.namer)
 	version := features.VersionFromServicePort(&sp)
+	scope := features.ScopeFromServicePort(&sp)
+	if scope == meta.Regional {
+		if err := checkRegionalBackendConfig(sp); err != nil {
+			return err
+		}
+	}
 
-	be, getErr := s.backendPool.Get(beName, version)
+	be, getErr := s.backendPool.Get(beName, version, scope)
 	hasLegacyHC := false
 	if be != nil {
 		// If the backend already exists, find out if it is using a legacy health check.
@@ -104,6 +130,14 @@ func (s *backendSyncer) ensureBackendService(sp utils.ServicePort) error {
 		}
 	}
 
+	if sp.BackendConfig != nil {
+		// Signed URL keys are managed with their own API calls and must be
+		// synced before the description records their fingerprints.
+		if err := features.EnsureSignedURLKeys(s.backendPool, sp, be, beName); err != nil {
+			return err
+		}
+	}
+
 	needUpdate := ensureProtocol(be, sp)
 	needUpdate = ensureHealthCheckLink(be, hcLink) || needUpdate
 	needUpdate = ensureDescription(be, &sp) || needUpdate
@@ -113,6 +147,12 @@ func (s *backendSyncer) ensureBackendService(sp utils.ServicePort) error {
 		needUpdate = features.EnsureTimeout(sp, be) || needUpdate
 		needUpdate = features.EnsureDraining(sp, be) || needUpdate
 		needUpdate = features.EnsureAffinity(sp, be) || needUpdate
+		needUpdate = features.EnsureCustomRequestHeaders(sp, be) || needUpdate
+		needUpdate = features.EnsureCustomResponseHeaders(sp, be) || needUpdate
+		needUpdate = features.EnsureLogging(sp, be) || needUpdate
+		needUpdate = features.EnsureOutlierDetection(sp, be) || needUpdate
+		needUpdate = features.EnsureCircuitBreakers(sp, be) || needUpdate
+		needUpdate = features.EnsureLocalityLbPolicy(sp, be) || needUpdate
 	}
 
 	if needUpdate {
@@ -122,8 +162,7 @@ func (s *backendSyncer) ensureBackendService(sp utils.ServicePort) error {
 	}
 
 	if sp.BackendConfig != nil {
-		cloud := s.backendPool.(*Backends).cloud
-		if err := features.EnsureSecurityPolicy(cloud, sp, be, beName); err != nil {
+		if err := features.EnsureSecurityPolicy(s.backendPool, sp, be, beName); err != nil {
 			return err
 		}
 	}
@@ -133,15 +172,47 @@ func (s *backendSyncer) ensureBackendService(sp utils.ServicePort) error {
 
 // GC implements Syncer.
 func (s *backendSyncer) GC(svcPorts []utils.ServicePort) error {
-	knownPorts := sets.NewString()
+	knownPorts := map[meta.KeyType]sets.String{
+		meta.Global:   sets.NewString(),
+		meta.Regional: sets.NewString(),
+	}
 	for _, sp := range svcPorts {
 		name := sp.BackendName(s.namer)
-		knownPorts.Insert(name)
+		knownPorts[features.ScopeFromServicePort(&sp)].Insert(name)
+	}
+
+	scopes := []meta.KeyType{meta.Global}
+	// Regional backends are only listed if they can be managed, so that
+	// no regional API calls are made otherwise.
+	if flags.F.EnableL7Ilb {
+		scopes = append(scopes, meta.Regional)
+	}
+	for _, scope := range scopes {
+		if err := s.gc(knownPorts[scope], scope); err != nil {
+			return err
+		}
+	}
+
+	s.PruneStatus(svcPorts)
+	return nil
+}
+
+// PruneStatus implements Syncer.
+func (s *backendSyncer) PruneStatus(svcPorts []utils.ServicePort) {
+	if s.statusRecorder == nil {
+		return
+	}
+	if err := s.statusRecorder.Prune(svcPorts); err != nil {
+		klog.Warningf("Failed to prune BackendConfig status: %v", err)
 	}
+}
 
-	backendNames, err := s.backendPool.List()
+// gc deletes the backends of the given scope which are not known, along
+// with their health checks.
+func (s *backendSyncer) gc(knownPorts sets.String, scope meta.KeyType) error {
+	backendNames, err := s.backendPool.List(scope)
 	if err != nil {
-		return fmt.Errorf("error getting the names of controller-managed backends: %v", err)
+		return fmt.Errorf("error getting the names of controller-managed %s backends: %v", scope, err)
 	}
 
 	for _, name := range backendNames {
@@ -149,17 +220,36 @@ func (s *backendSyncer) GC(svcPorts []utils.ServicePort) error {
 			continue
 		}
 
-		klog.V(3).Infof("GCing backendService for port %s", name)
-		if err := s.backendPool.Delete(name); err != nil && !utils.IsHTTPErrorCode(err, http.StatusNotFound) {
+		klog.V(3).Infof("GCing %s backendService for port %s", scope, name)
+		if err := s.delete(name, scope); err != nil {
 			return err
 		}
-		if err := s.healthChecker.Delete(name); err != nil {
+	}
+	return nil
+}
+
+// Delete implements Syncer.
+func (s *backendSyncer) Delete(svcPorts []utils.ServicePort) error {
+	for _, sp := range svcPorts {
+		name := sp.BackendName(s.namer)
+		scope := features.ScopeFromServicePort(&sp)
+		klog.V(3).Infof("Deleting %s backendService for port %s", scope, name)
+		if err := s.delete(name, scope); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
+// delete deletes a backend of the given scope along with its health check.
+// Resources which do not exist are not an error.
+func (s *backendSyncer) delete(name string, scope meta.KeyType) error {
+	if err := s.backendPool.Delete(name, scope); err != nil && !utils.IsHTTPErrorCode(err, http.StatusNotFound) {
+		return err
+	}
+	return utils.IgnoreHTTPNotFound(s.healthChecker.Delete(name, scope))
+}
+
 // Status implements Syncer.
 func (s *backendSyncer) Status(name string) string {
 	return s.backendPool.Health(name)
@@ -178,10 +268,19 @@ func (s *backendSyncer) ensureHealthCheck(sp utils.ServicePort, hasLegacyHC bool
 		klog.Errorf("Backend %+v has legacy health check", sp.ID)
 	}
 	hc := s.healthChecker.New(sp)
+	var bchcConfig *backendconfigv1.HealthCheckConfig
+	if sp.BackendConfig != nil {
+		bchcConfig = sp.BackendConfig.Spec.HealthCheck
+	}
 	if s.prober != nil {
 		probe, err := s.prober.GetProbe(sp)
 		if err != nil {
-			return "", err
+			// The readiness probe is not needed if the health check is
+			// configured through the BackendConfig.
+			if bchcConfig == nil {
+				return "", err
+			}
+			klog.V(2).Infof("Ignoring error getting readinessProbe for port %+v, using BackendConfig health check settings: %v", sp, err)
 		}
 		if probe != nil {
 			klog.V(4).Infof("Applying httpGet settings of readinessProbe to health check on port %+v", sp)
@@ -189,9 +288,30 @@ func (s *backendSyncer) ensureHealthCheck(sp utils.ServicePort, hasLegacyHC bool
 		}
 	}
 
+	// Settings specified in the BackendConfig take precedence over the probe.
+	if bchcConfig != nil {
+		klog.V(4).Infof("Applying BackendConfig health check settings to health check on port %+v", sp)
+		hc.UpdateFromBackendConfig(bchcConfig)
+	}
+
 	return s.healthChecker.Sync(hc)
 }
 
+// checkRegionalBackendConfig returns an error if the BackendConfig of the
+// given port uses features which regional backend services do not support.
+func checkRegionalBackendConfig(sp utils.ServicePort) error {
+	if sp.BackendConfig == nil {
+		return nil
+	}
+	if sp.BackendConfig.Spec.Cdn != nil && sp.BackendConfig.Spec.Cdn.Enabled {
+		return fmt.Errorf("backend %v of an internal load balancer does not support Cloud CDN", sp.ID)
+	}
+	if sp.BackendConfig.Spec.SecurityPolicy != nil {
+		return fmt.Errorf("backend %v of an internal load balancer does not support security policies", sp.ID)
+	}
+	return nil
+}
+
 // getHealthCheckLink gets the Healthcheck link off the BackendService
 func getHealthCheckLink(be *composite.BackendService) string {
 	if len(be.HealthChecks) == 1 {
@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"net/http"
 	"reflect"
+	"strings"
 	"testing"
 
 	computebeta "google.golang.org/api/compute/v0.beta"
@@ -30,7 +31,9 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/ingress-gce/pkg/annotations"
+	backendconfigv1 "k8s.io/ingress-gce/pkg/apis/backendconfig/v1"
 	"k8s.io/ingress-gce/pkg/backends/features"
+	"k8s.io/ingress-gce/pkg/flags"
 	"k8s.io/ingress-gce/pkg/healthchecks"
 	"k8s.io/ingress-gce/pkg/utils"
 	"k8s.io/kubernetes/pkg/cloudprovider/providers/gce"
@@ -75,6 +78,7 @@ func newTestSyncer(fakeGCE *gce.Cloud) *backendSyncer {
 	(fakeGCE.Compute().(*cloud.MockGCE)).MockAlphaBackendServices.UpdateHook = mock.UpdateAlphaBackendServiceHook
 	(fakeGCE.Compute().(*cloud.MockGCE)).MockBetaBackendServices.UpdateHook = mock.UpdateBetaBackendServiceHook
 	(fakeGCE.Compute().(*cloud.MockGCE)).MockBackendServices.UpdateHook = mock.UpdateBackendServiceHook
+	(fakeGCE.Compute().(*cloud.MockGCE)).MockAlphaRegionBackendServices.UpdateHook = updateAlphaRegionBackendServiceHook
 
 	return syncer
 }
@@ -98,7 +102,7 @@ func TestSync(t *testing.T) {
 			beName := sp.BackendName(defaultNamer)
 
 			// Check that the new backend has the right port
-			be, err := syncer.backendPool.Get(beName, features.VersionFromServicePort(&sp))
+			be, err := syncer.backendPool.Get(beName, features.VersionFromServicePort(&sp), meta.Global)
 			if err != nil {
 				t.Fatalf("Did not find expected backend with port %v", sp.NodePort)
 			}
@@ -106,7 +110,7 @@ func TestSync(t *testing.T) {
 				t.Fatalf("Backend %v has wrong port %v, expected %v", be.Name, be.Port, sp)
 			}
 
-			hc, err := syncer.healthChecker.Get(beName, features.VersionFromServicePort(&sp))
+			hc, err := syncer.healthChecker.Get(beName, features.VersionFromServicePort(&sp), meta.Global)
 			if err != nil {
 				t.Fatalf("Unexpected err when querying fake healthchecker: %v", err)
 			}
@@ -130,7 +134,7 @@ func TestSyncUpdateHTTPS(t *testing.T) {
 	syncer.Sync([]utils.ServicePort{p})
 	beName := p.BackendName(defaultNamer)
 
-	be, err := syncer.backendPool.Get(beName, features.VersionFromServicePort(&p))
+	be, err := syncer.backendPool.Get(beName, features.VersionFromServicePort(&p), meta.Global)
 	if err != nil {
 		t.Fatalf("Unexpected err: %v", err)
 	}
@@ -140,7 +144,7 @@ func TestSyncUpdateHTTPS(t *testing.T) {
 	}
 
 	// Assert the proper health check was created
-	hc, _ := syncer.healthChecker.Get(beName, features.VersionFromServicePort(&p))
+	hc, _ := syncer.healthChecker.Get(beName, features.VersionFromServicePort(&p), meta.Global)
 	if hc == nil || hc.Protocol() != p.Protocol {
 		t.Fatalf("Expected %s health check, received %v: ", p.Protocol, hc)
 	}
@@ -149,7 +153,7 @@ func TestSyncUpdateHTTPS(t *testing.T) {
 	p.Protocol = annotations.ProtocolHTTPS
 	syncer.Sync([]utils.ServicePort{p})
 
-	be, err = syncer.backendPool.Get(beName, features.VersionFromServicePort(&p))
+	be, err = syncer.backendPool.Get(beName, features.VersionFromServicePort(&p), meta.Global)
 	if err != nil {
 		t.Fatalf("Unexpected err retrieving backend service after update: %v", err)
 	}
@@ -160,7 +164,7 @@ func TestSyncUpdateHTTPS(t *testing.T) {
 	}
 
 	// Assert the proper health check was created
-	hc, _ = syncer.healthChecker.Get(beName, features.VersionFromServicePort(&p))
+	hc, _ = syncer.healthChecker.Get(beName, features.VersionFromServicePort(&p), meta.Global)
 	if hc == nil || hc.Protocol() != p.Protocol {
 		t.Fatalf("Expected %s health check, received %v: ", p.Protocol, hc)
 	}
@@ -174,7 +178,7 @@ func TestSyncUpdateHTTP2(t *testing.T) {
 	syncer.Sync([]utils.ServicePort{p})
 	beName := p.BackendName(defaultNamer)
 
-	be, err := syncer.backendPool.Get(beName, features.VersionFromServicePort(&p))
+	be, err := syncer.backendPool.Get(beName, features.VersionFromServicePort(&p), meta.Global)
 	if err != nil {
 		t.Fatalf("Unexpected err: %v", err)
 	}
@@ -184,7 +188,7 @@ func TestSyncUpdateHTTP2(t *testing.T) {
 	}
 
 	// Assert the proper health check was created
-	hc, _ := syncer.healthChecker.Get(beName, features.VersionFromServicePort(&p))
+	hc, _ := syncer.healthChecker.Get(beName, features.VersionFromServicePort(&p), meta.Global)
 	if hc == nil || hc.Protocol() != p.Protocol {
 		t.Fatalf("Expected %s health check, received %v: ", p.Protocol, hc)
 	}
@@ -193,7 +197,7 @@ func TestSyncUpdateHTTP2(t *testing.T) {
 	p.Protocol = annotations.ProtocolHTTP2
 	syncer.Sync([]utils.ServicePort{p})
 
-	beBeta, err := syncer.backendPool.Get(beName, features.VersionFromServicePort(&p))
+	beBeta, err := syncer.backendPool.Get(beName, features.VersionFromServicePort(&p), meta.Global)
 	if err != nil {
 		t.Fatalf("Unexpected err retrieving backend service after update: %v", err)
 	}
@@ -204,7 +208,7 @@ func TestSyncUpdateHTTP2(t *testing.T) {
 	}
 
 	// Assert the proper health check was created
-	hc, _ = syncer.healthChecker.Get(beName, meta.VersionAlpha)
+	hc, _ = syncer.healthChecker.Get(beName, meta.VersionAlpha, meta.Global)
 	if hc == nil || hc.Protocol() != p.Protocol {
 		t.Fatalf("Expected %s health check, received %v: ", p.Protocol, hc)
 	}
@@ -265,6 +269,101 @@ func TestGC(t *testing.T) {
 	}
 }
 
+func TestDelete(t *testing.T) {
+	fakeGCE := gce.NewFakeGCECloud(gce.DefaultTestClusterValues())
+	syncer := newTestSyncer(fakeGCE)
+
+	svcNodePorts := []utils.ServicePort{
+		{NodePort: 81, Protocol: annotations.ProtocolHTTP},
+		{NodePort: 82, Protocol: annotations.ProtocolHTTP},
+	}
+	if err := syncer.Sync(svcNodePorts); err != nil {
+		t.Fatalf("Expected syncer to add backends with error, err: %v", err)
+	}
+
+	// Ports without a backend are ignored.
+	missing := utils.ServicePort{NodePort: 83, Protocol: annotations.ProtocolHTTP}
+	if err := syncer.Delete([]utils.ServicePort{svcNodePorts[1], missing}); err != nil {
+		t.Fatalf("syncer.Delete() = %v, want nil", err)
+	}
+
+	beName := svcNodePorts[0].BackendName(defaultNamer)
+	if _, err := fakeGCE.GetGlobalBackendService(beName); err != nil {
+		t.Errorf("Expected to find backend for port %v, err: %v", svcNodePorts[0].NodePort, err)
+	}
+	beName = svcNodePorts[1].BackendName(defaultNamer)
+	if _, err := fakeGCE.GetGlobalBackendService(beName); err == nil {
+		t.Errorf("Expected to not find backend for port %v", svcNodePorts[1].NodePort)
+	}
+	if _, err := fakeGCE.GetHealthCheck(beName); err == nil {
+		t.Errorf("Expected to not find health check for port %v", svcNodePorts[1].NodePort)
+	}
+}
+
+func TestSyncL7ILB(t *testing.T) {
+	defer func(enabled bool) { flags.F.EnableL7Ilb = enabled }(flags.F.EnableL7Ilb)
+	flags.F.EnableL7Ilb = true
+
+	fakeGCE := gce.NewFakeGCECloud(gce.DefaultTestClusterValues())
+	syncer := newTestSyncer(fakeGCE)
+
+	globalPort := utils.ServicePort{NodePort: 81, Port: 80, Protocol: annotations.ProtocolHTTP, NEGEnabled: true}
+	globalPort.ID.Service = types.NamespacedName{Namespace: "ns", Name: "global"}
+	ilbPort := utils.ServicePort{NodePort: 82, Port: 80, Protocol: annotations.ProtocolHTTP, NEGEnabled: true, L7ILBEnabled: true}
+	ilbPort.ID.Service = types.NamespacedName{Namespace: "ns", Name: "ilb"}
+
+	if err := syncer.Sync([]utils.ServicePort{globalPort, ilbPort}); err != nil {
+		t.Fatalf("syncer.Sync() = %v, want nil", err)
+	}
+
+	ilbName := ilbPort.BackendName(defaultNamer)
+	be, err := syncer.backendPool.Get(ilbName, features.VersionFromServicePort(&ilbPort), meta.Regional)
+	if err != nil {
+		t.Fatalf("Did not find expected regional backend %v: %v", ilbName, err)
+	}
+	if be.LoadBalancingScheme != "INTERNAL_MANAGED" {
+		t.Errorf("Backend %v has load balancing scheme %q, want INTERNAL_MANAGED", ilbName, be.LoadBalancingScheme)
+	}
+	if _, err := syncer.backendPool.Get(ilbName, meta.VersionGA, meta.Global); err == nil {
+		t.Errorf("Found unexpected global backend %v", ilbName)
+	}
+	if !strings.Contains(getHealthCheckLink(be), "/regions/") {
+		t.Errorf("Backend %v has health check %q, want a regional health check", ilbName, getHealthCheckLink(be))
+	}
+	if _, err := syncer.healthChecker.Get(ilbName, meta.VersionAlpha, meta.Regional); err != nil {
+		t.Errorf("Did not find expected regional health check %v: %v", ilbName, err)
+	}
+
+	// Only the regional backend is garbage collected.
+	if err := syncer.GC([]utils.ServicePort{globalPort}); err != nil {
+		t.Fatalf("syncer.GC() = %v, want nil", err)
+	}
+	if _, err := syncer.backendPool.Get(ilbName, meta.VersionAlpha, meta.Regional); !utils.IsNotFoundError(err) {
+		t.Errorf("Got %v getting regional backend %v, want not found", err, ilbName)
+	}
+	if _, err := syncer.healthChecker.Get(ilbName, meta.VersionAlpha, meta.Regional); !utils.IsNotFoundError(err) {
+		t.Errorf("Got %v getting regional health check %v, want not found", err, ilbName)
+	}
+	if _, err := syncer.backendPool.Get(globalPort.BackendName(defaultNamer), meta.VersionBeta, meta.Global); err != nil {
+		t.Errorf("Did not find expected global backend: %v", err)
+	}
+}
+
+func TestSyncL7ILBUnsupportedBackendConfig(t *testing.T) {
+	fakeGCE := gce.NewFakeGCECloud(gce.DefaultTestClusterValues())
+	syncer := newTestSyncer(fakeGCE)
+
+	sp := utils.ServicePort{NodePort: 82, Port: 80, Protocol: annotations.ProtocolHTTP, NEGEnabled: true, L7ILBEnabled: true}
+	sp.BackendConfig = &backendconfigv1.BackendConfig{
+		Spec: backendconfigv1.BackendConfigSpec{
+			Cdn: &backendconfigv1.CDNConfig{Enabled: true},
+		},
+	}
+	if err := syncer.Sync([]utils.ServicePort{sp}); err == nil {
+		t.Errorf("syncer.Sync() = nil, want error for Cloud CDN on an internal backend")
+	}
+}
+
 func TestSyncQuota(t *testing.T) {
 	testCases := []struct {
 		oldPorts      []utils.ServicePort
@@ -416,7 +515,7 @@ func TestSyncNEG(t *testing.T) {
 	// GC should garbage collect the Backend on the old naming schema
 	syncer.GC([]utils.ServicePort{svcPort})
 
-	bs, err := syncer.backendPool.Get(nodePortName, features.VersionFromServicePort(&svcPort))
+	bs, err := syncer.backendPool.Get(nodePortName, features.VersionFromServicePort(&svcPort), meta.Global)
 	if err == nil {
 		t.Fatalf("Expected not to get BackendService with name %v, got: %+v", nodePortName, bs)
 	}
@@ -447,6 +546,38 @@ func TestShutdown(t *testing.T) {
 	}
 }
 
+func TestSyncBackendConfigHealthCheck(t *testing.T) {
+	fakeGCE := gce.NewFakeGCECloud(gce.DefaultTestClusterValues())
+	syncer := newTestSyncer(fakeGCE)
+
+	path := "/from-backendconfig"
+	sp := utils.ServicePort{
+		NodePort: 443,
+		Protocol: annotations.ProtocolHTTPS,
+		BackendConfig: &backendconfigv1.BackendConfig{
+			Spec: backendconfigv1.BackendConfigSpec{
+				HealthCheck: &backendconfigv1.HealthCheckConfig{
+					RequestPath: &path,
+				},
+			},
+		},
+	}
+	// The readiness probe has a different path which should be overridden.
+	syncer.Init(NewFakeProbeProvider(map[utils.ServicePort]*api_v1.Probe{sp: existingProbe}))
+
+	if err := syncer.Sync([]utils.ServicePort{sp}); err != nil {
+		t.Fatalf("Unexpected error when syncing backend with port %v: %v", sp.NodePort, err)
+	}
+
+	hc, err := syncer.healthChecker.Get(sp.BackendName(defaultNamer), features.VersionFromServicePort(&sp), meta.Global)
+	if err != nil {
+		t.Fatalf("Unexpected err when querying fake healthchecker: %v", err)
+	}
+	if hc.RequestPath != path {
+		t.Errorf("Healthcheck has request path %q, want %q from BackendConfig", hc.RequestPath, path)
+	}
+}
+
 func TestApplyProbeSettingsToHC(t *testing.T) {
 	p := "healthz"
 	hc := healthchecks.DefaultHealthCheck(8080, annotations.ProtocolHTTPS)
@@ -489,7 +620,7 @@ func TestEnsureBackendServiceProtocol(t *testing.T) {
 				fmt.Sprintf("Updating Port:%v Protocol:%v to Port:%v Protocol:%v", oldPort.NodePort, oldPort.Protocol, newPort.NodePort, newPort.Protocol),
 				func(t *testing.T) {
 					syncer.Sync([]utils.ServicePort{oldPort})
-					be, err := syncer.backendPool.Get(oldPort.BackendName(defaultNamer), features.VersionFromServicePort(&oldPort))
+					be, err := syncer.backendPool.Get(oldPort.BackendName(defaultNamer), features.VersionFromServicePort(&oldPort), meta.Global)
 					if err != nil {
 						t.Fatalf("%v", err)
 					}
@@ -533,7 +664,7 @@ func TestEnsureBackendServiceDescription(t *testing.T) {
 				fmt.Sprintf("Updating Port:%v Protocol:%v to Port:%v Protocol:%v", oldPort.NodePort, oldPort.Protocol, newPort.NodePort, newPort.Protocol),
 				func(t *testing.T) {
 					syncer.Sync([]utils.ServicePort{oldPort})
-					be, err := syncer.backendPool.Get(oldPort.BackendName(defaultNamer), features.VersionFromServicePort(&oldPort))
+					be, err := syncer.backendPool.Get(oldPort.BackendName(defaultNamer), features.VersionFromServicePort(&oldPort), meta.Global)
 					if err != nil {
 						t.Fatalf("%v", err)
 					}
@@ -561,7 +692,7 @@ func TestEnsureBackendServiceHealthCheckLink(t *testing.T) {
 
 	p := utils.ServicePort{NodePort: 80, Protocol: annotations.ProtocolHTTP, ID: utils.ServicePortID{Port: intstr.FromInt(1)}}
 	syncer.Sync([]utils.ServicePort{p})
-	be, err := syncer.backendPool.Get(p.BackendName(defaultNamer), features.VersionFromServicePort(&p))
+	be, err := syncer.backendPool.Get(p.BackendName(defaultNamer), features.VersionFromServicePort(&p), meta.Global)
 	if err != nil {
 		t.Fatalf("%v", err)
 	}
@@ -585,3 +716,47 @@ func TestEnsureBackendServiceHealthCheckLink(t *testing.T) {
 		t.Fatalf("Expected ensureHealthCheckLink for healthcheck with the same name to return false, got %v", needsHcUpdate)
 	}
 }
+
+type fakeStatusRecorder struct {
+	recorded map[string]error
+	pruned   []utils.ServicePort
+}
+
+func (r *fakeStatusRecorder) Record(sp utils.ServicePort, beName string, syncErr error) error {
+	r.recorded[beName] = syncErr
+	return nil
+}
+
+func (r *fakeStatusRecorder) Prune(svcPorts []utils.ServicePort) error {
+	r.pruned = svcPorts
+	return nil
+}
+
+func TestSyncRecordsBackendConfigStatus(t *testing.T) {
+	fakeGCE := gce.NewFakeGCECloud(gce.DefaultTestClusterValues())
+	syncer := newTestSyncer(fakeGCE)
+	recorder := &fakeStatusRecorder{recorded: map[string]error{}}
+	syncer.statusRecorder = recorder
+
+	spWithConfig := utils.ServicePort{NodePort: 80, Protocol: annotations.ProtocolHTTP, BackendConfig: &backendconfigv1.BackendConfig{}}
+	spWithoutConfig := utils.ServicePort{NodePort: 81, Protocol: annotations.ProtocolHTTP}
+	svcPorts := []utils.ServicePort{spWithConfig, spWithoutConfig}
+	if err := syncer.Sync(svcPorts); err != nil {
+		t.Fatalf("Unexpected error when syncing backends: %v", err)
+	}
+
+	beName := spWithConfig.BackendName(defaultNamer)
+	if err, ok := recorder.recorded[beName]; !ok || err != nil {
+		t.Errorf("Recorded (%v, %v) for backend %v, want (nil, true)", err, ok, beName)
+	}
+	if len(recorder.recorded) != 1 {
+		t.Errorf("Recorded status for %d backends, want 1: %v", len(recorder.recorded), recorder.recorded)
+	}
+
+	if err := syncer.GC(svcPorts); err != nil {
+		t.Fatalf("Unexpected error when GCing backends: %v", err)
+	}
+	if !reflect.DeepEqual(recorder.pruned, svcPorts) {
+		t.Errorf("Pruned with %v, want %v", recorder.pruned, svcPorts)
+	}
+}
@@ -0,0 +1,17 @@
+package operator
+
+import (
+	"k8s.io/ingress-gce/pkg/annotations"
+	frontendconfigv1beta1 "k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1"
+
+	extensions "k8s.io/api/extensions/v1beta1"
+)
+
+// doesIngressReferenceFrontendConfig returns true if the passed in Ingress directly references
+// the passed in FrontendConfig.
+func doesIngressReferenceFrontendConfig(ing *extensions.Ingress, feConfig *frontendconfigv1beta1.FrontendConfig) bool {
+	if ing.Namespace != feConfig.Namespace {
+		return false
+	}
+	return annotations.FromIngress(ing).FrontendConfig() == feConfig.Name
+}
@@ -0,0 +1,57 @@
+package operator
+
+import (
+	"testing"
+
+	"k8s.io/ingress-gce/pkg/annotations"
+	frontendconfigv1beta1 "k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1"
+
+	extensions "k8s.io/api/extensions/v1beta1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestDoesIngressReferenceFrontendConfig(t *testing.T) {
+	feConfig := &frontendconfigv1beta1.FrontendConfig{
+		ObjectMeta: meta_v1.ObjectMeta{Name: "config-test", Namespace: "test"},
+	}
+	newIngress := func(namespace string, ingAnnotations map[string]string) *extensions.Ingress {
+		return &extensions.Ingress{
+			ObjectMeta: meta_v1.ObjectMeta{Name: "ing", Namespace: namespace, Annotations: ingAnnotations},
+		}
+	}
+
+	testCases := []struct {
+		desc     string
+		ing      *extensions.Ingress
+		expected bool
+	}{
+		{
+			desc:     "ingress with no frontend config",
+			ing:      newIngress("test", nil),
+			expected: false,
+		},
+		{
+			desc:     "ingress with test frontend config",
+			ing:      newIngress("test", map[string]string{annotations.FrontendConfigKey: "config-test"}),
+			expected: true,
+		},
+		{
+			desc:     "ingress with test frontend config in a different namespace",
+			ing:      newIngress("other-namespace", map[string]string{annotations.FrontendConfigKey: "config-test"}),
+			expected: false,
+		},
+		{
+			desc:     "ingress with a different frontend config",
+			ing:      newIngress("test", map[string]string{annotations.FrontendConfigKey: "config-other"}),
+			expected: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			if got := doesIngressReferenceFrontendConfig(tc.ing, feConfig); got != tc.expected {
+				t.Errorf("doesIngressReferenceFrontendConfig() = %v, want %v", got, tc.expected)
+			}
+		})
+	}
+}
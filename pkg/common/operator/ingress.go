@@ -3,7 +3,8 @@ package operator
 import (
 	"fmt"
 
-	backendconfigv1beta1 "k8s.io/ingress-gce/pkg/apis/backendconfig/v1beta1"
+	backendconfigv1 "k8s.io/ingress-gce/pkg/apis/backendconfig/v1"
+	frontendconfigv1beta1 "k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1"
 
 	api_v1 "k8s.io/api/core/v1"
 	extensions "k8s.io/api/extensions/v1beta1"
@@ -54,7 +55,7 @@ func (op *IngressesOperator) ReferencesService(svc *api_v1.Service) *IngressesOp
 }
 
 // ReferencesBackendConfig returns the Ingresses that references the given BackendConfig.
-func (op *IngressesOperator) ReferencesBackendConfig(beConfig *backendconfigv1beta1.BackendConfig, svcsOp *ServicesOperator) *IngressesOperator {
+func (op *IngressesOperator) ReferencesBackendConfig(beConfig *backendconfigv1.BackendConfig, svcsOp *ServicesOperator) *IngressesOperator {
 	dupes := map[string]bool{}
 
 	var i []*extensions.Ingress
@@ -70,3 +71,10 @@ func (op *IngressesOperator) ReferencesBackendConfig(beConfig *backendconfigv1be
 	}
 	return Ingresses(i)
 }
+
+// ReferencesFrontendConfig returns the Ingresses that references the given FrontendConfig.
+func (op *IngressesOperator) ReferencesFrontendConfig(feConfig *frontendconfigv1beta1.FrontendConfig) *IngressesOperator {
+	return op.Filter(func(ing *extensions.Ingress) bool {
+		return doesIngressReferenceFrontendConfig(ing, feConfig)
+	})
+}
@@ -1,7 +1,7 @@
 package typed
 
 import (
-	backendconfigv1beta1 "k8s.io/ingress-gce/pkg/apis/backendconfig/v1beta1"
+	backendconfigv1 "k8s.io/ingress-gce/pkg/apis/backendconfig/v1"
 
 	"k8s.io/client-go/tools/cache"
 )
@@ -17,23 +17,23 @@ type BackendConfigStore struct {
 }
 
 // Add implements Store.
-func (s *BackendConfigStore) Add(b *backendconfigv1beta1.BackendConfig) error { return s.store.Add(b) }
+func (s *BackendConfigStore) Add(b *backendconfigv1.BackendConfig) error { return s.store.Add(b) }
 
 // Update implements Store.
-func (s *BackendConfigStore) Update(b *backendconfigv1beta1.BackendConfig) error {
+func (s *BackendConfigStore) Update(b *backendconfigv1.BackendConfig) error {
 	return s.store.Update(b)
 }
 
 // Delete implements Store.
-func (s *BackendConfigStore) Delete(b *backendconfigv1beta1.BackendConfig) error {
+func (s *BackendConfigStore) Delete(b *backendconfigv1.BackendConfig) error {
 	return s.store.Delete(b)
 }
 
 // List implements Store.
-func (s *BackendConfigStore) List() []*backendconfigv1beta1.BackendConfig {
-	var ret []*backendconfigv1beta1.BackendConfig
+func (s *BackendConfigStore) List() []*backendconfigv1.BackendConfig {
+	var ret []*backendconfigv1.BackendConfig
 	for _, obj := range s.store.List() {
-		ret = append(ret, obj.(*backendconfigv1beta1.BackendConfig))
+		ret = append(ret, obj.(*backendconfigv1.BackendConfig))
 	}
 	return ret
 }
@@ -42,25 +42,25 @@ func (s *BackendConfigStore) List() []*backendconfigv1beta1.BackendConfig {
 func (s *BackendConfigStore) ListKeys() []string { return s.store.ListKeys() }
 
 // Get implements Store.
-func (s *BackendConfigStore) Get(b *backendconfigv1beta1.BackendConfig) (*backendconfigv1beta1.BackendConfig, bool, error) {
+func (s *BackendConfigStore) Get(b *backendconfigv1.BackendConfig) (*backendconfigv1.BackendConfig, bool, error) {
 	item, exists, err := s.store.Get(b)
 	if item == nil {
 		return nil, exists, err
 	}
-	return item.(*backendconfigv1beta1.BackendConfig), exists, err
+	return item.(*backendconfigv1.BackendConfig), exists, err
 }
 
 // GetByKey implements Store.
-func (s *BackendConfigStore) GetByKey(key string) (*backendconfigv1beta1.BackendConfig, bool, error) {
+func (s *BackendConfigStore) GetByKey(key string) (*backendconfigv1.BackendConfig, bool, error) {
 	item, exists, err := s.store.GetByKey(key)
 	if item == nil {
 		return nil, exists, err
 	}
-	return item.(*backendconfigv1beta1.BackendConfig), exists, err
+	return item.(*backendconfigv1.BackendConfig), exists, err
 }
 
 // Resync implements Store.
 func (s *BackendConfigStore) Resync() error { return s.store.Resync() }
 
 // This function is mostly likely not useful for ordinary consumers.
-// func (s *BackendConfigStore) Replace(items []*backendconfigv1beta1.BackendConfig, string) error {}
+// func (s *BackendConfigStore) Replace(items []*backendconfigv1.BackendConfig, string) error {}
@@ -19,6 +19,7 @@ package composite
 import (
 	"encoding/json"
 	"fmt"
+	"sort"
 
 	"k8s.io/klog"
 
@@ -27,12 +28,24 @@ import (
 	compute "google.golang.org/api/compute/v1"
 	"google.golang.org/api/googleapi"
 	"k8s.io/kubernetes/pkg/cloudprovider/providers/gce"
+	gcecloud "k8s.io/kubernetes/pkg/cloudprovider/providers/gce/cloud"
+	"k8s.io/kubernetes/pkg/cloudprovider/providers/gce/cloud/filter"
 	"k8s.io/kubernetes/pkg/cloudprovider/providers/gce/cloud/meta"
 )
 
 // TODO(rramkumar): All code in this file should ideally be generated.
 
 func CreateBackendService(be *BackendService, cloud *gce.Cloud) error {
+	if be.Scope == meta.Regional {
+		alpha, err := be.toRegionalAlpha()
+		if err != nil {
+			return err
+		}
+		klog.V(3).Infof("Creating alpha region backend service %v", alpha.Name)
+		ctx, cancel := gcecloud.ContextWithCallTimeout()
+		defer cancel()
+		return cloud.Compute().AlphaRegionBackendServices().Insert(ctx, meta.RegionalKey(be.Name, cloud.Region()), alpha)
+	}
 	switch be.Version {
 	case meta.VersionAlpha:
 		alpha, err := be.toAlpha()
@@ -59,6 +72,16 @@ func CreateBackendService(be *BackendService, cloud *gce.Cloud) error {
 }
 
 func UpdateBackendService(be *BackendService, cloud *gce.Cloud) error {
+	if be.Scope == meta.Regional {
+		alpha, err := be.toRegionalAlpha()
+		if err != nil {
+			return err
+		}
+		klog.V(3).Infof("Updating alpha region backend service %v", alpha.Name)
+		ctx, cancel := gcecloud.ContextWithCallTimeout()
+		defer cancel()
+		return cloud.Compute().AlphaRegionBackendServices().Update(ctx, meta.RegionalKey(be.Name, cloud.Region()), alpha)
+	}
 	switch be.Version {
 	case meta.VersionAlpha:
 		alpha, err := be.toAlpha()
@@ -84,21 +107,237 @@ func UpdateBackendService(be *BackendService, cloud *gce.Cloud) error {
 	}
 }
 
-func GetBackendService(name string, version meta.Version, cloud *gce.Cloud) (*BackendService, error) {
+// GetBackendService gets the BackendService named name in the given scope.
+// Regional BackendServices, in the region of the cloud, are only served by
+// the alpha API so the version is ignored for them.
+func GetBackendService(name string, version meta.Version, scope meta.KeyType, cloud *gce.Cloud) (*BackendService, error) {
 	var gceObj interface{}
 	var err error
+	if scope == meta.Regional {
+		ctx, cancel := gcecloud.ContextWithCallTimeout()
+		defer cancel()
+		gceObj, err = cloud.Compute().AlphaRegionBackendServices().Get(ctx, meta.RegionalKey(name, cloud.Region()))
+	} else {
+		switch version {
+		case meta.VersionAlpha:
+			gceObj, err = cloud.GetAlphaGlobalBackendService(name)
+		case meta.VersionBeta:
+			gceObj, err = cloud.GetBetaGlobalBackendService(name)
+		default:
+			gceObj, err = cloud.GetGlobalBackendService(name)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	be, err := toBackendService(gceObj)
+	if err != nil {
+		return nil, err
+	}
+	be.Scope = scope
+	if scope == meta.Regional {
+		be.Version = meta.VersionAlpha
+	}
+	return be, nil
+}
+
+// DeleteBackendService deletes the BackendService named name in the given
+// scope.
+func DeleteBackendService(name string, scope meta.KeyType, cloud *gce.Cloud) error {
+	if scope == meta.Regional {
+		klog.V(3).Infof("Deleting alpha region backend service %v", name)
+		ctx, cancel := gcecloud.ContextWithCallTimeout()
+		defer cancel()
+		return cloud.Compute().AlphaRegionBackendServices().Delete(ctx, meta.RegionalKey(name, cloud.Region()))
+	}
+	klog.V(3).Infof("Deleting ga backend service %v", name)
+	return cloud.DeleteGlobalBackendService(name)
+}
+
+// ListBackendServiceNames lists the names of the BackendServices in the
+// given scope.
+func ListBackendServiceNames(scope meta.KeyType, cloud *gce.Cloud) ([]string, error) {
+	var names []string
+	if scope == meta.Regional {
+		ctx, cancel := gcecloud.ContextWithCallTimeout()
+		defer cancel()
+		backends, err := cloud.Compute().AlphaRegionBackendServices().List(ctx, cloud.Region(), filter.None)
+		if err != nil {
+			return nil, err
+		}
+		for _, bs := range backends {
+			names = append(names, bs.Name)
+		}
+		return names, nil
+	}
+	backends, err := cloud.ListGlobalBackendServices()
+	if err != nil {
+		return nil, err
+	}
+	for _, bs := range backends {
+		names = append(names, bs.Name)
+	}
+	return names, nil
+}
+
+// CreateUrlMap creates the given UrlMap with the API of its version.
+func CreateUrlMap(um *UrlMap, cloud *gce.Cloud) error {
+	switch um.Version {
+	case meta.VersionAlpha:
+		alpha, err := um.toAlpha()
+		if err != nil {
+			return err
+		}
+		klog.V(3).Infof("Creating alpha url map %v", alpha.Name)
+		op, err := cloud.ComputeServices().Alpha.UrlMaps.Insert(cloud.ProjectID(), alpha).Do()
+		if err != nil {
+			return err
+		}
+		return waitForOperation(op, cloud)
+	default:
+		ga, err := um.toGA()
+		if err != nil {
+			return err
+		}
+		klog.V(3).Infof("Creating ga url map %v", ga.Name)
+		return cloud.CreateURLMap(ga)
+	}
+}
+
+// UpdateUrlMap updates the given UrlMap with the API of its version.
+func UpdateUrlMap(um *UrlMap, cloud *gce.Cloud) error {
+	switch um.Version {
+	case meta.VersionAlpha:
+		alpha, err := um.toAlpha()
+		if err != nil {
+			return err
+		}
+		klog.V(3).Infof("Updating alpha url map %v", alpha.Name)
+		op, err := cloud.ComputeServices().Alpha.UrlMaps.Update(cloud.ProjectID(), alpha.Name, alpha).Do()
+		if err != nil {
+			return err
+		}
+		return waitForOperation(op, cloud)
+	default:
+		ga, err := um.toGA()
+		if err != nil {
+			return err
+		}
+		klog.V(3).Infof("Updating ga url map %v", ga.Name)
+		return cloud.UpdateURLMap(ga)
+	}
+}
+
+// GetUrlMap gets the UrlMap named name with the API of the given version.
+func GetUrlMap(name string, version meta.Version, cloud *gce.Cloud) (*UrlMap, error) {
+	var um *UrlMap
+	var err error
 	switch version {
 	case meta.VersionAlpha:
-		gceObj, err = cloud.GetAlphaGlobalBackendService(name)
-	case meta.VersionBeta:
-		gceObj, err = cloud.GetBetaGlobalBackendService(name)
+		var alpha *computealpha.UrlMap
+		alpha, err = cloud.ComputeServices().Alpha.UrlMaps.Get(cloud.ProjectID(), name).Do()
+		if err != nil {
+			return nil, err
+		}
+		um, err = toUrlMap(alpha)
 	default:
-		gceObj, err = cloud.GetGlobalBackendService(name)
+		var ga *compute.UrlMap
+		ga, err = cloud.GetURLMap(name)
+		if err != nil {
+			return nil, err
+		}
+		um, err = toUrlMap(ga)
+	}
+	if err != nil {
+		return nil, err
+	}
+	um.Version = version
+	return um, nil
+}
+
+// AddSignedUrlKey adds the given signed URL key to the BackendService
+// named beName. Signed URL keys are version independent so the GA API is
+// always used.
+func AddSignedUrlKey(beName string, key *SignedUrlKey, cloud *gce.Cloud) error {
+	klog.V(3).Infof("Adding signed url key %v to backend service %v", key.KeyName, beName)
+	op, err := cloud.ComputeServices().GA.BackendServices.AddSignedUrlKey(cloud.ProjectID(), beName, key.toGA()).Do()
+	if err != nil {
+		return err
+	}
+	return waitForOperation(op, cloud)
+}
+
+// DeleteSignedUrlKey deletes the signed URL key named keyName from the
+// BackendService named beName.
+func DeleteSignedUrlKey(beName, keyName string, cloud *gce.Cloud) error {
+	klog.V(3).Infof("Deleting signed url key %v from backend service %v", keyName, beName)
+	op, err := cloud.ComputeServices().GA.BackendServices.DeleteSignedUrlKey(cloud.ProjectID(), beName, keyName).Do()
+	if err != nil {
+		return err
 	}
+	return waitForOperation(op, cloud)
+}
+
+// SetSslPolicyForTargetHttpsProxy sets the SSL policy of the
+// TargetHttpsProxy named proxyName. An empty sslPolicyLink removes the SSL
+// policy from the proxy.
+func SetSslPolicyForTargetHttpsProxy(proxyName, sslPolicyLink string, cloud *gce.Cloud) error {
+	klog.V(3).Infof("Setting SSL policy %q on target https proxy %v", sslPolicyLink, proxyName)
+	ref := &compute.SslPolicyReference{SslPolicy: sslPolicyLink, ForceSendFields: []string{"SslPolicy"}}
+	op, err := cloud.ComputeServices().GA.TargetHttpsProxies.SetSslPolicy(cloud.ProjectID(), proxyName, ref).Do()
+	if err != nil {
+		return err
+	}
+	return waitForOperation(op, cloud)
+}
+
+// SetQuicOverrideForTargetHttpsProxy sets the QUIC override of the
+// TargetHttpsProxy named proxyName.
+func SetQuicOverrideForTargetHttpsProxy(proxyName, quicOverride string, cloud *gce.Cloud) error {
+	klog.V(3).Infof("Setting QUIC override %v on target https proxy %v", quicOverride, proxyName)
+	req := &compute.TargetHttpsProxiesSetQuicOverrideRequest{QuicOverride: quicOverride}
+	op, err := cloud.ComputeServices().GA.TargetHttpsProxies.SetQuicOverride(cloud.ProjectID(), proxyName, req).Do()
+	if err != nil {
+		return err
+	}
+	return waitForOperation(op, cloud)
+}
+
+// CreateBetaSslCertificate creates the given SslCertificate with the beta
+// API, which supports Google-managed certificates, and returns it as
+// created.
+func CreateBetaSslCertificate(cert *computebeta.SslCertificate, cloud *gce.Cloud) (*computebeta.SslCertificate, error) {
+	klog.V(3).Infof("Creating beta ssl certificate %v", cert.Name)
+	op, err := cloud.ComputeServices().Beta.SslCertificates.Insert(cloud.ProjectID(), cert).Do()
 	if err != nil {
 		return nil, err
 	}
-	return toBackendService(gceObj)
+	if err := waitForOperation(op, cloud); err != nil {
+		return nil, err
+	}
+	return GetBetaSslCertificate(cert.Name, cloud)
+}
+
+// GetBetaSslCertificate gets the SslCertificate named name with the beta
+// API, which includes the provisioning status of Google-managed
+// certificates.
+func GetBetaSslCertificate(name string, cloud *gce.Cloud) (*computebeta.SslCertificate, error) {
+	return cloud.ComputeServices().Beta.SslCertificates.Get(cloud.ProjectID(), name).Do()
+}
+
+// waitForOperation waits for the given GCE operation to complete.
+func waitForOperation(op interface{}, cloud *gce.Cloud) error {
+	services := cloud.ComputeServices()
+	s := &gcecloud.Service{
+		GA:            services.GA,
+		Alpha:         services.Alpha,
+		Beta:          services.Beta,
+		ProjectRouter: &gcecloud.SingleProjectRouter{ID: cloud.ProjectID()},
+		RateLimiter:   &gcecloud.NopRateLimiter{},
+	}
+	ctx, cancel := gcecloud.ContextWithCallTimeout()
+	defer cancel()
+	return s.WaitForCompletion(ctx, op)
 }
 
 // toBackendService converts a compute alpha, beta or GA
@@ -116,6 +355,20 @@ func toBackendService(obj interface{}) (*BackendService, error) {
 	return be, nil
 }
 
+// toUrlMap converts a compute alpha or GA UrlMap into our composite type.
+func toUrlMap(obj interface{}) (*UrlMap, error) {
+	um := &UrlMap{}
+	bytes, err := json.Marshal(obj)
+	if err != nil {
+		return nil, fmt.Errorf("could not marshal object %+v to JSON: %v", obj, err)
+	}
+	err = json.Unmarshal(bytes, um)
+	if err != nil {
+		return nil, fmt.Errorf("error unmarshalling to UrlMap: %v", err)
+	}
+	return um, nil
+}
+
 // BackendService is a composite type which embeds the
 // structure of all the compute alpha, beta and GA Backend Service.
 type BackendService struct {
@@ -123,15 +376,20 @@ type BackendService struct {
 	// Note that the compute API's do not contain this field. It is for our
 	// own bookkeeping purposes.
 	Version meta.Version `json:"-"`
+	// Scope is the scope of this BackendService. It is global if unset, and
+	// regional BackendServices are in the region of the cloud.
+	Scope meta.KeyType `json:"-"`
 
 	AffinityCookieTtlSec     int64                               `json:"affinityCookieTtlSec,omitempty"`
 	AppEngineBackend         *BackendServiceAppEngineBackend     `json:"appEngineBackend,omitempty"`
 	Backends                 []*Backend                          `json:"backends,omitempty"`
 	CdnPolicy                *BackendServiceCdnPolicy            `json:"cdnPolicy,omitempty"`
+	CircuitBreakers          *CircuitBreakers                    `json:"circuitBreakers,omitempty"`
 	CloudFunctionBackend     *BackendServiceCloudFunctionBackend `json:"cloudFunctionBackend,omitempty"`
 	ConnectionDraining       *ConnectionDraining                 `json:"connectionDraining,omitempty"`
 	CreationTimestamp        string                              `json:"creationTimestamp,omitempty"`
 	CustomRequestHeaders     []string                            `json:"customRequestHeaders,omitempty"`
+	CustomResponseHeaders    []string                            `json:"customResponseHeaders,omitempty"`
 	Description              string                              `json:"description,omitempty"`
 	EnableCDN                bool                                `json:"enableCDN,omitempty"`
 	FailoverPolicy           *BackendServiceFailoverPolicy       `json:"failoverPolicy,omitempty"`
@@ -141,7 +399,10 @@ type BackendService struct {
 	Id                       uint64                              `json:"id,omitempty,string"`
 	Kind                     string                              `json:"kind,omitempty"`
 	LoadBalancingScheme      string                              `json:"loadBalancingScheme,omitempty"`
+	LocalityLbPolicy         string                              `json:"localityLbPolicy,omitempty"`
+	LogConfig                *BackendServiceLogConfig            `json:"logConfig,omitempty"`
 	Name                     string                              `json:"name,omitempty"`
+	OutlierDetection         *OutlierDetection                   `json:"outlierDetection,omitempty"`
 	Port                     int64                               `json:"port,omitempty"`
 	PortName                 string                              `json:"portName,omitempty"`
 	Protocol                 string                              `json:"protocol,omitempty"`
@@ -191,11 +452,41 @@ type BackendServiceIAPOAuth2ClientInfo struct {
 }
 
 type BackendServiceCdnPolicy struct {
-	CacheKeyPolicy          *CacheKeyPolicy `json:"cacheKeyPolicy,omitempty"`
-	SignedUrlCacheMaxAgeSec int64           `json:"signedUrlCacheMaxAgeSec,omitempty,string"`
-	SignedUrlKeyNames       []string        `json:"signedUrlKeyNames,omitempty"`
-	ForceSendFields         []string        `json:"-"`
-	NullFields              []string        `json:"-"`
+	CacheKeyPolicy          *CacheKeyPolicy                                 `json:"cacheKeyPolicy,omitempty"`
+	CacheMode               string                                          `json:"cacheMode,omitempty"`
+	ClientTtl               int64                                           `json:"clientTtl,omitempty"`
+	DefaultTtl              int64                                           `json:"defaultTtl,omitempty"`
+	MaxTtl                  int64                                           `json:"maxTtl,omitempty"`
+	NegativeCaching         bool                                            `json:"negativeCaching,omitempty"`
+	NegativeCachingPolicy   []*BackendServiceCdnPolicyNegativeCachingPolicy `json:"negativeCachingPolicy,omitempty"`
+	SignedUrlCacheMaxAgeSec int64                                           `json:"signedUrlCacheMaxAgeSec,omitempty,string"`
+	SignedUrlKeyNames       []string                                        `json:"signedUrlKeyNames,omitempty"`
+	ForceSendFields         []string                                        `json:"-"`
+	NullFields              []string                                        `json:"-"`
+}
+
+type BackendServiceCdnPolicyNegativeCachingPolicy struct {
+	Code            int64    `json:"code,omitempty"`
+	Ttl             int64    `json:"ttl,omitempty"`
+	ForceSendFields []string `json:"-"`
+	NullFields      []string `json:"-"`
+}
+
+type SignedUrlKey struct {
+	KeyName         string   `json:"keyName,omitempty"`
+	KeyValue        string   `json:"keyValue,omitempty"`
+	ForceSendFields []string `json:"-"`
+	NullFields      []string `json:"-"`
+}
+
+// toGA converts our composite type into a GA type.
+func (key *SignedUrlKey) toGA() *compute.SignedUrlKey {
+	return &compute.SignedUrlKey{
+		KeyName:         key.KeyName,
+		KeyValue:        key.KeyValue,
+		ForceSendFields: key.ForceSendFields,
+		NullFields:      key.NullFields,
+	}
 }
 
 type CacheKeyPolicy struct {
@@ -237,6 +528,273 @@ type BackendServiceAppEngineBackend struct {
 	NullFields       []string `json:"-"`
 }
 
+type BackendServiceLogConfig struct {
+	Enable          bool     `json:"enable,omitempty"`
+	SampleRate      float64  `json:"sampleRate,omitempty"`
+	ForceSendFields []string `json:"-"`
+	NullFields      []string `json:"-"`
+}
+
+type CircuitBreakers struct {
+	ConnectTimeout           *Duration `json:"connectTimeout,omitempty"`
+	MaxConnections           int64     `json:"maxConnections,omitempty"`
+	MaxPendingRequests       int64     `json:"maxPendingRequests,omitempty"`
+	MaxRequests              int64     `json:"maxRequests,omitempty"`
+	MaxRequestsPerConnection int64     `json:"maxRequestsPerConnection,omitempty"`
+	MaxRetries               int64     `json:"maxRetries,omitempty"`
+	ForceSendFields          []string  `json:"-"`
+	NullFields               []string  `json:"-"`
+}
+
+type Duration struct {
+	Nanos           int64    `json:"nanos,omitempty"`
+	Seconds         int64    `json:"seconds,omitempty,string"`
+	ForceSendFields []string `json:"-"`
+	NullFields      []string `json:"-"`
+}
+
+type OutlierDetection struct {
+	BaseEjectionTime                   *Duration `json:"baseEjectionTime,omitempty"`
+	ConsecutiveErrors                  int64     `json:"consecutiveErrors,omitempty"`
+	ConsecutiveGatewayFailure          int64     `json:"consecutiveGatewayFailure,omitempty"`
+	EnforcingConsecutiveErrors         int64     `json:"enforcingConsecutiveErrors,omitempty"`
+	EnforcingConsecutiveGatewayFailure int64     `json:"enforcingConsecutiveGatewayFailure,omitempty"`
+	EnforcingSuccessRate               int64     `json:"enforcingSuccessRate,omitempty"`
+	Interval                           *Duration `json:"interval,omitempty"`
+	MaxEjectionPercent                 int64     `json:"maxEjectionPercent,omitempty"`
+	SuccessRateMinimumHosts            int64     `json:"successRateMinimumHosts,omitempty"`
+	SuccessRateRequestVolume           int64     `json:"successRateRequestVolume,omitempty"`
+	SuccessRateStdevFactor             int64     `json:"successRateStdevFactor,omitempty"`
+	ForceSendFields                    []string  `json:"-"`
+	NullFields                         []string  `json:"-"`
+}
+
+// UrlMap is a composite type which embeds the structure of the compute
+// alpha and GA UrlMap. Route rules are only supported by the alpha API.
+type UrlMap struct {
+	// Version keeps track of the intended compute version for this UrlMap.
+	// Note that the compute API's do not contain this field. It is for our
+	// own bookkeeping purposes.
+	Version meta.Version `json:"-"`
+
+	CreationTimestamp  string              `json:"creationTimestamp,omitempty"`
+	DefaultService     string              `json:"defaultService,omitempty"`
+	DefaultUrlRedirect *HttpRedirectAction `json:"defaultUrlRedirect,omitempty"`
+	Description        string              `json:"description,omitempty"`
+	Fingerprint        string              `json:"fingerprint,omitempty"`
+	HostRules          []*HostRule         `json:"hostRules,omitempty"`
+	Id                 uint64              `json:"id,omitempty,string"`
+	Kind               string              `json:"kind,omitempty"`
+	Name               string              `json:"name,omitempty"`
+	PathMatchers       []*PathMatcher      `json:"pathMatchers,omitempty"`
+	SelfLink           string              `json:"selfLink,omitempty"`
+	ForceSendFields    []string            `json:"-"`
+	NullFields         []string            `json:"-"`
+}
+
+type HostRule struct {
+	Description     string   `json:"description,omitempty"`
+	Hosts           []string `json:"hosts,omitempty"`
+	PathMatcher     string   `json:"pathMatcher,omitempty"`
+	ForceSendFields []string `json:"-"`
+	NullFields      []string `json:"-"`
+}
+
+type PathMatcher struct {
+	DefaultService  string           `json:"defaultService,omitempty"`
+	Description     string           `json:"description,omitempty"`
+	Name            string           `json:"name,omitempty"`
+	PathRules       []*PathRule      `json:"pathRules,omitempty"`
+	RouteRules      []*HttpRouteRule `json:"routeRules,omitempty"`
+	ForceSendFields []string         `json:"-"`
+	NullFields      []string         `json:"-"`
+}
+
+type PathRule struct {
+	Paths           []string            `json:"paths,omitempty"`
+	RouteAction     *HttpRouteAction    `json:"routeAction,omitempty"`
+	Service         string              `json:"service,omitempty"`
+	UrlRedirect     *HttpRedirectAction `json:"urlRedirect,omitempty"`
+	ForceSendFields []string            `json:"-"`
+	NullFields      []string            `json:"-"`
+}
+
+type HttpRouteRule struct {
+	Description     string                `json:"description,omitempty"`
+	MatchRules      []*HttpRouteRuleMatch `json:"matchRules,omitempty"`
+	Priority        int64                 `json:"priority,omitempty"`
+	RouteAction     *HttpRouteAction      `json:"routeAction,omitempty"`
+	Service         string                `json:"service,omitempty"`
+	UrlRedirect     *HttpRedirectAction   `json:"urlRedirect,omitempty"`
+	ForceSendFields []string              `json:"-"`
+	NullFields      []string              `json:"-"`
+}
+
+type HttpRouteRuleMatch struct {
+	FullPathMatch         string                     `json:"fullPathMatch,omitempty"`
+	HeaderMatches         []*HttpHeaderMatch         `json:"headerMatches,omitempty"`
+	PrefixMatch           string                     `json:"prefixMatch,omitempty"`
+	QueryParameterMatches []*HttpQueryParameterMatch `json:"queryParameterMatches,omitempty"`
+	ForceSendFields       []string                   `json:"-"`
+	NullFields            []string                   `json:"-"`
+}
+
+type HttpHeaderMatch struct {
+	ExactMatch      string   `json:"exactMatch,omitempty"`
+	HeaderName      string   `json:"headerName,omitempty"`
+	PresentMatch    bool     `json:"presentMatch,omitempty"`
+	ForceSendFields []string `json:"-"`
+	NullFields      []string `json:"-"`
+}
+
+type HttpQueryParameterMatch struct {
+	ExactMatch      string   `json:"exactMatch,omitempty"`
+	Name            string   `json:"name,omitempty"`
+	PresentMatch    bool     `json:"presentMatch,omitempty"`
+	ForceSendFields []string `json:"-"`
+	NullFields      []string `json:"-"`
+}
+
+type HttpRouteAction struct {
+	UrlRewrite              *UrlRewrite               `json:"urlRewrite,omitempty"`
+	WeightedBackendServices []*WeightedBackendService `json:"weightedBackendServices,omitempty"`
+	ForceSendFields         []string                  `json:"-"`
+	NullFields              []string                  `json:"-"`
+}
+
+type UrlRewrite struct {
+	HostRewrite       string   `json:"hostRewrite,omitempty"`
+	PathPrefixRewrite string   `json:"pathPrefixRewrite,omitempty"`
+	ForceSendFields   []string `json:"-"`
+	NullFields        []string `json:"-"`
+}
+
+// WeightedBackendService is always sent with its weight, since a weight of
+// 0 is valid.
+type WeightedBackendService struct {
+	BackendService  string   `json:"backendService,omitempty"`
+	Weight          int64    `json:"weight"`
+	ForceSendFields []string `json:"-"`
+	NullFields      []string `json:"-"`
+}
+
+type HttpRedirectAction struct {
+	HostRedirect         string   `json:"hostRedirect,omitempty"`
+	HttpsRedirect        bool     `json:"httpsRedirect,omitempty"`
+	PathRedirect         string   `json:"pathRedirect,omitempty"`
+	PrefixRedirect       string   `json:"prefixRedirect,omitempty"`
+	RedirectResponseCode string   `json:"redirectResponseCode,omitempty"`
+	StripQuery           bool     `json:"stripQuery,omitempty"`
+	ForceSendFields      []string `json:"-"`
+	NullFields           []string `json:"-"`
+}
+
+// toAlpha converts our composite type into an alpha type.
+// This alpha type can be used in GCE API calls.
+func (um *UrlMap) toAlpha() (*computealpha.UrlMap, error) {
+	bytes, err := json.Marshal(um)
+	if err != nil {
+		return nil, fmt.Errorf("error marshalling UrlMap to JSON: %v", err)
+	}
+	alpha := &computealpha.UrlMap{}
+	err = json.Unmarshal(bytes, alpha)
+	if err != nil {
+		return nil, fmt.Errorf("error unmarshalling UrlMap JSON to compute alpha type: %v", err)
+	}
+	// The alpha types omit zero weights unless they are forced.
+	for _, pm := range alpha.PathMatchers {
+		for _, rr := range pm.RouteRules {
+			if rr.RouteAction == nil {
+				continue
+			}
+			for _, wbs := range rr.RouteAction.WeightedBackendServices {
+				wbs.ForceSendFields = []string{"Weight"}
+			}
+		}
+	}
+	if err := checkFieldsConverted(um, alpha); err != nil {
+		return nil, fmt.Errorf("error converting UrlMap %v to compute alpha type: %v", um.Name, err)
+	}
+	return alpha, nil
+}
+
+// toGA converts our composite type into a GA type.
+// This GA type can be used in GCE API calls. An error is returned if the
+// GA type cannot represent the composite type, rather than silently
+// dropping fields such as the default URL redirect or route rules.
+func (um *UrlMap) toGA() (*compute.UrlMap, error) {
+	bytes, err := json.Marshal(um)
+	if err != nil {
+		return nil, fmt.Errorf("error marshalling UrlMap to JSON: %v", err)
+	}
+	ga := &compute.UrlMap{}
+	err = json.Unmarshal(bytes, ga)
+	if err != nil {
+		return nil, fmt.Errorf("error unmarshalling UrlMap JSON to compute GA type: %v", err)
+	}
+	if err := checkFieldsConverted(um, ga); err != nil {
+		return nil, fmt.Errorf("error converting UrlMap %v to compute GA type: %v", um.Name, err)
+	}
+	return ga, nil
+}
+
+// checkFieldsConverted returns an error if any field set in the composite
+// object, including the fields of nested objects, is missing from the
+// converted compute object.
+func checkFieldsConverted(composite, converted interface{}) error {
+	decode := func(obj interface{}) (interface{}, error) {
+		bytes, err := json.Marshal(obj)
+		if err != nil {
+			return nil, err
+		}
+		var v interface{}
+		return v, json.Unmarshal(bytes, &v)
+	}
+	want, err := decode(composite)
+	if err != nil {
+		return err
+	}
+	got, err := decode(converted)
+	if err != nil {
+		return err
+	}
+	if missing := missingFields("", want, got); len(missing) > 0 {
+		sort.Strings(missing)
+		return fmt.Errorf("fields %v are not supported by the compute API", missing)
+	}
+	return nil
+}
+
+// missingFields returns the paths of the fields of the decoded JSON value
+// want which are missing from got.
+func missingFields(path string, want, got interface{}) []string {
+	var missing []string
+	switch w := want.(type) {
+	case map[string]interface{}:
+		g, _ := got.(map[string]interface{})
+		for name, value := range w {
+			field := name
+			if path != "" {
+				field = path + "." + name
+			}
+			gotValue, ok := g[name]
+			if !ok {
+				missing = append(missing, field)
+				continue
+			}
+			missing = append(missing, missingFields(field, value, gotValue)...)
+		}
+	case []interface{}:
+		g, _ := got.([]interface{})
+		for i := range w {
+			if i < len(g) {
+				missing = append(missing, missingFields(fmt.Sprintf("%s[%d]", path, i), w[i], g[i])...)
+			}
+		}
+	}
+	return missing
+}
+
 // toAlpha converts our composite type into an alpha type.
 // This alpha type can be used in GCE API calls.
 func (be *BackendService) toAlpha() (*computealpha.BackendService, error) {
@@ -256,9 +814,27 @@ func (be *BackendService) toAlpha() (*computealpha.BackendService, error) {
 	if alpha.Iap != nil {
 		alpha.Iap.ForceSendFields = []string{"Enabled", "Oauth2ClientId", "Oauth2ClientSecret"}
 	}
+	if alpha.LogConfig != nil {
+		// SampleRate may only be specified when logging is enabled.
+		alpha.LogConfig.ForceSendFields = []string{"Enable"}
+		if alpha.LogConfig.Enable {
+			alpha.LogConfig.ForceSendFields = append(alpha.LogConfig.ForceSendFields, "SampleRate")
+		}
+	}
 	return alpha, nil
 }
 
+// toRegionalAlpha converts our composite type into an alpha type for a
+// regional BackendService. An error is returned if the BackendService does
+// not require the alpha API, as only the alpha API serves regional
+// BackendServices of internal HTTP(S) load balancers.
+func (be *BackendService) toRegionalAlpha() (*computealpha.BackendService, error) {
+	if be.Version != meta.VersionAlpha {
+		return nil, fmt.Errorf("regional backend service %v requires the alpha API, got version %q", be.Name, be.Version)
+	}
+	return be.toAlpha()
+}
+
 // toBeta converts our composite type into an beta type.
 // This beta type can be used in GCE API calls.
 func (be *BackendService) toBeta() (*computebeta.BackendService, error) {
@@ -19,6 +19,7 @@ package composite
 import (
 	"fmt"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/kr/pretty"
@@ -35,14 +36,14 @@ func TestBackendService(t *testing.T) {
 	compositeType := reflect.TypeOf(BackendService{})
 	alphaType := reflect.TypeOf(computealpha.BackendService{})
 
-	// For the composite type, remove the Version field from consideration
-	compositeTypeNumFields := compositeType.NumField() - 1
+	// For the composite type, remove the Version and Scope fields from consideration
+	compositeTypeNumFields := compositeType.NumField() - 2
 	if compositeTypeNumFields != alphaType.NumField() {
 		t.Fatalf("%v should contain %v fields. Got %v", alphaType.Name(), alphaType.NumField(), compositeTypeNumFields)
 	}
-	// Start loop at 1 to ignore the composite type's Version field.
-	for i := 1; i < compositeType.NumField(); i++ {
-		if err := compareFields(compositeType.Field(i), alphaType.Field(i-1)); err != nil {
+	// Start loop at 2 to ignore the composite type's Version and Scope fields.
+	for i := 2; i < compositeType.NumField(); i++ {
+		if err := compareFields(compositeType.Field(i), alphaType.Field(i-2)); err != nil {
 			t.Fatal(err)
 		}
 	}
@@ -80,6 +81,22 @@ func TestBackendServiceCdnPolicy(t *testing.T) {
 	}
 }
 
+func TestBackendServiceCdnPolicyNegativeCachingPolicy(t *testing.T) {
+	compositeType := reflect.TypeOf(BackendServiceCdnPolicyNegativeCachingPolicy{})
+	alphaType := reflect.TypeOf(computealpha.BackendServiceCdnPolicyNegativeCachingPolicy{})
+	if err := typeEquality(compositeType, alphaType); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSignedUrlKey(t *testing.T) {
+	compositeType := reflect.TypeOf(SignedUrlKey{})
+	alphaType := reflect.TypeOf(computealpha.SignedUrlKey{})
+	if err := typeEquality(compositeType, alphaType); err != nil {
+		t.Fatal(err)
+	}
+}
+
 func TestCacheKeyPolicy(t *testing.T) {
 	compositeType := reflect.TypeOf(CacheKeyPolicy{})
 	alphaType := reflect.TypeOf(computealpha.CacheKeyPolicy{})
@@ -120,6 +137,38 @@ func TestBackendServiceAppEngineBackend(t *testing.T) {
 	}
 }
 
+func TestBackendServiceLogConfig(t *testing.T) {
+	compositeType := reflect.TypeOf(BackendServiceLogConfig{})
+	alphaType := reflect.TypeOf(computealpha.BackendServiceLogConfig{})
+	if err := typeEquality(compositeType, alphaType); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCircuitBreakers(t *testing.T) {
+	compositeType := reflect.TypeOf(CircuitBreakers{})
+	alphaType := reflect.TypeOf(computealpha.CircuitBreakers{})
+	if err := typeEquality(compositeType, alphaType); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDuration(t *testing.T) {
+	compositeType := reflect.TypeOf(Duration{})
+	alphaType := reflect.TypeOf(computealpha.Duration{})
+	if err := typeEquality(compositeType, alphaType); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestOutlierDetection(t *testing.T) {
+	compositeType := reflect.TypeOf(OutlierDetection{})
+	alphaType := reflect.TypeOf(computealpha.OutlierDetection{})
+	if err := typeEquality(compositeType, alphaType); err != nil {
+		t.Fatal(err)
+	}
+}
+
 func TestToBackendService(t *testing.T) {
 	testCases := []struct {
 		input    interface{}
@@ -186,6 +235,111 @@ func TestToGA(t *testing.T) {
 	}
 }
 
+func TestUrlMapToGA(t *testing.T) {
+	composite := UrlMap{
+		Name:           "um",
+		DefaultService: "global/backendServices/be",
+	}
+	ga, err := composite.toGA()
+	if err != nil {
+		t.Fatalf("composite.toGA() = _, %v; want _, nil", err)
+	}
+	if ga.Name != "um" || ga.DefaultService != "global/backendServices/be" {
+		t.Errorf("composite.toGA() = %+v, want name and default service to be converted", ga)
+	}
+}
+
+func TestUrlMapWithRouteRules(t *testing.T) {
+	composite := UrlMap{
+		Name:      "um",
+		HostRules: []*HostRule{{Hosts: []string{"foo.com"}, PathMatcher: "host1"}},
+		PathMatchers: []*PathMatcher{{
+			Name:           "host1",
+			DefaultService: "global/backendServices/be",
+			RouteRules: []*HttpRouteRule{{
+				Priority: 0,
+				MatchRules: []*HttpRouteRuleMatch{{
+					PrefixMatch:   "/",
+					HeaderMatches: []*HttpHeaderMatch{{HeaderName: "x-canary", ExactMatch: "true"}},
+				}},
+				RouteAction: &HttpRouteAction{WeightedBackendServices: []*WeightedBackendService{
+					{BackendService: "global/backendServices/be", Weight: 100},
+					{BackendService: "global/backendServices/canary", Weight: 0},
+				}},
+			}},
+		}},
+	}
+	if _, err := composite.toGA(); err == nil {
+		t.Errorf("composite.toGA() = _, nil; want error as GA does not support route rules")
+	}
+	alpha, err := composite.toAlpha()
+	if err != nil {
+		t.Fatalf("composite.toAlpha() = _, %v; want _, nil", err)
+	}
+	wbs := alpha.PathMatchers[0].RouteRules[0].RouteAction.WeightedBackendServices
+	if len(wbs) != 2 || wbs[1].BackendService != "global/backendServices/canary" {
+		t.Fatalf("alpha weighted backend services = %+v, want both backend services", wbs)
+	}
+	bytes, err := wbs[1].MarshalJSON()
+	if err != nil {
+		t.Fatalf("MarshalJSON() = _, %v; want _, nil", err)
+	}
+	if !strings.Contains(string(bytes), `"weight":0`) {
+		t.Errorf("MarshalJSON() = %s, want a weight of 0 to be sent", bytes)
+	}
+}
+
+func TestCheckFieldsConverted(t *testing.T) {
+	type from struct {
+		A      string  `json:"a,omitempty"`
+		B      string  `json:"b,omitempty"`
+		Nested []*from `json:"nested,omitempty"`
+	}
+	type to struct {
+		A      string `json:"a,omitempty"`
+		Nested []*to  `json:"nested,omitempty"`
+	}
+	for _, tc := range []struct {
+		desc      string
+		composite from
+		converted to
+		wantErr   bool
+	}{
+		{
+			desc:      "all fields converted",
+			composite: from{A: "a"},
+			converted: to{A: "a"},
+		},
+		{
+			desc:      "unsupported field is unset",
+			composite: from{},
+			converted: to{},
+		},
+		{
+			desc:      "unsupported field is set",
+			composite: from{A: "a", B: "b"},
+			converted: to{A: "a"},
+			wantErr:   true,
+		},
+		{
+			desc:      "nested fields converted",
+			composite: from{A: "a", Nested: []*from{{A: "a"}}},
+			converted: to{A: "a", Nested: []*to{{A: "a"}}},
+		},
+		{
+			desc:      "unsupported nested field is set",
+			composite: from{A: "a", Nested: []*from{{A: "a", B: "b"}}},
+			converted: to{A: "a", Nested: []*to{{A: "a"}}},
+			wantErr:   true,
+		},
+	} {
+		err := checkFieldsConverted(tc.composite, tc.converted)
+		if gotErr := err != nil; gotErr != tc.wantErr {
+			t.Errorf("%s: checkFieldsConverted(%+v, %+v) = %v, want error %t", tc.desc, tc.composite, tc.converted, err, tc.wantErr)
+		}
+	}
+}
+
 // typeEquality is a generic function that checks type equality.
 func typeEquality(t1, t2 reflect.Type) error {
 	t1Fields, t2Fields := make(map[string]bool), make(map[string]bool)
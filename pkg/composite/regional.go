@@ -0,0 +1,375 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package composite
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"k8s.io/klog"
+
+	computealpha "google.golang.org/api/compute/v0.alpha"
+	compute "google.golang.org/api/compute/v1"
+	"k8s.io/kubernetes/pkg/cloudprovider/providers/gce"
+	gcecloud "k8s.io/kubernetes/pkg/cloudprovider/providers/gce/cloud"
+)
+
+// The regional resources of internal HTTP(S) load balancers are only served
+// by the alpha API. The functions in this file take and return GA objects,
+// which have the same representation for the fields managed by the
+// controller, and convert them to alpha objects for the API calls. All
+// regional resources are in the region of the cloud.
+
+// CreateRegionalHealthCheck creates the given regional HealthCheck.
+func CreateRegionalHealthCheck(hc *computealpha.HealthCheck, cloud *gce.Cloud) error {
+	klog.V(3).Infof("Creating alpha region health check %v", hc.Name)
+	op, err := cloud.ComputeServices().Alpha.RegionHealthChecks.Insert(cloud.ProjectID(), cloud.Region(), hc).Do()
+	if err != nil {
+		return err
+	}
+	return waitForOperation(op, cloud)
+}
+
+// UpdateRegionalHealthCheck updates the given regional HealthCheck.
+func UpdateRegionalHealthCheck(hc *computealpha.HealthCheck, cloud *gce.Cloud) error {
+	klog.V(3).Infof("Updating alpha region health check %v", hc.Name)
+	op, err := cloud.ComputeServices().Alpha.RegionHealthChecks.Update(cloud.ProjectID(), cloud.Region(), hc.Name, hc).Do()
+	if err != nil {
+		return err
+	}
+	return waitForOperation(op, cloud)
+}
+
+// GetRegionalHealthCheck gets the regional HealthCheck named name.
+func GetRegionalHealthCheck(name string, cloud *gce.Cloud) (*computealpha.HealthCheck, error) {
+	return cloud.ComputeServices().Alpha.RegionHealthChecks.Get(cloud.ProjectID(), cloud.Region(), name).Do()
+}
+
+// DeleteRegionalHealthCheck deletes the regional HealthCheck named name.
+func DeleteRegionalHealthCheck(name string, cloud *gce.Cloud) error {
+	klog.V(3).Infof("Deleting alpha region health check %v", name)
+	op, err := cloud.ComputeServices().Alpha.RegionHealthChecks.Delete(cloud.ProjectID(), cloud.Region(), name).Do()
+	if err != nil {
+		return err
+	}
+	return waitForOperation(op, cloud)
+}
+
+// CreateRegionalUrlMap creates the given regional UrlMap.
+func CreateRegionalUrlMap(um *compute.UrlMap, cloud *gce.Cloud) error {
+	alpha := &computealpha.UrlMap{}
+	if err := copyViaJSON(alpha, um); err != nil {
+		return err
+	}
+	klog.V(3).Infof("Creating alpha region url map %v", alpha.Name)
+	op, err := cloud.ComputeServices().Alpha.RegionUrlMaps.Insert(cloud.ProjectID(), cloud.Region(), alpha).Do()
+	if err != nil {
+		return err
+	}
+	return waitForOperation(op, cloud)
+}
+
+// UpdateRegionalUrlMap updates the given regional UrlMap.
+func UpdateRegionalUrlMap(um *compute.UrlMap, cloud *gce.Cloud) error {
+	alpha := &computealpha.UrlMap{}
+	if err := copyViaJSON(alpha, um); err != nil {
+		return err
+	}
+	klog.V(3).Infof("Updating alpha region url map %v", alpha.Name)
+	op, err := cloud.ComputeServices().Alpha.RegionUrlMaps.Update(cloud.ProjectID(), cloud.Region(), alpha.Name, alpha).Do()
+	if err != nil {
+		return err
+	}
+	return waitForOperation(op, cloud)
+}
+
+// GetRegionalUrlMap gets the regional UrlMap named name.
+func GetRegionalUrlMap(name string, cloud *gce.Cloud) (*compute.UrlMap, error) {
+	alpha, err := cloud.ComputeServices().Alpha.RegionUrlMaps.Get(cloud.ProjectID(), cloud.Region(), name).Do()
+	if err != nil {
+		return nil, err
+	}
+	um := &compute.UrlMap{}
+	return um, copyViaJSON(um, alpha)
+}
+
+// DeleteRegionalUrlMap deletes the regional UrlMap named name.
+func DeleteRegionalUrlMap(name string, cloud *gce.Cloud) error {
+	klog.V(3).Infof("Deleting alpha region url map %v", name)
+	op, err := cloud.ComputeServices().Alpha.RegionUrlMaps.Delete(cloud.ProjectID(), cloud.Region(), name).Do()
+	if err != nil {
+		return err
+	}
+	return waitForOperation(op, cloud)
+}
+
+// ListRegionalUrlMaps lists the regional UrlMaps.
+func ListRegionalUrlMaps(cloud *gce.Cloud) ([]*compute.UrlMap, error) {
+	ctx, cancel := gcecloud.ContextWithCallTimeout()
+	defer cancel()
+	var urlMaps []*compute.UrlMap
+	err := cloud.ComputeServices().Alpha.RegionUrlMaps.List(cloud.ProjectID(), cloud.Region()).Pages(ctx, func(page *computealpha.UrlMapList) error {
+		for _, alpha := range page.Items {
+			um := &compute.UrlMap{}
+			if err := copyViaJSON(um, alpha); err != nil {
+				return err
+			}
+			urlMaps = append(urlMaps, um)
+		}
+		return nil
+	})
+	return urlMaps, err
+}
+
+// CreateRegionalTargetHttpProxy creates the given regional TargetHttpProxy.
+func CreateRegionalTargetHttpProxy(proxy *compute.TargetHttpProxy, cloud *gce.Cloud) error {
+	alpha := &computealpha.TargetHttpProxy{}
+	if err := copyViaJSON(alpha, proxy); err != nil {
+		return err
+	}
+	klog.V(3).Infof("Creating alpha region target http proxy %v", alpha.Name)
+	op, err := cloud.ComputeServices().Alpha.RegionTargetHttpProxies.Insert(cloud.ProjectID(), cloud.Region(), alpha).Do()
+	if err != nil {
+		return err
+	}
+	return waitForOperation(op, cloud)
+}
+
+// GetRegionalTargetHttpProxy gets the regional TargetHttpProxy named name.
+func GetRegionalTargetHttpProxy(name string, cloud *gce.Cloud) (*compute.TargetHttpProxy, error) {
+	alpha, err := cloud.ComputeServices().Alpha.RegionTargetHttpProxies.Get(cloud.ProjectID(), cloud.Region(), name).Do()
+	if err != nil {
+		return nil, err
+	}
+	proxy := &compute.TargetHttpProxy{}
+	return proxy, copyViaJSON(proxy, alpha)
+}
+
+// DeleteRegionalTargetHttpProxy deletes the regional TargetHttpProxy named
+// name.
+func DeleteRegionalTargetHttpProxy(name string, cloud *gce.Cloud) error {
+	klog.V(3).Infof("Deleting alpha region target http proxy %v", name)
+	op, err := cloud.ComputeServices().Alpha.RegionTargetHttpProxies.Delete(cloud.ProjectID(), cloud.Region(), name).Do()
+	if err != nil {
+		return err
+	}
+	return waitForOperation(op, cloud)
+}
+
+// SetUrlMapForRegionalTargetHttpProxy sets the UrlMap of the regional
+// TargetHttpProxy named proxyName.
+func SetUrlMapForRegionalTargetHttpProxy(proxyName, urlMapLink string, cloud *gce.Cloud) error {
+	klog.V(3).Infof("Setting url map %v on alpha region target http proxy %v", urlMapLink, proxyName)
+	ref := &computealpha.UrlMapReference{UrlMap: urlMapLink}
+	op, err := cloud.ComputeServices().Alpha.RegionTargetHttpProxies.SetUrlMap(cloud.ProjectID(), cloud.Region(), proxyName, ref).Do()
+	if err != nil {
+		return err
+	}
+	return waitForOperation(op, cloud)
+}
+
+// CreateRegionalTargetHttpsProxy creates the given regional
+// TargetHttpsProxy.
+func CreateRegionalTargetHttpsProxy(proxy *compute.TargetHttpsProxy, cloud *gce.Cloud) error {
+	alpha := &computealpha.TargetHttpsProxy{}
+	if err := copyViaJSON(alpha, proxy); err != nil {
+		return err
+	}
+	klog.V(3).Infof("Creating alpha region target https proxy %v", alpha.Name)
+	op, err := cloud.ComputeServices().Alpha.RegionTargetHttpsProxies.Insert(cloud.ProjectID(), cloud.Region(), alpha).Do()
+	if err != nil {
+		return err
+	}
+	return waitForOperation(op, cloud)
+}
+
+// GetRegionalTargetHttpsProxy gets the regional TargetHttpsProxy named
+// name.
+func GetRegionalTargetHttpsProxy(name string, cloud *gce.Cloud) (*compute.TargetHttpsProxy, error) {
+	alpha, err := cloud.ComputeServices().Alpha.RegionTargetHttpsProxies.Get(cloud.ProjectID(), cloud.Region(), name).Do()
+	if err != nil {
+		return nil, err
+	}
+	proxy := &compute.TargetHttpsProxy{}
+	return proxy, copyViaJSON(proxy, alpha)
+}
+
+// DeleteRegionalTargetHttpsProxy deletes the regional TargetHttpsProxy
+// named name.
+func DeleteRegionalTargetHttpsProxy(name string, cloud *gce.Cloud) error {
+	klog.V(3).Infof("Deleting alpha region target https proxy %v", name)
+	op, err := cloud.ComputeServices().Alpha.RegionTargetHttpsProxies.Delete(cloud.ProjectID(), cloud.Region(), name).Do()
+	if err != nil {
+		return err
+	}
+	return waitForOperation(op, cloud)
+}
+
+// SetUrlMapForRegionalTargetHttpsProxy sets the UrlMap of the regional
+// TargetHttpsProxy named proxyName.
+func SetUrlMapForRegionalTargetHttpsProxy(proxyName, urlMapLink string, cloud *gce.Cloud) error {
+	klog.V(3).Infof("Setting url map %v on alpha region target https proxy %v", urlMapLink, proxyName)
+	ref := &computealpha.UrlMapReference{UrlMap: urlMapLink}
+	op, err := cloud.ComputeServices().Alpha.RegionTargetHttpsProxies.SetUrlMap(cloud.ProjectID(), cloud.Region(), proxyName, ref).Do()
+	if err != nil {
+		return err
+	}
+	return waitForOperation(op, cloud)
+}
+
+// SetSslCertificatesForRegionalTargetHttpsProxy sets the SslCertificates of
+// the regional TargetHttpsProxy named proxyName.
+func SetSslCertificatesForRegionalTargetHttpsProxy(proxyName string, sslCertLinks []string, cloud *gce.Cloud) error {
+	klog.V(3).Infof("Setting ssl certificates %v on alpha region target https proxy %v", sslCertLinks, proxyName)
+	req := &computealpha.RegionTargetHttpsProxiesSetSslCertificatesRequest{SslCertificates: sslCertLinks}
+	op, err := cloud.ComputeServices().Alpha.RegionTargetHttpsProxies.SetSslCertificates(cloud.ProjectID(), cloud.Region(), proxyName, req).Do()
+	if err != nil {
+		return err
+	}
+	return waitForOperation(op, cloud)
+}
+
+// CreateRegionalSslCertificate creates the given regional SslCertificate
+// and returns it as created.
+func CreateRegionalSslCertificate(cert *compute.SslCertificate, cloud *gce.Cloud) (*compute.SslCertificate, error) {
+	alpha := &computealpha.SslCertificate{}
+	if err := copyViaJSON(alpha, cert); err != nil {
+		return nil, err
+	}
+	klog.V(3).Infof("Creating alpha region ssl certificate %v", alpha.Name)
+	op, err := cloud.ComputeServices().Alpha.RegionSslCertificates.Insert(cloud.ProjectID(), cloud.Region(), alpha).Do()
+	if err != nil {
+		return nil, err
+	}
+	if err := waitForOperation(op, cloud); err != nil {
+		return nil, err
+	}
+	return GetRegionalSslCertificate(cert.Name, cloud)
+}
+
+// GetRegionalSslCertificate gets the regional SslCertificate named name.
+func GetRegionalSslCertificate(name string, cloud *gce.Cloud) (*compute.SslCertificate, error) {
+	alpha, err := cloud.ComputeServices().Alpha.RegionSslCertificates.Get(cloud.ProjectID(), cloud.Region(), name).Do()
+	if err != nil {
+		return nil, err
+	}
+	cert := &compute.SslCertificate{}
+	return cert, copyViaJSON(cert, alpha)
+}
+
+// DeleteRegionalSslCertificate deletes the regional SslCertificate named
+// name.
+func DeleteRegionalSslCertificate(name string, cloud *gce.Cloud) error {
+	klog.V(3).Infof("Deleting alpha region ssl certificate %v", name)
+	op, err := cloud.ComputeServices().Alpha.RegionSslCertificates.Delete(cloud.ProjectID(), cloud.Region(), name).Do()
+	if err != nil {
+		return err
+	}
+	return waitForOperation(op, cloud)
+}
+
+// ListRegionalSslCertificates lists the regional SslCertificates.
+func ListRegionalSslCertificates(cloud *gce.Cloud) ([]*compute.SslCertificate, error) {
+	ctx, cancel := gcecloud.ContextWithCallTimeout()
+	defer cancel()
+	var certs []*compute.SslCertificate
+	err := cloud.ComputeServices().Alpha.RegionSslCertificates.List(cloud.ProjectID(), cloud.Region()).Pages(ctx, func(page *computealpha.SslCertificateList) error {
+		for _, alpha := range page.Items {
+			cert := &compute.SslCertificate{}
+			if err := copyViaJSON(cert, alpha); err != nil {
+				return err
+			}
+			certs = append(certs, cert)
+		}
+		return nil
+	})
+	return certs, err
+}
+
+// CreateRegionalForwardingRule creates the given regional ForwardingRule.
+func CreateRegionalForwardingRule(rule *compute.ForwardingRule, cloud *gce.Cloud) error {
+	alpha := &computealpha.ForwardingRule{}
+	if err := copyViaJSON(alpha, rule); err != nil {
+		return err
+	}
+	klog.V(3).Infof("Creating alpha region forwarding rule %v", alpha.Name)
+	return cloud.CreateAlphaRegionForwardingRule(alpha, cloud.Region())
+}
+
+// GetRegionalForwardingRule gets the regional ForwardingRule named name.
+func GetRegionalForwardingRule(name string, cloud *gce.Cloud) (*compute.ForwardingRule, error) {
+	alpha, err := cloud.GetAlphaRegionForwardingRule(name, cloud.Region())
+	if err != nil {
+		return nil, err
+	}
+	rule := &compute.ForwardingRule{}
+	return rule, copyViaJSON(rule, alpha)
+}
+
+// DeleteRegionalForwardingRule deletes the regional ForwardingRule named
+// name.
+func DeleteRegionalForwardingRule(name string, cloud *gce.Cloud) error {
+	klog.V(3).Infof("Deleting region forwarding rule %v", name)
+	return cloud.DeleteRegionForwardingRule(name, cloud.Region())
+}
+
+// SetTargetForRegionalForwardingRule sets the target of the regional
+// ForwardingRule named name.
+func SetTargetForRegionalForwardingRule(name, targetLink string, cloud *gce.Cloud) error {
+	klog.V(3).Infof("Setting target %v on alpha region forwarding rule %v", targetLink, name)
+	ref := &computealpha.TargetReference{Target: targetLink}
+	op, err := cloud.ComputeServices().Alpha.ForwardingRules.SetTarget(cloud.ProjectID(), cloud.Region(), name, ref).Do()
+	if err != nil {
+		return err
+	}
+	return waitForOperation(op, cloud)
+}
+
+// ReserveRegionalAddress reserves the given regional Address with the given
+// purpose, which only applies to internal addresses.
+func ReserveRegionalAddress(addr *compute.Address, purpose string, cloud *gce.Cloud) error {
+	alpha := &computealpha.Address{}
+	if err := copyViaJSON(alpha, addr); err != nil {
+		return err
+	}
+	alpha.Purpose = purpose
+	klog.V(3).Infof("Reserving alpha region address %v", alpha.Name)
+	return cloud.ReserveAlphaRegionAddress(alpha, cloud.Region())
+}
+
+// GetRegionalAddress gets the regional Address named name.
+func GetRegionalAddress(name string, cloud *gce.Cloud) (*compute.Address, error) {
+	return cloud.GetRegionAddress(name, cloud.Region())
+}
+
+// DeleteRegionalAddress deletes the regional Address named name.
+func DeleteRegionalAddress(name string, cloud *gce.Cloud) error {
+	klog.V(3).Infof("Deleting region address %v", name)
+	return cloud.DeleteRegionAddress(name, cloud.Region())
+}
+
+// copyViaJSON copies src into dst, which are compute objects of different
+// versions.
+func copyViaJSON(dst, src interface{}) error {
+	bytes, err := json.Marshal(src)
+	if err != nil {
+		return fmt.Errorf("could not marshal object %+v to JSON: %v", src, err)
+	}
+	if err := json.Unmarshal(bytes, dst); err != nil {
+		return fmt.Errorf("error unmarshalling to %T: %v", dst, err)
+	}
+	return nil
+}
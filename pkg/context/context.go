@@ -20,6 +20,8 @@ import (
 	"k8s.io/klog"
 
 	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
 	informerv1 "k8s.io/client-go/informers/core/v1"
 	informerv1beta1 "k8s.io/client-go/informers/extensions/v1beta1"
 	"k8s.io/client-go/kubernetes"
@@ -28,8 +30,10 @@ import (
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/record"
 	backendconfigclient "k8s.io/ingress-gce/pkg/backendconfig/client/clientset/versioned"
-	informerbackendconfig "k8s.io/ingress-gce/pkg/backendconfig/client/informers/externalversions/backendconfig/v1beta1"
+	informerbackendconfig "k8s.io/ingress-gce/pkg/backendconfig/client/informers/externalversions/backendconfig/v1"
 	"k8s.io/ingress-gce/pkg/common/typed"
+	frontendconfigclient "k8s.io/ingress-gce/pkg/frontendconfig/client/clientset/versioned"
+	informerfrontendconfig "k8s.io/ingress-gce/pkg/frontendconfig/client/informers/externalversions/frontendconfig/v1beta1"
 	"k8s.io/ingress-gce/pkg/utils"
 	"k8s.io/kubernetes/pkg/cloudprovider/providers/gce"
 )
@@ -42,6 +46,10 @@ const (
 // ControllerContext holds the state needed for the execution of the controller.
 type ControllerContext struct {
 	KubeClient kubernetes.Interface
+	// BackendConfigClient is nil if BackendConfig is disabled.
+	BackendConfigClient backendconfigclient.Interface
+	// FrontendConfigClient is nil if FrontendConfig is disabled.
+	FrontendConfigClient frontendconfigclient.Interface
 
 	Cloud *gce.Cloud
 
@@ -55,6 +63,14 @@ type ControllerContext struct {
 	PodInformer           cache.SharedIndexInformer
 	NodeInformer          cache.SharedIndexInformer
 	EndpointInformer      cache.SharedIndexInformer
+	// SecretInformer only watches secrets of type kubernetes.io/tls. It
+	// caches their private keys.
+	SecretInformer cache.SharedIndexInformer
+	// FrontendConfigInformer is nil if FrontendConfig is disabled.
+	FrontendConfigInformer cache.SharedIndexInformer
+
+	// ResourceLocks are shared by the controllers syncing GCE resources.
+	ResourceLocks *utils.ResourceLocks
 
 	healthChecks map[string]func() error
 
@@ -78,24 +94,35 @@ type ControllerContextConfig struct {
 func NewControllerContext(
 	kubeClient kubernetes.Interface,
 	backendConfigClient backendconfigclient.Interface,
+	frontendConfigClient frontendconfigclient.Interface,
 	cloud *gce.Cloud,
 	namer *utils.Namer,
 	config ControllerContextConfig) *ControllerContext {
 
 	context := &ControllerContext{
 		KubeClient:              kubeClient,
+		BackendConfigClient:     backendConfigClient,
+		FrontendConfigClient:    frontendConfigClient,
 		Cloud:                   cloud,
 		ClusterNamer:            namer,
 		ControllerContextConfig: config,
-		IngressInformer:         informerv1beta1.NewIngressInformer(kubeClient, config.Namespace, config.ResyncPeriod, utils.NewNamespaceIndexer()),
+		IngressInformer:         informerv1beta1.NewIngressInformer(kubeClient, config.Namespace, config.ResyncPeriod, utils.NewIngressIndexer()),
 		ServiceInformer:         informerv1.NewServiceInformer(kubeClient, config.Namespace, config.ResyncPeriod, utils.NewNamespaceIndexer()),
 		BackendConfigInformer:   informerbackendconfig.NewBackendConfigInformer(backendConfigClient, config.Namespace, config.ResyncPeriod, utils.NewNamespaceIndexer()),
 		EndpointInformer:        informerv1.NewEndpointsInformer(kubeClient, config.Namespace, config.ResyncPeriod, utils.NewNamespaceIndexer()),
 		PodInformer:             informerv1.NewPodInformer(kubeClient, config.Namespace, config.ResyncPeriod, utils.NewNamespaceIndexer()),
 		NodeInformer:            informerv1.NewNodeInformer(kubeClient, config.ResyncPeriod, utils.NewNamespaceIndexer()),
+		ResourceLocks:           utils.NewResourceLocks(),
 		recorders:               map[string]record.EventRecorder{},
 		healthChecks:            make(map[string]func() error),
 	}
+	// Secrets of other types may hold credentials unrelated to Ingresses.
+	context.SecretInformer = informerv1.NewFilteredSecretInformer(kubeClient, config.Namespace, config.ResyncPeriod, utils.NewNamespaceIndexer(), func(options *metav1.ListOptions) {
+		options.FieldSelector = fields.OneTermEqualSelector("type", string(apiv1.SecretTypeTLS)).String()
+	})
+	if frontendConfigClient != nil {
+		context.FrontendConfigInformer = informerfrontendconfig.NewFrontendConfigInformer(frontendConfigClient, config.Namespace, config.ResyncPeriod, utils.NewNamespaceIndexer())
+	}
 
 	return context
 }
@@ -109,6 +136,10 @@ func (ctx *ControllerContext) HasSynced() bool {
 		ctx.PodInformer.HasSynced,
 		ctx.NodeInformer.HasSynced,
 		ctx.EndpointInformer.HasSynced,
+		ctx.SecretInformer.HasSynced,
+	}
+	if ctx.FrontendConfigInformer != nil {
+		funcs = append(funcs, ctx.FrontendConfigInformer.HasSynced)
 	}
 	for _, f := range funcs {
 		if !f() {
@@ -120,6 +151,8 @@ func (ctx *ControllerContext) HasSynced() bool {
 
 // Recorder return the event recorder for the given namespace.
 func (ctx *ControllerContext) Recorder(ns string) record.EventRecorder {
+	ctx.lock.Lock()
+	defer ctx.lock.Unlock()
 	if rec, ok := ctx.recorders[ns]; ok {
 		return rec
 	}
@@ -165,12 +198,16 @@ func (ctx *ControllerContext) Start(stopCh chan struct{}) {
 	go ctx.ServiceInformer.Run(stopCh)
 	go ctx.PodInformer.Run(stopCh)
 	go ctx.NodeInformer.Run(stopCh)
+	go ctx.SecretInformer.Run(stopCh)
 	if ctx.EndpointInformer != nil {
 		go ctx.EndpointInformer.Run(stopCh)
 	}
 	if ctx.BackendConfigInformer != nil {
 		go ctx.BackendConfigInformer.Run(stopCh)
 	}
+	if ctx.FrontendConfigInformer != nil {
+		go ctx.FrontendConfigInformer.Run(stopCh)
+	}
 }
 
 // Ingresses returns the store of Ingresses.
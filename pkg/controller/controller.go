@@ -17,34 +17,44 @@ limitations under the License.
 package controller
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"reflect"
+	"sort"
 	"sync"
 	"time"
 
 	"k8s.io/klog"
 
+	compute "google.golang.org/api/compute/v1"
 	apiv1 "k8s.io/api/core/v1"
 	extensions "k8s.io/api/extensions/v1beta1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/kubernetes"
 	unversionedcore "k8s.io/client-go/kubernetes/typed/core/v1"
 	listers "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/record"
-	backendconfigv1beta1 "k8s.io/ingress-gce/pkg/apis/backendconfig/v1beta1"
+	backendconfigv1 "k8s.io/ingress-gce/pkg/apis/backendconfig/v1"
+	frontendconfigv1beta1 "k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1"
+	"k8s.io/ingress-gce/pkg/backendconfig"
 	"k8s.io/ingress-gce/pkg/backends"
 	"k8s.io/ingress-gce/pkg/common/operator"
 	"k8s.io/ingress-gce/pkg/healthchecks"
 	"k8s.io/ingress-gce/pkg/instances"
+	"k8s.io/kubernetes/pkg/cloudprovider/providers/gce"
+	"k8s.io/kubernetes/pkg/cloudprovider/providers/gce/cloud"
 	"k8s.io/kubernetes/pkg/cloudprovider/providers/gce/cloud/meta"
 
 	"k8s.io/ingress-gce/pkg/annotations"
 	"k8s.io/ingress-gce/pkg/context"
 	"k8s.io/ingress-gce/pkg/controller/translator"
 	"k8s.io/ingress-gce/pkg/flags"
+	"k8s.io/ingress-gce/pkg/frontendconfig"
 	"k8s.io/ingress-gce/pkg/loadbalancers"
 	ingsync "k8s.io/ingress-gce/pkg/sync"
 	"k8s.io/ingress-gce/pkg/tls"
@@ -60,7 +70,6 @@ type LoadBalancerController struct {
 	nodeLister cache.Indexer
 	nodes      *NodeController
 
-	// TODO: Watch secrets
 	ingQueue   utils.TaskQueue
 	Translator *translator.Translator
 	stopCh     chan struct{}
@@ -87,12 +96,50 @@ type LoadBalancerController struct {
 
 	// Ingress sync + GC implementation
 	ingSyncer ingsync.Syncer
+	// svcPortRefs counts the Ingresses using each backend, so that the
+	// backends of an Ingress are collected without a full GC.
+	svcPortRefs *svcPortRefs
+	// gcLock is held for reading by the syncs of Ingresses and for writing
+	// by the full GC, which would otherwise delete the backends of an
+	// Ingress being synced.
+	gcLock sync.RWMutex
+}
+
+// Providers are the interfaces through which the controller manages GCE
+// resources.
+type Providers struct {
+	LoadBalancers  loadbalancers.LoadBalancers
+	BackendPool    backends.Pool
+	HealthChecks   healthchecks.HealthCheckProvider
+	InstanceGroups instances.InstanceGroups
+	NEGs           backends.NEGGetter
+}
+
+// NewGCEProviders returns the Providers which manage GCE resources with
+// cloud.
+func NewGCEProviders(cloud *gce.Cloud, namer *utils.Namer) Providers {
+	return Providers{
+		LoadBalancers:  loadbalancers.NewGCELoadBalancers(cloud),
+		BackendPool:    backends.NewPool(cloud, namer),
+		HealthChecks:   healthchecks.NewGCEHealthChecks(cloud),
+		InstanceGroups: cloud,
+		NEGs:           cloud,
+	}
 }
 
 // NewLoadBalancerController creates a controller for gce loadbalancers.
 func NewLoadBalancerController(
 	ctx *context.ControllerContext,
 	stopCh chan struct{}) *LoadBalancerController {
+	return NewLoadBalancerControllerWithProviders(ctx, stopCh, NewGCEProviders(ctx.Cloud, ctx.ClusterNamer))
+}
+
+// NewLoadBalancerControllerWithProviders creates a controller for gce
+// loadbalancers which manages GCE resources through the given providers.
+func NewLoadBalancerControllerWithProviders(
+	ctx *context.ControllerContext,
+	stopCh chan struct{},
+	providers Providers) *LoadBalancerController {
 
 	broadcaster := record.NewBroadcaster()
 	broadcaster.StartLogging(klog.Infof)
@@ -100,27 +147,32 @@ func NewLoadBalancerController(
 		Interface: ctx.KubeClient.Core().Events(""),
 	})
 
-	healthChecker := healthchecks.NewHealthChecker(ctx.Cloud, ctx.HealthCheckPath, ctx.DefaultBackendHealthCheckPath, ctx.ClusterNamer, ctx.DefaultBackendSvcPortID.Service)
-	instancePool := instances.NewNodePool(ctx.Cloud, ctx.ClusterNamer)
-	backendPool := backends.NewPool(ctx.Cloud, ctx.ClusterNamer)
+	healthChecker := healthchecks.NewHealthChecker(providers.HealthChecks, ctx.HealthCheckPath, ctx.DefaultBackendHealthCheckPath, ctx.ClusterNamer, ctx.DefaultBackendSvcPortID.Service)
+	instancePool := instances.NewNodePool(providers.InstanceGroups, ctx.ClusterNamer)
+	backendPool := providers.BackendPool
+	var statusRecorder backends.BackendConfigStatusRecorder
+	if ctx.BackendConfigClient != nil {
+		statusRecorder = backendconfig.NewStatusRecorder(ctx.BackendConfigClient, ctx.BackendConfigInformer.GetStore())
+	}
 
 	lbc := LoadBalancerController{
 		ctx:           ctx,
 		nodeLister:    ctx.NodeInformer.GetIndexer(),
 		Translator:    translator.NewTranslator(ctx),
-		tlsLoader:     &tls.TLSCertsFromSecretsLoader{Client: ctx.KubeClient},
+		tlsLoader:     &tls.TLSCertsFromSecretsLoader{Client: ctx.KubeClient, Recorders: ctx, Strict: flags.F.StrictTLSValidation},
 		stopCh:        stopCh,
 		hasSynced:     ctx.HasSynced,
 		nodes:         NewNodeController(ctx, instancePool),
 		instancePool:  instancePool,
-		l7Pool:        loadbalancers.NewLoadBalancerPool(ctx.Cloud, ctx.ClusterNamer, ctx),
-		backendSyncer: backends.NewBackendSyncer(backendPool, healthChecker, ctx.ClusterNamer),
-		negLinker:     backends.NewNEGLinker(backendPool, ctx.Cloud, ctx.ClusterNamer),
+		l7Pool:        loadbalancers.NewLoadBalancerPool(providers.LoadBalancers, ctx.ClusterNamer, ctx),
+		backendSyncer: backends.NewBackendSyncer(backendPool, healthChecker, ctx.ClusterNamer, statusRecorder),
+		negLinker:     backends.NewNEGLinker(backendPool, providers.NEGs, ctx.ClusterNamer),
 		igLinker:      backends.NewInstanceGroupLinker(instancePool, backendPool, ctx.ClusterNamer),
+		svcPortRefs:   newSvcPortRefs(ctx.ClusterNamer),
 	}
 	lbc.ingSyncer = ingsync.NewIngressSyncer(&lbc)
 
-	lbc.ingQueue = utils.NewPeriodicTaskQueue("ingress", "ingresses", lbc.sync)
+	lbc.ingQueue = utils.NewPeriodicTaskQueueWithMultipleWorkers("ingress", "ingresses", flags.F.NumIngressWorkers, lbc.sync)
 
 	// Ingress event handlers.
 	ctx.IngressInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
@@ -145,27 +197,7 @@ func NewLoadBalancerController(
 			klog.V(3).Infof("Ingress %v deleted, enqueueing", utils.IngressKeyFunc(delIng))
 			lbc.ingQueue.Enqueue(obj)
 		},
-		UpdateFunc: func(old, cur interface{}) {
-			curIng := cur.(*extensions.Ingress)
-			if !utils.IsGLBCIngress(curIng) {
-				oldIng := old.(*extensions.Ingress)
-				// If ingress was GLBC Ingress, we need to track ingress class change
-				// and run GC to delete LB resources.
-				if utils.IsGLBCIngress(oldIng) {
-					klog.V(4).Infof("Ingress %v class was changed, enqueuing", utils.IngressKeyFunc(curIng))
-					lbc.ingQueue.Enqueue(cur)
-					return
-				}
-				return
-			}
-			if reflect.DeepEqual(old, cur) {
-				klog.V(3).Infof("Periodic enqueueing of %v", utils.IngressKeyFunc(curIng))
-			} else {
-				klog.V(3).Infof("Ingress %v changed, enqueuing", utils.IngressKeyFunc(curIng))
-			}
-
-			lbc.ingQueue.Enqueue(cur)
-		},
+		UpdateFunc: lbc.ingressUpdated,
 	})
 
 	// Service event handlers.
@@ -188,27 +220,55 @@ func NewLoadBalancerController(
 	// BackendConfig event handlers.
 	ctx.BackendConfigInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
 		AddFunc: func(obj interface{}) {
-			beConfig := obj.(*backendconfigv1beta1.BackendConfig)
+			beConfig := obj.(*backendconfigv1.BackendConfig)
 			ings := operator.Ingresses(ctx.Ingresses().List()).ReferencesBackendConfig(beConfig, operator.Services(ctx.Services().List())).AsList()
 			lbc.ingQueue.Enqueue(convert(ings)...)
 		},
-		UpdateFunc: func(old, cur interface{}) {
-			if !reflect.DeepEqual(old, cur) {
-				beConfig := cur.(*backendconfigv1beta1.BackendConfig)
-				ings := operator.Ingresses(ctx.Ingresses().List()).ReferencesBackendConfig(beConfig, operator.Services(ctx.Services().List())).AsList()
-				lbc.ingQueue.Enqueue(convert(ings)...)
-			}
-		},
+		UpdateFunc: lbc.backendConfigUpdated,
 		DeleteFunc: func(obj interface{}) {
-			beConfig := obj.(*backendconfigv1beta1.BackendConfig)
+			beConfig := obj.(*backendconfigv1.BackendConfig)
 			ings := operator.Ingresses(ctx.Ingresses().List()).ReferencesBackendConfig(beConfig, operator.Services(ctx.Services().List())).AsList()
 			lbc.ingQueue.Enqueue(convert(ings)...)
 		},
 	})
 
+	// TLS Secret event handlers.
+	ctx.SecretInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: lbc.enqueueIngressesForSecret,
+		UpdateFunc: func(old, cur interface{}) {
+			if !reflect.DeepEqual(old, cur) {
+				lbc.enqueueIngressesForSecret(cur)
+			}
+		},
+		DeleteFunc: lbc.secretDeleted,
+	})
+
+	// FrontendConfig event handlers.
+	if ctx.FrontendConfigInformer != nil {
+		ctx.FrontendConfigInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc: func(obj interface{}) {
+				feConfig := obj.(*frontendconfigv1beta1.FrontendConfig)
+				ings := operator.Ingresses(ctx.Ingresses().List()).ReferencesFrontendConfig(feConfig).AsList()
+				lbc.ingQueue.Enqueue(convert(ings)...)
+			},
+			UpdateFunc: func(old, cur interface{}) {
+				if !reflect.DeepEqual(old, cur) {
+					feConfig := cur.(*frontendconfigv1beta1.FrontendConfig)
+					ings := operator.Ingresses(ctx.Ingresses().List()).ReferencesFrontendConfig(feConfig).AsList()
+					lbc.ingQueue.Enqueue(convert(ings)...)
+				}
+			},
+			DeleteFunc: func(obj interface{}) {
+				feConfig := obj.(*frontendconfigv1beta1.FrontendConfig)
+				ings := operator.Ingresses(ctx.Ingresses().List()).ReferencesFrontendConfig(feConfig).AsList()
+				lbc.ingQueue.Enqueue(convert(ings)...)
+			},
+		})
+	}
+
 	// Register health check on controller context.
 	ctx.AddHealthCheck("ingress", func() error {
-		_, err := backendPool.Get("foo", meta.VersionGA)
+		_, err := backendPool.Get("foo", meta.VersionGA, meta.Global)
 
 		// If this container is scheduled on a node without compute/rw it is
 		// effectively useless, but it is healthy. Reporting it as unhealthy
@@ -236,6 +296,11 @@ func (lbc *LoadBalancerController) Run() {
 	klog.Infof("Starting loadbalancer controller")
 	go lbc.ingQueue.Run()
 	go lbc.nodes.Run()
+	go func() {
+		// The first sync runs a full GC, so wait for a period before the next.
+		time.Sleep(flags.F.GCPeriod)
+		wait.Until(lbc.gc, flags.F.GCPeriod, lbc.stopCh)
+	}()
 
 	<-lbc.stopCh
 	klog.Infof("Shutting down Loadbalancer Controller")
@@ -270,29 +335,131 @@ func (lbc *LoadBalancerController) Stop(deleteAll bool) error {
 	return nil
 }
 
+// ingressUpdated enqueues the updated Ingress. The conditions annotation is
+// written by the sync of the Ingress, so an update which only changed it is
+// ignored.
+func (lbc *LoadBalancerController) ingressUpdated(old, cur interface{}) {
+	curIng := cur.(*extensions.Ingress)
+	if !utils.IsGLBCIngress(curIng) {
+		oldIng := old.(*extensions.Ingress)
+		// If ingress was GLBC Ingress, we need to track ingress class change
+		// and run GC to delete LB resources.
+		if utils.IsGLBCIngress(oldIng) {
+			klog.V(4).Infof("Ingress %v class was changed, enqueuing", utils.IngressKeyFunc(curIng))
+			lbc.ingQueue.Enqueue(cur)
+			return
+		}
+		return
+	}
+	if reflect.DeepEqual(old, cur) {
+		klog.V(3).Infof("Periodic enqueueing of %v", utils.IngressKeyFunc(curIng))
+	} else if onlyConditionsChanged(old.(*extensions.Ingress), curIng) {
+		klog.V(4).Infof("Ignoring update of the conditions of ingress %v", utils.IngressKeyFunc(curIng))
+		return
+	} else {
+		klog.V(3).Infof("Ingress %v changed, enqueuing", utils.IngressKeyFunc(curIng))
+	}
+
+	lbc.ingQueue.Enqueue(cur)
+}
+
+// onlyConditionsChanged returns true if the Ingresses differ in their
+// conditions annotation and nothing else but their resource version.
+func onlyConditionsChanged(old, cur *extensions.Ingress) bool {
+	if old.Annotations[annotations.ConditionsKey] == cur.Annotations[annotations.ConditionsKey] {
+		return false
+	}
+	old, cur = old.DeepCopy(), cur.DeepCopy()
+	for _, ing := range []*extensions.Ingress{old, cur} {
+		ing.ResourceVersion = ""
+		delete(ing.Annotations, annotations.ConditionsKey)
+		if len(ing.Annotations) == 0 {
+			ing.Annotations = nil
+		}
+	}
+	return reflect.DeepEqual(old, cur)
+}
+
+// backendConfigUpdated enqueues the Ingresses which reference the updated
+// BackendConfig if its spec changed. Its status is written by the sync of
+// those Ingresses and must not resync them, and status updates leave the
+// generation unchanged.
+func (lbc *LoadBalancerController) backendConfigUpdated(old, cur interface{}) {
+	oldConfig := old.(*backendconfigv1.BackendConfig)
+	beConfig := cur.(*backendconfigv1.BackendConfig)
+	if oldConfig.Generation == beConfig.Generation && reflect.DeepEqual(oldConfig.Spec, beConfig.Spec) {
+		return
+	}
+	ings := operator.Ingresses(lbc.ctx.Ingresses().List()).ReferencesBackendConfig(beConfig, operator.Services(lbc.ctx.Services().List())).AsList()
+	lbc.ingQueue.Enqueue(convert(ings)...)
+}
+
+// secretDeleted clears the cert problems reported for the deleted TLS secret
+// and enqueues the Ingresses which reference it.
+func (lbc *LoadBalancerController) secretDeleted(obj interface{}) {
+	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+	if err != nil {
+		klog.Errorf("Failed to get the key of secret %v: %v", obj, err)
+		return
+	}
+	tls.ClearCertProblems(key)
+	lbc.enqueueIngressesForSecret(obj)
+}
+
+// pruneCertProblems clears the cert problems reported for the TLS secrets
+// which are no longer referenced by an Ingress the controller syncs.
+func (lbc *LoadBalancerController) pruneCertProblems() {
+	secrets := sets.NewString()
+	for _, ing := range lbc.ctx.Ingresses().List() {
+		if !utils.IsGLBCIngress(ing) || utils.IsDeletionCandidate(ing.ObjectMeta, utils.FinalizerKey) {
+			continue
+		}
+		for _, t := range ing.Spec.TLS {
+			secrets.Insert(ing.Namespace + "/" + t.SecretName)
+		}
+	}
+	tls.PruneCertProblems(secrets)
+}
+
+// enqueueIngressesForSecret enqueues the Ingresses which reference the
+// given TLS secret, so that rotated certs are uploaded without waiting for
+// a resync.
+func (lbc *LoadBalancerController) enqueueIngressesForSecret(obj interface{}) {
+	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+	if err != nil {
+		klog.Errorf("Failed to get the key of secret %v: %v", obj, err)
+		return
+	}
+	ings, err := lbc.ctx.IngressInformer.GetIndexer().ByIndex(utils.TLSSecretIndex, key)
+	if err != nil {
+		klog.Errorf("Failed to list Ingresses referencing secret %v: %v", key, err)
+		return
+	}
+	if len(ings) > 0 {
+		klog.V(3).Infof("Secret %v changed, enqueuing %d Ingresses", key, len(ings))
+	}
+	lbc.ingQueue.Enqueue(ings...)
+}
+
 // SyncBackends implements Controller.
-func (lbc *LoadBalancerController) SyncBackends(state interface{}) error {
+func (lbc *LoadBalancerController) SyncBackends(state interface{}) (err error) {
 	// We expect state to be a syncState
 	syncState, ok := state.(*syncState)
 	if !ok {
 		return fmt.Errorf("expected state type to be syncState, type was %T", state)
 	}
+	defer func() {
+		// Multi-cluster Ingresses skip the backends and all later phases.
+		if err != ingsync.ErrSkipBackendsSync {
+			syncState.setCondition(annotations.BackendsSyncedCondition, err, backendSyncErrorReason)
+		}
+	}()
 	ingSvcPorts := syncState.urlMap.AllServicePorts()
 
-	// Create instance groups and set named ports.
-	igs, err := lbc.instancePool.EnsureInstanceGroupsAndPorts(lbc.ctx.ClusterNamer.InstanceGroup(), nodePorts(ingSvcPorts))
-	if err != nil {
-		return err
-	}
-
-	nodeNames, err := utils.GetReadyNodeNames(listers.NewNodeLister(lbc.nodeLister))
+	igs, err := lbc.ensureInstanceGroups(ingSvcPorts)
 	if err != nil {
 		return err
 	}
-	// Add/remove instances to the instance groups.
-	if err = lbc.instancePool.Sync(nodeNames); err != nil {
-		return err
-	}
 
 	// TODO: Remove this after deprecation
 	ing := syncState.ing
@@ -311,6 +478,9 @@ func (lbc *LoadBalancerController) SyncBackends(state interface{}) error {
 		return ingsync.ErrSkipBackendsSync
 	}
 
+	// Backends are shared by the Ingresses referencing the same Service port.
+	defer lbc.ctx.ResourceLocks.Lock(lbc.backendLockKeys(ingSvcPorts)...)()
+
 	// Sync the backends
 	if err := lbc.backendSyncer.Sync(ingSvcPorts); err != nil {
 		return err
@@ -341,28 +511,107 @@ func (lbc *LoadBalancerController) SyncBackends(state interface{}) error {
 	return nil
 }
 
-// GCBackends implements Controller.
-func (lbc *LoadBalancerController) GCBackends(state interface{}) error {
-	// We expect state to be a gcState
-	gcState, ok := state.(*gcState)
-	if !ok {
-		return fmt.Errorf("expected state type to be gcState, type was %T", state)
+// ensureInstanceGroups creates the instance groups with the node ports of
+// svcPorts and adds the ready nodes to them.
+func (lbc *LoadBalancerController) ensureInstanceGroups(svcPorts []utils.ServicePort) ([]*compute.InstanceGroup, error) {
+	igName := lbc.ctx.ClusterNamer.InstanceGroup()
+	// The instance groups are shared by all Ingresses and the node controller.
+	defer lbc.ctx.ResourceLocks.Lock(utils.ResourceKey(utils.InstanceGroupResource, igName))()
+
+	// Create instance groups and set named ports.
+	igs, err := lbc.instancePool.EnsureInstanceGroupsAndPorts(igName, nodePorts(svcPorts))
+	if err != nil {
+		return nil, err
 	}
 
-	if err := lbc.backendSyncer.GC(gcState.svcPorts); err != nil {
-		return err
+	nodeNames, err := utils.GetReadyNodeNames(listers.NewNodeLister(lbc.nodeLister))
+	if err != nil {
+		return nil, err
+	}
+	// Add/remove instances to the instance groups.
+	if err = lbc.instancePool.Sync(nodeNames); err != nil {
+		return nil, err
+	}
+	return igs, nil
+}
+
+// backendLockKeys returns the ResourceLocks keys of the backends of svcPorts.
+func (lbc *LoadBalancerController) backendLockKeys(svcPorts []utils.ServicePort) []string {
+	var keys []string
+	for _, sp := range svcPorts {
+		keys = append(keys, utils.ResourceKey(utils.BackendServiceResource, sp.BackendName(lbc.ctx.ClusterNamer)))
+	}
+	return keys
+}
+
+// deleteBackends deletes the backends of svcPorts which are still not
+// referenced by any Ingress once they are locked, as an Ingress being synced
+// may have referenced them since.
+func (lbc *LoadBalancerController) deleteBackends(svcPorts []utils.ServicePort) error {
+	for _, sp := range svcPorts {
+		err := func() error {
+			defer lbc.ctx.ResourceLocks.Lock(lbc.backendLockKeys([]utils.ServicePort{sp})...)()
+			if lbc.svcPortRefs.referenced(sp) {
+				return nil
+			}
+			return lbc.backendSyncer.Delete([]utils.ServicePort{sp})
+		}()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GCBackends implements Controller.
+func (lbc *LoadBalancerController) GCBackends(state interface{}) error {
+	// We expect state to be a gcState or a fullGCState
+	var ings []*extensions.Ingress
+	var igUnused bool
+	switch gcState := state.(type) {
+	case *gcState:
+		// Backends which are still in use by other Ingresses are not released.
+		released := lbc.svcPortRefs.set(gcState.key, gcState.svcPorts)
+		if err := lbc.deleteBackends(released); err != nil {
+			// A backend fails to be deleted while the load balancer of the
+			// Ingress still uses it. The Ingress keeps referencing the
+			// released backends, so that they are deleted again on retry.
+			lbc.svcPortRefs.acquire(gcState.key, released)
+			return err
+		}
+		// The status of BackendConfigs no longer referenced by the
+		// Ingress is pruned here rather than on the next full GC.
+		lbc.backendSyncer.PruneStatus(lbc.svcPortRefs.all())
+		if gcState.ing != nil {
+			ings = append(ings, gcState.ing)
+		}
+		igUnused = gcState.deleteLB && len(slice.RemoveString(lbc.ctx.Ingresses().ListKeys(), gcState.key, nil)) == 0
+	case *fullGCState:
+		lbc.svcPortRefs.reset(gcState.svcPorts)
+		var svcPorts []utils.ServicePort
+		for _, ports := range gcState.svcPorts {
+			svcPorts = append(svcPorts, ports...)
+		}
+		if err := lbc.backendSyncer.GC(svcPorts); err != nil {
+			return err
+		}
+		ings = gcState.ingresses
+		igUnused = len(gcState.lbNames) == 0
+	default:
+		return fmt.Errorf("expected state type to be gcState or fullGCState, type was %T", state)
 	}
 
 	// TODO(ingress#120): Move this to the backend pool so it mirrors creation
-	if len(gcState.lbNames) == 0 {
+	if igUnused {
 		igName := lbc.ctx.ClusterNamer.InstanceGroup()
+		defer lbc.ctx.ResourceLocks.Lock(utils.ResourceKey(utils.InstanceGroupResource, igName))()
 		klog.Infof("Deleting instance group %v", igName)
-		if err := lbc.instancePool.DeleteInstanceGroup(igName); err != err {
+		if err := lbc.instancePool.DeleteInstanceGroup(igName); err != nil {
 			return err
 		}
 	}
 
-	for _, ing := range gcState.ingresses {
+	for _, ing := range ings {
 		if utils.IsDeletionCandidate(ing.ObjectMeta, utils.FinalizerKey) {
 			ingClient := lbc.ctx.KubeClient.Extensions().Ingresses(ing.Namespace)
 			if flags.F.FinalizerRemove {
@@ -378,12 +627,15 @@ func (lbc *LoadBalancerController) GCBackends(state interface{}) error {
 }
 
 // SyncLoadBalancer implements Controller.
-func (lbc *LoadBalancerController) SyncLoadBalancer(state interface{}) error {
+func (lbc *LoadBalancerController) SyncLoadBalancer(state interface{}) (err error) {
 	// We expect state to be a syncState
 	syncState, ok := state.(*syncState)
 	if !ok {
 		return fmt.Errorf("expected state type to be syncState, type was %T", state)
 	}
+	defer func() {
+		syncState.setCondition(annotations.FrontendSyncedCondition, err, frontendSyncErrorReason)
+	}()
 
 	lb, err := lbc.toRuntimeInfo(syncState.ing, syncState.urlMap)
 	if err != nil {
@@ -402,17 +654,19 @@ func (lbc *LoadBalancerController) SyncLoadBalancer(state interface{}) error {
 
 // GCLoadBalancers implements Controller.
 func (lbc *LoadBalancerController) GCLoadBalancers(state interface{}) error {
-	// We expect state to be a gcState
-	gcState, ok := state.(*gcState)
-	if !ok {
-		return fmt.Errorf("expected state type to be gcState, type was %T", state)
-	}
-
-	if err := lbc.l7Pool.GC(gcState.lbNames); err != nil {
-		return err
+	// We expect state to be a gcState or a fullGCState
+	switch gcState := state.(type) {
+	case *gcState:
+		if !gcState.deleteLB {
+			return nil
+		}
+		lbc.pruneCertProblems()
+		return lbc.l7Pool.Delete(gcState.key)
+	case *fullGCState:
+		lbc.pruneCertProblems()
+		return lbc.l7Pool.GC(gcState.lbNames)
 	}
-
-	return nil
+	return fmt.Errorf("expected state type to be gcState or fullGCState, type was %T", state)
 }
 
 // PostProcess implements Controller.
@@ -424,7 +678,9 @@ func (lbc *LoadBalancerController) PostProcess(state interface{}) error {
 	}
 
 	// Update the ingress status.
-	return lbc.updateIngressStatus(syncState.l7, syncState.ing)
+	err := lbc.updateIngressStatus(syncState.l7, syncState.ing)
+	syncState.setCondition(annotations.ProgrammedCondition, err, statusUpdateErrorReason)
+	return err
 }
 
 // sync manages Ingress create/updates/deletes events from queue.
@@ -435,24 +691,22 @@ func (lbc *LoadBalancerController) sync(key string) error {
 	}
 	klog.V(3).Infof("Syncing %v", key)
 
-	// Create state needed for GC.
-	gceIngresses := operator.Ingresses(lbc.ctx.Ingresses().List()).Filter(func(ing *extensions.Ingress) bool {
-		return utils.IsGCEIngress(ing)
-	}).AsList()
-
-	// gceSvcPorts contains the ServicePorts used by only single-cluster ingress.
-	gceSvcPorts := lbc.ToSvcPorts(gceIngresses)
-	lbNames := lbc.ctx.Ingresses().ListKeys()
+	// The backends of a single Ingress are only collected once the
+	// Ingresses using each backend are known.
+	if err := lbc.seedSvcPortRefs(); err != nil {
+		return fmt.Errorf("error during GC of all Ingresses: %v", err)
+	}
+	lbc.gcLock.RLock()
+	defer lbc.gcLock.RUnlock()
 
 	ing, ingExists, err := lbc.ctx.Ingresses().GetByKey(key)
 	if err != nil {
 		return fmt.Errorf("error getting Ingress for key %s: %v", key, err)
 	}
-	gcState := &gcState{lbc.ctx.Ingresses().List(), lbNames, gceSvcPorts}
 	if !ingExists || utils.IsDeletionCandidate(ing.ObjectMeta, utils.FinalizerKey) {
 		klog.V(2).Infof("Ingress %q no longer exists, triggering GC", key)
 		// GC will find GCE resources that were used for this ingress and delete them.
-		return lbc.ingSyncer.GC(gcState)
+		return lbc.ingSyncer.GC(&gcState{key: key, ing: ing, deleteLB: true})
 	}
 
 	// Get ingress and DeepCopy for assurance that we don't pollute other goroutines with changes.
@@ -468,34 +722,45 @@ func (lbc *LoadBalancerController) sync(key string) error {
 	// Check if ingress class was changed to non-GLBC to remove ingress LB from state and trigger GC
 	if !utils.IsGLBCIngress(ing) {
 		klog.V(2).Infof("Ingress %q class was changed, triggering GC", key)
-		// Remove lb from state for GC
-		gcState.lbNames = slice.RemoveString(gcState.lbNames, key, nil)
-		if gcErr := lbc.ingSyncer.GC(gcState); gcErr != nil {
-			return gcErr
-		}
-
-		return nil
+		return lbc.ingSyncer.GC(&gcState{key: key, ing: ing, deleteLB: true})
 	}
 
 	// Bootstrap state for GCP sync.
+	syncState := &syncState{ing: ing}
 	urlMap, errs := lbc.Translator.TranslateIngress(ing, lbc.ctx.DefaultBackendSvcPortID)
 	if errs != nil {
 		msg := fmt.Errorf("error while evaluating the ingress spec: %v", utils.JoinErrs(errs))
 		lbc.ctx.Recorder(ing.Namespace).Eventf(ing, apiv1.EventTypeWarning, "Translate", msg.Error())
+		syncState.setCondition(annotations.TranslatedCondition, msg, translationErrorReason)
+		// The GCE resources are not synced, so their last state is unknown.
+		syncState.setUnknown(translationErrorReason, annotations.BackendsSyncedCondition, annotations.FrontendSyncedCondition)
+		if err := updateConditions(lbc.ctx.KubeClient, ing, syncState.conditions); err != nil {
+			klog.Errorf("Failed to update conditions of Ingress %q: %v", key, err)
+		}
 		return msg
 	}
+	syncState.setCondition(annotations.TranslatedCondition, nil, "")
+	syncState.urlMap = urlMap
+	// The backends of multi-cluster Ingresses are not managed by the controller.
+	var svcPorts []utils.ServicePort
+	if utils.IsGCEIngress(ing) {
+		svcPorts = urlMap.AllServicePorts()
+		lbc.svcPortRefs.acquire(key, svcPorts)
+	}
 
 	// Sync GCP resources.
-	syncState := &syncState{urlMap, ing, nil}
 	syncErr := lbc.ingSyncer.Sync(syncState)
 	if syncErr != nil {
 		lbc.ctx.Recorder(ing.Namespace).Eventf(ing, apiv1.EventTypeWarning, "Sync", fmt.Sprintf("Error during sync: %v", syncErr.Error()))
 	}
+	if err := updateConditions(lbc.ctx.KubeClient, ing, syncState.conditions); err != nil && syncErr == nil {
+		syncErr = fmt.Errorf("error updating conditions: %v", err)
+	}
 
 	// Garbage collection will occur regardless of an error occurring. If an error occurred,
 	// it could have been caused by quota issues; therefore, garbage collecting now may
 	// free up enough quota for the next sync to pass.
-	if gcErr := lbc.ingSyncer.GC(gcState); gcErr != nil {
+	if gcErr := lbc.ingSyncer.GC(&gcState{key: key, ing: ing, svcPorts: svcPorts}); gcErr != nil {
 		return fmt.Errorf("error during sync %v, error during GC %v", syncErr, gcErr)
 	}
 
@@ -513,23 +778,27 @@ func (lbc *LoadBalancerController) updateIngressStatus(l7 *loadbalancers.L7, ing
 	if err != nil {
 		return err
 	}
+	lbIngress := []apiv1.LoadBalancerIngress{{IP: ip}}
+	ips := ip
+	// The IPv6 address is reported after the IPv4 address.
+	if ipv6 := l7.GetIPv6(); ipv6 != "" {
+		lbIngress = append(lbIngress, apiv1.LoadBalancerIngress{IP: ipv6})
+		ips = fmt.Sprintf("%v, %v", ip, ipv6)
+	}
 	currIng.Status = extensions.IngressStatus{
 		LoadBalancer: apiv1.LoadBalancerStatus{
-			Ingress: []apiv1.LoadBalancerIngress{
-				{IP: ip},
-			},
+			Ingress: lbIngress,
 		},
 	}
 	if ip != "" {
-		lbIPs := ing.Status.LoadBalancer.Ingress
-		if len(lbIPs) == 0 || lbIPs[0].IP != ip {
+		if !reflect.DeepEqual(ing.Status.LoadBalancer.Ingress, lbIngress) {
 			// TODO: If this update fails it's probably resource version related,
 			// which means it's advantageous to retry right away vs requeuing.
-			klog.Infof("Updating loadbalancer %v/%v with IP %v", ing.Namespace, ing.Name, ip)
+			klog.Infof("Updating loadbalancer %v/%v with IP %v", ing.Namespace, ing.Name, ips)
 			if _, err := ingClient.UpdateStatus(currIng); err != nil {
 				return err
 			}
-			lbc.ctx.Recorder(ing.Namespace).Eventf(currIng, apiv1.EventTypeNormal, "CREATE", "ip: %v", ip)
+			lbc.ctx.Recorder(ing.Namespace).Eventf(currIng, apiv1.EventTypeNormal, "CREATE", "ip: %v", ips)
 		}
 	}
 	annotations, err := loadbalancers.GetLBAnnotations(l7, currIng.Annotations, lbc.backendSyncer)
@@ -569,15 +838,40 @@ func (lbc *LoadBalancerController) toRuntimeInfo(ing *extensions.Ingress, urlMap
 		}
 	}
 
-	return &loadbalancers.L7RuntimeInfo{
-		Name:         k,
-		TLS:          tls,
-		TLSName:      annotations.UseNamedTLS(),
-		Ingress:      ing,
-		AllowHTTP:    annotations.AllowHTTP(),
-		StaticIPName: annotations.StaticIPName(),
-		UrlMap:       urlMap,
-	}, nil
+	var feConfig *frontendconfigv1beta1.FrontendConfig
+	if lbc.ctx.FrontendConfigInformer != nil {
+		feConfig, err = frontendconfig.FrontendConfigForIngress(lbc.ctx.FrontendConfigInformer.GetStore(), ing)
+		if err != nil {
+			return nil, fmt.Errorf("error getting FrontendConfig %q for Ingress %v: %v", annotations.FrontendConfig(), k, err)
+		}
+		if err := frontendconfig.Validate(feConfig); err != nil {
+			return nil, fmt.Errorf("invalid FrontendConfig %q for Ingress %v: %v", annotations.FrontendConfig(), k, err)
+		}
+	}
+
+	ri := &loadbalancers.L7RuntimeInfo{
+		Name:               k,
+		TLS:                tls,
+		TLSName:            annotations.UseNamedTLS(),
+		Ingress:            ing,
+		AllowHTTP:          annotations.AllowHTTP(),
+		StaticIPName:       annotations.StaticIPName(),
+		UrlMap:             urlMap,
+		FrontendConfig:     feConfig,
+		ManagedCertDomains: managedCertDomains(ing),
+		IPv6:               annotations.EnableIPv6(),
+	}
+	if utils.IsGCEL7ILBIngress(ing) {
+		ri.Internal = true
+		ri.StaticIPName = annotations.RegionalStaticIPName()
+		ri.Network = lbc.ctx.Cloud.NetworkURL()
+		ri.Subnetwork = lbc.ctx.Cloud.SubnetworkURL()
+		if subnet := annotations.InternalSubnetwork(); subnet != "" {
+			resourceID := cloud.ResourceID{Resource: "subnetworks", Key: meta.RegionalKey(subnet, lbc.ctx.Cloud.Region())}
+			ri.Subnetwork = resourceID.ResourcePath()
+		}
+	}
+	return ri, nil
 }
 
 func updateAnnotations(client kubernetes.Interface, name, namespace string, annotations map[string]string) error {
@@ -596,13 +890,136 @@ func updateAnnotations(client kubernetes.Interface, name, namespace string, anno
 	return nil
 }
 
-// ToSvcPorts is a helper method over translator.TranslateIngress to process a list of ingresses.
-// Note: This method is used for GC.
-func (lbc *LoadBalancerController) ToSvcPorts(ings []*extensions.Ingress) []utils.ServicePort {
-	var knownPorts []utils.ServicePort
+// updateConditions merges the conditions recorded during a sync into the
+// conditions annotation of the Ingress.
+func updateConditions(client kubernetes.Interface, ing *extensions.Ingress, conditions []annotations.IngressCondition) error {
+	ingClient := client.Extensions().Ingresses(ing.Namespace)
+	currIng, err := ingClient.Get(ing.Name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	// Conditions which cannot be parsed are overwritten.
+	merged, _ := annotations.FromIngress(currIng).Conditions()
+	for _, c := range conditions {
+		merged = annotations.SetCondition(merged, c)
+	}
+	b, err := json.Marshal(merged)
+	if err != nil {
+		return err
+	}
+	if currIng.Annotations[annotations.ConditionsKey] == string(b) {
+		return nil
+	}
+	klog.V(3).Infof("Updating conditions of %v/%v", ing.Namespace, ing.Name)
+	if currIng.Annotations == nil {
+		currIng.Annotations = map[string]string{}
+	}
+	currIng.Annotations[annotations.ConditionsKey] = string(b)
+	_, err = ingClient.Update(currIng)
+	return err
+}
+
+// gc garbage collects the resources of all Ingresses. It is a safety net for
+// resources which the GC of a single Ingress missed, such as those of
+// Ingresses deleted while the controller was down.
+func (lbc *LoadBalancerController) gc() {
+	if !lbc.hasSynced() {
+		return
+	}
+	lbc.gcLock.Lock()
+	defer lbc.gcLock.Unlock()
+	if err := lbc.fullGC(); err != nil {
+		klog.Errorf("Failed to garbage collect the resources of all Ingresses: %v", err)
+	}
+}
+
+// seedSvcPortRefs runs a full GC unless one already set the Ingresses using
+// each backend.
+func (lbc *LoadBalancerController) seedSvcPortRefs() error {
+	if lbc.svcPortRefs.isSeeded() {
+		return nil
+	}
+	lbc.gcLock.Lock()
+	defer lbc.gcLock.Unlock()
+	// Another worker may have run a full GC while this one waited.
+	if lbc.svcPortRefs.isSeeded() {
+		return nil
+	}
+	return lbc.fullGC()
+}
+
+// fullGC garbage collects the resources of all Ingresses and resets the
+// Ingresses using each backend. The caller must hold gcLock for writing.
+func (lbc *LoadBalancerController) fullGC() error {
+	ings := lbc.ctx.Ingresses().List()
+	// svcPorts contains the ServicePorts used by only single-cluster ingress.
+	svcPorts := map[string][]utils.ServicePort{}
 	for _, ing := range ings {
+		if !utils.IsGCEIngress(ing) {
+			continue
+		}
 		urlMap, _ := lbc.Translator.TranslateIngress(ing, lbc.ctx.DefaultBackendSvcPortID)
-		knownPorts = append(knownPorts, urlMap.AllServicePorts()...)
+		svcPorts[utils.IngressKeyFunc(ing)] = urlMap.AllServicePorts()
+	}
+	return lbc.ingSyncer.GC(&fullGCState{
+		ingresses: ings,
+		lbNames:   lbc.ctx.Ingresses().ListKeys(),
+		svcPorts:  svcPorts,
+	})
+}
+
+// Plan syncs every Ingress once and then garbage collects the resources of
+// all Ingresses, without updating the Ingresses. It is used with Providers
+// which record the changes to GCE resources rather than making them, to
+// report what the controller would change. Multi-cluster Ingresses, whose
+// sync only updates the Ingress, are skipped.
+func (lbc *LoadBalancerController) Plan() error {
+	if !lbc.hasSynced() {
+		return fmt.Errorf("stores have not synced")
 	}
-	return knownPorts
+	lbc.gcLock.Lock()
+	defer lbc.gcLock.Unlock()
+
+	ings := lbc.ctx.Ingresses().List()
+	sort.Slice(ings, func(i, j int) bool {
+		return utils.IngressKeyFunc(ings[i]) < utils.IngressKeyFunc(ings[j])
+	})
+	var errs []error
+	var lbNames []string
+	svcPorts := map[string][]utils.ServicePort{}
+	for _, ing := range ings {
+		key := utils.IngressKeyFunc(ing)
+		// The load balancers of the other Ingresses are deleted by the GC.
+		if !utils.IsGLBCIngress(ing) || utils.IsDeletionCandidate(ing.ObjectMeta, utils.FinalizerKey) {
+			continue
+		}
+		lbNames = append(lbNames, key)
+		if !utils.IsGCEIngress(ing) {
+			continue
+		}
+		urlMap, translateErrs := lbc.Translator.TranslateIngress(ing, lbc.ctx.DefaultBackendSvcPortID)
+		svcPorts[key] = urlMap.AllServicePorts()
+		if translateErrs != nil {
+			errs = append(errs, fmt.Errorf("error while evaluating the spec of Ingress %s: %v", key, utils.JoinErrs(translateErrs)))
+			continue
+		}
+		syncState := &syncState{ing: ing.DeepCopy(), urlMap: urlMap}
+		if err := lbc.SyncBackends(syncState); err != nil {
+			errs = append(errs, fmt.Errorf("error syncing backends of Ingress %s: %v", key, err))
+			continue
+		}
+		if err := lbc.SyncLoadBalancer(syncState); err != nil {
+			errs = append(errs, fmt.Errorf("error syncing load balancer of Ingress %s: %v", key, err))
+		}
+	}
+
+	// The Ingresses are left out of the state so that their finalizers are
+	// not removed.
+	if err := lbc.ingSyncer.GC(&fullGCState{lbNames: lbNames, svcPorts: svcPorts}); err != nil {
+		errs = append(errs, fmt.Errorf("error during GC of all Ingresses: %v", err))
+	}
+	if len(errs) > 0 {
+		return utils.JoinErrs(errs)
+	}
+	return nil
 }
@@ -17,10 +17,13 @@ limitations under the License.
 package controller
 
 import (
+	"fmt"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
 	api_v1 "k8s.io/api/core/v1"
 	extensions "k8s.io/api/extensions/v1beta1"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -28,11 +31,18 @@ import (
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/ingress-gce/pkg/annotations"
+	backendconfigv1 "k8s.io/ingress-gce/pkg/apis/backendconfig/v1"
+	frontendconfigv1beta1 "k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1"
 	backendconfigclient "k8s.io/ingress-gce/pkg/backendconfig/client/clientset/versioned/fake"
+	"k8s.io/ingress-gce/pkg/backends"
+	"k8s.io/ingress-gce/pkg/composite"
 	"k8s.io/ingress-gce/pkg/events"
+	frontendconfigclient "k8s.io/ingress-gce/pkg/frontendconfig/client/clientset/versioned/fake"
 	"k8s.io/ingress-gce/pkg/instances"
 	"k8s.io/ingress-gce/pkg/loadbalancers"
 	"k8s.io/ingress-gce/pkg/test"
+	"k8s.io/ingress-gce/pkg/tls"
 	"k8s.io/ingress-gce/pkg/utils"
 	"k8s.io/kubernetes/pkg/cloudprovider/providers/gce"
 
@@ -49,6 +59,7 @@ var (
 func newLoadBalancerController() *LoadBalancerController {
 	kubeClient := fake.NewSimpleClientset()
 	backendConfigClient := backendconfigclient.NewSimpleClientset()
+	frontendConfigClient := frontendconfigclient.NewSimpleClientset()
 	fakeGCE := gce.NewFakeGCECloud(gce.DefaultTestClusterValues())
 	namer := utils.NewNamer(clusterUID, "")
 
@@ -60,7 +71,7 @@ func newLoadBalancerController() *LoadBalancerController {
 		HealthCheckPath:               "/",
 		DefaultBackendHealthCheckPath: "/healthz",
 	}
-	ctx := context.NewControllerContext(kubeClient, backendConfigClient, fakeGCE, namer, ctxConfig)
+	ctx := context.NewControllerContext(kubeClient, backendConfigClient, frontendConfigClient, fakeGCE, namer, ctxConfig)
 	lbc := NewLoadBalancerController(ctx, stopCh)
 	// TODO(rramkumar): Fix this so we don't have to override with our fake
 	lbc.instancePool = instances.NewNodePool(instances.NewFakeInstanceGroups(sets.NewString(), namer), namer)
@@ -160,6 +171,394 @@ func TestIngressSyncError(t *testing.T) {
 	}
 }
 
+// TestIngressFrontendConfig asserts that `sync` fails for an Ingress
+// referencing a missing or invalid FrontendConfig, and succeeds once the
+// FrontendConfig is valid.
+func TestIngressFrontendConfig(t *testing.T) {
+	lbc := newLoadBalancerController()
+
+	svc := test.NewService(types.NamespacedName{Name: "my-service", Namespace: "default"}, api_v1.ServiceSpec{
+		Type:  api_v1.ServiceTypeNodePort,
+		Ports: []api_v1.ServicePort{{Port: 80}},
+	})
+	addService(lbc, svc)
+
+	defaultBackend := backend("my-service", intstr.FromInt(80))
+	ing := test.NewIngress(types.NamespacedName{Name: "my-ingress", Namespace: "default"},
+		extensions.IngressSpec{
+			Backend: &defaultBackend,
+		})
+	ing.ObjectMeta.Annotations = map[string]string{annotations.FrontendConfigKey: "my-config"}
+	addIngress(lbc, ing)
+
+	ingStoreKey := getKey(ing, t)
+	if err := lbc.sync(ingStoreKey); err == nil || !strings.Contains(err.Error(), "FrontendConfig") {
+		t.Fatalf("lbc.sync(%v) = %v, want FrontendConfig error", ingStoreKey, err)
+	}
+
+	quicOverride := "AUTO"
+	feConfig := &frontendconfigv1beta1.FrontendConfig{
+		ObjectMeta: meta_v1.ObjectMeta{Name: "my-config", Namespace: "default"},
+		Spec:       frontendconfigv1beta1.FrontendConfigSpec{QuicOverride: &quicOverride},
+	}
+	lbc.ctx.FrontendConfigInformer.GetIndexer().Add(feConfig)
+	if err := lbc.sync(ingStoreKey); err == nil || !strings.Contains(err.Error(), "QuicOverride") {
+		t.Fatalf("lbc.sync(%v) = %v, want QuicOverride error", ingStoreKey, err)
+	}
+
+	quicOverride = "ENABLE"
+	if err := lbc.sync(ingStoreKey); err != nil {
+		t.Fatalf("lbc.sync(%v) = %v, want nil", ingStoreKey, err)
+	}
+}
+
+// TestIngressConditions asserts that `sync` records the conditions of each
+// phase in the conditions annotation of the Ingress.
+func TestIngressConditions(t *testing.T) {
+	lbc := newLoadBalancerController()
+
+	defaultBackend := backend("my-service", intstr.FromInt(80))
+	ing := test.NewIngress(types.NamespacedName{Name: "my-ingress", Namespace: "default"},
+		extensions.IngressSpec{
+			Backend: &defaultBackend,
+		})
+	addIngress(lbc, ing)
+
+	ingStoreKey := getKey(ing, t)
+	getConditions := func() (string, map[annotations.IngressConditionType]annotations.IngressCondition) {
+		t.Helper()
+		updatedIng, _ := lbc.ctx.KubeClient.Extensions().Ingresses(ing.Namespace).Get(ing.Name, meta_v1.GetOptions{})
+		conditions, err := annotations.FromIngress(updatedIng).Conditions()
+		if err != nil {
+			t.Fatalf("Conditions() = _, %v", err)
+		}
+		byType := map[annotations.IngressConditionType]annotations.IngressCondition{}
+		for _, c := range conditions {
+			byType[c.Type] = c
+		}
+		return updatedIng.Annotations[annotations.ConditionsKey], byType
+	}
+	checkStatus := func(conditions map[annotations.IngressConditionType]annotations.IngressCondition, want map[annotations.IngressConditionType]api_v1.ConditionStatus) {
+		t.Helper()
+		if len(conditions) != len(want) {
+			t.Errorf("conditions = %+v, want %v", conditions, want)
+		}
+		for conditionType, status := range want {
+			if got := conditions[conditionType]; got.Status != status {
+				t.Errorf("condition %v = %+v, want status %v", conditionType, got, status)
+			}
+		}
+	}
+
+	// The Service is missing.
+	if err := lbc.sync(ingStoreKey); err == nil {
+		t.Fatalf("lbc.sync(%v) = nil, want error", ingStoreKey)
+	}
+	_, conditions := getConditions()
+	checkStatus(conditions, map[annotations.IngressConditionType]api_v1.ConditionStatus{
+		annotations.TranslatedCondition:     api_v1.ConditionFalse,
+		annotations.BackendsSyncedCondition: api_v1.ConditionUnknown,
+		annotations.FrontendSyncedCondition: api_v1.ConditionUnknown,
+		annotations.ProgrammedCondition:     api_v1.ConditionFalse,
+	})
+	if c := conditions[annotations.TranslatedCondition]; c.Reason != translationErrorReason || !strings.Contains(c.Message, "my-service") {
+		t.Errorf("condition %v = %+v, want reason %v and a message about my-service", c.Type, c, translationErrorReason)
+	}
+
+	svc := test.NewService(types.NamespacedName{Name: "my-service", Namespace: "default"}, api_v1.ServiceSpec{
+		Type:  api_v1.ServiceTypeNodePort,
+		Ports: []api_v1.ServicePort{{Port: 80}},
+	})
+	addService(lbc, svc)
+	if err := lbc.sync(ingStoreKey); err != nil {
+		t.Fatalf("lbc.sync(%v) = %v", ingStoreKey, err)
+	}
+	synced, conditions := getConditions()
+	checkStatus(conditions, map[annotations.IngressConditionType]api_v1.ConditionStatus{
+		annotations.TranslatedCondition:     api_v1.ConditionTrue,
+		annotations.BackendsSyncedCondition: api_v1.ConditionTrue,
+		annotations.FrontendSyncedCondition: api_v1.ConditionTrue,
+		annotations.ProgrammedCondition:     api_v1.ConditionTrue,
+	})
+
+	// An unchanged sync leaves the annotation as is.
+	if err := lbc.sync(ingStoreKey); err != nil {
+		t.Fatalf("lbc.sync(%v) = %v", ingStoreKey, err)
+	}
+	if resynced, _ := getConditions(); resynced != synced {
+		t.Errorf("conditions = %v after an unchanged sync, want %v", resynced, synced)
+	}
+
+	// The FrontendConfig is missing.
+	ing.ObjectMeta.Annotations = map[string]string{annotations.FrontendConfigKey: "my-config"}
+	updateIngress(lbc, ing)
+	if err := lbc.sync(ingStoreKey); err == nil {
+		t.Fatalf("lbc.sync(%v) = nil, want error", ingStoreKey)
+	}
+	_, conditions = getConditions()
+	checkStatus(conditions, map[annotations.IngressConditionType]api_v1.ConditionStatus{
+		annotations.TranslatedCondition:     api_v1.ConditionTrue,
+		annotations.BackendsSyncedCondition: api_v1.ConditionTrue,
+		annotations.FrontendSyncedCondition: api_v1.ConditionFalse,
+		annotations.ProgrammedCondition:     api_v1.ConditionFalse,
+	})
+	if c := conditions[annotations.ProgrammedCondition]; c.Reason != frontendSyncErrorReason {
+		t.Errorf("condition %v = %+v, want reason %v", c.Type, c, frontendSyncErrorReason)
+	}
+
+	// A failure whose message alone changed is written.
+	changed := syncState{}
+	changed.setCondition(annotations.FrontendSyncedCondition, fmt.Errorf("another error"), frontendSyncErrorReason)
+	if err := updateConditions(lbc.ctx.KubeClient, ing, changed.conditions); err != nil {
+		t.Fatalf("updateConditions() = %v", err)
+	}
+	if _, conditions = getConditions(); !strings.Contains(conditions[annotations.FrontendSyncedCondition].Message, "another error") {
+		t.Errorf("condition %v = %+v, want the message of the last failure", annotations.FrontendSyncedCondition, conditions[annotations.FrontendSyncedCondition])
+	}
+
+	// Once the Service is deleted, the GCE resources synced before are no
+	// longer known to be up to date.
+	lbc.ctx.ServiceInformer.GetIndexer().Delete(svc)
+	if err := lbc.sync(ingStoreKey); err == nil {
+		t.Fatalf("lbc.sync(%v) = nil, want error", ingStoreKey)
+	}
+	_, conditions = getConditions()
+	checkStatus(conditions, map[annotations.IngressConditionType]api_v1.ConditionStatus{
+		annotations.TranslatedCondition:     api_v1.ConditionFalse,
+		annotations.BackendsSyncedCondition: api_v1.ConditionUnknown,
+		annotations.FrontendSyncedCondition: api_v1.ConditionUnknown,
+		annotations.ProgrammedCondition:     api_v1.ConditionFalse,
+	})
+}
+
+type fakeQueue struct {
+	objs []interface{}
+}
+
+func (q *fakeQueue) Run()                        {}
+func (q *fakeQueue) Shutdown()                   {}
+func (q *fakeQueue) Enqueue(objs ...interface{}) { q.objs = append(q.objs, objs...) }
+
+// TestSecretEnqueuesIngresses asserts that a change to a TLS secret only
+// enqueues the Ingresses which reference it.
+func TestSecretEnqueuesIngresses(t *testing.T) {
+	lbc := newLoadBalancerController()
+	queue := &fakeQueue{}
+	lbc.ingQueue = queue
+
+	newTLSIngress := func(name, namespace string, secrets ...string) *extensions.Ingress {
+		ing := test.NewIngress(types.NamespacedName{Name: name, Namespace: namespace}, extensions.IngressSpec{})
+		for _, secret := range secrets {
+			ing.Spec.TLS = append(ing.Spec.TLS, extensions.IngressTLS{SecretName: secret})
+		}
+		return ing
+	}
+	ing1 := newTLSIngress("ing-1", "default", "cert-a", "cert-b")
+	ing2 := newTLSIngress("ing-2", "default", "cert-a")
+	addIngress(lbc, ing1)
+	addIngress(lbc, ing2)
+	// Ingresses in other namespaces cannot reference the secret.
+	addIngress(lbc, newTLSIngress("ing-3", "other", "cert-a"))
+
+	for _, tc := range []struct {
+		secret   string
+		wantIngs []string
+	}{
+		{secret: "cert-a", wantIngs: []string{"default/ing-1", "default/ing-2"}},
+		{secret: "cert-b", wantIngs: []string{"default/ing-1"}},
+		{secret: "cert-c"},
+	} {
+		queue.objs = nil
+		secret := &api_v1.Secret{
+			ObjectMeta: meta_v1.ObjectMeta{Name: tc.secret, Namespace: "default"},
+			Type:       api_v1.SecretTypeTLS,
+		}
+		lbc.enqueueIngressesForSecret(secret)
+		var got []string
+		for _, obj := range queue.objs {
+			got = append(got, getKey(obj.(*extensions.Ingress), t))
+		}
+		if !sets.NewString(got...).Equal(sets.NewString(tc.wantIngs...)) || len(got) != len(tc.wantIngs) {
+			t.Errorf("enqueueIngressesForSecret(%v) enqueued %v, want %v", tc.secret, got, tc.wantIngs)
+		}
+	}
+}
+
+// TestBackendConfigUpdateEnqueuesIngresses asserts that only changes to the
+// spec of a BackendConfig resync the Ingresses referencing it, since its
+// status is written by their sync.
+func TestBackendConfigUpdateEnqueuesIngresses(t *testing.T) {
+	lbc := newLoadBalancerController()
+	queue := &fakeQueue{}
+	lbc.ingQueue = queue
+
+	svc := test.NewService(types.NamespacedName{Name: "svc", Namespace: "default"}, api_v1.ServiceSpec{
+		Type:  api_v1.ServiceTypeNodePort,
+		Ports: []api_v1.ServicePort{{Port: 80}},
+	})
+	svc.Annotations = map[string]string{annotations.BackendConfigKey: `{"default":"config"}`}
+	addService(lbc, svc)
+	addIngress(lbc, test.NewIngress(types.NamespacedName{Name: "ing", Namespace: "default"}, extensions.IngressSpec{
+		Backend: &extensions.IngressBackend{ServiceName: "svc", ServicePort: intstr.FromInt(80)},
+	}))
+
+	old := test.NewBackendConfig(types.NamespacedName{Name: "config", Namespace: "default"}, backendconfigv1.BackendConfigSpec{})
+	old.Generation = 1
+	timeout := int64(60)
+	for _, tc := range []struct {
+		desc        string
+		mutate      func(*backendconfigv1.BackendConfig)
+		wantEnqueue bool
+	}{
+		{
+			desc: "status update",
+			mutate: func(beConfig *backendconfigv1.BackendConfig) {
+				beConfig.Status.ObservedGeneration = 1
+			},
+		},
+		{
+			desc: "spec update",
+			mutate: func(beConfig *backendconfigv1.BackendConfig) {
+				beConfig.Generation = 2
+				beConfig.Spec.TimeoutSec = &timeout
+			},
+			wantEnqueue: true,
+		},
+	} {
+		queue.objs = nil
+		cur := old.DeepCopy()
+		tc.mutate(cur)
+		lbc.backendConfigUpdated(old, cur)
+		if gotEnqueue := len(queue.objs) > 0; gotEnqueue != tc.wantEnqueue {
+			t.Errorf("%s: backendConfigUpdated() enqueued %d Ingresses, want enqueue = %v", tc.desc, len(queue.objs), tc.wantEnqueue)
+		}
+	}
+}
+
+// TestIngressUpdateEnqueuesIngress asserts that an update of an Ingress
+// resyncs it, unless only its conditions annotation changed, which is
+// written by its sync.
+func TestIngressUpdateEnqueuesIngress(t *testing.T) {
+	lbc := newLoadBalancerController()
+	queue := &fakeQueue{}
+	lbc.ingQueue = queue
+
+	defaultBackend := backend("my-service", intstr.FromInt(80))
+	old := test.NewIngress(types.NamespacedName{Name: "my-ingress", Namespace: "default"}, extensions.IngressSpec{
+		Backend: &defaultBackend,
+	})
+	old.ResourceVersion = "1"
+	old.Annotations = map[string]string{annotations.ConditionsKey: "[]"}
+	for _, tc := range []struct {
+		desc        string
+		mutate      func(*extensions.Ingress)
+		wantEnqueue bool
+	}{
+		{
+			desc:        "periodic resync",
+			mutate:      func(ing *extensions.Ingress) {},
+			wantEnqueue: true,
+		},
+		{
+			desc: "conditions update",
+			mutate: func(ing *extensions.Ingress) {
+				ing.ResourceVersion = "2"
+				ing.Annotations[annotations.ConditionsKey] = `[{"type":"Programmed","status":"True"}]`
+			},
+		},
+		{
+			desc: "conditions removed",
+			mutate: func(ing *extensions.Ingress) {
+				ing.ResourceVersion = "2"
+				ing.Annotations = nil
+			},
+		},
+		{
+			desc: "conditions and spec update",
+			mutate: func(ing *extensions.Ingress) {
+				ing.ResourceVersion = "2"
+				ing.Annotations[annotations.ConditionsKey] = `[{"type":"Programmed","status":"True"}]`
+				ing.Spec.Backend.ServicePort = intstr.FromInt(8080)
+			},
+			wantEnqueue: true,
+		},
+		{
+			desc: "annotation update",
+			mutate: func(ing *extensions.Ingress) {
+				ing.ResourceVersion = "2"
+				ing.Annotations[annotations.AllowHTTPKey] = "false"
+			},
+			wantEnqueue: true,
+		},
+	} {
+		queue.objs = nil
+		cur := old.DeepCopy()
+		tc.mutate(cur)
+		lbc.ingressUpdated(old, cur)
+		if gotEnqueue := len(queue.objs) > 0; gotEnqueue != tc.wantEnqueue {
+			t.Errorf("%s: ingressUpdated() enqueued %d Ingresses, want enqueue = %v", tc.desc, len(queue.objs), tc.wantEnqueue)
+		}
+	}
+}
+
+// TestIngressIPv6Status asserts that the status of an Ingress which opts in
+// to IPv6 reports both its IPv4 and IPv6 addresses.
+func TestIngressIPv6Status(t *testing.T) {
+	lbc := newLoadBalancerController()
+	svc := test.NewService(types.NamespacedName{Name: "my-service", Namespace: "default"}, api_v1.ServiceSpec{
+		Type:  api_v1.ServiceTypeNodePort,
+		Ports: []api_v1.ServicePort{{Port: 80}},
+	})
+	addService(lbc, svc)
+
+	defaultBackend := backend("my-service", intstr.FromInt(80))
+	ing := test.NewIngress(types.NamespacedName{Name: "my-ingress", Namespace: "default"},
+		extensions.IngressSpec{
+			Backend: &defaultBackend,
+		})
+	ing.ObjectMeta.Annotations = map[string]string{annotations.EnableIPv6Key: "true"}
+	addIngress(lbc, ing)
+
+	ingStoreKey := getKey(ing, t)
+	if err := lbc.sync(ingStoreKey); err != nil {
+		t.Fatalf("lbc.sync(%v) = %v", ingStoreKey, err)
+	}
+	updatedIng, _ := lbc.ctx.KubeClient.Extensions().Ingresses(ing.Namespace).Get(ing.Name, meta_v1.GetOptions{})
+	lbIngress := updatedIng.Status.LoadBalancer.Ingress
+	if len(lbIngress) != 2 || strings.Contains(lbIngress[0].IP, ":") || !strings.Contains(lbIngress[1].IP, ":") {
+		t.Errorf("Get(%q) = status %+v, want an IPv4 and an IPv6 address", updatedIng.Name, lbIngress)
+	}
+}
+
+// TestToRuntimeInfoInternal asserts that a gce-internal Ingress is translated
+// to an internal loadbalancer on the subnetwork of its annotation.
+func TestToRuntimeInfoInternal(t *testing.T) {
+	flags.F.EnableL7Ilb = true
+	defer func() { flags.F.EnableL7Ilb = false }()
+	lbc := newLoadBalancerController()
+
+	ing := test.NewIngress(types.NamespacedName{Name: "my-ingress", Namespace: "default"}, extensions.IngressSpec{})
+	ing.ObjectMeta.Annotations = map[string]string{
+		annotations.IngressClassKey:         annotations.GceL7ILBIngressClass,
+		annotations.InternalSubnetworkKey:   "ilb-subnet",
+		annotations.StaticIPNameKey:         "global-ip",
+		annotations.RegionalStaticIPNameKey: "regional-ip",
+	}
+	ri, err := lbc.toRuntimeInfo(ing, utils.NewGCEURLMap())
+	if err != nil {
+		t.Fatalf("lbc.toRuntimeInfo() = _, %v", err)
+	}
+	if !ri.Internal {
+		t.Errorf("ri.Internal = false, want true")
+	}
+	if want := "regions/us-central1/subnetworks/ilb-subnet"; ri.Subnetwork != want {
+		t.Errorf("ri.Subnetwork = %q, want %q", ri.Subnetwork, want)
+	}
+	if ri.StaticIPName != "regional-ip" {
+		t.Errorf("ri.StaticIPName = %q, want %q", ri.StaticIPName, "regional-ip")
+	}
+}
+
 // TestIngressCreateDeleteFinalizer asserts that `sync` will will not return an
 // error for a good ingress config. It also tests garbage collection for
 // Ingresses that need to be deleted, and keep the ones that don't, depending
@@ -315,6 +714,297 @@ func TestIngressClassChange(t *testing.T) {
 	}
 }
 
+// TestIngressGC asserts that the sync of an Ingress only collects its own
+// resources, and that a full GC collects the resources missed by it.
+func TestIngressGC(t *testing.T) {
+	lbc := newLoadBalancerController()
+	for _, name := range []string{"svc-a", "svc-b"} {
+		addService(lbc, test.NewService(types.NamespacedName{Name: name, Namespace: "default"}, api_v1.ServiceSpec{
+			Type:  api_v1.ServiceTypeNodePort,
+			Ports: []api_v1.ServicePort{{Port: 80}},
+		}))
+	}
+	backendA := backend("svc-a", intstr.FromInt(80))
+	backendB := backend("svc-b", intstr.FromInt(80))
+	ing1 := test.NewIngress(types.NamespacedName{Name: "ing-1", Namespace: "default"}, extensions.IngressSpec{
+		Backend: &backendA,
+		Rules: []extensions.IngressRule{{
+			Host: "foo.com",
+			IngressRuleValue: extensions.IngressRuleValue{
+				HTTP: &extensions.HTTPIngressRuleValue{
+					Paths: []extensions.HTTPIngressPath{{Path: "/b", Backend: backendB}},
+				},
+			},
+		}},
+	})
+	ing2 := test.NewIngress(types.NamespacedName{Name: "ing-2", Namespace: "default"}, extensions.IngressSpec{
+		Backend: &backendA,
+	})
+	backendNames := map[string]string{}
+	for _, ing := range []*extensions.Ingress{ing1, ing2} {
+		addIngress(lbc, ing)
+		if err := lbc.sync(getKey(ing, t)); err != nil {
+			t.Fatalf("lbc.sync(%v) = err %v", getKey(ing, t), err)
+		}
+		urlMap, _ := lbc.Translator.TranslateIngress(ing, lbc.ctx.DefaultBackendSvcPortID)
+		for _, sp := range urlMap.AllServicePorts() {
+			backendNames[sp.ID.Service.Name] = sp.BackendName(lbc.ctx.ClusterNamer)
+		}
+	}
+
+	check := func(desc string, wantLBs []*extensions.Ingress, wantBackends ...string) {
+		t.Helper()
+		gotLBs, err := lbc.l7Pool.List()
+		if err != nil {
+			t.Fatalf("%s: l7Pool.List() = err %v", desc, err)
+		}
+		var want []string
+		for _, ing := range wantLBs {
+			want = append(want, lbc.ctx.ClusterNamer.LoadBalancer(getKey(ing, t)))
+		}
+		if !sets.NewString(gotLBs...).Equal(sets.NewString(want...)) {
+			t.Errorf("%s: got load balancers %v, want %v", desc, gotLBs, want)
+		}
+		wantBackendNames := sets.NewString()
+		for _, svc := range wantBackends {
+			wantBackendNames.Insert(backendNames[svc])
+		}
+		for svc, name := range backendNames {
+			_, err := lbc.ctx.Cloud.GetGlobalBackendService(name)
+			if exists := err == nil; exists != wantBackendNames.Has(name) {
+				t.Errorf("%s: backend of %v exists = %v, want %v", desc, svc, exists, !exists)
+			}
+		}
+	}
+	check("initial sync", []*extensions.Ingress{ing1, ing2}, "svc-a", "svc-b")
+
+	// svc-b is only used by ing-1.
+	ing1.Spec.Rules = nil
+	updateIngress(lbc, ing1)
+	if err := lbc.sync(getKey(ing1, t)); err != nil {
+		t.Fatalf("lbc.sync(%v) = err %v", getKey(ing1, t), err)
+	}
+	check("ing-1 updated", []*extensions.Ingress{ing1, ing2}, "svc-a")
+
+	// svc-a is still used by ing-2.
+	deleteIngress(lbc, ing1)
+	if err := lbc.sync(getKey(ing1, t)); err != nil {
+		t.Fatalf("lbc.sync(%v) = err %v", getKey(ing1, t), err)
+	}
+	check("ing-1 deleted", []*extensions.Ingress{ing2}, "svc-a")
+
+	// The deletion of ing-2 is missed by the sync and collected by a full GC.
+	deleteIngress(lbc, ing2)
+	lbc.gc()
+	check("full GC", nil)
+}
+
+// failingBackendSyncer fails to delete backends while failDelete is set.
+type failingBackendSyncer struct {
+	backends.Syncer
+	failDelete bool
+}
+
+func (s *failingBackendSyncer) Delete(svcPorts []utils.ServicePort) error {
+	if s.failDelete {
+		return fmt.Errorf("resource in use")
+	}
+	return s.Syncer.Delete(svcPorts)
+}
+
+// TestIngressGCRetriesBackends asserts that the backends of a deleted
+// Ingress which fail to be deleted are deleted by the next GC of the
+// Ingress.
+func TestIngressGCRetriesBackends(t *testing.T) {
+	lbc := newLoadBalancerController()
+	syncer := &failingBackendSyncer{Syncer: lbc.backendSyncer}
+	lbc.backendSyncer = syncer
+	addService(lbc, test.NewService(types.NamespacedName{Name: "my-service", Namespace: "default"}, api_v1.ServiceSpec{
+		Type:  api_v1.ServiceTypeNodePort,
+		Ports: []api_v1.ServicePort{{Port: 80}},
+	}))
+	defaultBackend := backend("my-service", intstr.FromInt(80))
+	ing := test.NewIngress(types.NamespacedName{Name: "my-ingress", Namespace: "default"}, extensions.IngressSpec{
+		Backend: &defaultBackend,
+	})
+	addIngress(lbc, ing)
+	ingStoreKey := getKey(ing, t)
+	if err := lbc.sync(ingStoreKey); err != nil {
+		t.Fatalf("lbc.sync(%v) = err %v", ingStoreKey, err)
+	}
+	urlMap, _ := lbc.Translator.TranslateIngress(ing, lbc.ctx.DefaultBackendSvcPortID)
+	var backendNames []string
+	for _, sp := range urlMap.AllServicePorts() {
+		if sp.ID.Service.Name == "my-service" {
+			backendNames = append(backendNames, sp.BackendName(lbc.ctx.ClusterNamer))
+		}
+	}
+	if len(backendNames) != 1 {
+		t.Fatalf("backends of my-service = %v, want 1 backend", backendNames)
+	}
+
+	deleteIngress(lbc, ing)
+	syncer.failDelete = true
+	if err := lbc.sync(ingStoreKey); err == nil {
+		t.Fatalf("lbc.sync(%v) = nil, want error", ingStoreKey)
+	}
+	if _, err := lbc.ctx.Cloud.GetGlobalBackendService(backendNames[0]); err != nil {
+		t.Fatalf("GetGlobalBackendService(%v) = %v, want the backend which failed to be deleted", backendNames[0], err)
+	}
+
+	syncer.failDelete = false
+	if err := lbc.sync(ingStoreKey); err != nil {
+		t.Fatalf("lbc.sync(%v) = err %v", ingStoreKey, err)
+	}
+	if _, err := lbc.ctx.Cloud.GetGlobalBackendService(backendNames[0]); err == nil {
+		t.Errorf("backend %v exists after the retried GC", backendNames[0])
+	}
+}
+
+// TestCertProblemsCleared asserts that the cert problems reported for a TLS
+// secret are cleared once no Ingress references it or it is deleted.
+func TestCertProblemsCleared(t *testing.T) {
+	lbc := newLoadBalancerController()
+	lbc.tlsLoader = &tls.TLSCertsFromSecretsLoader{Client: lbc.ctx.KubeClient}
+	addService(lbc, test.NewService(types.NamespacedName{Name: "my-service", Namespace: "default"}, api_v1.ServiceSpec{
+		Type:  api_v1.ServiceTypeNodePort,
+		Ports: []api_v1.ServicePort{{Port: 80}},
+	}))
+	defaultBackend := backend("my-service", intstr.FromInt(80))
+
+	// certProblemSet returns true if an invalid cert is reported for the
+	// secret.
+	certProblemSet := func(secret string) bool {
+		t.Helper()
+		families, err := prometheus.DefaultGatherer.Gather()
+		if err != nil {
+			t.Fatalf("Gather() = %v", err)
+		}
+		for _, family := range families {
+			if family.GetName() != "glbc_tls_cert_problems" {
+				continue
+			}
+			for _, m := range family.Metric {
+				labels := map[string]string{}
+				for _, l := range m.Label {
+					labels[l.GetName()] = l.GetValue()
+				}
+				if labels["secret"] == secret && labels["problem"] == "invalid_cert" {
+					return true
+				}
+			}
+		}
+		return false
+	}
+
+	var secrets []*api_v1.Secret
+	var ings []*extensions.Ingress
+	for _, name := range []string{"cert-a", "cert-b"} {
+		secret := &api_v1.Secret{
+			ObjectMeta: meta_v1.ObjectMeta{Name: name, Namespace: "default"},
+			Type:       api_v1.SecretTypeTLS,
+			Data: map[string][]byte{
+				api_v1.TLSCertKey:       []byte("not a cert"),
+				api_v1.TLSPrivateKeyKey: []byte("not a key"),
+			},
+		}
+		lbc.ctx.KubeClient.Core().Secrets("default").Create(secret)
+		secrets = append(secrets, secret)
+		ing := test.NewIngress(types.NamespacedName{Name: "ing-" + name, Namespace: "default"}, extensions.IngressSpec{
+			Backend: &defaultBackend,
+			TLS:     []extensions.IngressTLS{{SecretName: name}},
+		})
+		addIngress(lbc, ing)
+		lbc.sync(getKey(ing, t))
+		ings = append(ings, ing)
+		if !certProblemSet("default/" + name) {
+			t.Errorf("invalid cert of %v not reported after sync", name)
+		}
+	}
+
+	deleteIngress(lbc, ings[0])
+	if err := lbc.sync(getKey(ings[0], t)); err != nil {
+		t.Fatalf("lbc.sync(%v) = err %v", getKey(ings[0], t), err)
+	}
+	if certProblemSet("default/cert-a") {
+		t.Errorf("invalid cert of cert-a reported after its Ingress was deleted")
+	}
+	if !certProblemSet("default/cert-b") {
+		t.Errorf("invalid cert of cert-b not reported after another Ingress was deleted")
+	}
+
+	lbc.secretDeleted(secrets[1])
+	if certProblemSet("default/cert-b") {
+		t.Errorf("invalid cert of cert-b reported after it was deleted")
+	}
+}
+
+// gaLoadBalancers serves the url maps of the mock of the cloud, which does
+// not implement the alpha API, as url maps without alpha fields.
+type gaLoadBalancers struct {
+	loadbalancers.LoadBalancers
+}
+
+func (l gaLoadBalancers) GetAlphaURLMap(name string) (*composite.UrlMap, error) {
+	return nil, utils.FakeGoogleAPINotFoundErr()
+}
+
+// TestConcurrentIngressSyncs asserts that Ingresses sharing a Service can be
+// synced and deleted concurrently without deleting the shared backend.
+func TestConcurrentIngressSyncs(t *testing.T) {
+	lbc := newLoadBalancerController()
+	// Unlike the fake, the mock of the cloud is safe for concurrent use.
+	lbc.l7Pool = loadbalancers.NewLoadBalancerPool(gaLoadBalancers{loadbalancers.NewGCELoadBalancers(lbc.ctx.Cloud)}, lbc.ctx.ClusterNamer, events.RecorderProducerMock{})
+	addService(lbc, test.NewService(types.NamespacedName{Name: "my-service", Namespace: "default"}, api_v1.ServiceSpec{
+		Type:  api_v1.ServiceTypeNodePort,
+		Ports: []api_v1.ServicePort{{Port: 80}},
+	}))
+	defaultBackend := backend("my-service", intstr.FromInt(80))
+
+	var ings []*extensions.Ingress
+	for i := 0; i < 6; i++ {
+		ing := test.NewIngress(types.NamespacedName{Name: fmt.Sprintf("ing-%d", i), Namespace: "default"}, extensions.IngressSpec{
+			Backend: &defaultBackend,
+		})
+		addIngress(lbc, ing)
+		ings = append(ings, ing)
+	}
+	syncAll := func(ings []*extensions.Ingress) {
+		var wg sync.WaitGroup
+		for _, ing := range ings {
+			wg.Add(1)
+			go func(key string) {
+				defer wg.Done()
+				if err := lbc.sync(key); err != nil {
+					t.Errorf("lbc.sync(%v) = err %v", key, err)
+				}
+			}(getKey(ing, t))
+		}
+		wg.Wait()
+	}
+	syncAll(ings)
+
+	// Half of the Ingresses are deleted while the others are synced again.
+	for _, ing := range ings[:3] {
+		deleteIngress(lbc, ing)
+	}
+	syncAll(ings)
+
+	lbNames, err := lbc.l7Pool.List()
+	if err != nil {
+		t.Fatalf("l7Pool.List() = err %v", err)
+	}
+	if len(lbNames) != 3 {
+		t.Errorf("l7Pool.List() = %v, want 3 load balancers", lbNames)
+	}
+	urlMap, _ := lbc.Translator.TranslateIngress(ings[3], lbc.ctx.DefaultBackendSvcPortID)
+	for _, sp := range urlMap.AllServicePorts() {
+		if _, err := lbc.ctx.Cloud.GetGlobalBackendService(sp.BackendName(lbc.ctx.ClusterNamer)); err != nil {
+			t.Errorf("GetGlobalBackendService(%v) = err %v, want nil", sp.BackendName(lbc.ctx.ClusterNamer), err)
+		}
+	}
+}
+
 // TestEnsureMCIngress asserts a multi-cluster ingress will result with correct status annotations.
 func TestEnsureMCIngress(t *testing.T) {
 	lbc := newLoadBalancerController()
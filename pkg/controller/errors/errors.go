@@ -23,7 +23,7 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 
 	"k8s.io/ingress-gce/pkg/annotations"
-	backendconfigv1beta1 "k8s.io/ingress-gce/pkg/apis/backendconfig/v1beta1"
+	backendconfigv1 "k8s.io/ingress-gce/pkg/apis/backendconfig/v1"
 	"k8s.io/ingress-gce/pkg/utils"
 )
 
@@ -58,6 +58,17 @@ func (e ErrSvcPortNotFound) Error() string {
 	return fmt.Sprintf("could not find port %q in service %q", e.ServicePortID.Port.String(), e.ServicePortID.Service)
 }
 
+// ErrSvcNotNEGEnabled is returned when a service port used by an internal
+// HTTP(S) load balancer is not NEG-enabled.
+type ErrSvcNotNEGEnabled struct {
+	utils.ServicePortID
+}
+
+// Error returns the port name/number of the service which is not NEG-enabled.
+func (e ErrSvcNotNEGEnabled) Error() string {
+	return fmt.Sprintf("port %q of service %q must be NEG-enabled to be used by an internal load balancer", e.ServicePortID.Port.String(), e.ServicePortID.Service)
+}
+
 // ErrSvcAppProtosParsing is returned when the service is malformed.
 type ErrSvcAppProtosParsing struct {
 	Service types.NamespacedName
@@ -83,7 +94,7 @@ func (e ErrSvcBackendConfig) Error() string {
 
 // ErrBackendConfigValidation is returned when there was an error validating a BackendConfig.
 type ErrBackendConfigValidation struct {
-	backendconfigv1beta1.BackendConfig
+	backendconfigv1.BackendConfig
 	Err error
 }
 
@@ -34,6 +34,9 @@ type NodeController struct {
 	queue utils.TaskQueue
 	// instancePool is a NodePool to manage kubernetes nodes.
 	instancePool instances.NodePool
+	// igLockKey is the ResourceLocks key of the instance groups.
+	igLockKey string
+	locks     *utils.ResourceLocks
 }
 
 // NewNodeController returns a new node update controller.
@@ -41,6 +44,8 @@ func NewNodeController(ctx *context.ControllerContext, instancePool instances.No
 	c := &NodeController{
 		lister:       ctx.NodeInformer.GetIndexer(),
 		instancePool: instancePool,
+		igLockKey:    utils.ResourceKey(utils.InstanceGroupResource, ctx.ClusterNamer.InstanceGroup()),
+		locks:        ctx.ResourceLocks,
 	}
 	c.queue = utils.NewPeriodicTaskQueue("", "nodes", c.sync)
 
@@ -75,5 +80,6 @@ func (c *NodeController) sync(key string) error {
 	if err != nil {
 		return err
 	}
+	defer c.locks.Lock(c.igLockKey)()
 	return c.instancePool.Sync(nodeNames)
 }
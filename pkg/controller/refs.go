@@ -0,0 +1,146 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"sync"
+
+	"k8s.io/ingress-gce/pkg/backends/features"
+	"k8s.io/ingress-gce/pkg/utils"
+)
+
+// svcPortRefs counts the Ingresses referencing the backend of each
+// ServicePort, so that the backends of an Ingress can be garbage collected
+// without translating every other Ingress.
+type svcPortRefs struct {
+	namer *utils.Namer
+
+	// lock protects the fields below.
+	lock sync.Mutex
+	// seeded is true once the references of all Ingresses were set by
+	// reset. Until then, released backends may still be in use.
+	seeded bool
+	// ports are the ServicePorts referenced by each Ingress, by backend key.
+	ports map[string]map[string]utils.ServicePort
+	// counts are the number of Ingresses referencing each backend key.
+	counts map[string]int
+}
+
+func newSvcPortRefs(namer *utils.Namer) *svcPortRefs {
+	return &svcPortRefs{
+		namer:  namer,
+		ports:  map[string]map[string]utils.ServicePort{},
+		counts: map[string]int{},
+	}
+}
+
+// backendKey identifies the backend of a ServicePort. A ServicePort used by
+// both external and internal load balancers has a backend in each scope.
+func (r *svcPortRefs) backendKey(sp utils.ServicePort) string {
+	return fmt.Sprintf("%s/%s", features.ScopeFromServicePort(&sp), sp.BackendName(r.namer))
+}
+
+// isSeeded returns true once the references of all Ingresses were set.
+func (r *svcPortRefs) isSeeded() bool {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.seeded
+}
+
+// referenced returns true if any Ingress references the backend of sp.
+func (r *svcPortRefs) referenced(sp utils.ServicePort) bool {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.counts[r.backendKey(sp)] > 0
+}
+
+// all returns the ServicePorts referenced by any Ingress.
+func (r *svcPortRefs) all() []utils.ServicePort {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	var svcPorts []utils.ServicePort
+	for _, ports := range r.ports {
+		for _, sp := range ports {
+			svcPorts = append(svcPorts, sp)
+		}
+	}
+	return svcPorts
+}
+
+// acquire adds the given ServicePorts to those referenced by the Ingress
+// with the given key, so that their backends are not collected while the
+// Ingress is synced.
+func (r *svcPortRefs) acquire(ingKey string, svcPorts []utils.ServicePort) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	all := svcPorts
+	for _, sp := range r.ports[ingKey] {
+		all = append(all, sp)
+	}
+	r.setLocked(ingKey, all)
+}
+
+// set sets the ServicePorts referenced by the Ingress with the given key and
+// returns those which are no longer referenced by any Ingress.
+func (r *svcPortRefs) set(ingKey string, svcPorts []utils.ServicePort) []utils.ServicePort {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.setLocked(ingKey, svcPorts)
+}
+
+func (r *svcPortRefs) setLocked(ingKey string, svcPorts []utils.ServicePort) []utils.ServicePort {
+	ports := map[string]utils.ServicePort{}
+	for _, sp := range svcPorts {
+		ports[r.backendKey(sp)] = sp
+	}
+	for key := range ports {
+		if _, ok := r.ports[ingKey][key]; !ok {
+			r.counts[key]++
+		}
+	}
+	var released []utils.ServicePort
+	for key, sp := range r.ports[ingKey] {
+		if _, ok := ports[key]; ok {
+			continue
+		}
+		r.counts[key]--
+		if r.counts[key] == 0 {
+			delete(r.counts, key)
+			released = append(released, sp)
+		}
+	}
+	if len(ports) == 0 {
+		delete(r.ports, ingKey)
+	} else {
+		r.ports[ingKey] = ports
+	}
+	return released
+}
+
+// reset replaces the references of all Ingresses with the given
+// ServicePorts, by Ingress key.
+func (r *svcPortRefs) reset(refs map[string][]utils.ServicePort) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.ports = map[string]map[string]utils.ServicePort{}
+	r.counts = map[string]int{}
+	for ingKey, svcPorts := range refs {
+		r.setLocked(ingKey, svcPorts)
+	}
+	r.seeded = true
+}
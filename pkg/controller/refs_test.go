@@ -0,0 +1,132 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/ingress-gce/pkg/utils"
+)
+
+func TestSvcPortRefs(t *testing.T) {
+	namer := utils.NewNamer(clusterUID, "")
+	portA := utils.ServicePort{NodePort: 30001}
+	portB := utils.ServicePort{NodePort: 30002}
+	portC := utils.ServicePort{NodePort: 30003}
+	// An internal load balancer uses a backend of its own.
+	internalA := utils.ServicePort{NodePort: 30001, L7ILBEnabled: true}
+
+	refs := newSvcPortRefs(namer)
+	refs.reset(map[string][]utils.ServicePort{
+		"ns/ing-1": {portA, portB},
+		"ns/ing-2": {portA, internalA},
+	})
+	if !refs.isSeeded() {
+		t.Errorf("refs.isSeeded() = false after reset, want true")
+	}
+
+	for _, tc := range []struct {
+		desc         string
+		ingKey       string
+		svcPorts     []utils.ServicePort
+		wantReleased []utils.ServicePort
+	}{
+		{
+			desc:     "port added",
+			ingKey:   "ns/ing-1",
+			svcPorts: []utils.ServicePort{portA, portB, portC},
+		},
+		{
+			desc:     "port still used by another Ingress",
+			ingKey:   "ns/ing-1",
+			svcPorts: []utils.ServicePort{portB, portC},
+		},
+		{
+			desc:         "Ingress removed",
+			ingKey:       "ns/ing-2",
+			wantReleased: []utils.ServicePort{portA, internalA},
+		},
+		{
+			desc:         "port removed",
+			ingKey:       "ns/ing-1",
+			svcPorts:     []utils.ServicePort{portC},
+			wantReleased: []utils.ServicePort{portB},
+		},
+		{
+			desc:     "unknown Ingress removed",
+			ingKey:   "ns/ing-3",
+			svcPorts: nil,
+		},
+	} {
+		released := refs.set(tc.ingKey, tc.svcPorts)
+		got, want := sets.NewString(), sets.NewString()
+		for _, sp := range released {
+			got.Insert(refs.backendKey(sp))
+		}
+		for _, sp := range tc.wantReleased {
+			want.Insert(refs.backendKey(sp))
+		}
+		if !got.Equal(want) || len(released) != len(tc.wantReleased) {
+			t.Errorf("%s: refs.set(%q, %v) released %v, want %v", tc.desc, tc.ingKey, tc.svcPorts, got.List(), want.List())
+		}
+	}
+}
+
+func TestSvcPortRefsAcquire(t *testing.T) {
+	namer := utils.NewNamer(clusterUID, "")
+	portA := utils.ServicePort{NodePort: 30001}
+	portB := utils.ServicePort{NodePort: 30002}
+
+	refs := newSvcPortRefs(namer)
+	refs.set("ns/ing-1", []utils.ServicePort{portA})
+	// The ports of an Ingress being synced are referenced along with those
+	// it referenced before, until they are set after the sync.
+	refs.acquire("ns/ing-1", []utils.ServicePort{portB})
+	for _, sp := range []utils.ServicePort{portA, portB} {
+		if !refs.referenced(sp) {
+			t.Errorf("refs.referenced(%v) = false after acquire, want true", sp.NodePort)
+		}
+	}
+
+	released := refs.set("ns/ing-1", []utils.ServicePort{portB})
+	if len(released) != 1 || released[0].NodePort != portA.NodePort {
+		t.Errorf("refs.set() released %v, want [%v]", released, portA)
+	}
+	if refs.referenced(portA) {
+		t.Errorf("refs.referenced(%v) = true after set, want false", portA.NodePort)
+	}
+}
+
+func TestSvcPortRefsAll(t *testing.T) {
+	namer := utils.NewNamer(clusterUID, "")
+	portA := utils.ServicePort{NodePort: 30001}
+	portB := utils.ServicePort{NodePort: 30002}
+
+	refs := newSvcPortRefs(namer)
+	refs.set("ns/ing-1", []utils.ServicePort{portA, portB})
+	refs.set("ns/ing-2", []utils.ServicePort{portA})
+	refs.set("ns/ing-1", []utils.ServicePort{portA})
+
+	got := sets.NewString()
+	for _, sp := range refs.all() {
+		got.Insert(refs.backendKey(sp))
+	}
+	if want := sets.NewString(refs.backendKey(portA)); !got.Equal(want) {
+		t.Errorf("refs.all() = %v, want %v", got.List(), want.List())
+	}
+}
@@ -31,7 +31,7 @@ import (
 	"k8s.io/client-go/tools/cache"
 
 	"k8s.io/ingress-gce/pkg/annotations"
-	backendconfigv1beta1 "k8s.io/ingress-gce/pkg/apis/backendconfig/v1beta1"
+	backendconfigv1 "k8s.io/ingress-gce/pkg/apis/backendconfig/v1"
 	"k8s.io/ingress-gce/pkg/backendconfig"
 	"k8s.io/ingress-gce/pkg/context"
 	"k8s.io/ingress-gce/pkg/controller/errors"
@@ -50,8 +50,9 @@ type Translator struct {
 }
 
 // getServicePort looks in the svc store for a matching service:port,
-// and returns the nodeport.
-func (t *Translator) getServicePort(id utils.ServicePortID) (*utils.ServicePort, error) {
+// and returns the nodeport. Backends of internal HTTP(S) load balancers, as
+// requested by isL7ILB, must be NEG-enabled.
+func (t *Translator) getServicePort(id utils.ServicePortID, isL7ILB bool) (*utils.ServicePort, error) {
 	// We periodically add information to this ServicePort to ensure that we
 	// always return as much as possible, rather than nil, if there was a non-fatal error.
 	var svcPort *utils.ServicePort
@@ -89,12 +90,17 @@ func (t *Translator) getServicePort(id utils.ServicePortID) (*utils.ServicePort,
 		// This is a fatal error.
 		return nil, errors.ErrBadSvcType{Service: id.Service, ServiceType: svc.Spec.Type}
 	}
+	if isL7ILB && !negEnabled {
+		// This is a fatal error.
+		return nil, errors.ErrSvcNotNEGEnabled{ServicePortID: id}
+	}
 	svcPort = &utils.ServicePort{
-		ID:         id,
-		NodePort:   int64(port.NodePort),
-		Port:       int32(port.Port),
-		TargetPort: port.TargetPort.String(),
-		NEGEnabled: negEnabled,
+		ID:           id,
+		NodePort:     int64(port.NodePort),
+		Port:         int32(port.Port),
+		TargetPort:   port.TargetPort.String(),
+		NEGEnabled:   negEnabled,
+		L7ILBEnabled: isL7ILB,
 	}
 
 	appProtocols, err := annotations.FromService(svc).ApplicationProtocols()
@@ -108,7 +114,7 @@ func (t *Translator) getServicePort(id utils.ServicePortID) (*utils.ServicePort,
 	}
 	svcPort.Protocol = proto
 
-	var beConfig *backendconfigv1beta1.BackendConfig
+	var beConfig *backendconfigv1.BackendConfig
 	beConfig, err = backendconfig.GetBackendConfigForServicePort(t.ctx.BackendConfigInformer.GetIndexer(), svc, port)
 	if err != nil {
 		// If we could not find a backend config name for the current
@@ -134,6 +140,7 @@ func (t *Translator) getServicePort(id utils.ServicePortID) (*utils.ServicePort,
 func (t *Translator) TranslateIngress(ing *extensions.Ingress, systemDefaultBackend utils.ServicePortID) (*utils.GCEURLMap, []error) {
 	var errs []error
 	urlMap := utils.NewGCEURLMap()
+	isL7ILB := utils.IsGCEL7ILBIngress(ing)
 	for _, rule := range ing.Spec.Rules {
 		if rule.HTTP == nil {
 			continue
@@ -141,7 +148,7 @@ func (t *Translator) TranslateIngress(ing *extensions.Ingress, systemDefaultBack
 
 		pathRules := []utils.PathRule{}
 		for _, p := range rule.HTTP.Paths {
-			svcPort, err := t.getServicePort(utils.BackendToServicePortID(p.Backend, ing.Namespace))
+			svcPort, err := t.getServicePort(utils.BackendToServicePortID(p.Backend, ing.Namespace), isL7ILB)
 			if err != nil {
 				errs = append(errs, err)
 			}
@@ -162,9 +169,11 @@ func (t *Translator) TranslateIngress(ing *extensions.Ingress, systemDefaultBack
 		}
 		urlMap.PutPathRulesForHost(host, pathRules)
 	}
+	errs = append(errs, t.putRouteRules(urlMap, ing, isL7ILB)...)
+	errs = append(errs, setPathActions(urlMap, ing)...)
 
 	if ing.Spec.Backend != nil {
-		svcPort, err := t.getServicePort(utils.BackendToServicePortID(*ing.Spec.Backend, ing.Namespace))
+		svcPort, err := t.getServicePort(utils.BackendToServicePortID(*ing.Spec.Backend, ing.Namespace), isL7ILB)
 		if err == nil {
 			urlMap.DefaultBackend = svcPort
 			return urlMap, errs
@@ -174,7 +183,7 @@ func (t *Translator) TranslateIngress(ing *extensions.Ingress, systemDefaultBack
 		return urlMap, errs
 	}
 
-	svcPort, err := t.getServicePort(systemDefaultBackend)
+	svcPort, err := t.getServicePort(systemDefaultBackend, isL7ILB)
 	if err == nil {
 		urlMap.DefaultBackend = svcPort
 		return urlMap, errs
@@ -184,6 +193,113 @@ func (t *Translator) TranslateIngress(ing *extensions.Ingress, systemDefaultBack
 	return urlMap, errs
 }
 
+// setPathActions sets the rewrites and redirects of the Ingress on the paths
+// of urlMap. It must be called after the path rules are added. Actions for
+// paths which are not in the Ingress rules are errors, while actions for
+// paths which were dropped because of their backend are ignored.
+func setPathActions(urlMap *utils.GCEURLMap, ing *extensions.Ingress) []error {
+	actions, err := annotations.FromIngress(ing).PathActions()
+	if err != nil {
+		return []error{err}
+	}
+	var errs []error
+	for _, action := range actions {
+		host, path := action.Host, action.Path
+		if host == "" {
+			host = loadbalancers.DefaultHost
+		}
+		if path == "" {
+			path = loadbalancers.DefaultPath
+		}
+		var rewrite *utils.PathRewrite
+		if r := action.Rewrite; r != nil {
+			rewrite = &utils.PathRewrite{PathPrefix: r.PathPrefix, Host: r.Host}
+		}
+		var redirect *utils.PathRedirect
+		if r := action.Redirect; r != nil {
+			redirect = &utils.PathRedirect{
+				Host:         r.Host,
+				Path:         r.Path,
+				PathPrefix:   r.PathPrefix,
+				HTTPS:        r.HTTPS,
+				StripQuery:   r.StripQuery,
+				ResponseCode: r.ResponseCode,
+			}
+		}
+		if !urlMap.SetPathAction(host, path, rewrite, redirect) && !hasPath(ing, host, path) {
+			errs = append(errs, fmt.Errorf("path action for host %q and path %q does not match a path of the Ingress rules", host, path))
+		}
+	}
+	return errs
+}
+
+// hasPath returns true if the rules of the Ingress have the given host and
+// path, where empty hosts and paths are the default host and path.
+func hasPath(ing *extensions.Ingress, host, path string) bool {
+	for _, rule := range ing.Spec.Rules {
+		if rule.HTTP == nil || (rule.Host != host && (rule.Host != "" || host != loadbalancers.DefaultHost)) {
+			continue
+		}
+		for _, p := range rule.HTTP.Paths {
+			if p.Path == path || (p.Path == "" && path == loadbalancers.DefaultPath) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// putRouteRules adds the route rules of the Ingress to urlMap. It must be
+// called after the path rules of the Ingress are added. A route rule with
+// a backend which cannot be retrieved is skipped, as sending its share of
+// the requests to another backend would change the split.
+func (t *Translator) putRouteRules(urlMap *utils.GCEURLMap, ing *extensions.Ingress, isL7ILB bool) []error {
+	rules, err := annotations.FromIngress(ing).RouteRules()
+	if err != nil {
+		return []error{err}
+	}
+	var errs []error
+	var hosts []string
+	hostRules := map[string][]utils.RouteRule{}
+	for _, rule := range rules {
+		routeRule := utils.RouteRule{
+			Match: utils.RouteMatch{PathPrefix: rule.Match.PathPrefix},
+		}
+		for _, m := range rule.Match.Headers {
+			routeRule.Match.Headers = append(routeRule.Match.Headers, utils.ValueMatch{Name: m.Name, Value: m.Value})
+		}
+		for _, m := range rule.Match.QueryParameters {
+			routeRule.Match.QueryParameters = append(routeRule.Match.QueryParameters, utils.ValueMatch{Name: m.Name, Value: m.Value})
+		}
+		var backendErr error
+		for _, b := range rule.Backends {
+			svcPort, err := t.getServicePort(utils.BackendToServicePortID(b.IngressBackend, ing.Namespace), isL7ILB)
+			if err != nil {
+				backendErr = err
+				break
+			}
+			routeRule.Backends = append(routeRule.Backends, utils.WeightedBackend{Backend: *svcPort, Weight: b.Weight})
+		}
+		if backendErr != nil {
+			errs = append(errs, backendErr)
+			continue
+		}
+
+		host := rule.Host
+		if host == "" {
+			host = loadbalancers.DefaultHost
+		}
+		if _, ok := hostRules[host]; !ok {
+			hosts = append(hosts, host)
+		}
+		hostRules[host] = append(hostRules[host], routeRule)
+	}
+	for _, host := range hosts {
+		urlMap.PutRouteRulesForHost(host, hostRules[host])
+	}
+	return errs
+}
+
 func getZone(n *api_v1.Node) string {
 	zone, ok := n.Labels[annotations.ZoneKey]
 	if !ok {
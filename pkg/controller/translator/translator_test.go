@@ -30,7 +30,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/kubernetes/fake"
-	backendconfig "k8s.io/ingress-gce/pkg/apis/backendconfig/v1beta1"
+	backendconfig "k8s.io/ingress-gce/pkg/apis/backendconfig/v1"
 	backendconfigclient "k8s.io/ingress-gce/pkg/backendconfig/client/clientset/versioned/fake"
 	"k8s.io/ingress-gce/pkg/test"
 
@@ -56,7 +56,7 @@ func fakeTranslator() *Translator {
 		HealthCheckPath:               "/",
 		DefaultBackendHealthCheckPath: "/healthz",
 	}
-	ctx := context.NewControllerContext(client, backendConfigClient, nil, namer, ctxConfig)
+	ctx := context.NewControllerContext(client, backendConfigClient, nil, nil, namer, ctxConfig)
 	gce := &Translator{
 		ctx: ctx,
 	}
@@ -151,6 +151,18 @@ func TestTranslateIngress(t *testing.T) {
 			wantErrCount:  2,
 			wantGCEURLMap: gceURLMapFromFile(t, "ingress-missing-multi-svc.json"),
 		},
+		{
+			desc:          "route rules",
+			ing:           ingressFromFile(t, "ingress-route-rules.yaml"),
+			wantErrCount:  1,
+			wantGCEURLMap: gceURLMapFromFile(t, "ingress-route-rules.json"),
+		},
+		{
+			desc:          "path actions",
+			ing:           ingressFromFile(t, "ingress-path-actions.yaml"),
+			wantErrCount:  2,
+			wantGCEURLMap: gceURLMapFromFile(t, "ingress-path-actions.json"),
+		},
 		{
 			desc: "missing default service",
 			ing: test.NewIngress(types.NamespacedName{Name: "my-ingress", Namespace: "default"},
@@ -183,6 +195,7 @@ func TestGetServicePort(t *testing.T) {
 		spec        apiv1.ServiceSpec
 		annotations map[string]string
 		id          utils.ServicePortID
+		isL7ILB     bool
 		wantErr     bool
 		wantPort    bool
 	}{
@@ -219,6 +232,31 @@ func TestGetServicePort(t *testing.T) {
 			wantErr:  true,
 			wantPort: true,
 		},
+		{
+			desc: "internal load balancer without NEG",
+			spec: apiv1.ServiceSpec{
+				Type:  apiv1.ServiceTypeNodePort,
+				Ports: []apiv1.ServicePort{{Name: "http", Port: 80}},
+			},
+			id:       utils.ServicePortID{Port: intstr.FromString("http")},
+			isL7ILB:  true,
+			wantErr:  true,
+			wantPort: false,
+		},
+		{
+			desc: "internal load balancer with NEG",
+			spec: apiv1.ServiceSpec{
+				Type:  apiv1.ServiceTypeClusterIP,
+				Ports: []apiv1.ServicePort{{Name: "http", Port: 80}},
+			},
+			annotations: map[string]string{
+				annotations.NEGAnnotationKey: `{"ingress":true}`,
+			},
+			id:       utils.ServicePortID{Port: intstr.FromString("http")},
+			isL7ILB:  true,
+			wantErr:  false,
+			wantPort: true,
+		},
 	}
 	for _, tc := range cases {
 		t.Run(tc.desc, func(t *testing.T) {
@@ -231,12 +269,15 @@ func TestGetServicePort(t *testing.T) {
 			svcLister.Add(svc)
 			tc.id.Service = svcName
 
-			port, gotErr := translator.getServicePort(tc.id)
+			port, gotErr := translator.getServicePort(tc.id, tc.isL7ILB)
 			if (gotErr != nil) != tc.wantErr {
-				t.Errorf("translator.getServicePort(%+v) = _, %v, want err? %v", tc.id, gotErr, tc.wantErr)
+				t.Errorf("translator.getServicePort(%+v, %v) = _, %v, want err? %v", tc.id, tc.isL7ILB, gotErr, tc.wantErr)
 			}
 			if (port != nil) != tc.wantPort {
-				t.Errorf("translator.getServicePort(%+v) = %v, want port? %v", tc.id, port, tc.wantPort)
+				t.Errorf("translator.getServicePort(%+v, %v) = %v, want port? %v", tc.id, tc.isL7ILB, port, tc.wantPort)
+			}
+			if port != nil && port.L7ILBEnabled != tc.isL7ILB {
+				t.Errorf("translator.getServicePort(%+v, %v) = %+v, want L7ILBEnabled %v", tc.id, tc.isL7ILB, port, tc.isL7ILB)
 			}
 		})
 	}
@@ -307,7 +348,7 @@ func TestGetServicePortWithBackendConfigEnabled(t *testing.T) {
 			svcLister.Add(svc)
 			backendConfigLister.Add(backendConfig)
 
-			port, gotErr := translator.getServicePort(tc.id)
+			port, gotErr := translator.getServicePort(tc.id, false)
 			if (gotErr != nil) != tc.wantErr {
 				t.Errorf("%s: translator.getServicePort(%+v) = _, %v, want err? %v", tc.desc, tc.id, gotErr, tc.wantErr)
 			}
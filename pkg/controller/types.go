@@ -17,17 +17,41 @@ limitations under the License.
 package controller
 
 import (
+	"k8s.io/ingress-gce/pkg/annotations"
 	"k8s.io/ingress-gce/pkg/loadbalancers"
 	"k8s.io/ingress-gce/pkg/utils"
 
+	apiv1 "k8s.io/api/core/v1"
 	extensions "k8s.io/api/extensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
-// gcState is used by the controller to maintain state for garbage collection routines.
+// Reasons of the conditions of an Ingress whose sync failed.
+const (
+	translationErrorReason  = "TranslationError"
+	backendSyncErrorReason  = "BackendSyncError"
+	frontendSyncErrorReason = "FrontendSyncError"
+	statusUpdateErrorReason = "StatusUpdateError"
+)
+
+// gcState is used by the controller to maintain state for garbage collection routines of a single Ingress.
 type gcState struct {
+	// key of the Ingress.
+	key string
+	// ing is nil if the Ingress no longer exists.
+	ing *extensions.Ingress
+	// deleteLB is true if the load balancer of the Ingress must be deleted.
+	deleteLB bool
+	// svcPorts are the ServicePorts the Ingress still uses.
+	svcPorts []utils.ServicePort
+}
+
+// fullGCState is used by the controller to maintain state for garbage collection routines of all Ingresses.
+type fullGCState struct {
 	ingresses []*extensions.Ingress
 	lbNames   []string
-	svcPorts  []utils.ServicePort
+	// svcPorts are the ServicePorts used by each Ingress, by Ingress key.
+	svcPorts map[string][]utils.ServicePort
 }
 
 // syncState is used by the controller to maintain state for routines that sync GCP resources of an Ingress.
@@ -35,4 +59,45 @@ type syncState struct {
 	urlMap *utils.GCEURLMap
 	ing    *extensions.Ingress
 	l7     *loadbalancers.L7
+	// conditions are the conditions of the Ingress recorded by each phase
+	// of the sync.
+	conditions []annotations.IngressCondition
+}
+
+// setCondition records the condition of a sync phase, which failed if err
+// is not nil. A failed phase also marks the Ingress as not programmed.
+func (s *syncState) setCondition(t annotations.IngressConditionType, err error, failureReason string) {
+	now := metav1.Now()
+	if err == nil {
+		s.conditions = annotations.SetCondition(s.conditions, annotations.IngressCondition{
+			Type:               t,
+			Status:             apiv1.ConditionTrue,
+			Reason:             string(t),
+			LastTransitionTime: now,
+		})
+		return
+	}
+	for _, conditionType := range []annotations.IngressConditionType{t, annotations.ProgrammedCondition} {
+		s.conditions = annotations.SetCondition(s.conditions, annotations.IngressCondition{
+			Type:               conditionType,
+			Status:             apiv1.ConditionFalse,
+			Reason:             failureReason,
+			Message:            err.Error(),
+			LastTransitionTime: now,
+		})
+	}
+}
+
+// setUnknown records that the sync phases of the given condition types did
+// not run, for the given reason.
+func (s *syncState) setUnknown(reason string, types ...annotations.IngressConditionType) {
+	now := metav1.Now()
+	for _, t := range types {
+		s.conditions = annotations.SetCondition(s.conditions, annotations.IngressCondition{
+			Type:               t,
+			Status:             apiv1.ConditionUnknown,
+			Reason:             reason,
+			LastTransitionTime: now,
+		})
+	}
 }
@@ -19,9 +19,11 @@ package controller
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 
 	compute "google.golang.org/api/compute/v1"
 	extensions "k8s.io/api/extensions/v1beta1"
+	"k8s.io/apimachinery/pkg/util/sets"
 
 	api_v1 "k8s.io/api/core/v1"
 
@@ -47,11 +49,13 @@ func setInstanceGroupsAnnotation(existing map[string]string, igs []*compute.Inst
 	return nil
 }
 
-// uniq returns an array of unique service ports from the given array.
+// uniq returns an array of unique service ports from the given array. A
+// service port used by both external and internal load balancers is kept
+// once for each, as their backends are in different scopes.
 func uniq(svcPorts []utils.ServicePort) []utils.ServicePort {
 	portMap := map[string]utils.ServicePort{}
 	for _, p := range svcPorts {
-		portMap[fmt.Sprintf("%q-%d", p.ID.Service.String(), p.Port)] = p
+		portMap[fmt.Sprintf("%q-%d-%t", p.ID.Service.String(), p.Port, p.L7ILBEnabled)] = p
 	}
 	svcPorts = make([]utils.ServicePort, 0, len(portMap))
 	for _, sp := range portMap {
@@ -88,3 +92,20 @@ func nodePorts(svcPorts []utils.ServicePort) []int64 {
 	}
 	return ports
 }
+
+// managedCertDomains returns the sorted hosts of the rules of the Ingress,
+// if it requests a Google-managed certificate. Wildcard hosts are skipped as
+// managed certificates do not support them.
+func managedCertDomains(ing *extensions.Ingress) []string {
+	if !annotations.FromIngress(ing).ManagedCertificates() {
+		return nil
+	}
+	domains := sets.NewString()
+	for _, rule := range ing.Spec.Rules {
+		if rule.Host == "" || strings.Contains(rule.Host, "*") {
+			continue
+		}
+		domains.Insert(rule.Host)
+	}
+	return domains.List()
+}
@@ -23,6 +23,7 @@ import (
 	"google.golang.org/api/compute/v1"
 
 	api_v1 "k8s.io/api/core/v1"
+	extensions "k8s.io/api/extensions/v1beta1"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	//"k8s.io/apimachinery/pkg/util/sets"
@@ -204,6 +205,9 @@ func TestNodeStatusChanged(t *testing.T) {
 }
 
 func TestUniq(t *testing.T) {
+	ilbServicePort := testServicePort("ns", "name", "80", 80, 0, true)
+	ilbServicePort.L7ILBEnabled = true
+
 	testCases := []struct {
 		desc   string
 		input  []utils.ServicePort
@@ -281,6 +285,18 @@ func TestUniq(t *testing.T) {
 				testServicePort("ns", "name", "443", 443, 0, true),
 			},
 		},
+		{
+			"same service port used by external and internal load balancers",
+			[]utils.ServicePort{
+				testServicePort("ns", "name", "80", 80, 0, true),
+				ilbServicePort,
+				ilbServicePort,
+			},
+			[]utils.ServicePort{
+				testServicePort("ns", "name", "80", 80, 0, true),
+				ilbServicePort,
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -406,3 +422,39 @@ func testServicePort(namespace, name, port string, servicePort, nodePort int, en
 		NEGEnabled: enableNEG,
 	}
 }
+
+func TestManagedCertDomains(t *testing.T) {
+	rules := []extensions.IngressRule{
+		{Host: "foo.example.com"},
+		{Host: "*.example.com"},
+		{Host: ""},
+		{Host: "bar.example.com"},
+		{Host: "foo.example.com"},
+	}
+	for _, tc := range []struct {
+		desc        string
+		annotations map[string]string
+		want        []string
+	}{
+		{
+			desc: "not requested",
+		},
+		{
+			desc:        "disabled",
+			annotations: map[string]string{annotations.ManagedCertificatesKey: "false"},
+		},
+		{
+			desc:        "enabled",
+			annotations: map[string]string{annotations.ManagedCertificatesKey: "true"},
+			want:        []string{"bar.example.com", "foo.example.com"},
+		},
+	} {
+		ing := &extensions.Ingress{
+			ObjectMeta: meta_v1.ObjectMeta{Annotations: tc.annotations},
+			Spec:       extensions.IngressSpec{Rules: rules},
+		}
+		if got := managedCertDomains(ing); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%s: managedCertDomains() = %v, want %v", tc.desc, got, tc.want)
+		}
+	}
+}
@@ -27,6 +27,7 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/retry"
 )
 
 const (
@@ -76,6 +77,43 @@ func (h *CRDHandler) EnsureCRD(meta *CRDMeta) (*apiextensionsv1beta1.CustomResou
 	return crd, nil
 }
 
+// MigrateStorage makes sure every stored object of a CRD is encoded in the
+// storage version of meta. If objects may still be stored in other
+// versions, rewrite is called to rewrite all of them, after which the
+// other versions are dropped from the stored versions of the CRD so that
+// they can later be removed. Migration is skipped once only the storage
+// version is recorded.
+func (h *CRDHandler) MigrateStorage(meta *CRDMeta, rewrite func() error) error {
+	name := meta.plural + "." + meta.groupName
+	crd, err := h.client.ApiextensionsV1beta1().CustomResourceDefinitions().Get(name, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to get %v CRD: %v", meta.kind, err)
+	}
+	storedVersions := crd.Status.StoredVersions
+	if len(storedVersions) == 0 || (len(storedVersions) == 1 && storedVersions[0] == meta.version) {
+		klog.V(2).Infof("%v objects are stored in version %v, no migration needed.", meta.kind, meta.version)
+		return nil
+	}
+
+	klog.V(0).Infof("Migrating %v objects stored in versions %v to version %v...", meta.kind, storedVersions, meta.version)
+	if err := rewrite(); err != nil {
+		return fmt.Errorf("failed to rewrite %v objects: %v", meta.kind, err)
+	}
+
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		crd, err := h.client.ApiextensionsV1beta1().CustomResourceDefinitions().Get(name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		crd.Status.StoredVersions = []string{meta.version}
+		if _, err := h.client.ApiextensionsV1beta1().CustomResourceDefinitions().UpdateStatus(crd); err != nil {
+			return err
+		}
+		klog.V(0).Infof("Migrated %v objects to version %v.", meta.kind, meta.version)
+		return nil
+	})
+}
+
 func (h *CRDHandler) createOrUpdateCRD(meta *CRDMeta) (*apiextensionsv1beta1.CustomResourceDefinition, error) {
 	crd := crd(meta)
 	existingCRD, err := h.client.ApiextensionsV1beta1().CustomResourceDefinitions().Get(crd.Name, metav1.GetOptions{})
@@ -110,12 +148,30 @@ func crd(meta *CRDMeta) *apiextensionsv1beta1.CustomResourceDefinition {
 			},
 		},
 	}
-	if meta.typeSource != "" && meta.fn != nil {
-		validationSpec, err := validation(meta.typeSource, meta.fn)
-		if err != nil {
-			klog.Errorf("Error adding simple validation for %v CRD: %v", meta.kind, err)
+	validationSpec, err := meta.Validation()
+	if err != nil {
+		klog.Errorf("Error adding simple validation for %v CRD: %v", meta.kind, err)
+	}
+	crd.Spec.Validation = validationSpec
+	if len(meta.servedVersions) > 0 {
+		// The storage version must come first, as it is also set in Version.
+		crd.Spec.Versions = []apiextensionsv1beta1.CustomResourceDefinitionVersion{
+			{Name: meta.version, Served: true, Storage: true},
+		}
+		for _, version := range meta.servedVersions {
+			crd.Spec.Versions = append(crd.Spec.Versions, apiextensionsv1beta1.CustomResourceDefinitionVersion{Name: version, Served: true})
+		}
+	}
+	if meta.conversionWebhook != nil {
+		crd.Spec.Conversion = &apiextensionsv1beta1.CustomResourceConversion{
+			Strategy:            apiextensionsv1beta1.WebhookConverter,
+			WebhookClientConfig: meta.conversionWebhook,
+		}
+	}
+	if meta.statusSubresource {
+		crd.Spec.Subresources = &apiextensionsv1beta1.CustomResourceSubresources{
+			Status: &apiextensionsv1beta1.CustomResourceSubresourceStatus{},
 		}
-		crd.Spec.Validation = validationSpec
 	}
 	return crd
 }
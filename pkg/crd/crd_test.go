@@ -17,12 +17,14 @@ limitations under the License.
 package crd
 
 import (
+	"fmt"
 	"reflect"
 	"testing"
 
 	apiextensionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
 	crdclient "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
 	crdclientfake "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset/fake"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 var (
@@ -81,3 +83,102 @@ func TestCreateOrUpdateCRD(t *testing.T) {
 
 	}
 }
+
+func TestCRDStatusSubresource(t *testing.T) {
+	meta := NewCRDMeta("test.group.com", "v1alpha1", "Test", "TestList", "test", "tests")
+	if crd := crd(meta); crd.Spec.Subresources != nil {
+		t.Errorf("Unexpected subresources %v, want nil", crd.Spec.Subresources)
+	}
+	meta.EnableStatusSubresource()
+	if crd := crd(meta); crd.Spec.Subresources == nil || crd.Spec.Subresources.Status == nil {
+		t.Errorf("Unexpected subresources %v, want status subresource", crd.Spec.Subresources)
+	}
+}
+
+func TestCRDVersions(t *testing.T) {
+	meta := NewCRDMeta("test.group.com", "v1", "Test", "TestList", "test", "tests")
+	if crd := crd(meta); crd.Spec.Version != "v1" || crd.Spec.Versions != nil || crd.Spec.Conversion != nil {
+		t.Errorf("Unexpected version %q, versions %v and conversion %v, want v1 only", crd.Spec.Version, crd.Spec.Versions, crd.Spec.Conversion)
+	}
+
+	meta.AddServedVersion("v1beta1")
+	path := "/convert"
+	meta.EnableConversionWebhook(&apiextensionsv1beta1.WebhookClientConfig{
+		Service: &apiextensionsv1beta1.ServiceReference{Namespace: "kube-system", Name: "webhook", Path: &path},
+	})
+	crd := crd(meta)
+	expectedVersions := []apiextensionsv1beta1.CustomResourceDefinitionVersion{
+		{Name: "v1", Served: true, Storage: true},
+		{Name: "v1beta1", Served: true},
+	}
+	if crd.Spec.Version != "v1" || !reflect.DeepEqual(crd.Spec.Versions, expectedVersions) {
+		t.Errorf("Unexpected version %q and versions %v, want v1 and %v", crd.Spec.Version, crd.Spec.Versions, expectedVersions)
+	}
+	if crd.Spec.Conversion == nil || crd.Spec.Conversion.Strategy != apiextensionsv1beta1.WebhookConverter || crd.Spec.Conversion.WebhookClientConfig.Service.Name != "webhook" {
+		t.Errorf("Unexpected conversion %+v, want webhook conversion", crd.Spec.Conversion)
+	}
+}
+
+func TestMigrateStorage(t *testing.T) {
+	meta := NewCRDMeta("test.group.com", "v1", "Test", "TestList", "test", "tests")
+	meta.AddServedVersion("v1beta1")
+
+	testCases := []struct {
+		desc           string
+		storedVersions []string
+		rewriteErr     error
+		expectRewrite  bool
+		expectError    bool
+		expectVersions []string
+	}{
+		{
+			desc:           "only storage version stored",
+			storedVersions: []string{"v1"},
+			expectVersions: []string{"v1"},
+		},
+		{
+			desc:           "old version stored",
+			storedVersions: []string{"v1beta1", "v1"},
+			expectRewrite:  true,
+			expectVersions: []string{"v1"},
+		},
+		{
+			desc:           "rewrite fails",
+			storedVersions: []string{"v1beta1", "v1"},
+			rewriteErr:     fmt.Errorf("rewrite failed"),
+			expectRewrite:  true,
+			expectError:    true,
+			expectVersions: []string{"v1beta1", "v1"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			fakeCRDClient := crdclientfake.NewSimpleClientset()
+			existing := crd(meta)
+			existing.Status.StoredVersions = tc.storedVersions
+			if _, err := fakeCRDClient.ApiextensionsV1beta1().CustomResourceDefinitions().Create(existing); err != nil {
+				t.Fatalf("Error creating CRD: %v", err)
+			}
+
+			rewritten := false
+			err := NewCRDHandler(fakeCRDClient).MigrateStorage(meta, func() error {
+				rewritten = true
+				return tc.rewriteErr
+			})
+			if tc.expectError != (err != nil) {
+				t.Errorf("MigrateStorage() = %v, want error = %v", err, tc.expectError)
+			}
+			if rewritten != tc.expectRewrite {
+				t.Errorf("Objects rewritten = %v, want %v", rewritten, tc.expectRewrite)
+			}
+			updated, err := fakeCRDClient.ApiextensionsV1beta1().CustomResourceDefinitions().Get(existing.Name, metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("Error getting CRD: %v", err)
+			}
+			if !reflect.DeepEqual(updated.Status.StoredVersions, tc.expectVersions) {
+				t.Errorf("Got stored versions %v, want %v", updated.Status.StoredVersions, tc.expectVersions)
+			}
+		})
+	}
+}
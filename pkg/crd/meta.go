@@ -17,6 +17,7 @@ limitations under the License.
 package crd
 
 import (
+	apiextensionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
 	"k8s.io/kube-openapi/pkg/common"
 )
 
@@ -31,10 +32,19 @@ type CRDMeta struct {
 	shortNames []string
 	typeSource string
 	fn         common.GetOpenAPIDefinitions
+	// statusSubresource enables the /status subresource for the CRD.
+	statusSubresource bool
+	// servedVersions are the versions served in addition to version, which
+	// is the storage version.
+	servedVersions []string
+	// conversionWebhook is the webhook which converts objects between
+	// versions. If nil, objects are converted by only changing their
+	// apiVersion.
+	conversionWebhook *apiextensionsv1beta1.WebhookClientConfig
 }
 
 // NewCRDMeta creates a CRDMeta type which can be passed to a CRDHandler in
-// order to create/ensure a CRD.
+// order to create/ensure a CRD. Objects are stored in the given version.
 func NewCRDMeta(groupName, version, kind, listKind, singular, plural string, shortNames ...string) *CRDMeta {
 	return &CRDMeta{
 		groupName:  groupName,
@@ -53,3 +63,35 @@ func (m *CRDMeta) AddValidationInfo(typeSource string, fn common.GetOpenAPIDefin
 	m.typeSource = typeSource
 	m.fn = fn
 }
+
+// Validation returns the validation specification which is added to the CRD,
+// or nil if no validation information was added.
+func (m *CRDMeta) Validation() (*apiextensionsv1beta1.CustomResourceValidation, error) {
+	if m.typeSource == "" || m.fn == nil {
+		return nil, nil
+	}
+	return validation(m.typeSource, m.fn)
+}
+
+// EnableStatusSubresource enables the /status subresource for the CRD when
+// CRDHandler.EnsureCRD is called.
+func (m *CRDMeta) EnableStatusSubresource() {
+	m.statusSubresource = true
+}
+
+// AddServedVersion adds a version which is served in addition to the
+// storage version of the CRD.
+func (m *CRDMeta) AddServedVersion(version string) {
+	m.servedVersions = append(m.servedVersions, version)
+}
+
+// EnableConversionWebhook makes the API server call the given webhook to
+// convert objects between the versions of the CRD.
+func (m *CRDMeta) EnableConversionWebhook(config *apiextensionsv1beta1.WebhookClientConfig) {
+	m.conversionWebhook = config
+}
+
+// StorageVersion returns the version in which objects of the CRD are stored.
+func (m *CRDMeta) StorageVersion() string {
+	return m.version
+}
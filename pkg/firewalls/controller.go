@@ -56,8 +56,17 @@ type FirewallController struct {
 func NewFirewallController(
 	ctx *context.ControllerContext,
 	portRanges []string) *FirewallController {
+	return NewFirewallControllerWithCloud(ctx, portRanges, ctx.Cloud)
+}
+
+// NewFirewallControllerWithCloud returns a new firewall controller which
+// manages the firewall rule through cloud.
+func NewFirewallControllerWithCloud(
+	ctx *context.ControllerContext,
+	portRanges []string,
+	cloud Firewall) *FirewallController {
 
-	firewallPool := NewFirewallPool(ctx.Cloud, ctx.ClusterNamer, gce.LoadBalancerSrcRanges(), portRanges)
+	firewallPool := NewFirewallPool(cloud, ctx.ClusterNamer, gce.LoadBalancerSrcRanges(), portRanges)
 
 	fwc := &FirewallController{
 		ctx:          ctx,
@@ -140,12 +149,23 @@ func (fwc *FirewallController) shutdown() {
 	fwc.queue.Shutdown()
 }
 
+// Plan syncs the firewall rule once. It is used with a Firewall which records
+// the changes to the rule rather than making them.
+func (fwc *FirewallController) Plan() error {
+	if !fwc.hasSynced() {
+		return fmt.Errorf("stores have not synced")
+	}
+	return fwc.sync(queueKey.Name)
+}
+
 func (fwc *FirewallController) sync(key string) error {
 	if !fwc.hasSynced() {
 		time.Sleep(context.StoreSyncPollPeriod)
 		return fmt.Errorf("waiting for stores to sync")
 	}
 	klog.V(3).Infof("Syncing firewall")
+	// The firewall rule is shared by all Ingresses.
+	defer fwc.ctx.ResourceLocks.Lock(utils.ResourceKey(utils.FirewallResource, fwc.ctx.ClusterNamer.FirewallRule()))()
 
 	gceIngresses := operator.Ingresses(fwc.ctx.Ingresses().List()).Filter(func(ing *extensions.Ingress) bool {
 		return utils.IsGCEIngress(ing)
@@ -80,10 +80,25 @@ var (
 		WatchNamespace            string
 		NodePortRanges            PortRanges
 		EnableBackendConfig       bool
+		EnableFrontendConfig      bool
+		EnableL7Ilb               bool
 		NegGCPeriod               time.Duration
 		NegSyncerType             string
 		FinalizerAdd              bool
 		FinalizerRemove           bool
+		EnableWebhook             bool
+		WebhookPort               int
+		WebhookCertFile           string
+		WebhookKeyFile            string
+		WebhookCAFile             string
+		ConversionWebhookService  string
+		StrictTLSValidation       bool
+		GCPeriod                  time.Duration
+		NumIngressWorkers         int
+		Plan                      bool
+		PlanOutput                string
+		FindOrphans               bool
+		DeleteOrphans             bool
 
 		LeaderElection LeaderElectionConfiguration
 	}{}
@@ -189,11 +204,55 @@ L7 load balancing. CSV values accepted. Example: -node-port-ranges=80,8080,400-5
 	flag.StringVar(&F.LeaderElection.LockObjectName, "lock-object-name", F.LeaderElection.LockObjectName, "Define the name of the lock object.")
 	flag.DurationVar(&F.NegGCPeriod, "neg-gc-period", 120*time.Second,
 		`Relist and garbage collect NEGs this often.`)
+	flag.DurationVar(&F.GCPeriod, "gc-period", 10*time.Minute,
+		`Relist and garbage collect the load balancers and backends of all Ingresses this often.`)
+	flag.IntVar(&F.NumIngressWorkers, "num-ingress-workers", 1,
+		`Number of Ingresses synced concurrently.`)
+	flag.BoolVar(&F.Plan, "plan", false,
+		`Print the changes to GCE resources which the controller would make to sync
+all Ingresses and the firewall rule, and exit without making them. The
+cluster is only read.`)
+	flag.StringVar(&F.PlanOutput, "plan-output", "text",
+		`Format of the changes printed by -plan. Valid values are "text" and "json".`)
+	flag.BoolVar(&F.FindOrphans, "find-orphans", false,
+		`Print the GCE resources of the cluster which are not used by its Ingresses
+and Services, and exit. The regional resources of internal load balancers are
+not looked up, so it fails if -enable-l7-ilb is set.`)
+	flag.BoolVar(&F.DeleteOrphans, "delete-orphans", false,
+		`Delete the GCE resources printed by -find-orphans, and exit.`)
 	flag.StringVar(&F.NegSyncerType, "neg-syncer-type", "transaction", "Define the NEG syncer type to use. Valid values are \"batch\" and \"transaction\"")
 	flag.BoolVar(&F.FinalizerAdd, "enable-finalizer-add",
 		F.FinalizerAdd, "Enable adding Finalizer to Ingress.")
 	flag.BoolVar(&F.FinalizerRemove, "enable-finalizer-remove",
 		F.FinalizerRemove, "Enable removing Finalizer from Ingress.")
+	flag.BoolVar(&F.EnableFrontendConfig, "enable-frontend-config", false,
+		`Optional, whether or not to enable FrontendConfig, which configures the
+frontend resources of the load balancer of an Ingress referencing it.`)
+	flag.BoolVar(&F.EnableL7Ilb, "enable-l7-ilb", false,
+		`Optional, whether or not to manage Ingresses of the "gce-internal" class
+with regional internal HTTP(S) load balancers. Their backends must use NEGs.`)
+	flag.BoolVar(&F.EnableWebhook, "enable-admission-webhook", false,
+		`Optional, serve validating admission webhooks for the resources managed
+by the controller. Requires -webhook-cert-file and -webhook-key-file.`)
+	flag.BoolVar(&F.StrictTLSValidation, "strict-tls-validation", false,
+		`Optional, refuse to upload the TLS certs of an Ingress whose key does not
+match, whose chain is out of order or which expired, instead of only
+reporting them in warning events.`)
+	flag.IntVar(&F.WebhookPort, "webhook-port", 8443,
+		`Port to serve admission webhooks on over HTTPS.`)
+	flag.StringVar(&F.WebhookCertFile, "webhook-cert-file", "",
+		`Path to the PEM encoded certificate served by the admission webhook server.
+The file is reloaded when it changes.`)
+	flag.StringVar(&F.WebhookKeyFile, "webhook-key-file", "",
+		`Path to the PEM encoded private key of -webhook-cert-file.`)
+	flag.StringVar(&F.WebhookCAFile, "webhook-ca-file", "",
+		`Path to the PEM encoded CA bundle with which the API server verifies
+-webhook-cert-file. If unset, the system trust roots of the API server are used.`)
+	flag.StringVar(&F.ConversionWebhookService, "conversion-webhook-service", "",
+		`Optional, namespace/name of the Service through which the API server reaches
+the admission webhook server on port 443. If set along with
+-enable-admission-webhook, the API server calls the controller to convert
+BackendConfigs between versions. Otherwise only their apiVersion is changed.`)
 }
 
 type RateLimitSpecs struct {
@@ -0,0 +1,74 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package frontendconfig
+
+import (
+	"errors"
+
+	extensions "k8s.io/api/extensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+
+	"k8s.io/ingress-gce/pkg/annotations"
+	apisfrontendconfig "k8s.io/ingress-gce/pkg/apis/frontendconfig"
+	frontendconfigv1beta1 "k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1"
+	"k8s.io/ingress-gce/pkg/crd"
+)
+
+var (
+	ErrFrontendConfigDoesNotExist = errors.New("no FrontendConfig for ingress exists.")
+	ErrFrontendConfigFailedToGet  = errors.New("client had error getting FrontendConfig for ingress.")
+)
+
+// CRDMeta returns the metadata of the FrontendConfig CRD.
+func CRDMeta() *crd.CRDMeta {
+	meta := crd.NewCRDMeta(
+		apisfrontendconfig.GroupName,
+		"v1beta1",
+		"FrontendConfig",
+		"FrontendConfigList",
+		"frontendconfig",
+		"frontendconfigs",
+	)
+	meta.AddValidationInfo("k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1.FrontendConfig", frontendconfigv1beta1.GetOpenAPIDefinitions)
+	return meta
+}
+
+// FrontendConfigForIngress returns the FrontendConfig referenced by the
+// given Ingress, or nil if the Ingress does not reference one.
+func FrontendConfigForIngress(frontendConfigLister cache.Store, ing *extensions.Ingress) (*frontendconfigv1beta1.FrontendConfig, error) {
+	configName := annotations.FromIngress(ing).FrontendConfig()
+	if configName == "" {
+		return nil, nil
+	}
+
+	obj, exists, err := frontendConfigLister.Get(
+		&frontendconfigv1beta1.FrontendConfig{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      configName,
+				Namespace: ing.Namespace,
+			},
+		})
+	if err != nil {
+		return nil, ErrFrontendConfigFailedToGet
+	}
+	if !exists {
+		return nil, ErrFrontendConfigDoesNotExist
+	}
+
+	return obj.(*frontendconfigv1beta1.FrontendConfig), nil
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package frontendconfig
+
+import (
+	"reflect"
+	"testing"
+
+	extensions "k8s.io/api/extensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+
+	"k8s.io/ingress-gce/pkg/annotations"
+	frontendconfigv1beta1 "k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1"
+)
+
+func TestFrontendConfigForIngress(t *testing.T) {
+	testConfig := &frontendconfigv1beta1.FrontendConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "config-test",
+			Namespace: "test",
+		},
+	}
+	store := cache.NewStore(cache.MetaNamespaceKeyFunc)
+	store.Add(testConfig)
+
+	newIngress := func(namespace, configName string) *extensions.Ingress {
+		ing := &extensions.Ingress{
+			ObjectMeta: metav1.ObjectMeta{Name: "ing", Namespace: namespace},
+		}
+		if configName != "" {
+			ing.Annotations = map[string]string{annotations.FrontendConfigKey: configName}
+		}
+		return ing
+	}
+
+	testCases := []struct {
+		desc           string
+		ing            *extensions.Ingress
+		expectedConfig *frontendconfigv1beta1.FrontendConfig
+		expectedErr    error
+	}{
+		{
+			desc: "ingress with no frontend config",
+			ing:  newIngress("test", ""),
+		},
+		{
+			desc:           "ingress with frontend config",
+			ing:            newIngress("test", "config-test"),
+			expectedConfig: testConfig,
+		},
+		{
+			desc:        "frontend config does not exist",
+			ing:         newIngress("test", "config-other"),
+			expectedErr: ErrFrontendConfigDoesNotExist,
+		},
+		{
+			desc:        "frontend config in a different namespace",
+			ing:         newIngress("other-namespace", "config-test"),
+			expectedErr: ErrFrontendConfigDoesNotExist,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			config, err := FrontendConfigForIngress(store, tc.ing)
+			if err != tc.expectedErr {
+				t.Errorf("FrontendConfigForIngress() = _, %v, want %v", err, tc.expectedErr)
+			}
+			if !reflect.DeepEqual(config, tc.expectedConfig) {
+				t.Errorf("FrontendConfigForIngress() = %+v, want %+v", config, tc.expectedConfig)
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package frontendconfig
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+	frontendconfigv1beta1 "k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1"
+)
+
+var supportedQuicOverrides = map[string]bool{
+	"NONE":    true,
+	"ENABLE":  true,
+	"DISABLE": true,
+}
+
+var supportedRedirectResponseCodes = map[string]bool{
+	"MOVED_PERMANENTLY_DEFAULT": true,
+	"FOUND":                     true,
+	"SEE_OTHER":                 true,
+	"TEMPORARY_REDIRECT":        true,
+	"PERMANENT_REDIRECT":        true,
+}
+
+// Validate returns an error if the FrontendConfig cannot be applied to the
+// frontend resources of a load balancer.
+func Validate(feConfig *frontendconfigv1beta1.FrontendConfig) error {
+	if feConfig == nil {
+		return nil
+	}
+	for _, validate := range []func(*frontendconfigv1beta1.FrontendConfig) error{
+		validateSslPolicy,
+		validateQuicOverride,
+		validateRedirectToHttps,
+	} {
+		if err := validate(feConfig); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateSslPolicy(feConfig *frontendconfigv1beta1.FrontendConfig) error {
+	// An empty SslPolicy removes the SSL policy from the target proxy.
+	if feConfig.Spec.SslPolicy == nil || *feConfig.Spec.SslPolicy == "" {
+		return nil
+	}
+	if msgs := validation.IsDNS1035Label(*feConfig.Spec.SslPolicy); len(msgs) > 0 {
+		return fmt.Errorf("invalid SslPolicy name %q: %s", *feConfig.Spec.SslPolicy, strings.Join(msgs, ", "))
+	}
+	return nil
+}
+
+func validateQuicOverride(feConfig *frontendconfigv1beta1.FrontendConfig) error {
+	if feConfig.Spec.QuicOverride == nil {
+		return nil
+	}
+	if _, ok := supportedQuicOverrides[*feConfig.Spec.QuicOverride]; !ok {
+		return fmt.Errorf("unsupported QuicOverride: %s, should be one of NONE, ENABLE, or DISABLE", *feConfig.Spec.QuicOverride)
+	}
+	return nil
+}
+
+func validateRedirectToHttps(feConfig *frontendconfigv1beta1.FrontendConfig) error {
+	if feConfig.Spec.RedirectToHttps == nil || feConfig.Spec.RedirectToHttps.ResponseCodeName == "" {
+		return nil
+	}
+	if _, ok := supportedRedirectResponseCodes[feConfig.Spec.RedirectToHttps.ResponseCodeName]; !ok {
+		return fmt.Errorf("unsupported ResponseCodeName: %s, should be one of MOVED_PERMANENTLY_DEFAULT, FOUND, SEE_OTHER, TEMPORARY_REDIRECT, or PERMANENT_REDIRECT",
+			feConfig.Spec.RedirectToHttps.ResponseCodeName)
+	}
+	return nil
+}
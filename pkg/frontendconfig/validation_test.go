@@ -0,0 +1,84 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package frontendconfig
+
+import (
+	"testing"
+
+	frontendconfigv1beta1 "k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1"
+)
+
+func TestValidate(t *testing.T) {
+	str := func(s string) *string { return &s }
+
+	testCases := []struct {
+		desc        string
+		spec        frontendconfigv1beta1.FrontendConfigSpec
+		expectError bool
+	}{
+		{
+			desc: "empty spec",
+		},
+		{
+			desc: "valid spec",
+			spec: frontendconfigv1beta1.FrontendConfigSpec{
+				SslPolicy:    str("my-policy"),
+				QuicOverride: str("ENABLE"),
+				RedirectToHttps: &frontendconfigv1beta1.HttpsRedirectConfig{
+					Enabled:          true,
+					ResponseCodeName: "PERMANENT_REDIRECT",
+				},
+			},
+		},
+		{
+			desc: "empty ssl policy",
+			spec: frontendconfigv1beta1.FrontendConfigSpec{SslPolicy: str("")},
+		},
+		{
+			desc:        "invalid ssl policy name",
+			spec:        frontendconfigv1beta1.FrontendConfigSpec{SslPolicy: str("My_Policy")},
+			expectError: true,
+		},
+		{
+			desc:        "unsupported quic override",
+			spec:        frontendconfigv1beta1.FrontendConfigSpec{QuicOverride: str("AUTO")},
+			expectError: true,
+		},
+		{
+			desc: "redirect without response code",
+			spec: frontendconfigv1beta1.FrontendConfigSpec{
+				RedirectToHttps: &frontendconfigv1beta1.HttpsRedirectConfig{Enabled: true},
+			},
+		},
+		{
+			desc: "unsupported redirect response code",
+			spec: frontendconfigv1beta1.FrontendConfigSpec{
+				RedirectToHttps: &frontendconfigv1beta1.HttpsRedirectConfig{Enabled: true, ResponseCodeName: "302"},
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := Validate(&frontendconfigv1beta1.FrontendConfig{Spec: tc.spec})
+			if gotErr := err != nil; gotErr != tc.expectError {
+				t.Errorf("Validate() = %v, want error = %v", err, tc.expectError)
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package healthchecks
+
+import (
+	computealpha "google.golang.org/api/compute/v0.alpha"
+	"k8s.io/kubernetes/pkg/cloudprovider/providers/gce"
+
+	"k8s.io/ingress-gce/pkg/composite"
+)
+
+// gceHealthChecks implements HealthCheckProvider with gce.Cloud, adding the
+// operations on regional health checks which gce.Cloud does not provide.
+type gceHealthChecks struct {
+	*gce.Cloud
+}
+
+// NewGCEHealthChecks returns a HealthCheckProvider which manages health
+// checks with the given cloud.
+func NewGCEHealthChecks(cloud *gce.Cloud) HealthCheckProvider {
+	return &gceHealthChecks{Cloud: cloud}
+}
+
+// CreateAlphaRegionHealthCheck implements HealthCheckProvider.
+func (g *gceHealthChecks) CreateAlphaRegionHealthCheck(hc *computealpha.HealthCheck) error {
+	return composite.CreateRegionalHealthCheck(hc, g.Cloud)
+}
+
+// UpdateAlphaRegionHealthCheck implements HealthCheckProvider.
+func (g *gceHealthChecks) UpdateAlphaRegionHealthCheck(hc *computealpha.HealthCheck) error {
+	return composite.UpdateRegionalHealthCheck(hc, g.Cloud)
+}
+
+// GetAlphaRegionHealthCheck implements HealthCheckProvider.
+func (g *gceHealthChecks) GetAlphaRegionHealthCheck(name string) (*computealpha.HealthCheck, error) {
+	return composite.GetRegionalHealthCheck(name, g.Cloud)
+}
+
+// DeleteAlphaRegionHealthCheck implements HealthCheckProvider.
+func (g *gceHealthChecks) DeleteAlphaRegionHealthCheck(name string) error {
+	return composite.DeleteRegionalHealthCheck(name, g.Cloud)
+}
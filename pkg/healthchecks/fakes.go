@@ -29,15 +29,17 @@ import (
 // NewFakeHealthCheckProvider returns a new FakeHealthChecks.
 func NewFakeHealthCheckProvider() *FakeHealthCheckProvider {
 	return &FakeHealthCheckProvider{
-		http:    make(map[string]compute.HttpHealthCheck),
-		generic: make(map[string]computealpha.HealthCheck),
+		http:     make(map[string]compute.HttpHealthCheck),
+		generic:  make(map[string]computealpha.HealthCheck),
+		regional: make(map[string]computealpha.HealthCheck),
 	}
 }
 
 // FakeHealthCheckProvider fakes out health checks.
 type FakeHealthCheckProvider struct {
-	http    map[string]compute.HttpHealthCheck
-	generic map[string]computealpha.HealthCheck
+	http     map[string]compute.HttpHealthCheck
+	generic  map[string]computealpha.HealthCheck
+	regional map[string]computealpha.HealthCheck
 }
 
 // CreateHttpHealthCheck fakes out http health check creation.
@@ -166,3 +168,41 @@ func (f *FakeHealthCheckProvider) UpdateBetaHealthCheck(hc *computebeta.HealthCh
 	f.generic[hc.Name] = *alphaHC
 	return nil
 }
+
+// CreateAlphaRegionHealthCheck fakes out regional health check creation.
+func (f *FakeHealthCheckProvider) CreateAlphaRegionHealthCheck(hc *computealpha.HealthCheck) error {
+	v := *hc
+	v.SelfLink = (&cloud.ResourceID{ProjectID: "mock-project", Resource: "healthChecks", Key: meta.RegionalKey(hc.Name, "us-central1")}).SelfLink(meta.VersionAlpha)
+	f.regional[hc.Name] = v
+	return nil
+}
+
+// UpdateAlphaRegionHealthCheck sends the given regional health check as an
+// update.
+func (f *FakeHealthCheckProvider) UpdateAlphaRegionHealthCheck(hc *computealpha.HealthCheck) error {
+	if _, exists := f.regional[hc.Name]; !exists {
+		return utils.FakeGoogleAPINotFoundErr()
+	}
+
+	f.regional[hc.Name] = *hc
+	return nil
+}
+
+// GetAlphaRegionHealthCheck fakes out getting a regional health check from
+// the cloud.
+func (f *FakeHealthCheckProvider) GetAlphaRegionHealthCheck(name string) (*computealpha.HealthCheck, error) {
+	if hc, found := f.regional[name]; found {
+		return &hc, nil
+	}
+	return nil, utils.FakeGoogleAPINotFoundErr()
+}
+
+// DeleteAlphaRegionHealthCheck fakes out deleting a regional health check.
+func (f *FakeHealthCheckProvider) DeleteAlphaRegionHealthCheck(name string) error {
+	if _, exists := f.regional[name]; !exists {
+		return utils.FakeGoogleAPINotFoundErr()
+	}
+
+	delete(f.regional, name)
+	return nil
+}
@@ -28,6 +28,7 @@ import (
 
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/ingress-gce/pkg/annotations"
+	backendconfigv1 "k8s.io/ingress-gce/pkg/apis/backendconfig/v1"
 	"k8s.io/ingress-gce/pkg/utils"
 	"k8s.io/klog"
 	"k8s.io/kubernetes/pkg/cloudprovider/providers/gce/cloud/meta"
@@ -62,6 +63,8 @@ const (
 	// backends, the port or named port specified in the Backend Service is
 	// used for health checking.
 	UseServingPortSpecification = "USE_SERVING_PORT"
+	// USE_FIXED_PORT: The port number in port is used for health checking.
+	UseFixedPortSpecification = "USE_FIXED_PORT"
 )
 
 // HealthChecks manages health checks.
@@ -97,13 +100,14 @@ func (h *HealthChecks) New(sp utils.ServicePort) *HealthCheck {
 	hc.Name = sp.BackendName(h.namer)
 	hc.Port = sp.NodePort
 	hc.RequestPath = h.pathFromSvcPort(sp)
+	hc.ForILB = sp.L7ILBEnabled
 	return hc
 }
 
 // Sync retrieves a health check based on port, checks type and settings and updates/creates if necessary.
 // Sync is only called by the backends.Add func - it's not a pool like other resources.
 func (h *HealthChecks) Sync(hc *HealthCheck) (string, error) {
-	existingHC, err := h.Get(hc.Name, hc.Version())
+	existingHC, err := h.Get(hc.Name, hc.Version(), hc.Scope())
 	if err != nil {
 		if !utils.IsHTTPErrorCode(err, http.StatusNotFound) {
 			return "", err
@@ -113,7 +117,7 @@ func (h *HealthChecks) Sync(hc *HealthCheck) (string, error) {
 			return "", err
 		}
 
-		return h.getHealthCheckLink(hc.Name, hc.Version())
+		return h.getHealthCheckLink(hc.Name, hc.Version(), hc.Scope())
 	}
 
 	if needToUpdate(existingHC, hc) {
@@ -121,6 +125,18 @@ func (h *HealthChecks) Sync(hc *HealthCheck) (string, error) {
 		return existingHC.SelfLink, err
 	}
 
+	if hc.backendConfigHC != nil {
+		if backendConfigApplied(existingHC, hc.backendConfigHC) {
+			klog.V(2).Infof("Health check %v already has the settings specified in BackendConfig", hc.Name)
+			return existingHC.SelfLink, nil
+		}
+		// Only the fields specified in the BackendConfig are reconciled, all
+		// other settings of the existing health check are preserved.
+		klog.V(2).Infof("Updating health check %v with settings specified in BackendConfig", hc.Name)
+		err = h.update(existingHC, mergeBackendConfigSettings(existingHC, hc))
+		return existingHC.SelfLink, err
+	}
+
 	if existingHC.RequestPath != hc.RequestPath {
 		// TODO: reconcile health checks, and compare headers interval etc.
 		// Currently Ingress doesn't expose all the health check params
@@ -136,6 +152,10 @@ func (h *HealthChecks) Sync(hc *HealthCheck) (string, error) {
 func (h *HealthChecks) create(hc *HealthCheck) error {
 	switch hc.Version() {
 	case meta.VersionAlpha:
+		if hc.ForILB {
+			klog.V(2).Infof("Creating alpha region health check with protocol %v", hc.Type)
+			return h.cloud.CreateAlphaRegionHealthCheck(hc.ToAlphaComputeHealthCheck())
+		}
 		klog.V(2).Infof("Creating alpha health check with protocol %v", hc.Type)
 		return h.cloud.CreateAlphaHealthCheck(hc.ToAlphaComputeHealthCheck())
 	case meta.VersionBeta:
@@ -160,6 +180,10 @@ func (h *HealthChecks) create(hc *HealthCheck) error {
 func (h *HealthChecks) update(oldHC, newHC *HealthCheck) error {
 	switch newHC.Version() {
 	case meta.VersionAlpha:
+		if newHC.ForILB {
+			klog.V(2).Infof("Updating alpha region health check with protocol %v", newHC.Type)
+			return h.cloud.UpdateAlphaRegionHealthCheck(mergeHealthcheck(oldHC, newHC).ToAlphaComputeHealthCheck())
+		}
 		klog.V(2).Infof("Updating alpha health check with protocol %v", newHC.Type)
 		return h.cloud.UpdateAlphaHealthCheck(mergeHealthcheck(oldHC, newHC).ToAlphaComputeHealthCheck())
 	case meta.VersionBeta:
@@ -199,11 +223,49 @@ func mergeHealthcheck(oldHC, newHC *HealthCheck) *HealthCheck {
 		newHC.PortSpecification = ""
 		newHC.Port = port
 	}
+	// Settings specified in the BackendConfig always win over the
+	// existing ones.
+	if newHC.backendConfigHC != nil {
+		newHC.UpdateFromBackendConfig(newHC.backendConfigHC)
+	}
 	return newHC
 }
 
-func (h *HealthChecks) getHealthCheckLink(name string, version meta.Version) (string, error) {
-	hc, err := h.Get(name, version)
+// mergeBackendConfigSettings returns a copy of the existing health check
+// with the settings specified in the BackendConfig of the desired health
+// check applied on top of it.
+func mergeBackendConfigSettings(existingHC, newHC *HealthCheck) *HealthCheck {
+	merged := *existingHC
+	merged.ForNEG = newHC.ForNEG
+	merged.ForILB = newHC.ForILB
+	merged.UpdateFromBackendConfig(newHC.backendConfigHC)
+	return &merged
+}
+
+// backendConfigApplied returns true if all settings specified in the
+// BackendConfig are already present on the given health check.
+func backendConfigApplied(hc *HealthCheck, c *backendconfigv1.HealthCheckConfig) bool {
+	switch {
+	case c.CheckIntervalSec != nil && hc.CheckIntervalSec != *c.CheckIntervalSec:
+		return false
+	case c.TimeoutSec != nil && hc.TimeoutSec != *c.TimeoutSec:
+		return false
+	case c.HealthyThreshold != nil && hc.HealthyThreshold != *c.HealthyThreshold:
+		return false
+	case c.UnhealthyThreshold != nil && hc.UnhealthyThreshold != *c.UnhealthyThreshold:
+		return false
+	case c.Type != nil && hc.Type != *c.Type:
+		return false
+	case c.Port != nil && hc.Port != *c.Port:
+		return false
+	case c.RequestPath != nil && hc.RequestPath != *c.RequestPath:
+		return false
+	}
+	return true
+}
+
+func (h *HealthChecks) getHealthCheckLink(name string, version meta.Version, scope meta.KeyType) (string, error) {
+	hc, err := h.Get(name, version, scope)
 	if err != nil {
 		return "", err
 	}
@@ -211,13 +273,26 @@ func (h *HealthChecks) getHealthCheckLink(name string, version meta.Version) (st
 }
 
 // Delete deletes the health check by port.
-func (h *HealthChecks) Delete(name string) error {
-	klog.V(2).Infof("Deleting health check %v", name)
+func (h *HealthChecks) Delete(name string, scope meta.KeyType) error {
+	klog.V(2).Infof("Deleting %s health check %v", scope, name)
+	if scope == meta.Regional {
+		return h.cloud.DeleteAlphaRegionHealthCheck(name)
+	}
 	return h.cloud.DeleteHealthCheck(name)
 }
 
-// Get returns the health check by port
-func (h *HealthChecks) Get(name string, version meta.Version) (*HealthCheck, error) {
+// Get returns the health check by port. Regional health checks are only
+// served by the alpha API, regardless of the given version.
+func (h *HealthChecks) Get(name string, version meta.Version, scope meta.KeyType) (*HealthCheck, error) {
+	if scope == meta.Regional {
+		alphaHC, err := h.cloud.GetAlphaRegionHealthCheck(name)
+		if err != nil {
+			return nil, err
+		}
+		hc := NewHealthCheck(alphaHC)
+		hc.ForILB = true
+		return hc, nil
+	}
 	var hc *computealpha.HealthCheck
 	var err error
 	switch version {
@@ -301,6 +376,13 @@ type HealthCheck struct {
 	computealpha.HTTPHealthCheck
 	computealpha.HealthCheck
 	ForNEG bool
+	// ForILB is true if the health check is used by the regional backend
+	// service of an internal HTTP(S) load balancer.
+	ForILB bool
+
+	// backendConfigHC holds the settings from the BackendConfig, if any,
+	// that have been applied to this health check.
+	backendConfigHC *backendconfigv1.HealthCheckConfig
 }
 
 // NewHealthCheck creates a HealthCheck which abstracts nested structs away
@@ -328,6 +410,40 @@ func NewHealthCheck(hc *computealpha.HealthCheck) *HealthCheck {
 	return v
 }
 
+// UpdateFromBackendConfig applies the settings specified in the BackendConfig
+// to the health check. These settings take precedence over the defaults and
+// over the settings inferred from the readiness probe.
+func (hc *HealthCheck) UpdateFromBackendConfig(c *backendconfigv1.HealthCheckConfig) {
+	if c.CheckIntervalSec != nil {
+		hc.CheckIntervalSec = *c.CheckIntervalSec
+	}
+	if c.TimeoutSec != nil {
+		hc.TimeoutSec = *c.TimeoutSec
+	}
+	if c.HealthyThreshold != nil {
+		hc.HealthyThreshold = *c.HealthyThreshold
+	}
+	if c.UnhealthyThreshold != nil {
+		hc.UnhealthyThreshold = *c.UnhealthyThreshold
+	}
+	if c.Type != nil {
+		hc.Type = *c.Type
+	}
+	if c.RequestPath != nil {
+		hc.RequestPath = *c.RequestPath
+	}
+	if c.Port != nil {
+		hc.Port = *c.Port
+		if hc.ForNEG {
+			// Health check the specified port instead of the serving
+			// port of each network endpoint.
+			hc.PortSpecification = UseFixedPortSpecification
+		}
+	}
+	hc.Description = "Kubernetes L7 health check generated with BackendConfig settings."
+	hc.backendConfigHC = c
+}
+
 // Protocol returns the type cased to AppProtocol
 func (hc *HealthCheck) Protocol() annotations.AppProtocol {
 	return annotations.AppProtocol(hc.Type)
@@ -341,8 +457,8 @@ func (hc *HealthCheck) ToComputeHealthCheck() (*compute.HealthCheck, error) {
 
 // ToBetaComputeHealthCheck returns a valid computebeta.HealthCheck object
 func (hc *HealthCheck) ToBetaComputeHealthCheck() (*computebeta.HealthCheck, error) {
-	// Cannot specify both portSpecification and port field.
-	if len(hc.PortSpecification) > 0 {
+	// Cannot specify both portSpecification USE_SERVING_PORT and port field.
+	if hc.PortSpecification == UseServingPortSpecification {
 		hc.Port = 0
 	}
 	hc.merge()
@@ -351,8 +467,8 @@ func (hc *HealthCheck) ToBetaComputeHealthCheck() (*computebeta.HealthCheck, err
 
 // ToAlphaComputeHealthCheck returns a valid computealpha.HealthCheck object
 func (hc *HealthCheck) ToAlphaComputeHealthCheck() *computealpha.HealthCheck {
-	// Cannot specify both portSpecification and port field.
-	if len(hc.PortSpecification) > 0 {
+	// Cannot specify both portSpecification USE_SERVING_PORT and port field.
+	if hc.PortSpecification == UseServingPortSpecification {
 		hc.Port = 0
 	}
 	hc.merge()
@@ -384,13 +500,25 @@ func (hc *HealthCheck) isHttp2() bool {
 
 // Version returns the appropriate API version to handle the health check
 // Use Beta API for NEG as PORT_SPECIFICATION is required, and HTTP2
+// Use Alpha API for ILB as regional health checks are only served by it.
 func (hc *HealthCheck) Version() meta.Version {
+	if hc.ForILB {
+		return meta.VersionAlpha
+	}
 	if hc.isHttp2() || hc.ForNEG {
 		return meta.VersionBeta
 	}
 	return meta.VersionGA
 }
 
+// Scope returns the scope of the health check.
+func (hc *HealthCheck) Scope() meta.KeyType {
+	if hc.ForILB {
+		return meta.Regional
+	}
+	return meta.Global
+}
+
 func needToUpdate(old, new *HealthCheck) bool {
 	if old.Protocol() != new.Protocol() {
 		klog.V(2).Infof("Updating health check %v because it has protocol %v but need %v", old.Name, old.Type, new.Type)
@@ -18,10 +18,12 @@ package healthchecks
 
 import (
 	"net/http"
+	"strings"
 	"testing"
 
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/ingress-gce/pkg/annotations"
+	backendconfigv1 "k8s.io/ingress-gce/pkg/apis/backendconfig/v1"
 	"k8s.io/ingress-gce/pkg/utils"
 	"k8s.io/kubernetes/pkg/cloudprovider/providers/gce/cloud/meta"
 )
@@ -166,7 +168,7 @@ func TestHealthCheckDelete(t *testing.T) {
 	hcp.CreateHealthCheck(v1hc)
 
 	// Delete only HTTP 1234
-	err = healthChecks.Delete(namer.IGBackend(1234))
+	err = healthChecks.Delete(namer.IGBackend(1234), meta.Global)
 	if err != nil {
 		t.Errorf("unexpected error when deleting health check, err: %v", err)
 	}
@@ -178,7 +180,7 @@ func TestHealthCheckDelete(t *testing.T) {
 	}
 
 	// Delete only HTTP 1234
-	err = healthChecks.Delete(namer.IGBackend(1234))
+	err = healthChecks.Delete(namer.IGBackend(1234), meta.Global)
 	if err == nil {
 		t.Errorf("expected not-found error when deleting health check, err: %v", err)
 	}
@@ -196,7 +198,7 @@ func TestHTTP2HealthCheckDelete(t *testing.T) {
 	hcp.CreateAlphaHealthCheck(alphahc)
 
 	// Delete only HTTP2 1234
-	err := healthChecks.Delete(namer.IGBackend(1234))
+	err := healthChecks.Delete(namer.IGBackend(1234), meta.Global)
 	if err != nil {
 		t.Errorf("unexpected error when deleting health check, err: %v", err)
 	}
@@ -224,7 +226,7 @@ func TestHealthCheckUpdate(t *testing.T) {
 	hcp.CreateHealthCheck(v1hc)
 
 	// Verify the health check exists
-	_, err = healthChecks.Get(hc.Name, meta.VersionGA)
+	_, err = healthChecks.Get(hc.Name, meta.VersionGA, meta.Global)
 	if err != nil {
 		t.Fatalf("expected the health check to exist, err: %v", err)
 	}
@@ -237,7 +239,7 @@ func TestHealthCheckUpdate(t *testing.T) {
 	}
 
 	// Verify the health check exists
-	_, err = healthChecks.Get(hc.Name, meta.VersionGA)
+	_, err = healthChecks.Get(hc.Name, meta.VersionGA, meta.Global)
 	if err != nil {
 		t.Fatalf("expected the health check to exist, err: %v", err)
 	}
@@ -255,7 +257,7 @@ func TestHealthCheckUpdate(t *testing.T) {
 	}
 
 	// Verify the health check exists. HTTP2 is alpha-only.
-	_, err = healthChecks.Get(hc.Name, meta.VersionAlpha)
+	_, err = healthChecks.Get(hc.Name, meta.VersionAlpha, meta.Global)
 	if err != nil {
 		t.Fatalf("expected the health check to exist, err: %v", err)
 	}
@@ -275,7 +277,7 @@ func TestHealthCheckUpdate(t *testing.T) {
 	}
 
 	// Verify the health check exists.
-	hc, err = healthChecks.Get(hc.Name, meta.VersionAlpha)
+	hc, err = healthChecks.Get(hc.Name, meta.VersionAlpha, meta.Global)
 	if err != nil {
 		t.Fatalf("expected the health check to exist, err: %v", err)
 	}
@@ -295,7 +297,7 @@ func TestHealthCheckUpdate(t *testing.T) {
 	}
 
 	// Verify the health check exists.
-	hc, err = healthChecks.Get(hc.Name, meta.VersionAlpha)
+	hc, err = healthChecks.Get(hc.Name, meta.VersionAlpha, meta.Global)
 	if err != nil {
 		t.Fatalf("expected the health check to exist, err: %v", err)
 	}
@@ -319,7 +321,7 @@ func TestAlphaHealthCheck(t *testing.T) {
 		t.Fatalf("got %v, want nil", err)
 	}
 
-	ret, err := healthChecks.Get(hc.Name, meta.VersionAlpha)
+	ret, err := healthChecks.Get(hc.Name, meta.VersionAlpha, meta.Global)
 	if err != nil {
 		t.Fatalf("got %v, want nil", err)
 	}
@@ -330,3 +332,111 @@ func TestAlphaHealthCheck(t *testing.T) {
 		t.Errorf("got ret.PortSpecification = %q, want %q", UseServingPortSpecification, ret.PortSpecification)
 	}
 }
+
+func TestHealthCheckBackendConfig(t *testing.T) {
+	hcp := NewFakeHealthCheckProvider()
+	healthChecks := NewHealthChecker(hcp, "/", "/healthz", namer, defaultBackendSvc)
+
+	// Manually insert a health check which was modified by hand.
+	hc := DefaultHealthCheck(3000, annotations.ProtocolHTTP)
+	hc.Name = namer.IGBackend(3000)
+	hc.Host = "hand-modified.example.com"
+	v1hc, err := hc.ToComputeHealthCheck()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	hcp.CreateHealthCheck(v1hc)
+
+	var interval int64 = 20
+	path := "/from-backendconfig"
+	sp := utils.ServicePort{NodePort: 3000, Protocol: annotations.ProtocolHTTP}
+	hc = healthChecks.New(sp)
+	hc.UpdateFromBackendConfig(&backendconfigv1.HealthCheckConfig{
+		CheckIntervalSec: &interval,
+		RequestPath:      &path,
+	})
+	if _, err := healthChecks.Sync(hc); err != nil {
+		t.Fatalf("unexpected err while syncing healthcheck, err %v", err)
+	}
+
+	got, err := healthChecks.Get(hc.Name, meta.VersionGA, meta.Global)
+	if err != nil {
+		t.Fatalf("expected the health check to exist, err: %v", err)
+	}
+	if got.RequestPath != path {
+		t.Errorf("got RequestPath %q, want %q", got.RequestPath, path)
+	}
+	if got.CheckIntervalSec != interval {
+		t.Errorf("got CheckIntervalSec %d, want %d", got.CheckIntervalSec, interval)
+	}
+	// Fields not specified in the BackendConfig should not be clobbered.
+	if got.Host != "hand-modified.example.com" {
+		t.Errorf("got Host %q, want %q", got.Host, "hand-modified.example.com")
+	}
+	if got.UnhealthyThreshold != DefaultUnhealthyThreshold {
+		t.Errorf("got UnhealthyThreshold %d, want %d", got.UnhealthyThreshold, DefaultUnhealthyThreshold)
+	}
+}
+
+func TestNEGHealthCheckBackendConfigPort(t *testing.T) {
+	hcp := NewFakeHealthCheckProvider()
+	healthChecks := NewHealthChecker(hcp, "/", "/healthz", namer, defaultBackendSvc)
+
+	var port int64 = 8080
+	sp := utils.ServicePort{NodePort: 8000, Protocol: annotations.ProtocolHTTP, NEGEnabled: true}
+	hc := healthChecks.New(sp)
+	hc.UpdateFromBackendConfig(&backendconfigv1.HealthCheckConfig{Port: &port})
+	if _, err := healthChecks.Sync(hc); err != nil {
+		t.Fatalf("got %v, want nil", err)
+	}
+
+	ret, err := healthChecks.Get(hc.Name, meta.VersionAlpha, meta.Global)
+	if err != nil {
+		t.Fatalf("got %v, want nil", err)
+	}
+	if ret.Port != port {
+		t.Errorf("got ret.Port == %d, want %d", ret.Port, port)
+	}
+	if ret.PortSpecification != UseFixedPortSpecification {
+		t.Errorf("got ret.PortSpecification = %q, want %q", ret.PortSpecification, UseFixedPortSpecification)
+	}
+}
+
+func TestILBHealthCheck(t *testing.T) {
+	hcp := NewFakeHealthCheckProvider()
+	healthChecks := NewHealthChecker(hcp, "/", "/healthz", namer, defaultBackendSvc)
+	sp := utils.ServicePort{NodePort: 8000, Protocol: annotations.ProtocolHTTP, NEGEnabled: true, L7ILBEnabled: true}
+	hc := healthChecks.New(sp)
+	if hc.Version() != meta.VersionAlpha {
+		t.Errorf("got hc.Version() = %q, want %q", hc.Version(), meta.VersionAlpha)
+	}
+	link, err := healthChecks.Sync(hc)
+	if err != nil {
+		t.Fatalf("got %v, want nil", err)
+	}
+	if !strings.Contains(link, "/regions/us-central1/healthChecks/") {
+		t.Errorf("got link %q, want a regional health check", link)
+	}
+
+	// The health check must only exist in the region.
+	if _, err := healthChecks.Get(hc.Name, meta.VersionAlpha, meta.Global); !utils.IsHTTPErrorCode(err, http.StatusNotFound) {
+		t.Errorf("got %v getting the global health check, want 404", err)
+	}
+	ret, err := healthChecks.Get(hc.Name, meta.VersionAlpha, meta.Regional)
+	if err != nil {
+		t.Fatalf("got %v, want nil", err)
+	}
+	if !ret.ForILB {
+		t.Errorf("got ret.ForILB = false, want true")
+	}
+	if ret.PortSpecification != UseServingPortSpecification {
+		t.Errorf("got ret.PortSpecification = %q, want %q", ret.PortSpecification, UseServingPortSpecification)
+	}
+
+	if err := healthChecks.Delete(hc.Name, meta.Regional); err != nil {
+		t.Fatalf("got %v, want nil", err)
+	}
+	if _, err := healthChecks.Get(hc.Name, meta.VersionAlpha, meta.Regional); !utils.IsHTTPErrorCode(err, http.StatusNotFound) {
+		t.Errorf("got %v getting the deleted health check, want 404", err)
+	}
+}
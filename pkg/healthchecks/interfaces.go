@@ -42,12 +42,19 @@ type HealthCheckProvider interface {
 	GetAlphaHealthCheck(name string) (*computealpha.HealthCheck, error)
 	GetBetaHealthCheck(name string) (*computebeta.HealthCheck, error)
 	GetHealthCheck(name string) (*compute.HealthCheck, error)
+
+	// Regional health checks are used by internal HTTP(S) load balancers,
+	// in the region of the cloud.
+	CreateAlphaRegionHealthCheck(hc *computealpha.HealthCheck) error
+	UpdateAlphaRegionHealthCheck(hc *computealpha.HealthCheck) error
+	DeleteAlphaRegionHealthCheck(name string) error
+	GetAlphaRegionHealthCheck(name string) (*computealpha.HealthCheck, error)
 }
 
 // HealthChecker is an interface to manage cloud HTTPHealthChecks.
 type HealthChecker interface {
 	New(sp utils.ServicePort) *HealthCheck
 	Sync(hc *HealthCheck) (string, error)
-	Delete(name string) error
-	Get(name string, version meta.Version) (*HealthCheck, error)
+	Delete(name string, scope meta.KeyType) error
+	Get(name string, version meta.Version, scope meta.KeyType) (*HealthCheck, error)
 }
@@ -57,3 +57,33 @@ func (l *L7) checkStaticIP() (err error) {
 	l.ip = ip
 	return nil
 }
+
+// checkInternalStaticIP reserves the internal IP shared by the Forwarding
+// Rules of an internal L7. Unlike an external IP, an ephemeral internal IP
+// cannot be promoted and shared afterwards, so it is reserved before either
+// Forwarding Rule is created.
+func (l *L7) checkInternalStaticIP() (err error) {
+	if address, manageStaticIP := l.getEffectiveIP(); !manageStaticIP {
+		klog.V(3).Infof("Not managing user specified static IP %v", address)
+		return nil
+	}
+	staticIPName := l.namer.ForwardingRule(l.Name, utils.HTTPProtocol)
+	ip, _ := l.cloud.GetRegionalAddress(staticIPName)
+	if ip == nil {
+		klog.V(3).Infof("Creating internal static ip %v", staticIPName)
+		addr := &compute.Address{
+			Name:        staticIPName,
+			AddressType: "INTERNAL",
+			Subnetwork:  l.runtimeInfo.Subnetwork,
+		}
+		if err = l.cloud.ReserveRegionalAddress(addr); err != nil {
+			return err
+		}
+		ip, err = l.cloud.GetRegionalAddress(staticIPName)
+		if err != nil {
+			return err
+		}
+	}
+	l.ip = ip
+	return nil
+}
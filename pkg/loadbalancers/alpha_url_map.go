@@ -0,0 +1,315 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package loadbalancers
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	compute "google.golang.org/api/compute/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/ingress-gce/pkg/composite"
+	"k8s.io/ingress-gce/pkg/utils"
+	"k8s.io/klog"
+	"k8s.io/kubernetes/pkg/cloudprovider/providers/gce/cloud"
+	"k8s.io/kubernetes/pkg/cloudprovider/providers/gce/cloud/meta"
+)
+
+// ensureAlphaURLMap creates or updates the UrlMap of an L7 whose hosts have
+// route rules, or whose paths are rewritten or redirected, which only the
+// alpha API supports. gaMap is the same UrlMap without them, which describes
+// the L7 to the rest of the controller.
+func (l *L7) ensureAlphaURLMap(gaMap *compute.UrlMap) error {
+	expectedMap := toAlphaURLMap(l.Name, l.runtimeInfo.UrlMap, l.namer)
+	currentMap, err := l.cloud.GetAlphaURLMap(expectedMap.Name)
+	if utils.IgnoreHTTPNotFound(err) != nil {
+		return err
+	}
+
+	switch {
+	case currentMap == nil:
+		klog.V(3).Infof("Creating alpha URLMap %q", expectedMap.Name)
+		if err := l.cloud.CreateAlphaURLMap(expectedMap); err != nil {
+			return fmt.Errorf("CreateAlphaURLMap: %v", err)
+		}
+	case alphaMapsEqual(currentMap, expectedMap):
+		klog.V(4).Infof("URLMap for %q is unchanged", l.Name)
+	default:
+		klog.V(3).Infof("Updating alpha URLMap for %q", l.Name)
+		expectedMap.Fingerprint = currentMap.Fingerprint
+		if err := l.cloud.UpdateAlphaURLMap(expectedMap); err != nil {
+			return fmt.Errorf("UpdateAlphaURLMap: %v", err)
+		}
+	}
+
+	l.um = gaMap
+	l.alphaUm = expectedMap
+	return nil
+}
+
+// toAlphaURLMap translates the given GCEURLMap into an alpha url map. It is
+// laid out like toComputeURLMap, except that paths carry their rewrite or
+// redirect, and that the path matchers of hosts with route rules have route
+// rules instead of path rules, as a path matcher cannot have both. The paths
+// of such hosts are translated into route rules which are matched after those
+// of the host.
+func toAlphaURLMap(lbName string, g *utils.GCEURLMap, namer *utils.Namer) *composite.UrlMap {
+	m := &composite.UrlMap{
+		Version:        meta.VersionAlpha,
+		Name:           namer.UrlMap(lbName),
+		DefaultService: backendLink(*g.DefaultBackend, namer),
+	}
+
+	for _, hostRule := range g.HostRules {
+		pmName := getNameForPathMatcher(hostRule.Hostname)
+		m.HostRules = append(m.HostRules, &composite.HostRule{
+			Hosts:       []string{hostRule.Hostname},
+			PathMatcher: pmName,
+		})
+		pathMatcher := &composite.PathMatcher{
+			Name:           pmName,
+			DefaultService: m.DefaultService,
+		}
+
+		if len(hostRule.RouteRules) == 0 {
+			for _, rule := range hostRule.Paths {
+				pathRule := &composite.PathRule{Paths: []string{rule.Path}}
+				pathRule.Service, pathRule.RouteAction, pathRule.UrlRedirect = pathRuleActions(rule, namer)
+				pathMatcher.PathRules = append(pathMatcher.PathRules, pathRule)
+			}
+			m.PathMatchers = append(m.PathMatchers, pathMatcher)
+			continue
+		}
+
+		// Priorities start at 1, as the API omits a priority of 0.
+		for _, rule := range hostRule.RouteRules {
+			pathMatcher.RouteRules = append(pathMatcher.RouteRules, toHTTPRouteRule(rule, int64(len(pathMatcher.RouteRules)+1), namer))
+		}
+		for _, rule := range pathRouteRules(hostRule.Paths) {
+			routeRule := &composite.HttpRouteRule{
+				Priority:   int64(len(pathMatcher.RouteRules) + 1),
+				MatchRules: []*composite.HttpRouteRuleMatch{rule.match},
+			}
+			routeRule.Service, routeRule.RouteAction, routeRule.UrlRedirect = pathRuleActions(rule.path, namer)
+			pathMatcher.RouteRules = append(pathMatcher.RouteRules, routeRule)
+		}
+		m.PathMatchers = append(m.PathMatchers, pathMatcher)
+	}
+	return m
+}
+
+// toHTTPRouteRule translates the given route rule into an alpha route rule
+// with the given priority.
+func toHTTPRouteRule(rule utils.RouteRule, priority int64, namer *utils.Namer) *composite.HttpRouteRule {
+	match := &composite.HttpRouteRuleMatch{PrefixMatch: rule.Match.PathPrefix}
+	if match.PrefixMatch == "" {
+		match.PrefixMatch = "/"
+	}
+	for _, h := range rule.Match.Headers {
+		headerMatch := &composite.HttpHeaderMatch{HeaderName: h.Name, ExactMatch: h.Value}
+		if h.Value == "" {
+			headerMatch.PresentMatch = true
+		}
+		match.HeaderMatches = append(match.HeaderMatches, headerMatch)
+	}
+	for _, q := range rule.Match.QueryParameters {
+		queryMatch := &composite.HttpQueryParameterMatch{Name: q.Name, ExactMatch: q.Value}
+		if q.Value == "" {
+			queryMatch.PresentMatch = true
+		}
+		match.QueryParameterMatches = append(match.QueryParameterMatches, queryMatch)
+	}
+
+	routeRule := &composite.HttpRouteRule{
+		Priority:   priority,
+		MatchRules: []*composite.HttpRouteRuleMatch{match},
+	}
+	// A single backend receives all requests, whatever its weight.
+	if len(rule.Backends) == 1 {
+		routeRule.Service = backendLink(rule.Backends[0].Backend, namer)
+		return routeRule
+	}
+	routeRule.RouteAction = &composite.HttpRouteAction{}
+	for _, b := range rule.Backends {
+		routeRule.RouteAction.WeightedBackendServices = append(routeRule.RouteAction.WeightedBackendServices, &composite.WeightedBackendService{
+			BackendService: backendLink(b.Backend, namer),
+			Weight:         b.Weight,
+		})
+	}
+	return routeRule
+}
+
+// pathRuleActions returns what the url map does with the requests of the
+// given path: either the link of its backend with an optional rewrite, or
+// the redirect of the path.
+func pathRuleActions(rule utils.PathRule, namer *utils.Namer) (string, *composite.HttpRouteAction, *composite.HttpRedirectAction) {
+	if r := rule.Redirect; r != nil {
+		redirect := &composite.HttpRedirectAction{
+			HostRedirect:         r.Host,
+			HttpsRedirect:        r.HTTPS,
+			PathRedirect:         r.Path,
+			PrefixRedirect:       r.PathPrefix,
+			RedirectResponseCode: r.ResponseCode,
+			StripQuery:           r.StripQuery,
+		}
+		if redirect.RedirectResponseCode == "" {
+			redirect.RedirectResponseCode = defaultRedirectResponseCode
+		}
+		return "", nil, redirect
+	}
+	var routeAction *composite.HttpRouteAction
+	if r := rule.Rewrite; r != nil {
+		routeAction = &composite.HttpRouteAction{
+			UrlRewrite: &composite.UrlRewrite{
+				HostRewrite:       r.Host,
+				PathPrefixRewrite: r.PathPrefix,
+			},
+		}
+	}
+	return backendLink(rule.Backend, namer), routeAction, nil
+}
+
+type pathRouteRule struct {
+	match *composite.HttpRouteRuleMatch
+	path  utils.PathRule
+}
+
+// pathRouteRules translates the given path rules into route rule matches,
+// ordered so that the longest path matches first as it does in a path
+// matcher. Paths ending in /* match on their prefix, other paths match
+// the full path.
+func pathRouteRules(paths []utils.PathRule) []pathRouteRule {
+	var rules []pathRouteRule
+	for _, p := range paths {
+		match := &composite.HttpRouteRuleMatch{}
+		switch {
+		case p.Path == DefaultPath:
+			match.PrefixMatch = "/"
+		case strings.HasSuffix(p.Path, "/*"):
+			match.PrefixMatch = strings.TrimSuffix(p.Path, "*")
+		default:
+			match.FullPathMatch = p.Path
+		}
+		rules = append(rules, pathRouteRule{match: match, path: p})
+	}
+	length := func(m *composite.HttpRouteRuleMatch) int {
+		return len(m.FullPathMatch) + len(m.PrefixMatch)
+	}
+	sort.SliceStable(rules, func(i, j int) bool {
+		return length(rules[i].match) > length(rules[j].match)
+	})
+	return rules
+}
+
+// backendLink returns the resource path of the backend service of sp.
+func backendLink(sp utils.ServicePort, namer *utils.Namer) string {
+	return cloud.NewBackendServicesResourceID("", sp.BackendName(namer)).ResourcePath()
+}
+
+// alphaMapsEqual compares the structure of two alpha url maps, including
+// their route rules, rewrites and redirects. Like mapsEqual, service links
+// are compared as resource paths.
+func alphaMapsEqual(a, b *composite.UrlMap) bool {
+	a, b = withServiceResourcePaths(a), withServiceResourcePaths(b)
+	if a == nil || b == nil {
+		return false
+	}
+	return a.DefaultService == b.DefaultService &&
+		reflect.DeepEqual(a.HostRules, b.HostRules) &&
+		reflect.DeepEqual(a.PathMatchers, b.PathMatchers)
+}
+
+// hasAlphaFields returns true if um has route rules, rewrites or redirects,
+// which only the alpha API supports.
+func hasAlphaFields(um *composite.UrlMap) bool {
+	for _, pm := range um.PathMatchers {
+		if len(pm.RouteRules) > 0 {
+			return true
+		}
+		for _, pr := range pm.PathRules {
+			if pr.RouteAction != nil || pr.UrlRedirect != nil {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// withServiceResourcePaths returns a copy of um whose service links are
+// resource paths, or nil if um cannot be copied.
+func withServiceResourcePaths(um *composite.UrlMap) *composite.UrlMap {
+	bytes, err := json.Marshal(um)
+	if err != nil {
+		return nil
+	}
+	c := &composite.UrlMap{}
+	if err := json.Unmarshal(bytes, c); err != nil {
+		return nil
+	}
+	resourcePath := func(link *string) {
+		if id, err := cloud.ParseResourceURL(*link); err == nil {
+			*link = id.ResourcePath()
+		}
+	}
+	resourcePath(&c.DefaultService)
+	for _, pm := range c.PathMatchers {
+		resourcePath(&pm.DefaultService)
+		for _, pr := range pm.PathRules {
+			resourcePath(&pr.Service)
+		}
+		for _, rr := range pm.RouteRules {
+			resourcePath(&rr.Service)
+			if rr.RouteAction == nil {
+				continue
+			}
+			for _, wbs := range rr.RouteAction.WeightedBackendServices {
+				resourcePath(&wbs.BackendService)
+			}
+		}
+	}
+	return c
+}
+
+// getRouteRuleBackendNames returns the names of the backends which the
+// route rules of um refer to.
+func getRouteRuleBackendNames(um *composite.UrlMap) ([]string, error) {
+	beNames := sets.NewString()
+	for _, pm := range um.PathMatchers {
+		for _, rr := range pm.RouteRules {
+			links := []string{}
+			if rr.Service != "" {
+				links = append(links, rr.Service)
+			}
+			if rr.RouteAction != nil {
+				for _, wbs := range rr.RouteAction.WeightedBackendServices {
+					links = append(links, wbs.BackendService)
+				}
+			}
+			for _, link := range links {
+				name, err := utils.KeyName(link)
+				if err != nil {
+					return nil, err
+				}
+				beNames.Insert(name)
+			}
+		}
+	}
+	return beNames.List(), nil
+}
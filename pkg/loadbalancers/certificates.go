@@ -21,13 +21,20 @@ import (
 	"fmt"
 	"strings"
 
+	computebeta "google.golang.org/api/compute/v0.beta"
 	compute "google.golang.org/api/compute/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/ingress-gce/pkg/utils"
 	"k8s.io/klog"
 )
 
-const SslCertificateMissing = "SslCertificateMissing"
+const (
+	SslCertificateMissing = "SslCertificateMissing"
+
+	// managedCertActive is the status of a Google-managed cert which has
+	// been provisioned.
+	managedCertActive = "ACTIVE"
+)
 
 func (l *L7) checkSSLCert() error {
 	// Use both pre-shared and secret-based certs if available,
@@ -55,6 +62,12 @@ func (l *L7) checkSSLCert() error {
 		errs = append(errs, err)
 	}
 	l.sslCerts = append(l.sslCerts, secretsSslCerts...)
+
+	managedSslCerts, err := l.ensureManagedSslCerts()
+	if err != nil {
+		errs = append(errs, err)
+	}
+	l.sslCerts = append(l.sslCerts, managedSslCerts...)
 	if len(errs) > 0 {
 		return utils.JoinErrs(errs)
 	}
@@ -97,7 +110,7 @@ func (l *L7) createSslCertificates(existingCerts []*compute.SslCertificate) ([]*
 		// Controller needs to create the certificate, no need to check if it exists and delete. If it did exist, it
 		// would have been listed in the populateSSLCert function and matched in the check above.
 		klog.V(2).Infof("Creating new sslCertificate %q for LB %q", gcpCertName, l.Name)
-		cert, err := l.cloud.CreateSslCertificate(&compute.SslCertificate{
+		cert, err := l.createSslCertificate(&compute.SslCertificate{
 			Name:        gcpCertName,
 			Certificate: ingCert,
 			PrivateKey:  ingKey,
@@ -118,13 +131,91 @@ func (l *L7) createSslCertificates(existingCerts []*compute.SslCertificate) ([]*
 	return result, nil
 }
 
+// ensureManagedSslCerts creates the Google-managed certificate for the
+// domains in the Ingress configuration, and returns the managed certificates
+// to serve. The status of a managed certificate is polled on every sync, and
+// the previous managed certificates are served until the new one is ACTIVE.
+// They are then deleted with the other old certificates.
+func (l *L7) ensureManagedSslCerts() ([]*compute.SslCertificate, error) {
+	existingCerts, err := l.getManagedSslCerts()
+	if err != nil {
+		return nil, err
+	}
+	l.oldSSLCerts = append(l.oldSSLCerts, existingCerts...)
+	l.managedSSLCert = nil
+
+	domains := l.runtimeInfo.ManagedCertDomains
+	if len(domains) == 0 {
+		return nil, nil
+	}
+	// The domains determine the cert name, as they cannot be updated.
+	gcpCertName := l.namer.ManagedSSLCertName(l.Name, GetCertHash(strings.Join(domains, ",")))
+	cert, err := l.cloud.GetBetaSslCertificate(gcpCertName)
+	if utils.IgnoreHTTPNotFound(err) != nil {
+		return nil, err
+	}
+	if cert == nil {
+		klog.V(2).Infof("Creating new managed sslCertificate %q for domains %v of LB %q", gcpCertName, domains, l.Name)
+		cert, err = l.cloud.CreateBetaSslCertificate(&computebeta.SslCertificate{
+			Name: gcpCertName,
+			Type: "MANAGED",
+			Managed: &computebeta.SslCertificateManagedSslCertificate{
+				Domains: domains,
+			},
+		})
+		if err != nil {
+			klog.Errorf("Failed to create new managed sslCertificate %q for %q - %v", gcpCertName, l.Name, err)
+			return nil, fmt.Errorf("Managed cert creation failure - %s Error:%s", gcpCertName, err.Error())
+		}
+	}
+	l.managedSSLCert = cert
+
+	// GCE only provisions managed certs which are attached to a target proxy,
+	// so the new cert is served even before it is ACTIVE.
+	result := []*compute.SslCertificate{{Name: cert.Name, SelfLink: cert.SelfLink}}
+	if status := managedCertStatus(cert); status != managedCertActive {
+		klog.V(3).Infof("Managed sslCertificate %q for LB %q is %v, still serving previous managed certificates", gcpCertName, l.Name, status)
+		for _, c := range existingCerts {
+			if c.Name != gcpCertName {
+				result = append(result, c)
+			}
+		}
+	}
+	return result, nil
+}
+
+// managedCertStatus returns the provisioning status of a Google-managed cert.
+func managedCertStatus(cert *computebeta.SslCertificate) string {
+	if cert.Managed == nil {
+		return ""
+	}
+	return cert.Managed.Status
+}
+
+// getManagedSslCerts fetches the Google-managed SslCertificate resources
+// created by this load balancer instance.
+func (l *L7) getManagedSslCerts() ([]*compute.SslCertificate, error) {
+	var result []*compute.SslCertificate
+	certs, err := l.listSslCertificates()
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range certs {
+		if l.namer.IsManagedCertUsedForLB(l.Name, c.Name) {
+			klog.V(4).Infof("Populating managed ssl cert %s for l7 %s", c.Name, l.Name)
+			result = append(result, c)
+		}
+	}
+	return result, nil
+}
+
 // getSslCertificates fetches GCE SslCertificate resources by names.
 func (l *L7) getSslCertificates(names []string) ([]*compute.SslCertificate, error) {
 	var result []*compute.SslCertificate
 	var failedCerts []string
 	for _, name := range names {
 		// Ask GCE for the cert, checking for problems and existence.
-		cert, err := l.cloud.GetSslCertificate(name)
+		cert, err := l.getSslCertificate(name)
 		if err != nil {
 			failedCerts = append(failedCerts, name+": "+err.Error())
 			l.recorder.Eventf(l.runtimeInfo.Ingress, corev1.EventTypeNormal, SslCertificateMissing, err.Error())
@@ -179,7 +270,7 @@ func (l *L7) getIngressManagedSslCerts() ([]*compute.SslCertificate, error) {
 	// Currently we list all certs available in gcloud and filter the ones managed by this loadbalancer instance. This is
 	// to make sure we garbage collect any old certs that this instance might have lost track of due to crashes.
 	// Can be a performance issue if there are too many global certs, default quota is only 10.
-	certs, err := l.cloud.ListSslCertificates()
+	certs, err := l.listSslCertificates()
 	if err != nil {
 		return nil, err
 	}
@@ -208,7 +299,7 @@ func (l *L7) getIngressManagedSslCerts() ([]*compute.SslCertificate, error) {
 			if !l.namer.IsLegacySSLCert(l.Name, name) {
 				continue
 			}
-			cert, _ := l.cloud.GetSslCertificate(name)
+			cert, _ := l.getSslCertificate(name)
 			if cert != nil {
 				klog.V(4).Infof("Populating legacy ssl cert %s for l7 %s", cert.Name, l.Name)
 				result = append(result, cert)
@@ -224,7 +315,7 @@ func (l *L7) deleteOldSSLCerts() {
 	}
 	certsMap := getMapfromCertList(l.sslCerts)
 	for _, cert := range l.oldSSLCerts {
-		if !l.namer.IsCertUsedForLB(l.Name, cert.Name) && !l.namer.IsLegacySSLCert(l.Name, cert.Name) {
+		if !l.namer.IsCertUsedForLB(l.Name, cert.Name) && !l.namer.IsLegacySSLCert(l.Name, cert.Name) && !l.namer.IsManagedCertUsedForLB(l.Name, cert.Name) {
 			// retain cert if it is managed by GCE(non-ingress)
 			continue
 		}
@@ -233,7 +324,7 @@ func (l *L7) deleteOldSSLCerts() {
 			continue
 		}
 		klog.V(3).Infof("Cleaning up old SSL Certificate %s", cert.Name)
-		if certErr := utils.IgnoreHTTPNotFound(l.cloud.DeleteSslCertificate(cert.Name)); certErr != nil {
+		if certErr := utils.IgnoreHTTPNotFound(l.deleteSslCertificate(cert.Name)); certErr != nil {
 			klog.Errorf("Old cert delete failed - %v", certErr)
 		}
 	}
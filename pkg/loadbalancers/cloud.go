@@ -0,0 +1,219 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package loadbalancers
+
+import (
+	computebeta "google.golang.org/api/compute/v0.beta"
+	compute "google.golang.org/api/compute/v1"
+	"k8s.io/kubernetes/pkg/cloudprovider/providers/gce"
+	"k8s.io/kubernetes/pkg/cloudprovider/providers/gce/cloud/meta"
+
+	"k8s.io/ingress-gce/pkg/composite"
+)
+
+// sharedLoadBalancerVIPPurpose is the purpose of an internal address which
+// is shared by the forwarding rules of a loadbalancer.
+const sharedLoadBalancerVIPPurpose = "SHARED_LOADBALANCER_VIP"
+
+// gceLoadBalancers implements LoadBalancers with gce.Cloud, adding the
+// operations which gce.Cloud does not provide.
+type gceLoadBalancers struct {
+	*gce.Cloud
+}
+
+// NewGCELoadBalancers returns a LoadBalancers which manages the resources
+// of loadbalancers with the given cloud.
+func NewGCELoadBalancers(cloud *gce.Cloud) LoadBalancers {
+	return &gceLoadBalancers{Cloud: cloud}
+}
+
+// GetRedirectURLMap implements LoadBalancers.
+func (g *gceLoadBalancers) GetRedirectURLMap(name string) (*composite.UrlMap, error) {
+	return composite.GetUrlMap(name, meta.VersionGA, g.Cloud)
+}
+
+// CreateRedirectURLMap implements LoadBalancers.
+func (g *gceLoadBalancers) CreateRedirectURLMap(urlMap *composite.UrlMap) error {
+	return composite.CreateUrlMap(urlMap, g.Cloud)
+}
+
+// UpdateRedirectURLMap implements LoadBalancers.
+func (g *gceLoadBalancers) UpdateRedirectURLMap(urlMap *composite.UrlMap) error {
+	return composite.UpdateUrlMap(urlMap, g.Cloud)
+}
+
+// GetAlphaURLMap implements LoadBalancers.
+func (g *gceLoadBalancers) GetAlphaURLMap(name string) (*composite.UrlMap, error) {
+	return composite.GetUrlMap(name, meta.VersionAlpha, g.Cloud)
+}
+
+// CreateAlphaURLMap implements LoadBalancers.
+func (g *gceLoadBalancers) CreateAlphaURLMap(urlMap *composite.UrlMap) error {
+	urlMap.Version = meta.VersionAlpha
+	return composite.CreateUrlMap(urlMap, g.Cloud)
+}
+
+// UpdateAlphaURLMap implements LoadBalancers.
+func (g *gceLoadBalancers) UpdateAlphaURLMap(urlMap *composite.UrlMap) error {
+	urlMap.Version = meta.VersionAlpha
+	return composite.UpdateUrlMap(urlMap, g.Cloud)
+}
+
+// SetSslPolicyForTargetHTTPSProxy implements LoadBalancers.
+func (g *gceLoadBalancers) SetSslPolicyForTargetHTTPSProxy(proxy *compute.TargetHttpsProxy, sslPolicyLink string) error {
+	return composite.SetSslPolicyForTargetHttpsProxy(proxy.Name, sslPolicyLink, g.Cloud)
+}
+
+// SetQuicOverrideForTargetHTTPSProxy implements LoadBalancers.
+func (g *gceLoadBalancers) SetQuicOverrideForTargetHTTPSProxy(proxy *compute.TargetHttpsProxy, quicOverride string) error {
+	return composite.SetQuicOverrideForTargetHttpsProxy(proxy.Name, quicOverride, g.Cloud)
+}
+
+// GetBetaSslCertificate implements LoadBalancers.
+func (g *gceLoadBalancers) GetBetaSslCertificate(name string) (*computebeta.SslCertificate, error) {
+	return composite.GetBetaSslCertificate(name, g.Cloud)
+}
+
+// CreateBetaSslCertificate implements LoadBalancers.
+func (g *gceLoadBalancers) CreateBetaSslCertificate(cert *computebeta.SslCertificate) (*computebeta.SslCertificate, error) {
+	return composite.CreateBetaSslCertificate(cert, g.Cloud)
+}
+
+// GetRegionalForwardingRule implements LoadBalancers.
+func (g *gceLoadBalancers) GetRegionalForwardingRule(name string) (*compute.ForwardingRule, error) {
+	return composite.GetRegionalForwardingRule(name, g.Cloud)
+}
+
+// CreateRegionalForwardingRule implements LoadBalancers.
+func (g *gceLoadBalancers) CreateRegionalForwardingRule(rule *compute.ForwardingRule) error {
+	return composite.CreateRegionalForwardingRule(rule, g.Cloud)
+}
+
+// DeleteRegionalForwardingRule implements LoadBalancers.
+func (g *gceLoadBalancers) DeleteRegionalForwardingRule(name string) error {
+	return composite.DeleteRegionalForwardingRule(name, g.Cloud)
+}
+
+// SetProxyForRegionalForwardingRule implements LoadBalancers.
+func (g *gceLoadBalancers) SetProxyForRegionalForwardingRule(fw, proxy string) error {
+	return composite.SetTargetForRegionalForwardingRule(fw, proxy, g.Cloud)
+}
+
+// GetRegionalURLMap implements LoadBalancers.
+func (g *gceLoadBalancers) GetRegionalURLMap(name string) (*compute.UrlMap, error) {
+	return composite.GetRegionalUrlMap(name, g.Cloud)
+}
+
+// CreateRegionalURLMap implements LoadBalancers.
+func (g *gceLoadBalancers) CreateRegionalURLMap(urlMap *compute.UrlMap) error {
+	return composite.CreateRegionalUrlMap(urlMap, g.Cloud)
+}
+
+// UpdateRegionalURLMap implements LoadBalancers.
+func (g *gceLoadBalancers) UpdateRegionalURLMap(urlMap *compute.UrlMap) error {
+	return composite.UpdateRegionalUrlMap(urlMap, g.Cloud)
+}
+
+// DeleteRegionalURLMap implements LoadBalancers.
+func (g *gceLoadBalancers) DeleteRegionalURLMap(name string) error {
+	return composite.DeleteRegionalUrlMap(name, g.Cloud)
+}
+
+// ListRegionalURLMaps implements LoadBalancers.
+func (g *gceLoadBalancers) ListRegionalURLMaps() ([]*compute.UrlMap, error) {
+	return composite.ListRegionalUrlMaps(g.Cloud)
+}
+
+// GetRegionalTargetHTTPProxy implements LoadBalancers.
+func (g *gceLoadBalancers) GetRegionalTargetHTTPProxy(name string) (*compute.TargetHttpProxy, error) {
+	return composite.GetRegionalTargetHttpProxy(name, g.Cloud)
+}
+
+// CreateRegionalTargetHTTPProxy implements LoadBalancers.
+func (g *gceLoadBalancers) CreateRegionalTargetHTTPProxy(proxy *compute.TargetHttpProxy) error {
+	return composite.CreateRegionalTargetHttpProxy(proxy, g.Cloud)
+}
+
+// DeleteRegionalTargetHTTPProxy implements LoadBalancers.
+func (g *gceLoadBalancers) DeleteRegionalTargetHTTPProxy(name string) error {
+	return composite.DeleteRegionalTargetHttpProxy(name, g.Cloud)
+}
+
+// SetURLMapForRegionalTargetHTTPProxy implements LoadBalancers.
+func (g *gceLoadBalancers) SetURLMapForRegionalTargetHTTPProxy(proxy *compute.TargetHttpProxy, urlMapLink string) error {
+	return composite.SetUrlMapForRegionalTargetHttpProxy(proxy.Name, urlMapLink, g.Cloud)
+}
+
+// GetRegionalTargetHTTPSProxy implements LoadBalancers.
+func (g *gceLoadBalancers) GetRegionalTargetHTTPSProxy(name string) (*compute.TargetHttpsProxy, error) {
+	return composite.GetRegionalTargetHttpsProxy(name, g.Cloud)
+}
+
+// CreateRegionalTargetHTTPSProxy implements LoadBalancers.
+func (g *gceLoadBalancers) CreateRegionalTargetHTTPSProxy(proxy *compute.TargetHttpsProxy) error {
+	return composite.CreateRegionalTargetHttpsProxy(proxy, g.Cloud)
+}
+
+// DeleteRegionalTargetHTTPSProxy implements LoadBalancers.
+func (g *gceLoadBalancers) DeleteRegionalTargetHTTPSProxy(name string) error {
+	return composite.DeleteRegionalTargetHttpsProxy(name, g.Cloud)
+}
+
+// SetURLMapForRegionalTargetHTTPSProxy implements LoadBalancers.
+func (g *gceLoadBalancers) SetURLMapForRegionalTargetHTTPSProxy(proxy *compute.TargetHttpsProxy, urlMapLink string) error {
+	return composite.SetUrlMapForRegionalTargetHttpsProxy(proxy.Name, urlMapLink, g.Cloud)
+}
+
+// SetSslCertificateForRegionalTargetHTTPSProxy implements LoadBalancers.
+func (g *gceLoadBalancers) SetSslCertificateForRegionalTargetHTTPSProxy(proxy *compute.TargetHttpsProxy, sslCertURLs []string) error {
+	return composite.SetSslCertificatesForRegionalTargetHttpsProxy(proxy.Name, sslCertURLs, g.Cloud)
+}
+
+// GetRegionalSslCertificate implements LoadBalancers.
+func (g *gceLoadBalancers) GetRegionalSslCertificate(name string) (*compute.SslCertificate, error) {
+	return composite.GetRegionalSslCertificate(name, g.Cloud)
+}
+
+// ListRegionalSslCertificates implements LoadBalancers.
+func (g *gceLoadBalancers) ListRegionalSslCertificates() ([]*compute.SslCertificate, error) {
+	return composite.ListRegionalSslCertificates(g.Cloud)
+}
+
+// CreateRegionalSslCertificate implements LoadBalancers.
+func (g *gceLoadBalancers) CreateRegionalSslCertificate(cert *compute.SslCertificate) (*compute.SslCertificate, error) {
+	return composite.CreateRegionalSslCertificate(cert, g.Cloud)
+}
+
+// DeleteRegionalSslCertificate implements LoadBalancers.
+func (g *gceLoadBalancers) DeleteRegionalSslCertificate(name string) error {
+	return composite.DeleteRegionalSslCertificate(name, g.Cloud)
+}
+
+// ReserveRegionalAddress implements LoadBalancers.
+func (g *gceLoadBalancers) ReserveRegionalAddress(addr *compute.Address) error {
+	return composite.ReserveRegionalAddress(addr, sharedLoadBalancerVIPPurpose, g.Cloud)
+}
+
+// GetRegionalAddress implements LoadBalancers.
+func (g *gceLoadBalancers) GetRegionalAddress(name string) (*compute.Address, error) {
+	return composite.GetRegionalAddress(name, g.Cloud)
+}
+
+// DeleteRegionalAddress implements LoadBalancers.
+func (g *gceLoadBalancers) DeleteRegionalAddress(name string) error {
+	return composite.DeleteRegionalAddress(name, g.Cloud)
+}
@@ -17,15 +17,18 @@ limitations under the License.
 package loadbalancers
 
 import (
+	"encoding/json"
 	"fmt"
 
 	"k8s.io/klog"
 
+	computebeta "google.golang.org/api/compute/v0.beta"
 	compute "google.golang.org/api/compute/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/kubernetes/pkg/cloudprovider/providers/gce/cloud"
 	"k8s.io/kubernetes/pkg/cloudprovider/providers/gce/cloud/meta"
 
+	"k8s.io/ingress-gce/pkg/composite"
 	"k8s.io/ingress-gce/pkg/utils"
 )
 
@@ -42,6 +45,11 @@ func (t *testIP) ip() string {
 	return fmt.Sprintf("0.0.0.%v", t.start)
 }
 
+func (t *testIP) ipv6() string {
+	t.start++
+	return fmt.Sprintf("2001:db8::%x", t.start)
+}
+
 // Loadbalancer fakes
 
 // FakeLoadBalancers is a type that fakes out the loadbalancer interface.
@@ -55,6 +63,22 @@ type FakeLoadBalancers struct {
 	name  string
 	calls []string // list of calls that were made
 
+	// RedirectUm are the redirect url maps, which are listed with Um.
+	RedirectUm []*composite.UrlMap
+	// AlphaUm are the url maps with route rules or path actions. Um holds
+	// them without those, as the GA API returns them.
+	AlphaUm []*composite.UrlMap
+	// BetaCerts are the Google-managed certs, which are listed with Certs.
+	BetaCerts []*computebeta.SslCertificate
+
+	// Regional resources of internal loadbalancers.
+	RegionalFw    []*compute.ForwardingRule
+	RegionalUm    []*compute.UrlMap
+	RegionalTp    []*compute.TargetHttpProxy
+	RegionalTps   []*compute.TargetHttpsProxy
+	RegionalIP    []*compute.Address
+	RegionalCerts []*compute.SslCertificate
+
 	namer *utils.Namer
 }
 
@@ -202,6 +226,7 @@ func (f *FakeLoadBalancers) CreateURLMap(urlMap *compute.UrlMap) error {
 	f.calls = append(f.calls, "CreateURLMap")
 	urlMap.SelfLink = cloud.NewUrlMapsResourceID("mock-project", urlMap.Name).SelfLink(meta.VersionGA)
 	f.Um = append(f.Um, urlMap)
+	f.deleteAlphaURLMap(urlMap.Name)
 	return nil
 }
 
@@ -211,6 +236,8 @@ func (f *FakeLoadBalancers) UpdateURLMap(urlMap *compute.UrlMap) error {
 	for i := range f.Um {
 		if f.Um[i].Name == urlMap.Name {
 			f.Um[i] = urlMap
+			// Updating with the GA API drops the route rules and path actions.
+			f.deleteAlphaURLMap(urlMap.Name)
 			return nil
 		}
 	}
@@ -226,18 +253,143 @@ func (f *FakeLoadBalancers) DeleteURLMap(name string) error {
 			um = append(um, f.Um[i])
 		}
 	}
-	if len(f.Um) == len(um) {
+	redirectUm := []*composite.UrlMap{}
+	for i := range f.RedirectUm {
+		if f.RedirectUm[i].Name != name {
+			redirectUm = append(redirectUm, f.RedirectUm[i])
+		}
+	}
+	if len(f.Um) == len(um) && len(f.RedirectUm) == len(redirectUm) {
 		// Nothing was deleted.
 		return utils.FakeGoogleAPINotFoundErr()
 	}
 	f.Um = um
+	f.RedirectUm = redirectUm
+	f.deleteAlphaURLMap(name)
 	return nil
 }
 
 // ListURLMaps fakes getting url maps from the cloud.
 func (f *FakeLoadBalancers) ListURLMaps() ([]*compute.UrlMap, error) {
 	f.calls = append(f.calls, "ListURLMaps")
-	return f.Um, nil
+	um := append([]*compute.UrlMap{}, f.Um...)
+	for _, redirectUm := range f.RedirectUm {
+		um = append(um, &compute.UrlMap{Name: redirectUm.Name, SelfLink: redirectUm.SelfLink})
+	}
+	return um, nil
+}
+
+// GetRedirectURLMap fakes getting redirect url maps from the cloud.
+func (f *FakeLoadBalancers) GetRedirectURLMap(name string) (*composite.UrlMap, error) {
+	f.calls = append(f.calls, "GetRedirectURLMap")
+	for i := range f.RedirectUm {
+		if f.RedirectUm[i].Name == name {
+			return f.RedirectUm[i], nil
+		}
+	}
+	return nil, utils.FakeGoogleAPINotFoundErr()
+}
+
+// CreateRedirectURLMap fakes redirect url-map creation.
+func (f *FakeLoadBalancers) CreateRedirectURLMap(urlMap *composite.UrlMap) error {
+	f.calls = append(f.calls, "CreateRedirectURLMap")
+	urlMap.SelfLink = cloud.NewUrlMapsResourceID("mock-project", urlMap.Name).SelfLink(meta.VersionGA)
+	f.RedirectUm = append(f.RedirectUm, urlMap)
+	return nil
+}
+
+// UpdateRedirectURLMap fakes updating redirect url-maps.
+func (f *FakeLoadBalancers) UpdateRedirectURLMap(urlMap *composite.UrlMap) error {
+	f.calls = append(f.calls, "UpdateRedirectURLMap")
+	for i := range f.RedirectUm {
+		if f.RedirectUm[i].Name == urlMap.Name {
+			urlMap.SelfLink = f.RedirectUm[i].SelfLink
+			f.RedirectUm[i] = urlMap
+			return nil
+		}
+	}
+	return utils.FakeGoogleAPINotFoundErr()
+}
+
+// GetAlphaURLMap fakes getting alpha url maps from the cloud. Url maps
+// created with the GA API are returned without alpha fields.
+func (f *FakeLoadBalancers) GetAlphaURLMap(name string) (*composite.UrlMap, error) {
+	f.calls = append(f.calls, "GetAlphaURLMap")
+	for i := range f.AlphaUm {
+		if f.AlphaUm[i].Name == name {
+			return f.AlphaUm[i], nil
+		}
+	}
+	for i := range f.Um {
+		if f.Um[i].Name == name {
+			um := &composite.UrlMap{}
+			if err := convertURLMap(f.Um[i], um); err != nil {
+				return nil, err
+			}
+			um.Version = meta.VersionAlpha
+			return um, nil
+		}
+	}
+	return nil, utils.FakeGoogleAPINotFoundErr()
+}
+
+// CreateAlphaURLMap fakes alpha url-map creation.
+func (f *FakeLoadBalancers) CreateAlphaURLMap(urlMap *composite.UrlMap) error {
+	f.calls = append(f.calls, "CreateAlphaURLMap")
+	urlMap.Version = meta.VersionAlpha
+	urlMap.SelfLink = cloud.NewUrlMapsResourceID("mock-project", urlMap.Name).SelfLink(meta.VersionAlpha)
+	ga := &compute.UrlMap{}
+	if err := convertURLMap(urlMap, ga); err != nil {
+		return err
+	}
+	ga.SelfLink = cloud.NewUrlMapsResourceID("mock-project", urlMap.Name).SelfLink(meta.VersionGA)
+	f.Um = append(f.Um, ga)
+	f.AlphaUm = append(f.AlphaUm, urlMap)
+	return nil
+}
+
+// UpdateAlphaURLMap fakes updating alpha url-maps.
+func (f *FakeLoadBalancers) UpdateAlphaURLMap(urlMap *composite.UrlMap) error {
+	f.calls = append(f.calls, "UpdateAlphaURLMap")
+	for i := range f.Um {
+		if f.Um[i].Name != urlMap.Name {
+			continue
+		}
+		urlMap.Version = meta.VersionAlpha
+		urlMap.SelfLink = cloud.NewUrlMapsResourceID("mock-project", urlMap.Name).SelfLink(meta.VersionAlpha)
+		ga := &compute.UrlMap{}
+		if err := convertURLMap(urlMap, ga); err != nil {
+			return err
+		}
+		ga.SelfLink = f.Um[i].SelfLink
+		f.Um[i] = ga
+		f.deleteAlphaURLMap(urlMap.Name)
+		if hasAlphaFields(urlMap) {
+			f.AlphaUm = append(f.AlphaUm, urlMap)
+		}
+		return nil
+	}
+	return utils.FakeGoogleAPINotFoundErr()
+}
+
+func (f *FakeLoadBalancers) deleteAlphaURLMap(name string) {
+	alphaUm := []*composite.UrlMap{}
+	for i := range f.AlphaUm {
+		if f.AlphaUm[i].Name != name {
+			alphaUm = append(alphaUm, f.AlphaUm[i])
+		}
+	}
+	f.AlphaUm = alphaUm
+}
+
+// convertURLMap converts between compute and composite url maps as the GCE
+// API would, dropping the fields which the target does not have.
+func convertURLMap(from, to interface{}) error {
+	bytes, err := json.Marshal(from)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(bytes, to)
 }
 
 // TargetProxies fakes
@@ -358,11 +510,38 @@ func (f *FakeLoadBalancers) SetSslCertificateForTargetHTTPSProxy(proxy *compute.
 	return nil
 }
 
+// SetSslPolicyForTargetHTTPSProxy fakes out setting the SSL policy.
+func (f *FakeLoadBalancers) SetSslPolicyForTargetHTTPSProxy(proxy *compute.TargetHttpsProxy, sslPolicyLink string) error {
+	f.calls = append(f.calls, "SetSslPolicyForTargetHTTPSProxy")
+	for i := range f.Tps {
+		if f.Tps[i].Name == proxy.Name {
+			f.Tps[i].SslPolicy = sslPolicyLink
+			return nil
+		}
+	}
+	return utils.FakeGoogleAPINotFoundErr()
+}
+
+// SetQuicOverrideForTargetHTTPSProxy fakes out setting the QUIC override.
+func (f *FakeLoadBalancers) SetQuicOverrideForTargetHTTPSProxy(proxy *compute.TargetHttpsProxy, quicOverride string) error {
+	f.calls = append(f.calls, "SetQuicOverrideForTargetHTTPSProxy")
+	for i := range f.Tps {
+		if f.Tps[i].Name == proxy.Name {
+			f.Tps[i].QuicOverride = quicOverride
+			return nil
+		}
+	}
+	return utils.FakeGoogleAPINotFoundErr()
+}
+
 // Static IP fakes
 
 // ReserveGlobalAddress fakes out static IP reservation.
 func (f *FakeLoadBalancers) ReserveGlobalAddress(addr *compute.Address) error {
 	f.calls = append(f.calls, "ReserveGlobalAddress")
+	if addr.Address == "" && addr.IpVersion == "IPV6" {
+		addr.Address = testIPManager.ipv6()
+	}
 	f.IP = append(f.IP, addr)
 	return nil
 }
@@ -439,6 +618,357 @@ func (f *FakeLoadBalancers) DeleteSslCertificate(name string) error {
 		return utils.FakeGoogleAPINotFoundErr()
 	}
 	f.Certs = certs
+	betaCerts := []*computebeta.SslCertificate{}
+	for i := range f.BetaCerts {
+		if f.BetaCerts[i].Name != name {
+			betaCerts = append(betaCerts, f.BetaCerts[i])
+		}
+	}
+	f.BetaCerts = betaCerts
+	return nil
+}
+
+// GetBetaSslCertificate fakes out getting Google-managed ssl certs.
+func (f *FakeLoadBalancers) GetBetaSslCertificate(name string) (*computebeta.SslCertificate, error) {
+	f.calls = append(f.calls, "GetBetaSslCertificate")
+	for i := range f.BetaCerts {
+		if f.BetaCerts[i].Name == name {
+			return f.BetaCerts[i], nil
+		}
+	}
+	return nil, utils.FakeGoogleAPINotFoundErr()
+}
+
+// CreateBetaSslCertificate fakes out Google-managed certificate creation.
+// Managed certificates are created as PROVISIONING.
+func (f *FakeLoadBalancers) CreateBetaSslCertificate(cert *computebeta.SslCertificate) (*computebeta.SslCertificate, error) {
+	f.calls = append(f.calls, "CreateBetaSslCertificate")
+	cert.SelfLink = cloud.NewSslCertificatesResourceID("mock-project", cert.Name).SelfLink(meta.VersionBeta)
+	if len(f.Certs) == FakeCertQuota {
+		// Simulate cert creation failure
+		return nil, fmt.Errorf("unable to create cert, Exceeded cert limit of %d.", FakeCertQuota)
+	}
+	if cert.Managed != nil {
+		cert.Managed.Status = "PROVISIONING"
+	}
+	f.Certs = append(f.Certs, &compute.SslCertificate{Name: cert.Name, SelfLink: cert.SelfLink})
+	f.BetaCerts = append(f.BetaCerts, cert)
+	return cert, nil
+}
+
+// Regional fakes
+
+// fakeRegion is the region of the regional fakes.
+const fakeRegion = "us-central1"
+
+func regionalSelfLink(resource, name string) string {
+	resourceID := cloud.ResourceID{ProjectID: "mock-project", Resource: resource, Key: meta.RegionalKey(name, fakeRegion)}
+	return resourceID.SelfLink(meta.VersionGA)
+}
+
+// Region returns the region of the regional fakes.
+func (f *FakeLoadBalancers) Region() string {
+	return fakeRegion
+}
+
+// GetRegionalForwardingRule fakes getting a regional forwarding rule.
+func (f *FakeLoadBalancers) GetRegionalForwardingRule(name string) (*compute.ForwardingRule, error) {
+	f.calls = append(f.calls, "GetRegionalForwardingRule")
+	for i := range f.RegionalFw {
+		if f.RegionalFw[i].Name == name {
+			return f.RegionalFw[i], nil
+		}
+	}
+	return nil, utils.FakeGoogleAPINotFoundErr()
+}
+
+// CreateRegionalForwardingRule fakes regional forwarding rule creation.
+func (f *FakeLoadBalancers) CreateRegionalForwardingRule(rule *compute.ForwardingRule) error {
+	f.calls = append(f.calls, "CreateRegionalForwardingRule")
+	if rule.IPAddress == "" {
+		rule.IPAddress = testIPManager.ip()
+	}
+	rule.SelfLink = regionalSelfLink("forwardingRules", rule.Name)
+	f.RegionalFw = append(f.RegionalFw, rule)
+	return nil
+}
+
+// DeleteRegionalForwardingRule fakes deleting a regional forwarding rule.
+func (f *FakeLoadBalancers) DeleteRegionalForwardingRule(name string) error {
+	f.calls = append(f.calls, "DeleteRegionalForwardingRule")
+	fw := []*compute.ForwardingRule{}
+	for i := range f.RegionalFw {
+		if f.RegionalFw[i].Name != name {
+			fw = append(fw, f.RegionalFw[i])
+		}
+	}
+	if len(f.RegionalFw) == len(fw) {
+		// Nothing was deleted.
+		return utils.FakeGoogleAPINotFoundErr()
+	}
+	f.RegionalFw = fw
+	return nil
+}
+
+// SetProxyForRegionalForwardingRule fakes setting the target of a regional
+// forwarding rule.
+func (f *FakeLoadBalancers) SetProxyForRegionalForwardingRule(forwardingRuleName, proxyLink string) error {
+	f.calls = append(f.calls, "SetProxyForRegionalForwardingRule")
+	for i := range f.RegionalFw {
+		if f.RegionalFw[i].Name == forwardingRuleName {
+			f.RegionalFw[i].Target = proxyLink
+		}
+	}
+	return nil
+}
+
+// GetRegionalURLMap fakes getting a regional url map.
+func (f *FakeLoadBalancers) GetRegionalURLMap(name string) (*compute.UrlMap, error) {
+	f.calls = append(f.calls, "GetRegionalURLMap")
+	for i := range f.RegionalUm {
+		if f.RegionalUm[i].Name == name {
+			return f.RegionalUm[i], nil
+		}
+	}
+	return nil, utils.FakeGoogleAPINotFoundErr()
+}
+
+// CreateRegionalURLMap fakes regional url-map creation.
+func (f *FakeLoadBalancers) CreateRegionalURLMap(urlMap *compute.UrlMap) error {
+	f.calls = append(f.calls, "CreateRegionalURLMap")
+	urlMap.SelfLink = regionalSelfLink("urlMaps", urlMap.Name)
+	f.RegionalUm = append(f.RegionalUm, urlMap)
+	return nil
+}
+
+// UpdateRegionalURLMap fakes updating regional url-maps.
+func (f *FakeLoadBalancers) UpdateRegionalURLMap(urlMap *compute.UrlMap) error {
+	f.calls = append(f.calls, "UpdateRegionalURLMap")
+	for i := range f.RegionalUm {
+		if f.RegionalUm[i].Name == urlMap.Name {
+			f.RegionalUm[i] = urlMap
+			return nil
+		}
+	}
+	return utils.FakeGoogleAPINotFoundErr()
+}
+
+// DeleteRegionalURLMap fakes regional url-map deletion.
+func (f *FakeLoadBalancers) DeleteRegionalURLMap(name string) error {
+	f.calls = append(f.calls, "DeleteRegionalURLMap")
+	um := []*compute.UrlMap{}
+	for i := range f.RegionalUm {
+		if f.RegionalUm[i].Name != name {
+			um = append(um, f.RegionalUm[i])
+		}
+	}
+	if len(f.RegionalUm) == len(um) {
+		// Nothing was deleted.
+		return utils.FakeGoogleAPINotFoundErr()
+	}
+	f.RegionalUm = um
+	return nil
+}
+
+// ListRegionalURLMaps fakes listing regional url maps.
+func (f *FakeLoadBalancers) ListRegionalURLMaps() ([]*compute.UrlMap, error) {
+	f.calls = append(f.calls, "ListRegionalURLMaps")
+	return f.RegionalUm, nil
+}
+
+// GetRegionalTargetHTTPProxy fakes getting a regional target http proxy.
+func (f *FakeLoadBalancers) GetRegionalTargetHTTPProxy(name string) (*compute.TargetHttpProxy, error) {
+	f.calls = append(f.calls, "GetRegionalTargetHTTPProxy")
+	for i := range f.RegionalTp {
+		if f.RegionalTp[i].Name == name {
+			return f.RegionalTp[i], nil
+		}
+	}
+	return nil, utils.FakeGoogleAPINotFoundErr()
+}
+
+// CreateRegionalTargetHTTPProxy fakes creating a regional target http proxy.
+func (f *FakeLoadBalancers) CreateRegionalTargetHTTPProxy(proxy *compute.TargetHttpProxy) error {
+	f.calls = append(f.calls, "CreateRegionalTargetHTTPProxy")
+	proxy.SelfLink = regionalSelfLink("targetHttpProxies", proxy.Name)
+	f.RegionalTp = append(f.RegionalTp, proxy)
+	return nil
+}
+
+// DeleteRegionalTargetHTTPProxy fakes deleting a regional target http proxy.
+func (f *FakeLoadBalancers) DeleteRegionalTargetHTTPProxy(name string) error {
+	f.calls = append(f.calls, "DeleteRegionalTargetHTTPProxy")
+	tp := []*compute.TargetHttpProxy{}
+	for i := range f.RegionalTp {
+		if f.RegionalTp[i].Name != name {
+			tp = append(tp, f.RegionalTp[i])
+		}
+	}
+	if len(f.RegionalTp) == len(tp) {
+		// Nothing was deleted.
+		return utils.FakeGoogleAPINotFoundErr()
+	}
+	f.RegionalTp = tp
+	return nil
+}
+
+// SetURLMapForRegionalTargetHTTPProxy fakes setting an url-map for a regional
+// target http proxy.
+func (f *FakeLoadBalancers) SetURLMapForRegionalTargetHTTPProxy(proxy *compute.TargetHttpProxy, urlMapLink string) error {
+	f.calls = append(f.calls, "SetURLMapForRegionalTargetHTTPProxy")
+	for i := range f.RegionalTp {
+		if f.RegionalTp[i].Name == proxy.Name {
+			f.RegionalTp[i].UrlMap = urlMapLink
+		}
+	}
+	return nil
+}
+
+// GetRegionalTargetHTTPSProxy fakes getting a regional target https proxy.
+func (f *FakeLoadBalancers) GetRegionalTargetHTTPSProxy(name string) (*compute.TargetHttpsProxy, error) {
+	f.calls = append(f.calls, "GetRegionalTargetHTTPSProxy")
+	for i := range f.RegionalTps {
+		if f.RegionalTps[i].Name == name {
+			return f.RegionalTps[i], nil
+		}
+	}
+	return nil, utils.FakeGoogleAPINotFoundErr()
+}
+
+// CreateRegionalTargetHTTPSProxy fakes creating a regional target https proxy.
+func (f *FakeLoadBalancers) CreateRegionalTargetHTTPSProxy(proxy *compute.TargetHttpsProxy) error {
+	f.calls = append(f.calls, "CreateRegionalTargetHTTPSProxy")
+	proxy.SelfLink = regionalSelfLink("targetHttpsProxies", proxy.Name)
+	f.RegionalTps = append(f.RegionalTps, proxy)
+	return nil
+}
+
+// DeleteRegionalTargetHTTPSProxy fakes deleting a regional target https proxy.
+func (f *FakeLoadBalancers) DeleteRegionalTargetHTTPSProxy(name string) error {
+	f.calls = append(f.calls, "DeleteRegionalTargetHTTPSProxy")
+	tp := []*compute.TargetHttpsProxy{}
+	for i := range f.RegionalTps {
+		if f.RegionalTps[i].Name != name {
+			tp = append(tp, f.RegionalTps[i])
+		}
+	}
+	if len(f.RegionalTps) == len(tp) {
+		// Nothing was deleted.
+		return utils.FakeGoogleAPINotFoundErr()
+	}
+	f.RegionalTps = tp
+	return nil
+}
+
+// SetURLMapForRegionalTargetHTTPSProxy fakes setting an url-map for a
+// regional target https proxy.
+func (f *FakeLoadBalancers) SetURLMapForRegionalTargetHTTPSProxy(proxy *compute.TargetHttpsProxy, urlMapLink string) error {
+	f.calls = append(f.calls, "SetURLMapForRegionalTargetHTTPSProxy")
+	for i := range f.RegionalTps {
+		if f.RegionalTps[i].Name == proxy.Name {
+			f.RegionalTps[i].UrlMap = urlMapLink
+		}
+	}
+	return nil
+}
+
+// SetSslCertificateForRegionalTargetHTTPSProxy fakes setting the certificates
+// of a regional target https proxy.
+func (f *FakeLoadBalancers) SetSslCertificateForRegionalTargetHTTPSProxy(proxy *compute.TargetHttpsProxy, sslCertURLs []string) error {
+	f.calls = append(f.calls, "SetSslCertificateForRegionalTargetHTTPSProxy")
+	for i := range f.RegionalTps {
+		if f.RegionalTps[i].Name == proxy.Name {
+			if len(sslCertURLs) > TargetProxyCertLimit {
+				return utils.FakeGoogleAPIForbiddenErr()
+			}
+			f.RegionalTps[i].SslCertificates = sslCertURLs
+			return nil
+		}
+	}
+	return utils.FakeGoogleAPINotFoundErr()
+}
+
+// GetRegionalSslCertificate fakes getting a regional ssl cert.
+func (f *FakeLoadBalancers) GetRegionalSslCertificate(name string) (*compute.SslCertificate, error) {
+	f.calls = append(f.calls, "GetRegionalSslCertificate")
+	for i := range f.RegionalCerts {
+		if f.RegionalCerts[i].Name == name {
+			return f.RegionalCerts[i], nil
+		}
+	}
+	return nil, utils.FakeGoogleAPINotFoundErr()
+}
+
+// ListRegionalSslCertificates fakes listing regional ssl certs.
+func (f *FakeLoadBalancers) ListRegionalSslCertificates() ([]*compute.SslCertificate, error) {
+	f.calls = append(f.calls, "ListRegionalSslCertificates")
+	return f.RegionalCerts, nil
+}
+
+// CreateRegionalSslCertificate fakes out regional certificate creation.
+func (f *FakeLoadBalancers) CreateRegionalSslCertificate(cert *compute.SslCertificate) (*compute.SslCertificate, error) {
+	f.calls = append(f.calls, "CreateRegionalSslCertificate")
+	cert.SelfLink = regionalSelfLink("sslCertificates", cert.Name)
+	if len(f.RegionalCerts) == FakeCertQuota {
+		// Simulate cert creation failure
+		return nil, fmt.Errorf("unable to create cert, Exceeded cert limit of %d.", FakeCertQuota)
+	}
+	f.RegionalCerts = append(f.RegionalCerts, cert)
+	return cert, nil
+}
+
+// DeleteRegionalSslCertificate fakes out regional certificate deletion.
+func (f *FakeLoadBalancers) DeleteRegionalSslCertificate(name string) error {
+	f.calls = append(f.calls, "DeleteRegionalSslCertificate")
+	certs := []*compute.SslCertificate{}
+	for i := range f.RegionalCerts {
+		if f.RegionalCerts[i].Name != name {
+			certs = append(certs, f.RegionalCerts[i])
+		}
+	}
+	if len(f.RegionalCerts) == len(certs) {
+		// Nothing was deleted.
+		return utils.FakeGoogleAPINotFoundErr()
+	}
+	f.RegionalCerts = certs
+	return nil
+}
+
+// ReserveRegionalAddress fakes out internal IP reservation.
+func (f *FakeLoadBalancers) ReserveRegionalAddress(addr *compute.Address) error {
+	f.calls = append(f.calls, "ReserveRegionalAddress")
+	if addr.Address == "" {
+		addr.Address = testIPManager.ip()
+	}
+	f.RegionalIP = append(f.RegionalIP, addr)
+	return nil
+}
+
+// GetRegionalAddress fakes out internal IP retrieval.
+func (f *FakeLoadBalancers) GetRegionalAddress(name string) (*compute.Address, error) {
+	f.calls = append(f.calls, "GetRegionalAddress")
+	for i := range f.RegionalIP {
+		if f.RegionalIP[i].Name == name {
+			return f.RegionalIP[i], nil
+		}
+	}
+	return nil, utils.FakeGoogleAPINotFoundErr()
+}
+
+// DeleteRegionalAddress fakes out internal IP deletion.
+func (f *FakeLoadBalancers) DeleteRegionalAddress(name string) error {
+	f.calls = append(f.calls, "DeleteRegionalAddress")
+	ip := []*compute.Address{}
+	for i := range f.RegionalIP {
+		if f.RegionalIP[i].Name != name {
+			ip = append(ip, f.RegionalIP[i])
+		}
+	}
+	if len(f.RegionalIP) == len(ip) {
+		// Nothing was deleted.
+		return utils.FakeGoogleAPINotFoundErr()
+	}
+	f.RegionalIP = ip
 	return nil
 }
 
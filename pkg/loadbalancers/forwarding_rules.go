@@ -35,7 +35,7 @@ func (l *L7) checkHttpForwardingRule() (err error) {
 	}
 	name := l.namer.ForwardingRule(l.Name, utils.HTTPProtocol)
 	address, _ := l.getEffectiveIP()
-	fw, err := l.checkForwardingRule(name, l.tp.SelfLink, address, httpDefaultPortRange)
+	fw, err := l.checkForwardingRule(name, l.tp.SelfLink, address, httpDefaultPortRange, "")
 	if err != nil {
 		return err
 	}
@@ -50,7 +50,7 @@ func (l *L7) checkHttpsForwardingRule() (err error) {
 	}
 	name := l.namer.ForwardingRule(l.Name, utils.HTTPSProtocol)
 	address, _ := l.getEffectiveIP()
-	fws, err := l.checkForwardingRule(name, l.tps.SelfLink, address, httpsDefaultPortRange)
+	fws, err := l.checkForwardingRule(name, l.tps.SelfLink, address, httpsDefaultPortRange, "")
 	if err != nil {
 		return err
 	}
@@ -58,12 +58,14 @@ func (l *L7) checkHttpsForwardingRule() (err error) {
 	return nil
 }
 
-func (l *L7) checkForwardingRule(name, proxyLink, ip, portRange string) (fw *compute.ForwardingRule, err error) {
-	fw, _ = l.cloud.GetGlobalForwardingRule(name)
+// checkForwardingRule ensures the forwarding rule named name for the given
+// proxy, ip and port range. An empty ipVersion means IPv4.
+func (l *L7) checkForwardingRule(name, proxyLink, ip, portRange, ipVersion string) (fw *compute.ForwardingRule, err error) {
+	fw, _ = l.getForwardingRule(name)
 	if fw != nil && (ip != "" && fw.IPAddress != ip || fw.PortRange != portRange) {
 		klog.Warningf("Recreating forwarding rule %v(%v), so it has %v(%v)",
 			fw.IPAddress, fw.PortRange, ip, portRange)
-		if err = utils.IgnoreHTTPNotFound(l.cloud.DeleteGlobalForwardingRule(name)); err != nil {
+		if err = utils.IgnoreHTTPNotFound(l.deleteForwardingRule(name)); err != nil {
 			return nil, err
 		}
 		fw = nil
@@ -76,11 +78,12 @@ func (l *L7) checkForwardingRule(name, proxyLink, ip, portRange string) (fw *com
 			Target:     proxyLink,
 			PortRange:  portRange,
 			IPProtocol: "TCP",
+			IpVersion:  ipVersion,
 		}
-		if err = l.cloud.CreateGlobalForwardingRule(rule); err != nil {
+		if err = l.createForwardingRule(rule); err != nil {
 			return nil, err
 		}
-		fw, err = l.cloud.GetGlobalForwardingRule(name)
+		fw, err = l.getForwardingRule(name)
 		if err != nil {
 			return nil, err
 		}
@@ -91,7 +94,7 @@ func (l *L7) checkForwardingRule(name, proxyLink, ip, portRange string) (fw *com
 	} else {
 		klog.V(3).Infof("Forwarding rule %v has the wrong proxy, setting %v overwriting %v",
 			fw.Name, fw.Target, proxyLink)
-		if err := l.cloud.SetProxyForGlobalForwardingRule(fw.Name, proxyLink); err != nil {
+		if err := l.setProxyForForwardingRule(fw.Name, proxyLink); err != nil {
 			return nil, err
 		}
 	}
@@ -123,8 +126,8 @@ func (l *L7) getEffectiveIP() (string, bool) {
 	if l.runtimeInfo.StaticIPName != "" {
 		// Existing static IPs allocated to forwarding rules will get orphaned
 		// till the Ingress is torn down.
-		if ip, err := l.cloud.GetGlobalAddress(l.runtimeInfo.StaticIPName); err != nil || ip == nil {
-			klog.Warningf("The given static IP name %v doesn't translate to an existing global static IP, ignoring it and allocating a new IP: %v",
+		if ip, err := l.getAddress(l.runtimeInfo.StaticIPName); err != nil || ip == nil {
+			klog.Warningf("The given static IP name %v doesn't translate to an existing static IP, ignoring it and allocating a new IP: %v",
 				l.runtimeInfo.StaticIPName, err)
 		} else {
 			return ip.Address, false
@@ -17,7 +17,10 @@ limitations under the License.
 package loadbalancers
 
 import (
+	computebeta "google.golang.org/api/compute/v0.beta"
 	compute "google.golang.org/api/compute/v1"
+
+	"k8s.io/ingress-gce/pkg/composite"
 )
 
 // LoadBalancers is an interface for managing all the gce resources needed by L7
@@ -40,6 +43,18 @@ type LoadBalancers interface {
 	DeleteURLMap(name string) error
 	ListURLMaps() ([]*compute.UrlMap, error)
 
+	// Redirect UrlMaps, which are deleted with DeleteURLMap.
+	GetRedirectURLMap(name string) (*composite.UrlMap, error)
+	CreateRedirectURLMap(urlMap *composite.UrlMap) error
+	UpdateRedirectURLMap(urlMap *composite.UrlMap) error
+
+	// UrlMaps with route rules or path rewrites and redirects, which are
+	// only supported by the alpha API.
+	// They are listed and deleted with the UrlMaps above.
+	GetAlphaURLMap(name string) (*composite.UrlMap, error)
+	CreateAlphaURLMap(urlMap *composite.UrlMap) error
+	UpdateAlphaURLMap(urlMap *composite.UrlMap) error
+
 	// TargetProxies
 	GetTargetHTTPProxy(name string) (*compute.TargetHttpProxy, error)
 	CreateTargetHTTPProxy(proxy *compute.TargetHttpProxy) error
@@ -52,6 +67,8 @@ type LoadBalancers interface {
 	DeleteTargetHTTPSProxy(name string) error
 	SetURLMapForTargetHTTPSProxy(proxy *compute.TargetHttpsProxy, urlMapLink string) error
 	SetSslCertificateForTargetHTTPSProxy(proxy *compute.TargetHttpsProxy, sslCertURLs []string) error
+	SetSslPolicyForTargetHTTPSProxy(proxy *compute.TargetHttpsProxy, sslPolicyLink string) error
+	SetQuicOverrideForTargetHTTPSProxy(proxy *compute.TargetHttpsProxy, quicOverride string) error
 
 	// SslCertificates
 	GetSslCertificate(name string) (*compute.SslCertificate, error)
@@ -59,11 +76,47 @@ type LoadBalancers interface {
 	CreateSslCertificate(certs *compute.SslCertificate) (*compute.SslCertificate, error)
 	DeleteSslCertificate(name string) error
 
+	// Google-managed SslCertificates, which are listed and deleted with
+	// the SslCertificates above.
+	GetBetaSslCertificate(name string) (*computebeta.SslCertificate, error)
+	CreateBetaSslCertificate(cert *computebeta.SslCertificate) (*computebeta.SslCertificate, error)
+
 	// Static IP
 
 	ReserveGlobalAddress(addr *compute.Address) error
 	GetGlobalAddress(name string) (*compute.Address, error)
 	DeleteGlobalAddress(name string) error
+
+	// Regional resources of internal loadbalancers, in the region of the
+	// cluster.
+	Region() string
+	GetRegionalForwardingRule(name string) (*compute.ForwardingRule, error)
+	CreateRegionalForwardingRule(rule *compute.ForwardingRule) error
+	DeleteRegionalForwardingRule(name string) error
+	SetProxyForRegionalForwardingRule(fw, proxy string) error
+	GetRegionalURLMap(name string) (*compute.UrlMap, error)
+	CreateRegionalURLMap(urlMap *compute.UrlMap) error
+	UpdateRegionalURLMap(urlMap *compute.UrlMap) error
+	DeleteRegionalURLMap(name string) error
+	ListRegionalURLMaps() ([]*compute.UrlMap, error)
+	GetRegionalTargetHTTPProxy(name string) (*compute.TargetHttpProxy, error)
+	CreateRegionalTargetHTTPProxy(proxy *compute.TargetHttpProxy) error
+	DeleteRegionalTargetHTTPProxy(name string) error
+	SetURLMapForRegionalTargetHTTPProxy(proxy *compute.TargetHttpProxy, urlMapLink string) error
+	GetRegionalTargetHTTPSProxy(name string) (*compute.TargetHttpsProxy, error)
+	CreateRegionalTargetHTTPSProxy(proxy *compute.TargetHttpsProxy) error
+	DeleteRegionalTargetHTTPSProxy(name string) error
+	SetURLMapForRegionalTargetHTTPSProxy(proxy *compute.TargetHttpsProxy, urlMapLink string) error
+	SetSslCertificateForRegionalTargetHTTPSProxy(proxy *compute.TargetHttpsProxy, sslCertURLs []string) error
+	GetRegionalSslCertificate(name string) (*compute.SslCertificate, error)
+	ListRegionalSslCertificates() ([]*compute.SslCertificate, error)
+	CreateRegionalSslCertificate(cert *compute.SslCertificate) (*compute.SslCertificate, error)
+	DeleteRegionalSslCertificate(name string) error
+	// ReserveRegionalAddress reserves an internal address which may be
+	// shared by the forwarding rules of a loadbalancer.
+	ReserveRegionalAddress(addr *compute.Address) error
+	GetRegionalAddress(name string) (*compute.Address, error)
+	DeleteRegionalAddress(name string) error
 }
 
 // LoadBalancerPool is an interface to manage the cloud resources associated
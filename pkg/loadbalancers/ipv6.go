@@ -0,0 +1,108 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package loadbalancers
+
+import (
+	compute "google.golang.org/api/compute/v1"
+	"k8s.io/klog"
+
+	"k8s.io/ingress-gce/pkg/utils"
+)
+
+// ipv6Version is the IP version of IPv6 addresses and forwarding rules.
+const ipv6Version = "IPV6"
+
+// edgeHopIPv6 ensures the IPv6 address and forwarding rules of the L7, next
+// to the IPv4 forwarding rules of each proxy, if the L7 serves IPv6. The
+// IPv6 resources are deleted otherwise.
+func (l *L7) edgeHopIPv6() error {
+	// Internal loadbalancers only serve IPv4.
+	if l.runtimeInfo.Internal {
+		return nil
+	}
+	if !l.runtimeInfo.IPv6 {
+		// The address is reserved before the forwarding rules, so there
+		// is nothing to delete without it.
+		ip, err := l.cloud.GetGlobalAddress(l.namer.IPv6ForwardingRule(l.Name, utils.HTTPProtocol))
+		if utils.IgnoreHTTPNotFound(err) != nil {
+			return err
+		}
+		if ip == nil {
+			return nil
+		}
+		return l.deleteIPv6()
+	}
+
+	if err := l.checkIPv6StaticIP(); err != nil {
+		return err
+	}
+	if l.tp != nil {
+		name := l.namer.IPv6ForwardingRule(l.Name, utils.HTTPProtocol)
+		fw, err := l.checkForwardingRule(name, l.tp.SelfLink, l.ipv6.Address, httpDefaultPortRange, ipv6Version)
+		if err != nil {
+			return err
+		}
+		l.fwIPv6 = fw
+	}
+	if l.tps != nil {
+		name := l.namer.IPv6ForwardingRule(l.Name, utils.HTTPSProtocol)
+		fws, err := l.checkForwardingRule(name, l.tps.SelfLink, l.ipv6.Address, httpsDefaultPortRange, ipv6Version)
+		if err != nil {
+			return err
+		}
+		l.fwsIPv6 = fws
+	}
+	return nil
+}
+
+// checkIPv6StaticIP reserves the static IPv6 address shared by the IPv6
+// forwarding rules. Ephemeral IPv6 addresses cannot be shared, so the
+// address is reserved before any IPv6 forwarding rule is created.
+func (l *L7) checkIPv6StaticIP() (err error) {
+	name := l.namer.IPv6ForwardingRule(l.Name, utils.HTTPProtocol)
+	ip, _ := l.cloud.GetGlobalAddress(name)
+	if ip == nil {
+		klog.V(3).Infof("Creating static IPv6 address %v", name)
+		if err = l.cloud.ReserveGlobalAddress(&compute.Address{Name: name, IpVersion: ipv6Version}); err != nil {
+			return err
+		}
+		ip, err = l.cloud.GetGlobalAddress(name)
+		if err != nil {
+			return err
+		}
+	}
+	l.ipv6 = ip
+	return nil
+}
+
+// deleteIPv6 deletes the IPv6 forwarding rules and address of the L7.
+func (l *L7) deleteIPv6() error {
+	for _, protocol := range []utils.NamerProtocol{utils.HTTPProtocol, utils.HTTPSProtocol} {
+		name := l.namer.IPv6ForwardingRule(l.Name, protocol)
+		klog.V(2).Infof("Deleting IPv6 forwarding rule %v", name)
+		if err := utils.IgnoreHTTPNotFound(l.cloud.DeleteGlobalForwardingRule(name)); err != nil {
+			return err
+		}
+	}
+	name := l.namer.IPv6ForwardingRule(l.Name, utils.HTTPProtocol)
+	klog.V(2).Infof("Deleting static IPv6 address %v", name)
+	if err := utils.IgnoreHTTPNotFound(l.cloud.DeleteGlobalAddress(name)); err != nil {
+		return err
+	}
+	l.fwIPv6, l.fwsIPv6, l.ipv6 = nil, nil, nil
+	return nil
+}
@@ -23,13 +23,17 @@ import (
 
 	"k8s.io/klog"
 
+	computebeta "google.golang.org/api/compute/v0.beta"
 	compute "google.golang.org/api/compute/v1"
 
 	extensions "k8s.io/api/extensions/v1beta1"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/tools/record"
 
 	"k8s.io/ingress-gce/pkg/annotations"
+	frontendconfigv1beta1 "k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1"
 	"k8s.io/ingress-gce/pkg/backends"
+	"k8s.io/ingress-gce/pkg/composite"
 	"k8s.io/ingress-gce/pkg/utils"
 )
 
@@ -60,9 +64,26 @@ type L7RuntimeInfo struct {
 	AllowHTTP bool
 	// The name of a Global Static IP. If specified, the IP associated with
 	// this name is used in the Forwarding Rules for this loadbalancer.
+	// For internal loadbalancers, this is the name of a regional internal IP.
 	StaticIPName string
 	// UrlMap is our internal representation of a url map.
 	UrlMap *utils.GCEURLMap
+	// FrontendConfig is the FrontendConfig referenced by the Ingress, if any.
+	FrontendConfig *frontendconfigv1beta1.FrontendConfig
+	// ManagedCertDomains are the domains of the Google-managed certificate
+	// to provision. If empty, no managed certificate is provisioned.
+	ManagedCertDomains []string
+	// Internal is true if the loadbalancer is a regional internal
+	// loadbalancer, on the Subnetwork of the Network.
+	Internal bool
+	// Network is the URL of the network of an internal loadbalancer.
+	Network string
+	// Subnetwork is the URL of the subnetwork from which an internal
+	// loadbalancer is assigned its IP.
+	Subnetwork string
+	// IPv6 is true if the loadbalancer is also served on a global IPv6
+	// address, which is reserved by the controller.
+	IPv6 bool
 }
 
 // TLSCerts encapsulates .pem encoded TLS information.
@@ -92,6 +113,12 @@ type L7 struct {
 	cloud LoadBalancers
 	// um is the UrlMap associated with this L7.
 	um *compute.UrlMap
+	// redirectUm is the UrlMap redirecting HTTP to HTTPS, which the
+	// TargetHTTPProxy points to instead of um if set.
+	redirectUm *composite.UrlMap
+	// alphaUm is um with its route rules, rewrites and redirects, if it has
+	// any.
+	alphaUm *composite.UrlMap
 	// tp is the TargetHTTPProxy associated with this L7.
 	tp *compute.TargetHttpProxy
 	// tps is the TargetHTTPSProxy associated with this L7.
@@ -102,6 +129,14 @@ type L7 struct {
 	fws *compute.ForwardingRule
 	// ip is the static-ip associated with both GlobalForwardingRules.
 	ip *compute.Address
+	// fwIPv6 is the IPv6 GlobalForwardingRule that points to the
+	// TargetHTTPProxy.
+	fwIPv6 *compute.ForwardingRule
+	// fwsIPv6 is the IPv6 GlobalForwardingRule that points to the
+	// TargetHTTPSProxy.
+	fwsIPv6 *compute.ForwardingRule
+	// ipv6 is the static IPv6 address of both IPv6 GlobalForwardingRules.
+	ipv6 *compute.Address
 	// sslCerts is the list of ssl certs associated with the targetHTTPSProxy.
 	sslCerts []*compute.SslCertificate
 	// oldSSLCerts is the list of certs that used to be hooked up to the
@@ -109,6 +144,9 @@ type L7 struct {
 	// to create - update - delete and storing the old certs in a list
 	// prevents leakage if there's a failure along the way.
 	oldSSLCerts []*compute.SslCertificate
+	// managedSSLCert is the Google-managed cert for the current domains,
+	// with its provisioning status.
+	managedSSLCert *computebeta.SslCertificate
 	// namer is used to compute names of the various sub-components of an L7.
 	namer *utils.Namer
 	// recorder is used to generate k8s Events.
@@ -126,17 +164,36 @@ func (l *L7) UrlMap() *compute.UrlMap {
 }
 
 func (l *L7) edgeHop() error {
+	if err := l.validateInternal(); err != nil {
+		return err
+	}
+	sslConfigured := l.sslConfigured()
+	if l.runtimeInfo.Internal && l.runtimeInfo.AllowHTTP && sslConfigured {
+		klog.V(3).Infof("checking internal static ip for %v", l.Name)
+		if err := l.checkInternalStaticIP(); err != nil {
+			return err
+		}
+	}
 	if err := l.ensureComputeURLMap(); err != nil {
 		return err
 	}
+	if err := l.ensureRedirectURLMap(); err != nil {
+		return err
+	}
 	if l.runtimeInfo.AllowHTTP {
 		if err := l.edgeHopHttp(); err != nil {
 			return err
 		}
+		// The http proxy no longer points to the redirect url map, if it
+		// ever did, so a stale one can be deleted.
+		if l.redirectUm == nil {
+			if err := l.deleteRedirectURLMap(); err != nil {
+				return err
+			}
+		}
 	}
 	// Defer promoting an ephemeral to a static IP until it's really needed.
-	sslConfigured := l.runtimeInfo.TLS != nil || l.runtimeInfo.TLSName != ""
-	if l.runtimeInfo.AllowHTTP && sslConfigured {
+	if !l.runtimeInfo.Internal && l.runtimeInfo.AllowHTTP && sslConfigured {
 		klog.V(3).Infof("checking static ip for %v", l.Name)
 		if err := l.checkStaticIP(); err != nil {
 			return err
@@ -148,7 +205,7 @@ func (l *L7) edgeHop() error {
 			return err
 		}
 	}
-	return nil
+	return l.edgeHopIPv6()
 }
 
 func (l *L7) edgeHopHttp() error {
@@ -173,6 +230,12 @@ func (l *L7) edgeHopHttps() error {
 	return l.checkHttpsForwardingRule()
 }
 
+// sslConfigured returns true if the l7 serves HTTPS, with certs from
+// secrets, pre-shared certs or a Google-managed cert.
+func (l *L7) sslConfigured() bool {
+	return l.runtimeInfo.TLS != nil || l.runtimeInfo.TLSName != "" || len(l.runtimeInfo.ManagedCertDomains) > 0
+}
+
 // GetIP returns the ip associated with the forwarding rule for this l7.
 func (l *L7) GetIP() string {
 	if l.fw != nil {
@@ -184,53 +247,72 @@ func (l *L7) GetIP() string {
 	return ""
 }
 
+// GetIPv6 returns the IPv6 address of the IPv6 forwarding rules for this l7.
+func (l *L7) GetIPv6() string {
+	if l.ipv6 != nil && (l.fwIPv6 != nil || l.fwsIPv6 != nil) {
+		return l.ipv6.Address
+	}
+	return ""
+}
+
 // Cleanup deletes resources specific to this l7 in the right order.
 // forwarding rule -> target proxy -> url map
 // This leaves backends and health checks, which are shared across loadbalancers.
 func (l *L7) Cleanup() error {
 	fwName := l.namer.ForwardingRule(l.Name, utils.HTTPProtocol)
-	klog.V(2).Infof("Deleting global forwarding rule %v", fwName)
-	if err := utils.IgnoreHTTPNotFound(l.cloud.DeleteGlobalForwardingRule(fwName)); err != nil {
+	klog.V(2).Infof("Deleting forwarding rule %v", fwName)
+	if err := utils.IgnoreHTTPNotFound(l.deleteForwardingRule(fwName)); err != nil {
 		return err
 	}
 
 	fwsName := l.namer.ForwardingRule(l.Name, utils.HTTPSProtocol)
-	klog.V(2).Infof("Deleting global forwarding rule %v", fwsName)
-	if err := utils.IgnoreHTTPNotFound(l.cloud.DeleteGlobalForwardingRule(fwsName)); err != nil {
+	klog.V(2).Infof("Deleting forwarding rule %v", fwsName)
+	if err := utils.IgnoreHTTPNotFound(l.deleteForwardingRule(fwsName)); err != nil {
 		return err
 	}
 
-	ip, err := l.cloud.GetGlobalAddress(fwName)
+	ip, err := l.getAddress(fwName)
 	if ip != nil && utils.IgnoreHTTPNotFound(err) == nil {
 		klog.V(2).Infof("Deleting static IP %v(%v)", ip.Name, ip.Address)
-		if err := utils.IgnoreHTTPNotFound(l.cloud.DeleteGlobalAddress(ip.Name)); err != nil {
+		if err := utils.IgnoreHTTPNotFound(l.deleteAddress(ip.Name)); err != nil {
+			return err
+		}
+	}
+
+	if !l.runtimeInfo.Internal {
+		if err := l.deleteIPv6(); err != nil {
 			return err
 		}
 	}
 
 	tpName := l.namer.TargetProxy(l.Name, utils.HTTPProtocol)
 	klog.V(2).Infof("Deleting target http proxy %v", tpName)
-	if err := utils.IgnoreHTTPNotFound(l.cloud.DeleteTargetHTTPProxy(tpName)); err != nil {
+	if err := utils.IgnoreHTTPNotFound(l.deleteTargetHTTPProxy(tpName)); err != nil {
 		return err
 	}
 
 	tpsName := l.namer.TargetProxy(l.Name, utils.HTTPSProtocol)
 	klog.V(2).Infof("Deleting target https proxy %v", tpsName)
-	if err := utils.IgnoreHTTPNotFound(l.cloud.DeleteTargetHTTPSProxy(tpsName)); err != nil {
+	if err := utils.IgnoreHTTPNotFound(l.deleteTargetHTTPSProxy(tpsName)); err != nil {
 		return err
 	}
 
-	// Delete the SSL cert if it is from a secret, not referencing a pre-created GCE cert or managed certificates.
+	// Delete the SSL cert if it is from a secret or Google-managed, not referencing a pre-created GCE cert.
 	secretsSslCerts, err := l.getIngressManagedSslCerts()
 	if err != nil {
 		return err
 	}
+	managedSslCerts, err := l.getManagedSslCerts()
+	if err != nil {
+		return err
+	}
+	secretsSslCerts = append(secretsSslCerts, managedSslCerts...)
 
 	if len(secretsSslCerts) != 0 {
 		var certErr error
 		for _, cert := range secretsSslCerts {
 			klog.V(2).Infof("Deleting sslcert %s", cert.Name)
-			if err := utils.IgnoreHTTPNotFound(l.cloud.DeleteSslCertificate(cert.Name)); err != nil {
+			if err := utils.IgnoreHTTPNotFound(l.deleteSslCertificate(cert.Name)); err != nil {
 				klog.Errorf("Old cert delete failed - %v", err)
 				certErr = err
 			}
@@ -243,7 +325,13 @@ func (l *L7) Cleanup() error {
 
 	umName := l.namer.UrlMap(l.Name)
 	klog.V(2).Infof("Deleting URL Map %v", umName)
-	if err := utils.IgnoreHTTPNotFound(l.cloud.DeleteURLMap(umName)); err != nil {
+	if err := utils.IgnoreHTTPNotFound(l.deleteURLMap(umName)); err != nil {
+		return err
+	}
+
+	redirectUmName := l.namer.RedirectUrlMap(l.Name)
+	klog.V(2).Infof("Deleting redirect URL Map %v", redirectUmName)
+	if err := utils.IgnoreHTTPNotFound(l.deleteURLMap(redirectUmName)); err != nil {
 		return err
 	}
 
@@ -259,6 +347,13 @@ func GetLBAnnotations(l7 *L7, existing map[string]string, backendSyncer backends
 	if err != nil {
 		return nil, err
 	}
+	if l7.alphaUm != nil {
+		routeRuleBackends, err := getRouteRuleBackendNames(l7.alphaUm)
+		if err != nil {
+			return nil, err
+		}
+		backends = sets.NewString(append(backends, routeRuleBackends...)...).List()
+	}
 	backendState := map[string]string{}
 	for _, beName := range backends {
 		backendState[beName] = backendSyncer.Status(beName)
@@ -274,6 +369,12 @@ func GetLBAnnotations(l7 *L7, existing map[string]string, backendSyncer backends
 	}
 
 	existing[fmt.Sprintf("%v/url-map", annotations.StatusPrefix)] = l7.um.Name
+	// The redirect url map only exists if HTTP is redirected to HTTPS.
+	if l7.redirectUm != nil {
+		existing[fmt.Sprintf("%v/redirect-url-map", annotations.StatusPrefix)] = l7.redirectUm.Name
+	} else {
+		delete(existing, fmt.Sprintf("%v/redirect-url-map", annotations.StatusPrefix))
+	}
 	// Forwarding rule and target proxy might not exist if allowHTTP == false
 	if l7.fw != nil {
 		existing[fmt.Sprintf("%v/forwarding-rule", annotations.StatusPrefix)] = l7.fw.Name
@@ -291,9 +392,36 @@ func GetLBAnnotations(l7 *L7, existing map[string]string, backendSyncer backends
 	if l7.ip != nil {
 		existing[fmt.Sprintf("%v/static-ip", annotations.StatusPrefix)] = l7.ip.Name
 	}
+	// IPv6 resources only exist if the Ingress opts in.
+	ipv6Annotations := map[string]string{}
+	if l7.fwIPv6 != nil {
+		ipv6Annotations["ipv6-forwarding-rule"] = l7.fwIPv6.Name
+	}
+	if l7.fwsIPv6 != nil {
+		ipv6Annotations["ipv6-https-forwarding-rule"] = l7.fwsIPv6.Name
+	}
+	if l7.ipv6 != nil {
+		ipv6Annotations["ipv6-static-ip"] = l7.ipv6.Name
+	}
+	for _, resource := range []string{"ipv6-forwarding-rule", "ipv6-https-forwarding-rule", "ipv6-static-ip"} {
+		key := fmt.Sprintf("%v/%v", annotations.StatusPrefix, resource)
+		if name, ok := ipv6Annotations[resource]; ok {
+			existing[key] = name
+		} else {
+			delete(existing, key)
+		}
+	}
 	if len(certs) > 0 {
 		existing[fmt.Sprintf("%v/ssl-cert", annotations.StatusPrefix)] = strings.Join(certs, ",")
 	}
+	// The managed cert only exists if the Ingress requests one.
+	if l7.managedSSLCert != nil {
+		existing[fmt.Sprintf("%v/managed-ssl-cert", annotations.StatusPrefix)] = l7.managedSSLCert.Name
+		existing[fmt.Sprintf("%v/managed-ssl-cert-status", annotations.StatusPrefix)] = managedCertStatus(l7.managedSSLCert)
+	} else {
+		delete(existing, fmt.Sprintf("%v/managed-ssl-cert", annotations.StatusPrefix))
+		delete(existing, fmt.Sprintf("%v/managed-ssl-cert-status", annotations.StatusPrefix))
+	}
 	// TODO: We really want to know *when* a backend flipped states.
 	existing[fmt.Sprintf("%v/backends", annotations.StatusPrefix)] = jsonBackendState
 	return existing, nil
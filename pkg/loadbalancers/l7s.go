@@ -23,6 +23,7 @@ import (
 
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/ingress-gce/pkg/events"
+	"k8s.io/ingress-gce/pkg/flags"
 	"k8s.io/ingress-gce/pkg/utils"
 )
 
@@ -65,7 +66,8 @@ func (l *L7s) Ensure(ri *L7RuntimeInfo) (*L7, error) {
 	return lb, nil
 }
 
-// Delete deletes a load balancer by name.
+// Delete deletes a load balancer by name. As the name does not tell whether
+// the load balancer is internal, the resources of both scopes are deleted.
 func (l *L7s) Delete(name string) error {
 	lb := &L7{
 		runtimeInfo: &L7RuntimeInfo{Name: name},
@@ -78,6 +80,13 @@ func (l *L7s) Delete(name string) error {
 	if err := lb.Cleanup(); err != nil {
 		return err
 	}
+	if flags.F.EnableL7Ilb {
+		lb.runtimeInfo.Internal = true
+		klog.V(3).Infof("Deleting internal lb %v", lb.Name)
+		if err := lb.Cleanup(); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -90,10 +99,21 @@ func (l *L7s) List() ([]string, error) {
 	if err != nil {
 		return nil, err
 	}
+	if flags.F.EnableL7Ilb {
+		regionalURLMaps, err := l.cloud.ListRegionalURLMaps()
+		if err != nil {
+			return nil, err
+		}
+		urlMaps = append(urlMaps, regionalURLMaps...)
+	}
 
 	for _, um := range urlMaps {
 		if l.namer.NameBelongsToCluster(um.Name) {
 			nameParts := l.namer.ParseName(um.Name)
+			if nameParts.LbName == "" {
+				// Not the url map of a loadbalancer, e.g. a redirect url map.
+				continue
+			}
 			l7Name := l.namer.LoadBalancerFromLbName(nameParts.LbName)
 			names = append(names, l7Name)
 		}
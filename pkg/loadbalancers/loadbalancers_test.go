@@ -18,9 +18,11 @@ package loadbalancers
 
 import (
 	"fmt"
+	"net/http"
 	"reflect"
 	"testing"
 
+	"github.com/kr/pretty"
 	compute "google.golang.org/api/compute/v1"
 	extensions "k8s.io/api/extensions/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -30,7 +32,11 @@ import (
 	"strings"
 
 	"k8s.io/ingress-gce/pkg/annotations"
+	frontendconfigv1beta1 "k8s.io/ingress-gce/pkg/apis/frontendconfig/v1beta1"
+	"k8s.io/ingress-gce/pkg/backends"
+	"k8s.io/ingress-gce/pkg/composite"
 	"k8s.io/ingress-gce/pkg/events"
+	"k8s.io/ingress-gce/pkg/flags"
 	"k8s.io/ingress-gce/pkg/instances"
 	"k8s.io/ingress-gce/pkg/utils"
 )
@@ -107,6 +113,306 @@ func TestCreateHTTPSLoadBalancer(t *testing.T) {
 	verifyHTTPSForwardingRuleAndProxyLinks(t, f)
 }
 
+func TestFrontendConfigHTTPSProxy(t *testing.T) {
+	gceUrlMap := utils.NewGCEURLMap()
+	gceUrlMap.DefaultBackend = &utils.ServicePort{NodePort: 31234}
+	namer := utils.NewNamer("uid1", "fw1")
+	sslPolicy, otherSslPolicy, noSslPolicy := "policy-a", "policy-b", ""
+	quicOverride, otherQuicOverride := "ENABLE", "DISABLE"
+	lbInfo := &L7RuntimeInfo{
+		Name:           namer.LoadBalancer("test"),
+		AllowHTTP:      false,
+		TLS:            []*TLSCerts{createCert("key", "cert", "name")},
+		UrlMap:         gceUrlMap,
+		Ingress:        newIngress(),
+		FrontendConfig: &frontendconfigv1beta1.FrontendConfig{},
+	}
+	f := NewFakeLoadBalancers(lbInfo.Name, namer)
+	pool := newFakeLoadBalancerPool(f, t, namer)
+
+	for _, step := range []struct {
+		desc             string
+		sslPolicy        *string
+		quicOverride     *string
+		wantSslPolicy    string
+		wantQuicOverride string
+	}{
+		{
+			desc:             "create with SSL policy and QUIC override",
+			sslPolicy:        &sslPolicy,
+			quicOverride:     &quicOverride,
+			wantSslPolicy:    "global/sslPolicies/policy-a",
+			wantQuicOverride: "ENABLE",
+		},
+		{
+			desc:             "unset fields leave the proxy as is",
+			wantSslPolicy:    "global/sslPolicies/policy-a",
+			wantQuicOverride: "ENABLE",
+		},
+		{
+			desc:             "change SSL policy and QUIC override",
+			sslPolicy:        &otherSslPolicy,
+			quicOverride:     &otherQuicOverride,
+			wantSslPolicy:    "global/sslPolicies/policy-b",
+			wantQuicOverride: "DISABLE",
+		},
+		{
+			desc:             "empty SSL policy removes it",
+			sslPolicy:        &noSslPolicy,
+			wantSslPolicy:    "",
+			wantQuicOverride: "DISABLE",
+		},
+	} {
+		lbInfo.FrontendConfig.Spec.SslPolicy = step.sslPolicy
+		lbInfo.FrontendConfig.Spec.QuicOverride = step.quicOverride
+		if _, err := pool.Ensure(lbInfo); err != nil {
+			t.Fatalf("%s: pool.Ensure() = %v", step.desc, err)
+		}
+		tps, err := f.GetTargetHTTPSProxy(f.TPName(true))
+		if err != nil {
+			t.Fatalf("%s: f.GetTargetHTTPSProxy(%q) = _, %v; want nil", step.desc, f.TPName(true), err)
+		}
+		if tps.SslPolicy != step.wantSslPolicy {
+			t.Errorf("%s: tps.SslPolicy = %q, want %q", step.desc, tps.SslPolicy, step.wantSslPolicy)
+		}
+		if tps.QuicOverride != step.wantQuicOverride {
+			t.Errorf("%s: tps.QuicOverride = %q, want %q", step.desc, tps.QuicOverride, step.wantQuicOverride)
+		}
+	}
+}
+
+// statusSyncer is a backends.Syncer which only implements Status.
+type statusSyncer struct {
+	backends.Syncer
+}
+
+func (statusSyncer) Status(name string) string {
+	return "HEALTHY"
+}
+
+func TestHTTPSRedirect(t *testing.T) {
+	gceUrlMap := utils.NewGCEURLMap()
+	gceUrlMap.DefaultBackend = &utils.ServicePort{NodePort: 31234}
+	namer := utils.NewNamer("uid1", "fw1")
+	lbInfo := &L7RuntimeInfo{
+		Name:           namer.LoadBalancer("test"),
+		AllowHTTP:      true,
+		TLS:            []*TLSCerts{createCert("key", "cert", "name")},
+		UrlMap:         gceUrlMap,
+		Ingress:        newIngress(),
+		FrontendConfig: &frontendconfigv1beta1.FrontendConfig{},
+	}
+	f := NewFakeLoadBalancers(lbInfo.Name, namer)
+	pool := newFakeLoadBalancerPool(f, t, namer)
+	redirectUmName := namer.RedirectUrlMap(lbInfo.Name)
+
+	for _, step := range []struct {
+		desc             string
+		redirect         *frontendconfigv1beta1.HttpsRedirectConfig
+		wantResponseCode string
+	}{
+		{
+			desc: "no redirect",
+		},
+		{
+			desc:             "enable redirect",
+			redirect:         &frontendconfigv1beta1.HttpsRedirectConfig{Enabled: true},
+			wantResponseCode: "MOVED_PERMANENTLY_DEFAULT",
+		},
+		{
+			desc:             "change response code",
+			redirect:         &frontendconfigv1beta1.HttpsRedirectConfig{Enabled: true, ResponseCodeName: "FOUND"},
+			wantResponseCode: "FOUND",
+		},
+		{
+			desc:     "disable redirect",
+			redirect: &frontendconfigv1beta1.HttpsRedirectConfig{Enabled: false},
+		},
+	} {
+		lbInfo.FrontendConfig.Spec.RedirectToHttps = step.redirect
+		l7, err := pool.Ensure(lbInfo)
+		if err != nil {
+			t.Fatalf("%s: pool.Ensure() = %v", step.desc, err)
+		}
+		tp, err := f.GetTargetHTTPProxy(f.TPName(false))
+		if err != nil {
+			t.Fatalf("%s: f.GetTargetHTTPProxy(%q) = _, %v; want nil", step.desc, f.TPName(false), err)
+		}
+		redirectUm, err := f.GetRedirectURLMap(redirectUmName)
+		if step.wantResponseCode == "" {
+			if !utils.IsHTTPErrorCode(err, http.StatusNotFound) {
+				t.Errorf("%s: f.GetRedirectURLMap(%q) = _, %v; want not found", step.desc, redirectUmName, err)
+			}
+			verifyHTTPForwardingRuleAndProxyLinks(t, f)
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%s: f.GetRedirectURLMap(%q) = _, %v; want nil", step.desc, redirectUmName, err)
+		}
+		wantRedirect := &composite.HttpRedirectAction{HttpsRedirect: true, RedirectResponseCode: step.wantResponseCode}
+		if !reflect.DeepEqual(redirectUm.DefaultUrlRedirect, wantRedirect) {
+			t.Errorf("%s: redirectUm.DefaultUrlRedirect = %+v, want %+v", step.desc, redirectUm.DefaultUrlRedirect, wantRedirect)
+		}
+		if !utils.EqualResourcePaths(tp.UrlMap, redirectUm.SelfLink) {
+			t.Errorf("%s: tp.UrlMap = %q, want %q", step.desc, tp.UrlMap, redirectUm.SelfLink)
+		}
+		verifyHTTPSForwardingRuleAndProxyLinks(t, f)
+
+		lbAnnotations, err := GetLBAnnotations(l7, nil, statusSyncer{})
+		if err != nil {
+			t.Fatalf("%s: GetLBAnnotations() = _, %v", step.desc, err)
+		}
+		if got := GCEResourceName(lbAnnotations, "redirect-url-map"); got != redirectUmName {
+			t.Errorf("%s: redirect-url-map annotation = %q, want %q", step.desc, got, redirectUmName)
+		}
+	}
+
+	// The redirect url map is not listed as a loadbalancer, and is deleted
+	// with the loadbalancer.
+	lbInfo.FrontendConfig.Spec.RedirectToHttps = &frontendconfigv1beta1.HttpsRedirectConfig{Enabled: true}
+	if _, err := pool.Ensure(lbInfo); err != nil {
+		t.Fatalf("pool.Ensure() = %v", err)
+	}
+	names, err := pool.List()
+	if err != nil {
+		t.Fatalf("pool.List() = _, %v", err)
+	}
+	if want := []string{lbInfo.Name}; !reflect.DeepEqual(names, want) {
+		t.Errorf("pool.List() = %v, want %v", names, want)
+	}
+	if err := pool.Delete(lbInfo.Name); err != nil {
+		t.Fatalf("pool.Delete(%q) = %v", lbInfo.Name, err)
+	}
+	if _, err := f.GetRedirectURLMap(redirectUmName); !utils.IsHTTPErrorCode(err, http.StatusNotFound) {
+		t.Errorf("f.GetRedirectURLMap(%q) = _, %v; want not found", redirectUmName, err)
+	}
+}
+
+func TestManagedCertificates(t *testing.T) {
+	gceUrlMap := utils.NewGCEURLMap()
+	gceUrlMap.DefaultBackend = &utils.ServicePort{NodePort: 31234}
+	namer := utils.NewNamer("uid1", "fw1")
+	lbInfo := &L7RuntimeInfo{
+		Name:      namer.LoadBalancer("test"),
+		AllowHTTP: false,
+		UrlMap:    gceUrlMap,
+		Ingress:   newIngress(),
+	}
+	f := NewFakeLoadBalancers(lbInfo.Name, namer)
+	pool := newFakeLoadBalancerPool(f, t, namer)
+	certName := func(domains ...string) string {
+		return namer.ManagedSSLCertName(lbInfo.Name, GetCertHash(strings.Join(domains, ",")))
+	}
+	setStatus := func(name, status string) {
+		for _, cert := range f.BetaCerts {
+			if cert.Name == name {
+				cert.Managed.Status = status
+			}
+		}
+	}
+
+	for _, step := range []struct {
+		desc        string
+		domains     []string
+		tls         []*TLSCerts
+		setup       func()
+		wantCerts   []string
+		wantManaged string
+		wantStatus  string
+	}{
+		{
+			desc:        "create managed cert",
+			domains:     []string{"a.example.com"},
+			wantCerts:   []string{certName("a.example.com")},
+			wantManaged: certName("a.example.com"),
+			wantStatus:  "PROVISIONING",
+		},
+		{
+			desc:        "managed cert is provisioned",
+			domains:     []string{"a.example.com"},
+			setup:       func() { setStatus(certName("a.example.com"), "ACTIVE") },
+			wantCerts:   []string{certName("a.example.com")},
+			wantManaged: certName("a.example.com"),
+			wantStatus:  "ACTIVE",
+		},
+		{
+			desc:        "domains change, previous cert is kept",
+			domains:     []string{"a.example.com", "b.example.com"},
+			wantCerts:   []string{certName("a.example.com", "b.example.com"), certName("a.example.com")},
+			wantManaged: certName("a.example.com", "b.example.com"),
+			wantStatus:  "PROVISIONING",
+		},
+		{
+			desc:        "new cert is provisioned, previous cert is deleted",
+			domains:     []string{"a.example.com", "b.example.com"},
+			setup:       func() { setStatus(certName("a.example.com", "b.example.com"), "ACTIVE") },
+			wantCerts:   []string{certName("a.example.com", "b.example.com")},
+			wantManaged: certName("a.example.com", "b.example.com"),
+			wantStatus:  "ACTIVE",
+		},
+		{
+			desc:      "managed cert no longer requested",
+			tls:       []*TLSCerts{createCert("key", "cert", "name")},
+			wantCerts: []string{namer.SSLCertName(lbInfo.Name, GetCertHash("cert"))},
+		},
+	} {
+		if step.setup != nil {
+			step.setup()
+		}
+		lbInfo.ManagedCertDomains = step.domains
+		lbInfo.TLS = step.tls
+		l7, err := pool.Ensure(lbInfo)
+		if err != nil {
+			t.Fatalf("%s: pool.Ensure() = %v", step.desc, err)
+		}
+		tps, err := f.GetTargetHTTPSProxy(f.TPName(true))
+		if err != nil {
+			t.Fatalf("%s: f.GetTargetHTTPSProxy(%q) = _, %v; want nil", step.desc, f.TPName(true), err)
+		}
+		var gotCerts []string
+		for _, link := range tps.SslCertificates {
+			name, err := utils.KeyName(link)
+			if err != nil {
+				t.Fatalf("%s: utils.KeyName(%q) = _, %v", step.desc, link, err)
+			}
+			gotCerts = append(gotCerts, name)
+		}
+		if !reflect.DeepEqual(gotCerts, step.wantCerts) {
+			t.Errorf("%s: tps.SslCertificates = %v, want %v", step.desc, gotCerts, step.wantCerts)
+		}
+		var allCerts []string
+		for _, cert := range f.Certs {
+			allCerts = append(allCerts, cert.Name)
+		}
+		if !sets.NewString(allCerts...).Equal(sets.NewString(step.wantCerts...)) {
+			t.Errorf("%s: certs = %v, want %v", step.desc, allCerts, step.wantCerts)
+		}
+
+		lbAnnotations, err := GetLBAnnotations(l7, nil, statusSyncer{})
+		if err != nil {
+			t.Fatalf("%s: GetLBAnnotations() = _, %v", step.desc, err)
+		}
+		if got := GCEResourceName(lbAnnotations, "managed-ssl-cert"); got != step.wantManaged {
+			t.Errorf("%s: managed-ssl-cert annotation = %q, want %q", step.desc, got, step.wantManaged)
+		}
+		if got := GCEResourceName(lbAnnotations, "managed-ssl-cert-status"); got != step.wantStatus {
+			t.Errorf("%s: managed-ssl-cert-status annotation = %q, want %q", step.desc, got, step.wantStatus)
+		}
+	}
+
+	// Managed certs are deleted with the loadbalancer.
+	lbInfo.ManagedCertDomains = []string{"a.example.com"}
+	if _, err := pool.Ensure(lbInfo); err != nil {
+		t.Fatalf("pool.Ensure() = %v", err)
+	}
+	if err := pool.Delete(lbInfo.Name); err != nil {
+		t.Fatalf("pool.Delete(%q) = %v", lbInfo.Name, err)
+	}
+	if len(f.Certs) != 0 || len(f.BetaCerts) != 0 {
+		t.Errorf("certs = %v, managed certs = %v; want none", f.Certs, f.BetaCerts)
+	}
+}
+
 func verifyHTTPSForwardingRuleAndProxyLinks(t *testing.T, f *FakeLoadBalancers) {
 	t.Helper()
 
@@ -740,6 +1046,439 @@ func TestCreateBothLoadBalancers(t *testing.T) {
 	}
 }
 
+func TestIPv6LoadBalancer(t *testing.T) {
+	gceUrlMap := utils.NewGCEURLMap()
+	gceUrlMap.DefaultBackend = &utils.ServicePort{NodePort: 31234}
+	gceUrlMap.PutPathRulesForHost("bar.example.com", []utils.PathRule{utils.PathRule{Path: "/bar", Backend: utils.ServicePort{NodePort: 30000}}})
+	namer := utils.NewNamer("uid1", "fw1")
+	lbInfo := &L7RuntimeInfo{
+		Name:      namer.LoadBalancer("test"),
+		AllowHTTP: true,
+		TLS:       []*TLSCerts{{Key: "key", Cert: "cert"}},
+		UrlMap:    gceUrlMap,
+		Ingress:   newIngress(),
+		IPv6:      true,
+	}
+	f := NewFakeLoadBalancers(lbInfo.Name, namer)
+	pool := newFakeLoadBalancerPool(f, t, namer)
+
+	if _, err := pool.Ensure(lbInfo); err != nil {
+		t.Fatalf("pool.Ensure() = err %v", err)
+	}
+	l7, err := pool.Ensure(lbInfo)
+	if err != nil {
+		t.Fatalf("pool.Ensure() = err %v", err)
+	}
+
+	// The IPv4 frontend is unchanged.
+	verifyHTTPSForwardingRuleAndProxyLinks(t, f)
+	verifyHTTPForwardingRuleAndProxyLinks(t, f)
+
+	ipv6Name := namer.IPv6ForwardingRule(l7.Name, utils.HTTPProtocol)
+	ipv6, err := f.GetGlobalAddress(ipv6Name)
+	if err != nil {
+		t.Fatalf("f.GetGlobalAddress(%q) = _, %v", ipv6Name, err)
+	}
+	if ipv6.IpVersion != "IPV6" {
+		t.Errorf("IPv6 address IpVersion = %q, want IPV6", ipv6.IpVersion)
+	}
+	if got := l7.GetIPv6(); got != ipv6.Address {
+		t.Errorf("l7.GetIPv6() = %q, want %q", got, ipv6.Address)
+	}
+	if l7.GetIP() == ipv6.Address {
+		t.Errorf("l7.GetIP() = %q, want the IPv4 address", l7.GetIP())
+	}
+	for _, tc := range []struct {
+		protocol  utils.NamerProtocol
+		proxyName string
+		portRange string
+	}{
+		{utils.HTTPProtocol, f.TPName(false), httpDefaultPortRange},
+		{utils.HTTPSProtocol, f.TPName(true), httpsDefaultPortRange},
+	} {
+		name := namer.IPv6ForwardingRule(l7.Name, tc.protocol)
+		fw, err := f.GetGlobalForwardingRule(name)
+		if err != nil {
+			t.Fatalf("f.GetGlobalForwardingRule(%q) = _, %v", name, err)
+		}
+		if fw.IpVersion != "IPV6" || fw.IPAddress != ipv6.Address || fw.PortRange != tc.portRange {
+			t.Errorf("Forwarding rule %q = %+v, want an IPv6 rule on %v:%v", name, fw, ipv6.Address, tc.portRange)
+		}
+		if !strings.HasSuffix(fw.Target, tc.proxyName) {
+			t.Errorf("Forwarding rule %q target = %q, want proxy %q", name, fw.Target, tc.proxyName)
+		}
+	}
+
+	annotations, err := GetLBAnnotations(l7, nil, statusSyncer{})
+	if err != nil {
+		t.Fatalf("GetLBAnnotations() = _, %v", err)
+	}
+	for _, resource := range []string{"ipv6-forwarding-rule", "ipv6-https-forwarding-rule", "ipv6-static-ip"} {
+		if GCEResourceName(annotations, resource) == "" {
+			t.Errorf("GetLBAnnotations() has no %v annotation", resource)
+		}
+	}
+
+	// Opting out deletes the IPv6 resources.
+	lbInfo.IPv6 = false
+	l7, err = pool.Ensure(lbInfo)
+	if err != nil {
+		t.Fatalf("pool.Ensure() = err %v", err)
+	}
+	if len(f.Fw) != 2 || len(f.IP) != 1 {
+		t.Errorf("IPv6 resources remain after opting out: %v, %v", f.Fw, f.IP)
+	}
+	if got := l7.GetIPv6(); got != "" {
+		t.Errorf("l7.GetIPv6() = %q, want empty", got)
+	}
+	annotations, err = GetLBAnnotations(l7, annotations, statusSyncer{})
+	if err != nil {
+		t.Fatalf("GetLBAnnotations() = _, %v", err)
+	}
+	if name := GCEResourceName(annotations, "ipv6-static-ip"); name != "" {
+		t.Errorf("GetLBAnnotations() has ipv6-static-ip annotation %q after opting out", name)
+	}
+
+	// Deleting the loadbalancer deletes the IPv6 resources.
+	lbInfo.IPv6 = true
+	if _, err := pool.Ensure(lbInfo); err != nil {
+		t.Fatalf("pool.Ensure() = err %v", err)
+	}
+	if err := pool.Delete(lbInfo.Name); err != nil {
+		t.Fatalf("pool.Delete(%q) = %v", lbInfo.Name, err)
+	}
+	if len(f.Fw) != 0 || len(f.IP) != 0 {
+		t.Errorf("Resources remain after pool.Delete(): %v, %v", f.Fw, f.IP)
+	}
+}
+
+func TestRouteRulesLoadBalancer(t *testing.T) {
+	gceUrlMap := utils.NewGCEURLMap()
+	gceUrlMap.DefaultBackend = &utils.ServicePort{NodePort: 31234}
+	gceUrlMap.PutPathRulesForHost("bar.example.com", []utils.PathRule{utils.PathRule{Path: "/bar", Backend: utils.ServicePort{NodePort: 30000}}})
+	gceUrlMap.PutRouteRulesForHost("bar.example.com", []utils.RouteRule{
+		{
+			Match: utils.RouteMatch{PathPrefix: "/bar"},
+			Backends: []utils.WeightedBackend{
+				{Backend: utils.ServicePort{NodePort: 30000}, Weight: 95},
+				{Backend: utils.ServicePort{NodePort: 30001}, Weight: 5},
+			},
+		},
+	})
+	namer := utils.NewNamer("uid1", "fw1")
+	lbInfo := &L7RuntimeInfo{
+		Name:      namer.LoadBalancer("test"),
+		AllowHTTP: true,
+		UrlMap:    gceUrlMap,
+		Ingress:   newIngress(),
+	}
+	f := NewFakeLoadBalancers(lbInfo.Name, namer)
+	pool := newFakeLoadBalancerPool(f, t, namer)
+
+	l7, err := pool.Ensure(lbInfo)
+	if err != nil {
+		t.Fatalf("pool.Ensure() = err %v", err)
+	}
+	verifyHTTPForwardingRuleAndProxyLinks(t, f)
+	umName := namer.UrlMap(lbInfo.Name)
+	um, err := f.GetAlphaURLMap(umName)
+	if err != nil {
+		t.Fatalf("f.GetAlphaURLMap(%q) = _, %v", umName, err)
+	}
+	if want := toAlphaURLMap(lbInfo.Name, gceUrlMap, namer); !alphaMapsEqual(um, want) {
+		t.Errorf("f.GetAlphaURLMap(%q) = %s, want %s", umName, pretty.Sprint(um), pretty.Sprint(want))
+	}
+	lbAnnotations, err := GetLBAnnotations(l7, nil, statusSyncer{})
+	if err != nil {
+		t.Fatalf("GetLBAnnotations() = _, %v", err)
+	}
+	canary := utils.ServicePort{NodePort: 30001}
+	if backends := lbAnnotations[fmt.Sprintf("%v/backends", annotations.StatusPrefix)]; !strings.Contains(backends, canary.BackendName(namer)) {
+		t.Errorf("GetLBAnnotations() backends = %v, want the backend of the route rule %v", backends, canary.BackendName(namer))
+	}
+
+	// An unchanged sync does not update the url map.
+	f.calls = nil
+	if _, err := pool.Ensure(lbInfo); err != nil {
+		t.Fatalf("pool.Ensure() = err %v", err)
+	}
+	for _, call := range f.calls {
+		if call == "UpdateAlphaURLMap" || call == "UpdateURLMap" {
+			t.Errorf("pool.Ensure() called %v for an unchanged url map", call)
+		}
+	}
+
+	// Removing the route rules reverts to a GA url map with path rules.
+	gceUrlMap.PutRouteRulesForHost("bar.example.com", nil)
+	if _, err := pool.Ensure(lbInfo); err != nil {
+		t.Fatalf("pool.Ensure() = err %v", err)
+	}
+	if len(f.AlphaUm) != 0 {
+		t.Errorf("f.AlphaUm = %v, want no url maps with route rules", f.AlphaUm)
+	}
+	verifyURLMap(t, f, umName, gceUrlMap)
+}
+
+func TestPathActionsLoadBalancer(t *testing.T) {
+	gceUrlMap := utils.NewGCEURLMap()
+	gceUrlMap.DefaultBackend = &utils.ServicePort{NodePort: 31234}
+	gceUrlMap.PutPathRulesForHost("bar.example.com", []utils.PathRule{
+		{Path: "/api/bar/*", Backend: utils.ServicePort{NodePort: 30000}},
+		{Path: "/old/*", Backend: utils.ServicePort{NodePort: 30000}},
+	})
+	gceUrlMap.SetPathAction("bar.example.com", "/api/bar/*", &utils.PathRewrite{PathPrefix: "/"}, nil)
+	namer := utils.NewNamer("uid1", "fw1")
+	lbInfo := &L7RuntimeInfo{
+		Name:      namer.LoadBalancer("test"),
+		AllowHTTP: true,
+		UrlMap:    gceUrlMap,
+		Ingress:   newIngress(),
+	}
+	f := NewFakeLoadBalancers(lbInfo.Name, namer)
+	pool := newFakeLoadBalancerPool(f, t, namer)
+
+	if _, err := pool.Ensure(lbInfo); err != nil {
+		t.Fatalf("pool.Ensure() = err %v", err)
+	}
+	umName := namer.UrlMap(lbInfo.Name)
+	um, err := f.GetAlphaURLMap(umName)
+	if err != nil {
+		t.Fatalf("f.GetAlphaURLMap(%q) = _, %v", umName, err)
+	}
+	if want := toAlphaURLMap(lbInfo.Name, gceUrlMap, namer); !alphaMapsEqual(um, want) {
+		t.Errorf("f.GetAlphaURLMap(%q) = %s, want %s", umName, pretty.Sprint(um), pretty.Sprint(want))
+	}
+
+	// Changing only an action updates the url map.
+	gceUrlMap.SetPathAction("bar.example.com", "/old/*", nil, &utils.PathRedirect{PathPrefix: "/api/bar/", ResponseCode: "FOUND"})
+	if _, err := pool.Ensure(lbInfo); err != nil {
+		t.Fatalf("pool.Ensure() = err %v", err)
+	}
+	um, err = f.GetAlphaURLMap(umName)
+	if err != nil {
+		t.Fatalf("f.GetAlphaURLMap(%q) = _, %v", umName, err)
+	}
+	if want := toAlphaURLMap(lbInfo.Name, gceUrlMap, namer); !alphaMapsEqual(um, want) {
+		t.Errorf("f.GetAlphaURLMap(%q) = %s, want %s", umName, pretty.Sprint(um), pretty.Sprint(want))
+	}
+	if redirect := um.PathMatchers[0].PathRules[1].UrlRedirect; redirect == nil || redirect.RedirectResponseCode != "FOUND" {
+		t.Errorf("f.GetAlphaURLMap(%q) redirect = %+v, want a FOUND redirect", umName, redirect)
+	}
+
+	// Removing the actions reverts to a GA url map.
+	gceUrlMap.SetPathAction("bar.example.com", "/api/bar/*", nil, nil)
+	gceUrlMap.SetPathAction("bar.example.com", "/old/*", nil, nil)
+	if _, err := pool.Ensure(lbInfo); err != nil {
+		t.Fatalf("pool.Ensure() = err %v", err)
+	}
+	if len(f.AlphaUm) != 0 {
+		t.Errorf("f.AlphaUm = %v, want no alpha url maps", f.AlphaUm)
+	}
+	verifyURLMap(t, f, umName, gceUrlMap)
+}
+
+// TestRemovePathRewrite asserts that removing the only path action of a url
+// map, a rewrite which the GA API does not see, removes it from the url map.
+func TestRemovePathRewrite(t *testing.T) {
+	gceUrlMap := utils.NewGCEURLMap()
+	gceUrlMap.DefaultBackend = &utils.ServicePort{NodePort: 31234}
+	gceUrlMap.PutPathRulesForHost("bar.example.com", []utils.PathRule{
+		{Path: "/api/bar/*", Backend: utils.ServicePort{NodePort: 30000}},
+	})
+	gceUrlMap.SetPathAction("bar.example.com", "/api/bar/*", &utils.PathRewrite{PathPrefix: "/"}, nil)
+	namer := utils.NewNamer("uid1", "fw1")
+	lbInfo := &L7RuntimeInfo{
+		Name:      namer.LoadBalancer("test"),
+		AllowHTTP: true,
+		UrlMap:    gceUrlMap,
+		Ingress:   newIngress(),
+	}
+	f := NewFakeLoadBalancers(lbInfo.Name, namer)
+	pool := newFakeLoadBalancerPool(f, t, namer)
+
+	if _, err := pool.Ensure(lbInfo); err != nil {
+		t.Fatalf("pool.Ensure() = err %v", err)
+	}
+	gceUrlMap.SetPathAction("bar.example.com", "/api/bar/*", nil, nil)
+	if _, err := pool.Ensure(lbInfo); err != nil {
+		t.Fatalf("pool.Ensure() = err %v", err)
+	}
+
+	umName := namer.UrlMap(lbInfo.Name)
+	um, err := f.GetAlphaURLMap(umName)
+	if err != nil {
+		t.Fatalf("f.GetAlphaURLMap(%q) = _, %v", umName, err)
+	}
+	if hasAlphaFields(um) {
+		t.Errorf("f.GetAlphaURLMap(%q) = %s, want no rewrite", umName, pretty.Sprint(um))
+	}
+	verifyURLMap(t, f, umName, gceUrlMap)
+
+	// Once removed, the url map is not updated again.
+	f.calls = nil
+	if _, err := pool.Ensure(lbInfo); err != nil {
+		t.Fatalf("pool.Ensure() = err %v", err)
+	}
+	for _, call := range f.calls {
+		if call == "UpdateAlphaURLMap" || call == "UpdateURLMap" {
+			t.Errorf("pool.Ensure() called %v for an unchanged url map", call)
+		}
+	}
+}
+
+func TestCreateInternalLoadBalancer(t *testing.T) {
+	flags.F.EnableL7Ilb = true
+	defer func() { flags.F.EnableL7Ilb = false }()
+
+	gceUrlMap := utils.NewGCEURLMap()
+	gceUrlMap.DefaultBackend = &utils.ServicePort{NodePort: 31234}
+	gceUrlMap.PutPathRulesForHost("bar.example.com", []utils.PathRule{utils.PathRule{Path: "/bar", Backend: utils.ServicePort{NodePort: 30000}}})
+	namer := utils.NewNamer("uid1", "fw1")
+	subnetwork := "regions/us-central1/subnetworks/ilb-subnet"
+	lbInfo := &L7RuntimeInfo{
+		Name:       namer.LoadBalancer("test"),
+		AllowHTTP:  true,
+		TLS:        []*TLSCerts{{Key: "key", Cert: "cert"}},
+		UrlMap:     gceUrlMap,
+		Ingress:    newIngress(),
+		Internal:   true,
+		Network:    "global/networks/default",
+		Subnetwork: subnetwork,
+	}
+	f := NewFakeLoadBalancers(lbInfo.Name, namer)
+	pool := newFakeLoadBalancerPool(f, t, namer)
+
+	if _, err := pool.Ensure(lbInfo); err != nil {
+		t.Fatalf("pool.Ensure() = err %v", err)
+	}
+	if _, err := pool.Ensure(lbInfo); err != nil {
+		t.Fatalf("pool.Ensure() = err %v", err)
+	}
+
+	if len(f.Fw) != 0 || len(f.Um) != 0 || len(f.Tp) != 0 || len(f.Tps) != 0 || len(f.IP) != 0 || len(f.Certs) != 0 {
+		t.Fatalf("Internal loadbalancer created global resources:\n%v", f)
+	}
+
+	um, err := f.GetRegionalURLMap(f.UMName())
+	if err != nil {
+		t.Fatalf("f.GetRegionalURLMap(%q) = _, %v", f.UMName(), err)
+	}
+	for _, link := range []string{um.DefaultService, um.PathMatchers[0].PathRules[0].Service} {
+		if !strings.HasPrefix(link, "regions/us-central1/backendServices/") {
+			t.Errorf("Url map service = %q, want a regional backend service", link)
+		}
+	}
+
+	ip, err := f.GetRegionalAddress(f.FWName(false))
+	if err != nil {
+		t.Fatalf("f.GetRegionalAddress(%q) = _, %v", f.FWName(false), err)
+	}
+	if ip.AddressType != "INTERNAL" || ip.Subnetwork != subnetwork {
+		t.Errorf("Internal IP = %+v, want an internal address on %q", ip, subnetwork)
+	}
+
+	for _, https := range []bool{false, true} {
+		fw, err := f.GetRegionalForwardingRule(f.FWName(https))
+		if err != nil {
+			t.Fatalf("f.GetRegionalForwardingRule(%q) = _, %v", f.FWName(https), err)
+		}
+		if fw.LoadBalancingScheme != "INTERNAL_MANAGED" || fw.Subnetwork != subnetwork || fw.IPAddress != ip.Address {
+			t.Errorf("Forwarding rule %q = %+v, want an internal managed rule on %q with IP %v", fw.Name, fw, subnetwork, ip.Address)
+		}
+	}
+	tp, err := f.GetRegionalTargetHTTPProxy(f.TPName(false))
+	if err != nil {
+		t.Fatalf("f.GetRegionalTargetHTTPProxy(%q) = _, %v", f.TPName(false), err)
+	}
+	if !utils.EqualResourcePaths(tp.UrlMap, um.SelfLink) {
+		t.Errorf("Target http proxy url map = %q, want %q", tp.UrlMap, um.SelfLink)
+	}
+	tps, err := f.GetRegionalTargetHTTPSProxy(f.TPName(true))
+	if err != nil {
+		t.Fatalf("f.GetRegionalTargetHTTPSProxy(%q) = _, %v", f.TPName(true), err)
+	}
+	if len(tps.SslCertificates) != 1 || len(f.RegionalCerts) != 1 || tps.SslCertificates[0] != f.RegionalCerts[0].SelfLink {
+		t.Errorf("Target https proxy certs = %v, want the regional certs %v", tps.SslCertificates, f.RegionalCerts)
+	}
+
+	lbNames, err := pool.List()
+	if err != nil {
+		t.Fatalf("pool.List() = err %v", err)
+	}
+	if want := []string{"test--uid1"}; !reflect.DeepEqual(lbNames, want) {
+		t.Errorf("pool.List() = %v, want %v", lbNames, want)
+	}
+
+	if err := pool.Delete(lbInfo.Name); err != nil {
+		t.Fatalf("pool.Delete(%q) = %v", lbInfo.Name, err)
+	}
+	if len(f.RegionalFw) != 0 || len(f.RegionalUm) != 0 || len(f.RegionalTp) != 0 || len(f.RegionalTps) != 0 || len(f.RegionalIP) != 0 || len(f.RegionalCerts) != 0 {
+		t.Errorf("Internal loadbalancer resources remain after pool.Delete(): %v %v %v %v %v %v",
+			f.RegionalFw, f.RegionalUm, f.RegionalTp, f.RegionalTps, f.RegionalIP, f.RegionalCerts)
+	}
+}
+
+func TestInternalLoadBalancerUnsupportedFeatures(t *testing.T) {
+	redirect := &frontendconfigv1beta1.FrontendConfig{
+		Spec: frontendconfigv1beta1.FrontendConfigSpec{
+			RedirectToHttps: &frontendconfigv1beta1.HttpsRedirectConfig{Enabled: true},
+		},
+	}
+	for _, tc := range []struct {
+		desc   string
+		modify func(*L7RuntimeInfo)
+	}{
+		{
+			desc:   "managed certificate",
+			modify: func(ri *L7RuntimeInfo) { ri.ManagedCertDomains = []string{"foo.example.com"} },
+		},
+		{
+			desc:   "redirect to https",
+			modify: func(ri *L7RuntimeInfo) { ri.FrontendConfig = redirect },
+		},
+		{
+			desc: "route rules",
+			modify: func(ri *L7RuntimeInfo) {
+				ri.UrlMap.PutRouteRulesForHost("foo.example.com", []utils.RouteRule{
+					{Backends: []utils.WeightedBackend{{Backend: utils.ServicePort{NodePort: 30000}}}},
+				})
+			},
+		},
+		{
+			desc: "path rewrite",
+			modify: func(ri *L7RuntimeInfo) {
+				ri.UrlMap.PutPathRulesForHost("foo.example.com", []utils.PathRule{
+					{Path: "/api/*", Backend: utils.ServicePort{NodePort: 30000}, Rewrite: &utils.PathRewrite{PathPrefix: "/"}},
+				})
+			},
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			gceUrlMap := utils.NewGCEURLMap()
+			gceUrlMap.DefaultBackend = &utils.ServicePort{NodePort: 31234}
+			namer := utils.NewNamer("uid1", "fw1")
+			lbInfo := &L7RuntimeInfo{
+				Name:      namer.LoadBalancer("test"),
+				AllowHTTP: true,
+				TLS:       []*TLSCerts{{Key: "key", Cert: "cert"}},
+				UrlMap:    gceUrlMap,
+				Ingress:   newIngress(),
+				Internal:  true,
+			}
+			tc.modify(lbInfo)
+			f := NewFakeLoadBalancers(lbInfo.Name, namer)
+			pool := newFakeLoadBalancerPool(f, t, namer)
+
+			if _, err := pool.Ensure(lbInfo); err == nil {
+				t.Errorf("pool.Ensure() = nil, want error")
+			}
+			if len(f.RegionalUm) != 0 || len(f.RegionalFw) != 0 {
+				t.Errorf("pool.Ensure() created resources for an invalid internal loadbalancer")
+			}
+		})
+	}
+}
+
 // verifyURLMap gets the created URLMap and compares it against an expected one.
 func verifyURLMap(t *testing.T, f *FakeLoadBalancers, name string, wantGCEURLMap *utils.GCEURLMap) {
 	t.Helper()
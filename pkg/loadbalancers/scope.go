@@ -0,0 +1,268 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package loadbalancers
+
+import (
+	"fmt"
+
+	compute "google.golang.org/api/compute/v1"
+	"k8s.io/kubernetes/pkg/cloudprovider/providers/gce/cloud"
+	"k8s.io/kubernetes/pkg/cloudprovider/providers/gce/cloud/meta"
+
+	"k8s.io/ingress-gce/pkg/utils"
+)
+
+// The methods below manage the resources of an L7 in its scope: internal
+// loadbalancers are made of regional resources, others of global ones.
+
+func (l *L7) getForwardingRule(name string) (*compute.ForwardingRule, error) {
+	if l.runtimeInfo.Internal {
+		return l.cloud.GetRegionalForwardingRule(name)
+	}
+	return l.cloud.GetGlobalForwardingRule(name)
+}
+
+func (l *L7) createForwardingRule(rule *compute.ForwardingRule) error {
+	if l.runtimeInfo.Internal {
+		rule.LoadBalancingScheme = "INTERNAL_MANAGED"
+		rule.Network = l.runtimeInfo.Network
+		rule.Subnetwork = l.runtimeInfo.Subnetwork
+		return l.cloud.CreateRegionalForwardingRule(rule)
+	}
+	return l.cloud.CreateGlobalForwardingRule(rule)
+}
+
+func (l *L7) deleteForwardingRule(name string) error {
+	if l.runtimeInfo.Internal {
+		return l.cloud.DeleteRegionalForwardingRule(name)
+	}
+	return l.cloud.DeleteGlobalForwardingRule(name)
+}
+
+func (l *L7) setProxyForForwardingRule(name, proxyLink string) error {
+	if l.runtimeInfo.Internal {
+		return l.cloud.SetProxyForRegionalForwardingRule(name, proxyLink)
+	}
+	return l.cloud.SetProxyForGlobalForwardingRule(name, proxyLink)
+}
+
+func (l *L7) getURLMap(name string) (*compute.UrlMap, error) {
+	if l.runtimeInfo.Internal {
+		return l.cloud.GetRegionalURLMap(name)
+	}
+	return l.cloud.GetURLMap(name)
+}
+
+func (l *L7) createURLMap(urlMap *compute.UrlMap) error {
+	if l.runtimeInfo.Internal {
+		return l.cloud.CreateRegionalURLMap(urlMap)
+	}
+	return l.cloud.CreateURLMap(urlMap)
+}
+
+func (l *L7) updateURLMap(urlMap *compute.UrlMap) error {
+	if l.runtimeInfo.Internal {
+		return l.cloud.UpdateRegionalURLMap(urlMap)
+	}
+	return l.cloud.UpdateURLMap(urlMap)
+}
+
+func (l *L7) deleteURLMap(name string) error {
+	if l.runtimeInfo.Internal {
+		return l.cloud.DeleteRegionalURLMap(name)
+	}
+	return l.cloud.DeleteURLMap(name)
+}
+
+// urlMapLink returns the resource path of the url map named name.
+func (l *L7) urlMapLink(name string) string {
+	if l.runtimeInfo.Internal {
+		resourceID := cloud.ResourceID{Resource: "urlMaps", Key: meta.RegionalKey(name, l.cloud.Region())}
+		return resourceID.ResourcePath()
+	}
+	return cloud.NewUrlMapsResourceID("", name).ResourcePath()
+}
+
+func (l *L7) getTargetHTTPProxy(name string) (*compute.TargetHttpProxy, error) {
+	if l.runtimeInfo.Internal {
+		return l.cloud.GetRegionalTargetHTTPProxy(name)
+	}
+	return l.cloud.GetTargetHTTPProxy(name)
+}
+
+func (l *L7) createTargetHTTPProxy(proxy *compute.TargetHttpProxy) error {
+	if l.runtimeInfo.Internal {
+		return l.cloud.CreateRegionalTargetHTTPProxy(proxy)
+	}
+	return l.cloud.CreateTargetHTTPProxy(proxy)
+}
+
+func (l *L7) deleteTargetHTTPProxy(name string) error {
+	if l.runtimeInfo.Internal {
+		return l.cloud.DeleteRegionalTargetHTTPProxy(name)
+	}
+	return l.cloud.DeleteTargetHTTPProxy(name)
+}
+
+func (l *L7) setURLMapForTargetHTTPProxy(proxy *compute.TargetHttpProxy, urlMapLink string) error {
+	if l.runtimeInfo.Internal {
+		return l.cloud.SetURLMapForRegionalTargetHTTPProxy(proxy, urlMapLink)
+	}
+	return l.cloud.SetURLMapForTargetHTTPProxy(proxy, urlMapLink)
+}
+
+func (l *L7) getTargetHTTPSProxy(name string) (*compute.TargetHttpsProxy, error) {
+	if l.runtimeInfo.Internal {
+		return l.cloud.GetRegionalTargetHTTPSProxy(name)
+	}
+	return l.cloud.GetTargetHTTPSProxy(name)
+}
+
+func (l *L7) createTargetHTTPSProxy(proxy *compute.TargetHttpsProxy) error {
+	if l.runtimeInfo.Internal {
+		return l.cloud.CreateRegionalTargetHTTPSProxy(proxy)
+	}
+	return l.cloud.CreateTargetHTTPSProxy(proxy)
+}
+
+func (l *L7) deleteTargetHTTPSProxy(name string) error {
+	if l.runtimeInfo.Internal {
+		return l.cloud.DeleteRegionalTargetHTTPSProxy(name)
+	}
+	return l.cloud.DeleteTargetHTTPSProxy(name)
+}
+
+func (l *L7) setURLMapForTargetHTTPSProxy(proxy *compute.TargetHttpsProxy, urlMapLink string) error {
+	if l.runtimeInfo.Internal {
+		return l.cloud.SetURLMapForRegionalTargetHTTPSProxy(proxy, urlMapLink)
+	}
+	return l.cloud.SetURLMapForTargetHTTPSProxy(proxy, urlMapLink)
+}
+
+func (l *L7) setSslCertificateForTargetHTTPSProxy(proxy *compute.TargetHttpsProxy, sslCertURLs []string) error {
+	if l.runtimeInfo.Internal {
+		return l.cloud.SetSslCertificateForRegionalTargetHTTPSProxy(proxy, sslCertURLs)
+	}
+	return l.cloud.SetSslCertificateForTargetHTTPSProxy(proxy, sslCertURLs)
+}
+
+func (l *L7) getSslCertificate(name string) (*compute.SslCertificate, error) {
+	if l.runtimeInfo.Internal {
+		return l.cloud.GetRegionalSslCertificate(name)
+	}
+	return l.cloud.GetSslCertificate(name)
+}
+
+func (l *L7) listSslCertificates() ([]*compute.SslCertificate, error) {
+	if l.runtimeInfo.Internal {
+		return l.cloud.ListRegionalSslCertificates()
+	}
+	return l.cloud.ListSslCertificates()
+}
+
+func (l *L7) createSslCertificate(cert *compute.SslCertificate) (*compute.SslCertificate, error) {
+	if l.runtimeInfo.Internal {
+		return l.cloud.CreateRegionalSslCertificate(cert)
+	}
+	return l.cloud.CreateSslCertificate(cert)
+}
+
+func (l *L7) deleteSslCertificate(name string) error {
+	if l.runtimeInfo.Internal {
+		return l.cloud.DeleteRegionalSslCertificate(name)
+	}
+	return l.cloud.DeleteSslCertificate(name)
+}
+
+func (l *L7) getAddress(name string) (*compute.Address, error) {
+	if l.runtimeInfo.Internal {
+		return l.cloud.GetRegionalAddress(name)
+	}
+	return l.cloud.GetGlobalAddress(name)
+}
+
+func (l *L7) deleteAddress(name string) error {
+	if l.runtimeInfo.Internal {
+		return l.cloud.DeleteRegionalAddress(name)
+	}
+	return l.cloud.DeleteGlobalAddress(name)
+}
+
+// toRegionalBackendLinks points the given url map at the regional backend
+// services of the same names, as a regional url map may only reference
+// backend services in its region.
+func toRegionalBackendLinks(m *compute.UrlMap, region string) error {
+	toRegional := func(link string) (string, error) {
+		name, err := utils.KeyName(link)
+		if err != nil {
+			return "", err
+		}
+		resourceID := cloud.ResourceID{Resource: "backendServices", Key: meta.RegionalKey(name, region)}
+		return resourceID.ResourcePath(), nil
+	}
+	var err error
+	if m.DefaultService, err = toRegional(m.DefaultService); err != nil {
+		return err
+	}
+	for _, pathMatcher := range m.PathMatchers {
+		if pathMatcher.DefaultService, err = toRegional(pathMatcher.DefaultService); err != nil {
+			return err
+		}
+		for _, pathRule := range pathMatcher.PathRules {
+			if pathRule.Service, err = toRegional(pathRule.Service); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// validateInternal returns an error if the L7 is internal but is configured
+// with features which internal loadbalancers do not support.
+func (l *L7) validateInternal() error {
+	if !l.runtimeInfo.Internal {
+		return nil
+	}
+	var unsupported []string
+	if len(l.runtimeInfo.ManagedCertDomains) > 0 {
+		unsupported = append(unsupported, "Google-managed certificates")
+	}
+	if l.runtimeInfo.IPv6 {
+		unsupported = append(unsupported, "IPv6")
+	}
+	if l.runtimeInfo.UrlMap != nil && l.runtimeInfo.UrlMap.HasRouteRules() {
+		unsupported = append(unsupported, "route rules")
+	}
+	if l.runtimeInfo.UrlMap != nil && l.runtimeInfo.UrlMap.HasPathActions() {
+		unsupported = append(unsupported, "path rewrites and redirects")
+	}
+	if feConfig := l.runtimeInfo.FrontendConfig; feConfig != nil {
+		if feConfig.Spec.RedirectToHttps != nil && feConfig.Spec.RedirectToHttps.Enabled {
+			unsupported = append(unsupported, "redirecting HTTP to HTTPS")
+		}
+		if feConfig.Spec.SslPolicy != nil {
+			unsupported = append(unsupported, "SSL policies")
+		}
+		if feConfig.Spec.QuicOverride != nil {
+			unsupported = append(unsupported, "QUIC overrides")
+		}
+	}
+	if len(unsupported) > 0 {
+		return fmt.Errorf("internal loadbalancer %v does not support %v", l.Name, unsupported)
+	}
+	return nil
+}
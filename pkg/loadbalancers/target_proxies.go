@@ -21,6 +21,7 @@ import (
 	"k8s.io/ingress-gce/pkg/utils"
 	"k8s.io/klog"
 	"k8s.io/kubernetes/pkg/cloudprovider/providers/gce/cloud"
+	"k8s.io/kubernetes/pkg/cloudprovider/providers/gce/cloud/meta"
 )
 
 const (
@@ -29,19 +30,23 @@ const (
 )
 
 func (l *L7) checkProxy() (err error) {
-	urlMapLink := cloud.NewUrlMapsResourceID("", l.um.Name).ResourcePath()
+	urlMapName := l.um.Name
+	if l.redirectUm != nil {
+		urlMapName = l.redirectUm.Name
+	}
+	urlMapLink := l.urlMapLink(urlMapName)
 	proxyName := l.namer.TargetProxy(l.Name, utils.HTTPProtocol)
-	proxy, _ := l.cloud.GetTargetHTTPProxy(proxyName)
+	proxy, _ := l.getTargetHTTPProxy(proxyName)
 	if proxy == nil {
-		klog.V(3).Infof("Creating new http proxy for urlmap %v", l.um.Name)
+		klog.V(3).Infof("Creating new http proxy for urlmap %v", urlMapName)
 		newProxy := &compute.TargetHttpProxy{
 			Name:   proxyName,
 			UrlMap: urlMapLink,
 		}
-		if err = l.cloud.CreateTargetHTTPProxy(newProxy); err != nil {
+		if err = l.createTargetHTTPProxy(newProxy); err != nil {
 			return err
 		}
-		proxy, err = l.cloud.GetTargetHTTPProxy(proxyName)
+		proxy, err = l.getTargetHTTPProxy(proxyName)
 		if err != nil {
 			return err
 		}
@@ -51,7 +56,7 @@ func (l *L7) checkProxy() (err error) {
 	if !utils.EqualResourcePaths(proxy.UrlMap, urlMapLink) {
 		klog.V(3).Infof("Proxy %v has the wrong url map, setting %v overwriting %v",
 			proxy.Name, urlMapLink, proxy.UrlMap)
-		if err := l.cloud.SetURLMapForTargetHTTPProxy(proxy, urlMapLink); err != nil {
+		if err := l.setURLMapForTargetHTTPProxy(proxy, urlMapLink); err != nil {
 			return err
 		}
 	}
@@ -65,9 +70,9 @@ func (l *L7) checkHttpsProxy() (err error) {
 		return nil
 	}
 
-	urlMapLink := cloud.NewUrlMapsResourceID("", l.um.Name).ResourcePath()
+	urlMapLink := l.urlMapLink(l.um.Name)
 	proxyName := l.namer.TargetProxy(l.Name, utils.HTTPSProtocol)
-	proxy, _ := l.cloud.GetTargetHTTPSProxy(proxyName)
+	proxy, _ := l.getTargetHTTPSProxy(proxyName)
 	if proxy == nil {
 		klog.V(3).Infof("Creating new https proxy for urlmap %q", l.um.Name)
 		newProxy := &compute.TargetHttpsProxy{
@@ -78,12 +83,18 @@ func (l *L7) checkHttpsProxy() (err error) {
 		for _, c := range l.sslCerts {
 			newProxy.SslCertificates = append(newProxy.SslCertificates, c.SelfLink)
 		}
+		if sslPolicyLink, ok := l.sslPolicyLink(); ok {
+			newProxy.SslPolicy = sslPolicyLink
+		}
+		if quicOverride, ok := l.quicOverride(); ok {
+			newProxy.QuicOverride = quicOverride
+		}
 
-		if err = l.cloud.CreateTargetHTTPSProxy(newProxy); err != nil {
+		if err = l.createTargetHTTPSProxy(newProxy); err != nil {
 			return err
 		}
 
-		proxy, err = l.cloud.GetTargetHTTPSProxy(proxyName)
+		proxy, err = l.getTargetHTTPSProxy(proxyName)
 		if err != nil {
 			return err
 		}
@@ -94,7 +105,7 @@ func (l *L7) checkHttpsProxy() (err error) {
 	if !utils.EqualResourcePaths(proxy.UrlMap, urlMapLink) {
 		klog.V(3).Infof("Https proxy %v has the wrong url map, setting %v overwriting %v",
 			proxy.Name, urlMapLink, proxy.UrlMap)
-		if err := l.cloud.SetURLMapForTargetHTTPSProxy(proxy, urlMapLink); err != nil {
+		if err := l.setURLMapForTargetHTTPSProxy(proxy, urlMapLink); err != nil {
 			return err
 		}
 	}
@@ -106,18 +117,78 @@ func (l *L7) checkHttpsProxy() (err error) {
 		for _, cert := range l.sslCerts {
 			sslCertURLs = append(sslCertURLs, cert.SelfLink)
 		}
-		if err := l.cloud.SetSslCertificateForTargetHTTPSProxy(proxy, sslCertURLs); err != nil {
+		if err := l.setSslCertificateForTargetHTTPSProxy(proxy, sslCertURLs); err != nil {
 			return err
 		}
 
 	}
+
+	if sslPolicyLink, ok := l.sslPolicyLink(); ok && !equalSslPolicies(proxy.SslPolicy, sslPolicyLink) {
+		klog.V(3).Infof("Https proxy %q has the wrong ssl policy, setting %q overwriting %q",
+			proxy.Name, sslPolicyLink, proxy.SslPolicy)
+		if err := l.cloud.SetSslPolicyForTargetHTTPSProxy(proxy, sslPolicyLink); err != nil {
+			return err
+		}
+	}
+
+	if quicOverride, ok := l.quicOverride(); ok && !equalQuicOverrides(proxy.QuicOverride, quicOverride) {
+		klog.V(3).Infof("Https proxy %q has the wrong quic override, setting %v overwriting %v",
+			proxy.Name, quicOverride, proxy.QuicOverride)
+		if err := l.cloud.SetQuicOverrideForTargetHTTPSProxy(proxy, quicOverride); err != nil {
+			return err
+		}
+	}
 	l.tps = proxy
 	return nil
 }
 
+// sslPolicyLink returns the link of the SSL policy set by the FrontendConfig
+// of the L7, and false if the FrontendConfig does not manage the SSL policy.
+// An empty link means the proxy should not have an SSL policy.
+func (l *L7) sslPolicyLink() (string, bool) {
+	feConfig := l.runtimeInfo.FrontendConfig
+	if feConfig == nil || feConfig.Spec.SslPolicy == nil {
+		return "", false
+	}
+	if *feConfig.Spec.SslPolicy == "" {
+		return "", true
+	}
+	resourceID := cloud.ResourceID{Resource: "sslPolicies", Key: meta.GlobalKey(*feConfig.Spec.SslPolicy)}
+	return resourceID.ResourcePath(), true
+}
+
+// quicOverride returns the QUIC override set by the FrontendConfig of the
+// L7, and false if the FrontendConfig does not manage the QUIC override.
+func (l *L7) quicOverride() (string, bool) {
+	feConfig := l.runtimeInfo.FrontendConfig
+	if feConfig == nil || feConfig.Spec.QuicOverride == nil {
+		return "", false
+	}
+	return *feConfig.Spec.QuicOverride, true
+}
+
+func equalSslPolicies(a, b string) bool {
+	if a == "" || b == "" {
+		return a == b
+	}
+	return utils.EqualResourcePaths(a, b)
+}
+
+// equalQuicOverrides returns true if a and b are the same QUIC override,
+// where unset means NONE.
+func equalQuicOverrides(a, b string) bool {
+	if a == "" {
+		a = "NONE"
+	}
+	if b == "" {
+		b = "NONE"
+	}
+	return a == b
+}
+
 func (l *L7) getSslCertLinkInUse() ([]string, error) {
 	proxyName := l.namer.TargetProxy(l.Name, utils.HTTPSProtocol)
-	proxy, err := l.cloud.GetTargetHTTPSProxy(proxyName)
+	proxy, err := l.getTargetHTTPSProxy(proxyName)
 	if err != nil {
 		return nil, err
 	}
@@ -20,9 +20,11 @@ import (
 	"crypto/md5"
 	"encoding/hex"
 	"fmt"
+	"reflect"
 
 	compute "google.golang.org/api/compute/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/ingress-gce/pkg/composite"
 	"k8s.io/ingress-gce/pkg/utils"
 	"k8s.io/klog"
 	"k8s.io/kubernetes/pkg/cloudprovider/providers/gce/cloud"
@@ -31,6 +33,10 @@ import (
 const (
 	// The gce api uses the name of a path rule to match a host rule.
 	hostRulePrefix = "host"
+
+	// defaultRedirectResponseCode is the response code with which HTTP is
+	// redirected to HTTPS if the FrontendConfig does not set one.
+	defaultRedirectResponseCode = "MOVED_PERMANENTLY_DEFAULT"
 )
 
 // ensureComputeURLMap retrieves the current URLMap and overwrites it if incorrect. If the resource
@@ -42,15 +48,43 @@ func (l *L7) ensureComputeURLMap() error {
 
 	// Every update replaces the entire urlmap.
 	expectedMap := toComputeURLMap(l.Name, l.runtimeInfo.UrlMap, l.namer)
+	if l.runtimeInfo.Internal {
+		if err := toRegionalBackendLinks(expectedMap, l.cloud.Region()); err != nil {
+			return err
+		}
+	}
+
+	if l.runtimeInfo.UrlMap.HasRouteRules() || l.runtimeInfo.UrlMap.HasPathActions() {
+		return l.ensureAlphaURLMap(expectedMap)
+	}
+	l.alphaUm = nil
+	// The GA API does not see the route rules and path actions of a UrlMap
+	// which had them before, so they are removed through the alpha API.
+	if !l.runtimeInfo.Internal {
+		alphaMap, err := l.cloud.GetAlphaURLMap(expectedMap.Name)
+		if utils.IgnoreHTTPNotFound(err) != nil {
+			return err
+		}
+		if alphaMap != nil && hasAlphaFields(alphaMap) {
+			klog.V(3).Infof("Removing the route rules and path actions of URLMap for %q", l.Name)
+			updatedMap := toAlphaURLMap(l.Name, l.runtimeInfo.UrlMap, l.namer)
+			updatedMap.Fingerprint = alphaMap.Fingerprint
+			if err := l.cloud.UpdateAlphaURLMap(updatedMap); err != nil {
+				return fmt.Errorf("UpdateAlphaURLMap: %v", err)
+			}
+			l.um = expectedMap
+			return nil
+		}
+	}
 
-	currentMap, err := l.cloud.GetURLMap(expectedMap.Name)
+	currentMap, err := l.getURLMap(expectedMap.Name)
 	if utils.IgnoreHTTPNotFound(err) != nil {
 		return err
 	}
 
 	if currentMap == nil {
 		klog.V(3).Infof("Creating URLMap %q", expectedMap.Name)
-		if err := l.cloud.CreateURLMap(expectedMap); err != nil {
+		if err := l.createURLMap(expectedMap); err != nil {
 			return fmt.Errorf("CreateUrlMap: %v", err)
 		}
 		l.um = expectedMap
@@ -65,7 +99,7 @@ func (l *L7) ensureComputeURLMap() error {
 
 	klog.V(3).Infof("Updating URLMap for %q", l.Name)
 	expectedMap.Fingerprint = currentMap.Fingerprint
-	if err := l.cloud.UpdateURLMap(expectedMap); err != nil {
+	if err := l.updateURLMap(expectedMap); err != nil {
 		return fmt.Errorf("UpdateURLMap: %v", err)
 	}
 
@@ -73,6 +107,91 @@ func (l *L7) ensureComputeURLMap() error {
 	return nil
 }
 
+// ensureRedirectURLMap creates or updates the UrlMap which redirects HTTP to
+// HTTPS, if the L7 should redirect. The UrlMap is not deleted here, as the
+// http proxy may still point to it.
+func (l *L7) ensureRedirectURLMap() error {
+	responseCode, ok := l.redirectResponseCode()
+	if !ok {
+		l.redirectUm = nil
+		return nil
+	}
+
+	expectedMap := &composite.UrlMap{
+		Name: l.namer.RedirectUrlMap(l.Name),
+		DefaultUrlRedirect: &composite.HttpRedirectAction{
+			HttpsRedirect:        true,
+			RedirectResponseCode: responseCode,
+		},
+	}
+
+	currentMap, err := l.cloud.GetRedirectURLMap(expectedMap.Name)
+	if utils.IgnoreHTTPNotFound(err) != nil {
+		return err
+	}
+
+	if currentMap == nil {
+		klog.V(3).Infof("Creating redirect URLMap %q", expectedMap.Name)
+		if err := l.cloud.CreateRedirectURLMap(expectedMap); err != nil {
+			return fmt.Errorf("CreateRedirectURLMap: %v", err)
+		}
+		l.redirectUm = expectedMap
+		return nil
+	}
+
+	if reflect.DeepEqual(currentMap.DefaultUrlRedirect, expectedMap.DefaultUrlRedirect) {
+		klog.V(4).Infof("Redirect URLMap for %q is unchanged", l.Name)
+		l.redirectUm = currentMap
+		return nil
+	}
+
+	klog.V(3).Infof("Updating redirect URLMap for %q", l.Name)
+	expectedMap.Fingerprint = currentMap.Fingerprint
+	if err := l.cloud.UpdateRedirectURLMap(expectedMap); err != nil {
+		return fmt.Errorf("UpdateRedirectURLMap: %v", err)
+	}
+
+	l.redirectUm = expectedMap
+	return nil
+}
+
+// deleteRedirectURLMap deletes the UrlMap which redirects HTTP to HTTPS, if
+// it exists.
+func (l *L7) deleteRedirectURLMap() error {
+	// Internal loadbalancers never redirect.
+	if l.runtimeInfo.Internal {
+		return nil
+	}
+	name := l.namer.RedirectUrlMap(l.Name)
+	um, err := l.cloud.GetRedirectURLMap(name)
+	if utils.IgnoreHTTPNotFound(err) != nil {
+		return err
+	}
+	if um == nil {
+		return nil
+	}
+	klog.V(2).Infof("Deleting redirect URL Map %v", name)
+	return utils.IgnoreHTTPNotFound(l.cloud.DeleteURLMap(name))
+}
+
+// redirectResponseCode returns the response code with which HTTP is
+// redirected to HTTPS, and false if the L7 should not redirect. Redirecting
+// requires both the http and https frontends.
+func (l *L7) redirectResponseCode() (string, bool) {
+	feConfig := l.runtimeInfo.FrontendConfig
+	if feConfig == nil || feConfig.Spec.RedirectToHttps == nil || !feConfig.Spec.RedirectToHttps.Enabled {
+		return "", false
+	}
+	if !l.runtimeInfo.AllowHTTP || !l.sslConfigured() {
+		klog.V(3).Infof("Not redirecting HTTP to HTTPS for %v, which does not serve both", l.Name)
+		return "", false
+	}
+	if feConfig.Spec.RedirectToHttps.ResponseCodeName == "" {
+		return defaultRedirectResponseCode, true
+	}
+	return feConfig.Spec.RedirectToHttps.ResponseCodeName, true
+}
+
 // getBackendNames returns the names of backends in this L7 urlmap.
 func getBackendNames(computeURLMap *compute.UrlMap) ([]string, error) {
 	beNames := sets.NewString()
@@ -208,6 +327,9 @@ func mapsEqual(a, b *compute.UrlMap) bool {
 // and remove the mapping. When a new path is added to a host (happens
 // more frequently than service deletion) we just need to lookup the 1
 // pathmatcher of the host.
+//
+// The GA API has no route rules, rewrites or redirects, so they are left out
+// here and emitted by toAlphaURLMap instead, which alphaMapsEqual compares.
 func toComputeURLMap(lbName string, g *utils.GCEURLMap, namer *utils.Namer) *compute.UrlMap {
 	defaultBackendName := g.DefaultBackend.BackendName(namer)
 	m := &compute.UrlMap{
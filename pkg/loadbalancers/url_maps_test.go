@@ -17,11 +17,15 @@ limitations under the License.
 package loadbalancers
 
 import (
+	"reflect"
 	"testing"
 
+	"github.com/kr/pretty"
 	compute "google.golang.org/api/compute/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/ingress-gce/pkg/composite"
 	"k8s.io/ingress-gce/pkg/utils"
+	"k8s.io/kubernetes/pkg/cloudprovider/providers/gce/cloud/meta"
 )
 
 func TestComputeURLMapEquals(t *testing.T) {
@@ -132,6 +136,169 @@ func testComputeURLMap() *compute.UrlMap {
 	}
 }
 
+func TestToAlphaURLMap(t *testing.T) {
+	t.Parallel()
+
+	gceURLMap := &utils.GCEURLMap{
+		DefaultBackend: &utils.ServicePort{NodePort: 30000},
+		HostRules: []utils.HostRule{
+			{
+				Hostname: "abc.com",
+				Paths: []utils.PathRule{
+					{Path: "/web", Backend: utils.ServicePort{NodePort: 32000}},
+					{
+						Path:    "/api/web/*",
+						Backend: utils.ServicePort{NodePort: 32000},
+						Rewrite: &utils.PathRewrite{PathPrefix: "/", Host: "web.internal"},
+					},
+					{
+						Path:     "/old/*",
+						Backend:  utils.ServicePort{NodePort: 32000},
+						Redirect: &utils.PathRedirect{PathPrefix: "/web/", HTTPS: true},
+					},
+				},
+			},
+			{
+				Hostname: "foo.bar.com",
+				Paths: []utils.PathRule{
+					{Path: "/*", Backend: utils.ServicePort{NodePort: 33000}},
+					{Path: "/api/*", Backend: utils.ServicePort{NodePort: 33500}},
+					{
+						Path:     "/api/v1",
+						Backend:  utils.ServicePort{NodePort: 34000},
+						Redirect: &utils.PathRedirect{Path: "/api/v2", ResponseCode: "FOUND"},
+					},
+				},
+				RouteRules: []utils.RouteRule{
+					{
+						Match: utils.RouteMatch{
+							Headers:         []utils.ValueMatch{{Name: "x-canary", Value: "true"}},
+							QueryParameters: []utils.ValueMatch{{Name: "debug"}},
+						},
+						Backends: []utils.WeightedBackend{{Backend: utils.ServicePort{NodePort: 35000}}},
+					},
+					{
+						Match: utils.RouteMatch{PathPrefix: "/api/"},
+						Backends: []utils.WeightedBackend{
+							{Backend: utils.ServicePort{NodePort: 33500}, Weight: 90},
+							{Backend: utils.ServicePort{NodePort: 35000}, Weight: 10},
+						},
+					},
+				},
+			},
+		},
+	}
+	wantMap := &composite.UrlMap{
+		Version:        meta.VersionAlpha,
+		Name:           "k8s-um-lb-name",
+		DefaultService: "global/backendServices/k8s-be-30000--uid1",
+		HostRules: []*composite.HostRule{
+			{
+				Hosts:       []string{"abc.com"},
+				PathMatcher: "host929ba26f492f86d4a9d66a080849865a",
+			},
+			{
+				Hosts:       []string{"foo.bar.com"},
+				PathMatcher: "host2d50cf9711f59181be6a5e5658e42c21",
+			},
+		},
+		PathMatchers: []*composite.PathMatcher{
+			{
+				DefaultService: "global/backendServices/k8s-be-30000--uid1",
+				Name:           "host929ba26f492f86d4a9d66a080849865a",
+				PathRules: []*composite.PathRule{
+					{
+						Paths:   []string{"/web"},
+						Service: "global/backendServices/k8s-be-32000--uid1",
+					},
+					{
+						Paths:   []string{"/api/web/*"},
+						Service: "global/backendServices/k8s-be-32000--uid1",
+						RouteAction: &composite.HttpRouteAction{
+							UrlRewrite: &composite.UrlRewrite{PathPrefixRewrite: "/", HostRewrite: "web.internal"},
+						},
+					},
+					{
+						Paths: []string{"/old/*"},
+						UrlRedirect: &composite.HttpRedirectAction{
+							PrefixRedirect:       "/web/",
+							HttpsRedirect:        true,
+							RedirectResponseCode: "MOVED_PERMANENTLY_DEFAULT",
+						},
+					},
+				},
+			},
+			{
+				DefaultService: "global/backendServices/k8s-be-30000--uid1",
+				Name:           "host2d50cf9711f59181be6a5e5658e42c21",
+				RouteRules: []*composite.HttpRouteRule{
+					{
+						Priority: 1,
+						MatchRules: []*composite.HttpRouteRuleMatch{{
+							PrefixMatch:           "/",
+							HeaderMatches:         []*composite.HttpHeaderMatch{{HeaderName: "x-canary", ExactMatch: "true"}},
+							QueryParameterMatches: []*composite.HttpQueryParameterMatch{{Name: "debug", PresentMatch: true}},
+						}},
+						Service: "global/backendServices/k8s-be-35000--uid1",
+					},
+					{
+						Priority:   2,
+						MatchRules: []*composite.HttpRouteRuleMatch{{PrefixMatch: "/api/"}},
+						RouteAction: &composite.HttpRouteAction{
+							WeightedBackendServices: []*composite.WeightedBackendService{
+								{BackendService: "global/backendServices/k8s-be-33500--uid1", Weight: 90},
+								{BackendService: "global/backendServices/k8s-be-35000--uid1", Weight: 10},
+							},
+						},
+					},
+					// The paths of the host follow, longest first.
+					{
+						Priority:   3,
+						MatchRules: []*composite.HttpRouteRuleMatch{{FullPathMatch: "/api/v1"}},
+						UrlRedirect: &composite.HttpRedirectAction{
+							PathRedirect:         "/api/v2",
+							RedirectResponseCode: "FOUND",
+						},
+					},
+					{
+						Priority:   4,
+						MatchRules: []*composite.HttpRouteRuleMatch{{PrefixMatch: "/api/"}},
+						Service:    "global/backendServices/k8s-be-33500--uid1",
+					},
+					{
+						Priority:   5,
+						MatchRules: []*composite.HttpRouteRuleMatch{{PrefixMatch: "/"}},
+						Service:    "global/backendServices/k8s-be-33000--uid1",
+					},
+				},
+			},
+		},
+	}
+
+	namer := utils.NewNamer("uid1", "fw1")
+	gotMap := toAlphaURLMap("lb-name", gceURLMap, namer)
+	if !reflect.DeepEqual(gotMap, wantMap) {
+		t.Errorf("toAlphaURLMap() = \n%s\n   want\n%s", pretty.Sprint(gotMap), pretty.Sprint(wantMap))
+	}
+
+	// Links returned by the API differ only in their endpoint and project.
+	fromAPI := toAlphaURLMap("lb-name", gceURLMap, namer)
+	wbs := fromAPI.PathMatchers[1].RouteRules[1].RouteAction.WeightedBackendServices[0]
+	wbs.BackendService = "https://www.googleapis.com/compute/alpha/projects/p/" + wbs.BackendService
+	if !alphaMapsEqual(fromAPI, wantMap) {
+		t.Errorf("alphaMapsEqual(%s, %s) = false, want true", pretty.Sprint(fromAPI), pretty.Sprint(wantMap))
+	}
+	wbs.Weight = 80
+	if alphaMapsEqual(fromAPI, wantMap) {
+		t.Errorf("alphaMapsEqual(%s, %s) = true, want false", pretty.Sprint(fromAPI), pretty.Sprint(wantMap))
+	}
+	wbs.Weight = 90
+	fromAPI.PathMatchers[0].PathRules[1].RouteAction.UrlRewrite.PathPrefixRewrite = "/web/"
+	if alphaMapsEqual(fromAPI, wantMap) {
+		t.Errorf("alphaMapsEqual(%s, %s) = true, want false", pretty.Sprint(fromAPI), pretty.Sprint(wantMap))
+	}
+}
+
 func TestGetBackendNames(t *testing.T) {
 	t.Parallel()
 
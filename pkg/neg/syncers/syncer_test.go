@@ -64,7 +64,7 @@ func newSyncerTester() *syncerTester {
 		ResyncPeriod:            1 * time.Second,
 		DefaultBackendSvcPortID: defaultBackend,
 	}
-	context := context.NewControllerContext(kubeClient, backendConfigClient, nil, namer, ctxConfig)
+	context := context.NewControllerContext(kubeClient, backendConfigClient, nil, nil, namer, ctxConfig)
 	negSyncerKey := NegSyncerKey{
 		Namespace:  testServiceNamespace,
 		Name:       testServiceName,
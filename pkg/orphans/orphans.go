@@ -0,0 +1,503 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package orphans finds the GCE resources of a cluster which are no longer
+// used by its Ingresses and Services, and deletes them.
+package orphans
+
+import (
+	"fmt"
+	"sort"
+
+	computebeta "google.golang.org/api/compute/v0.beta"
+	compute "google.golang.org/api/compute/v1"
+	"k8s.io/api/core/v1"
+	extensions "k8s.io/api/extensions/v1beta1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog"
+	"k8s.io/kubernetes/pkg/cloudprovider/providers/gce/cloud"
+	"k8s.io/kubernetes/pkg/cloudprovider/providers/gce/cloud/filter"
+	"k8s.io/kubernetes/pkg/cloudprovider/providers/gce/cloud/meta"
+
+	"k8s.io/ingress-gce/pkg/annotations"
+	"k8s.io/ingress-gce/pkg/utils"
+)
+
+// Kinds of the resources, as named by the GCE API.
+const (
+	KindForwardingRule       = "ForwardingRule"
+	KindTargetHTTPProxy      = "TargetHttpProxy"
+	KindTargetHTTPSProxy     = "TargetHttpsProxy"
+	KindURLMap               = "UrlMap"
+	KindSslCertificate       = "SslCertificate"
+	KindBackendService       = "BackendService"
+	KindHealthCheck          = "HealthCheck"
+	KindHTTPHealthCheck      = "HttpHealthCheck"
+	KindNetworkEndpointGroup = "NetworkEndpointGroup"
+	KindInstanceGroup        = "InstanceGroup"
+	KindFirewall             = "Firewall"
+)
+
+// deletionOrder lists the kinds so that a resource comes before the
+// resources it uses, which GCE refuses to delete while they are used.
+var deletionOrder = []string{
+	KindForwardingRule,
+	KindTargetHTTPSProxy,
+	KindTargetHTTPProxy,
+	KindURLMap,
+	KindSslCertificate,
+	KindBackendService,
+	KindHealthCheck,
+	KindHTTPHealthCheck,
+	KindNetworkEndpointGroup,
+	KindInstanceGroup,
+	KindFirewall,
+}
+
+// Resource is a GCE resource of a cluster.
+type Resource struct {
+	Kind string `json:"kind"`
+	// Zone is empty for a global resource.
+	Zone string `json:"zone,omitempty"`
+	Name string `json:"name"`
+}
+
+func (r Resource) String() string {
+	scope := "global"
+	if r.Zone != "" {
+		scope = r.Zone
+	}
+	return fmt.Sprintf("%s %s/%s", r.Kind, scope, r.Name)
+}
+
+// Inventory is the GCE resources of a cluster. The regional resources of
+// internal load balancers are not part of it.
+type Inventory struct {
+	namer *utils.Namer
+
+	forwardingRules      []*compute.ForwardingRule
+	targetHTTPProxies    []*compute.TargetHttpProxy
+	targetHTTPSProxies   []*compute.TargetHttpsProxy
+	urlMaps              []*compute.UrlMap
+	sslCertificates      []*compute.SslCertificate
+	backendServices      []*compute.BackendService
+	healthChecks         []*compute.HealthCheck
+	httpHealthChecks     []*compute.HttpHealthCheck
+	firewalls            []*compute.Firewall
+	negsByZone           map[string][]*computebeta.NetworkEndpointGroup
+	instanceGroupsByZone map[string][]*compute.InstanceGroup
+}
+
+// Scanner lists and deletes the GCE resources of a cluster.
+type Scanner struct {
+	cloud cloud.Cloud
+	namer *utils.Namer
+	zones []string
+}
+
+// NewScanner returns a Scanner of the resources named by namer. Zonal
+// resources are looked up in zones.
+func NewScanner(c cloud.Cloud, namer *utils.Namer, zones []string) *Scanner {
+	return &Scanner{cloud: c, namer: namer, zones: zones}
+}
+
+// List returns the GCE resources of the cluster. It fails for a cluster
+// without a UID, whose resources can not be told apart from those of other
+// clusters.
+func (s *Scanner) List() (*Inventory, error) {
+	if s.namer.UID() == "" {
+		return nil, fmt.Errorf("the resources of a cluster without a UID can not be told apart from those of other clusters")
+	}
+	ctx, cancel := cloud.ContextWithCallTimeout()
+	defer cancel()
+
+	inv := &Inventory{
+		namer:                s.namer,
+		negsByZone:           map[string][]*computebeta.NetworkEndpointGroup{},
+		instanceGroupsByZone: map[string][]*compute.InstanceGroup{},
+	}
+	frs, err := s.cloud.GlobalForwardingRules().List(ctx, filter.None)
+	if err != nil {
+		return nil, err
+	}
+	for _, fr := range frs {
+		if s.namer.NameBelongsToCluster(fr.Name) {
+			inv.forwardingRules = append(inv.forwardingRules, fr)
+		}
+	}
+	tps, err := s.cloud.TargetHttpProxies().List(ctx, filter.None)
+	if err != nil {
+		return nil, err
+	}
+	for _, tp := range tps {
+		if s.namer.NameBelongsToCluster(tp.Name) {
+			inv.targetHTTPProxies = append(inv.targetHTTPProxies, tp)
+		}
+	}
+	tpss, err := s.cloud.TargetHttpsProxies().List(ctx, filter.None)
+	if err != nil {
+		return nil, err
+	}
+	for _, tp := range tpss {
+		if s.namer.NameBelongsToCluster(tp.Name) {
+			inv.targetHTTPSProxies = append(inv.targetHTTPSProxies, tp)
+		}
+	}
+	ums, err := s.cloud.UrlMaps().List(ctx, filter.None)
+	if err != nil {
+		return nil, err
+	}
+	for _, um := range ums {
+		if s.namer.NameBelongsToCluster(um.Name) {
+			inv.urlMaps = append(inv.urlMaps, um)
+		}
+	}
+	certs, err := s.cloud.SslCertificates().List(ctx, filter.None)
+	if err != nil {
+		return nil, err
+	}
+	for _, cert := range certs {
+		if s.namer.NameBelongsToCluster(cert.Name) {
+			inv.sslCertificates = append(inv.sslCertificates, cert)
+		}
+	}
+	bes, err := s.cloud.BackendServices().List(ctx, filter.None)
+	if err != nil {
+		return nil, err
+	}
+	for _, be := range bes {
+		if s.namer.NameBelongsToCluster(be.Name) {
+			inv.backendServices = append(inv.backendServices, be)
+		}
+	}
+	hcs, err := s.cloud.HealthChecks().List(ctx, filter.None)
+	if err != nil {
+		return nil, err
+	}
+	for _, hc := range hcs {
+		if s.namer.NameBelongsToCluster(hc.Name) {
+			inv.healthChecks = append(inv.healthChecks, hc)
+		}
+	}
+	httpHCs, err := s.cloud.HttpHealthChecks().List(ctx, filter.None)
+	if err != nil {
+		return nil, err
+	}
+	for _, hc := range httpHCs {
+		if s.namer.NameBelongsToCluster(hc.Name) {
+			inv.httpHealthChecks = append(inv.httpHealthChecks, hc)
+		}
+	}
+	// The firewall rule is named after the firewall name of the cluster
+	// rather than its UID, so it is looked up by name.
+	fw, err := s.cloud.Firewalls().Get(ctx, meta.GlobalKey(s.namer.FirewallRule()))
+	if err != nil && !utils.IsNotFoundError(err) {
+		return nil, err
+	}
+	if fw != nil {
+		inv.firewalls = append(inv.firewalls, fw)
+	}
+
+	for _, zone := range s.zones {
+		negs, err := s.cloud.BetaNetworkEndpointGroups().List(ctx, zone, filter.None)
+		if err != nil {
+			return nil, err
+		}
+		for _, neg := range negs {
+			if s.namer.IsNEG(neg.Name) {
+				inv.negsByZone[zone] = append(inv.negsByZone[zone], neg)
+			}
+		}
+		igs, err := s.cloud.InstanceGroups().List(ctx, zone, filter.None)
+		if err != nil {
+			return nil, err
+		}
+		for _, ig := range igs {
+			if ig.Name == s.namer.InstanceGroup() {
+				inv.instanceGroupsByZone[zone] = append(inv.instanceGroupsByZone[zone], ig)
+			}
+		}
+	}
+	return inv, nil
+}
+
+// Orphans returns the resources of the inventory which the given Ingresses
+// and Services do not use, in the order in which they can be deleted.
+// A resource is used if it is:
+//
+//   - A forwarding rule, target proxy or URL map of an Ingress, or a cert
+//     named after an Ingress or used by one of its target proxies.
+//   - A BackendService of a Service port referenced by an Ingress, including
+//     by its route rules, or used by a used URL map.
+//   - A health check of a used BackendService.
+//   - A NEG of a Service port, or used by a used BackendService.
+//   - An instance group, if an Ingress references a Service port with a
+//     NodePort or a used BackendService uses it.
+//   - The firewall rule, if there is an Ingress of the "gce" class.
+//
+// The Ingresses and Services must be listed after the inventory, so that
+// the resources of an Ingress being created are not reported. It fails if
+// the route rules of an Ingress cannot be parsed, since the backends they
+// used may still be in use.
+func (inv *Inventory) Orphans(ings []*extensions.Ingress, svcs []*v1.Service) ([]Resource, error) {
+	services := map[string]*v1.Service{}
+	svcNEGs := sets.NewString()
+	for _, svc := range svcs {
+		services[utils.ServiceKeyFunc(svc.Namespace, svc.Name)] = svc
+		for _, port := range svc.Spec.Ports {
+			svcNEGs.Insert(inv.namer.NEG(svc.Namespace, svc.Name, port.Port))
+		}
+	}
+
+	var lbNames []string
+	frontends := sets.NewString()
+	backends := sets.NewString()
+	usesInstanceGroups := false
+	usesFirewall := false
+	for _, ing := range ings {
+		if !utils.IsGLBCIngress(ing) {
+			continue
+		}
+		usesFirewall = usesFirewall || utils.IsGCEIngress(ing)
+		lbName := inv.namer.LoadBalancer(utils.IngressKeyFunc(ing))
+		lbNames = append(lbNames, lbName)
+		for _, protocol := range []utils.NamerProtocol{utils.HTTPProtocol, utils.HTTPSProtocol} {
+			frontends.Insert(
+				inv.namer.ForwardingRule(lbName, protocol),
+				inv.namer.IPv6ForwardingRule(lbName, protocol),
+				inv.namer.TargetProxy(lbName, protocol),
+			)
+		}
+		frontends.Insert(inv.namer.UrlMap(lbName), inv.namer.RedirectUrlMap(lbName))
+
+		markUsed := func(id utils.ServicePortID) bool {
+			svc, ok := services[utils.ServiceKeyFunc(id.Service.Namespace, id.Service.Name)]
+			if !ok {
+				return false
+			}
+			for _, port := range svc.Spec.Ports {
+				if !portMatches(port, id.Port) {
+					continue
+				}
+				// Both backends of the port are used, since whether it
+				// uses NEGs depends on an annotation which may be changing.
+				backends.Insert(inv.namer.NEG(svc.Namespace, svc.Name, port.Port))
+				if port.NodePort != 0 {
+					backends.Insert(inv.namer.IGBackend(int64(port.NodePort)))
+					usesInstanceGroups = true
+				}
+			}
+			return false
+		}
+		utils.TraverseIngressBackends(ing, markUsed)
+		// The GA API does not see the route rules of a URL map, so their
+		// backends are looked up in the annotation.
+		rules, err := annotations.FromIngress(ing).RouteRules()
+		if err != nil {
+			return nil, fmt.Errorf("Ingress %v: %v", utils.IngressKeyFunc(ing), err)
+		}
+		for _, rule := range rules {
+			for _, b := range rule.Backends {
+				markUsed(utils.BackendToServicePortID(b.IngressBackend, ing.Namespace))
+			}
+		}
+	}
+
+	var orphans []Resource
+	check := func(kind, zone, name string, used bool) {
+		if !used {
+			orphans = append(orphans, Resource{Kind: kind, Zone: zone, Name: name})
+		}
+	}
+
+	for _, fr := range inv.forwardingRules {
+		check(KindForwardingRule, "", fr.Name, frontends.Has(fr.Name))
+	}
+	for _, tp := range inv.targetHTTPProxies {
+		check(KindTargetHTTPProxy, "", tp.Name, frontends.Has(tp.Name))
+	}
+	certs := sets.NewString()
+	for _, tp := range inv.targetHTTPSProxies {
+		used := frontends.Has(tp.Name)
+		if used {
+			certs.Insert(linkNames(tp.SslCertificates)...)
+		}
+		check(KindTargetHTTPSProxy, "", tp.Name, used)
+	}
+	for _, um := range inv.urlMaps {
+		used := frontends.Has(um.Name)
+		if used {
+			backends.Insert(linkNames(urlMapServices(um))...)
+		}
+		check(KindURLMap, "", um.Name, used)
+	}
+	for _, cert := range inv.sslCertificates {
+		used := certs.Has(cert.Name)
+		for _, lbName := range lbNames {
+			used = used || inv.namer.IsCertUsedForLB(lbName, cert.Name) ||
+				inv.namer.IsLegacySSLCert(lbName, cert.Name) ||
+				inv.namer.IsManagedCertUsedForLB(lbName, cert.Name)
+		}
+		check(KindSslCertificate, "", cert.Name, used)
+	}
+
+	// Health checks are named after their BackendService.
+	healthChecks := sets.NewString()
+	groups := sets.NewString()
+	for _, be := range inv.backendServices {
+		used := backends.Has(be.Name)
+		if used {
+			healthChecks.Insert(be.Name)
+			healthChecks.Insert(linkNames(be.HealthChecks)...)
+			for _, b := range be.Backends {
+				groups.Insert(linkNames([]string{b.Group})...)
+			}
+		}
+		check(KindBackendService, "", be.Name, used)
+	}
+	for _, hc := range inv.healthChecks {
+		check(KindHealthCheck, "", hc.Name, healthChecks.Has(hc.Name))
+	}
+	for _, hc := range inv.httpHealthChecks {
+		check(KindHTTPHealthCheck, "", hc.Name, healthChecks.Has(hc.Name))
+	}
+	for zone, negs := range inv.negsByZone {
+		for _, neg := range negs {
+			check(KindNetworkEndpointGroup, zone, neg.Name, svcNEGs.Has(neg.Name) || groups.Has(neg.Name))
+		}
+	}
+	for zone, igs := range inv.instanceGroupsByZone {
+		for _, ig := range igs {
+			check(KindInstanceGroup, zone, ig.Name, usesInstanceGroups || groups.Has(ig.Name))
+		}
+	}
+	for _, fw := range inv.firewalls {
+		check(KindFirewall, "", fw.Name, usesFirewall)
+	}
+
+	sortForDeletion(orphans)
+	return orphans, nil
+}
+
+// Delete deletes the given resources in order. It carries on after a
+// failure, and returns the errors of all failed deletions. Resources which
+// do not exist are ignored.
+func (s *Scanner) Delete(resources []Resource) error {
+	var errs []error
+	for _, r := range resources {
+		klog.V(0).Infof("Deleting %v", r)
+		if err := utils.IgnoreHTTPNotFound(s.delete(r)); err != nil {
+			errs = append(errs, fmt.Errorf("error deleting %v: %v", r, err))
+		}
+	}
+	if len(errs) > 0 {
+		return utils.JoinErrs(errs)
+	}
+	return nil
+}
+
+func (s *Scanner) delete(r Resource) error {
+	ctx, cancel := cloud.ContextWithCallTimeout()
+	defer cancel()
+
+	key := meta.GlobalKey(r.Name)
+	if r.Zone != "" {
+		key = meta.ZonalKey(r.Name, r.Zone)
+	}
+	switch r.Kind {
+	case KindForwardingRule:
+		return s.cloud.GlobalForwardingRules().Delete(ctx, key)
+	case KindTargetHTTPProxy:
+		return s.cloud.TargetHttpProxies().Delete(ctx, key)
+	case KindTargetHTTPSProxy:
+		return s.cloud.TargetHttpsProxies().Delete(ctx, key)
+	case KindURLMap:
+		return s.cloud.UrlMaps().Delete(ctx, key)
+	case KindSslCertificate:
+		return s.cloud.SslCertificates().Delete(ctx, key)
+	case KindBackendService:
+		return s.cloud.BackendServices().Delete(ctx, key)
+	case KindHealthCheck:
+		return s.cloud.HealthChecks().Delete(ctx, key)
+	case KindHTTPHealthCheck:
+		return s.cloud.HttpHealthChecks().Delete(ctx, key)
+	case KindNetworkEndpointGroup:
+		return s.cloud.BetaNetworkEndpointGroups().Delete(ctx, key)
+	case KindInstanceGroup:
+		return s.cloud.InstanceGroups().Delete(ctx, key)
+	case KindFirewall:
+		return s.cloud.Firewalls().Delete(ctx, key)
+	}
+	return fmt.Errorf("unknown kind %q", r.Kind)
+}
+
+// sortForDeletion sorts resources by the deletion order of their kind, then
+// by zone and name.
+func sortForDeletion(resources []Resource) {
+	order := map[string]int{}
+	for i, kind := range deletionOrder {
+		order[kind] = i
+	}
+	sort.Slice(resources, func(i, j int) bool {
+		a, b := resources[i], resources[j]
+		if a.Kind != b.Kind {
+			return order[a.Kind] < order[b.Kind]
+		}
+		if a.Zone != b.Zone {
+			return a.Zone < b.Zone
+		}
+		return a.Name < b.Name
+	})
+}
+
+// portMatches returns true if port is the Service port referenced by id.
+func portMatches(port v1.ServicePort, id intstr.IntOrString) bool {
+	if id.Type == intstr.String {
+		return port.Name == id.StrVal
+	}
+	return port.Port == id.IntVal
+}
+
+// urlMapServices returns the links of the BackendServices used by um.
+func urlMapServices(um *compute.UrlMap) []string {
+	links := []string{um.DefaultService}
+	for _, pm := range um.PathMatchers {
+		links = append(links, pm.DefaultService)
+		for _, rule := range pm.PathRules {
+			links = append(links, rule.Service)
+		}
+	}
+	return links
+}
+
+// linkNames returns the names of the resources of links. Empty and invalid
+// links are skipped.
+func linkNames(links []string) []string {
+	var names []string
+	for _, link := range links {
+		if link == "" {
+			continue
+		}
+		name, err := utils.KeyName(link)
+		if err != nil {
+			klog.Warningf("Skipping invalid link %q: %v", link, err)
+			continue
+		}
+		names = append(names, name)
+	}
+	return names
+}
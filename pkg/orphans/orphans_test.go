@@ -0,0 +1,230 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package orphans
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	computebeta "google.golang.org/api/compute/v0.beta"
+	compute "google.golang.org/api/compute/v1"
+	"k8s.io/api/core/v1"
+	extensions "k8s.io/api/extensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/kubernetes/pkg/cloudprovider/providers/gce"
+	"k8s.io/kubernetes/pkg/cloudprovider/providers/gce/cloud"
+	"k8s.io/kubernetes/pkg/cloudprovider/providers/gce/cloud/meta"
+
+	"k8s.io/ingress-gce/pkg/annotations"
+	"k8s.io/ingress-gce/pkg/utils"
+)
+
+const (
+	testZone    = "us-central1-b"
+	usedPort    = 30001
+	orphanPort  = 30002
+	testProject = "test-project"
+)
+
+var namer = utils.NewNamer("uid1", "fw1")
+
+func link(resource, name string) string {
+	return cloud.SelfLink(meta.VersionGA, testProject, resource, meta.GlobalKey(name))
+}
+
+// newCloud returns a fake cloud with the resources of the load balancers of
+// the Ingresses default/used and default/deleted. The former uses the
+// BackendService of the NodePort usedPort and the latter of orphanPort.
+func newCloud(t *testing.T) cloud.Cloud {
+	t.Helper()
+	c := gce.NewFakeGCECloud(gce.DefaultTestClusterValues()).Compute()
+	ctx := context.Background()
+	insert := func(err error) {
+		t.Helper()
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for ing, port := range map[string]int64{"default/used": usedPort, "default/deleted": orphanPort} {
+		lbName := namer.LoadBalancer(ing)
+		beName := namer.IGBackend(port)
+		umName := namer.UrlMap(lbName)
+		tpName := namer.TargetProxy(lbName, utils.HTTPSProtocol)
+		certName := namer.SSLCertName(lbName, "hash")
+
+		insert(c.HealthChecks().Insert(ctx, meta.GlobalKey(beName), &compute.HealthCheck{Name: beName}))
+		insert(c.BackendServices().Insert(ctx, meta.GlobalKey(beName), &compute.BackendService{
+			Name:         beName,
+			HealthChecks: []string{link("healthChecks", beName)},
+		}))
+		insert(c.UrlMaps().Insert(ctx, meta.GlobalKey(umName), &compute.UrlMap{
+			Name:           umName,
+			DefaultService: link("backendServices", beName),
+		}))
+		insert(c.SslCertificates().Insert(ctx, meta.GlobalKey(certName), &compute.SslCertificate{Name: certName}))
+		insert(c.TargetHttpsProxies().Insert(ctx, meta.GlobalKey(tpName), &compute.TargetHttpsProxy{
+			Name:            tpName,
+			UrlMap:          link("urlMaps", umName),
+			SslCertificates: []string{link("sslCertificates", certName)},
+		}))
+		frName := namer.ForwardingRule(lbName, utils.HTTPSProtocol)
+		insert(c.GlobalForwardingRules().Insert(ctx, meta.GlobalKey(frName), &compute.ForwardingRule{
+			Name:   frName,
+			Target: link("targetHttpsProxies", tpName),
+		}))
+	}
+
+	// A legacy health check of a BackendService which was deleted.
+	legacyHC := namer.IGBackend(30003)
+	insert(c.HttpHealthChecks().Insert(ctx, meta.GlobalKey(legacyHC), &compute.HttpHealthCheck{Name: legacyHC}))
+	for _, neg := range []string{namer.NEG("default", "svc", 80), namer.NEG("default", "deleted", 80)} {
+		insert(c.BetaNetworkEndpointGroups().Insert(ctx, meta.ZonalKey(neg, testZone), &computebeta.NetworkEndpointGroup{Name: neg}))
+	}
+	insert(c.InstanceGroups().Insert(ctx, meta.ZonalKey(namer.InstanceGroup(), testZone), &compute.InstanceGroup{Name: namer.InstanceGroup()}))
+	insert(c.Firewalls().Insert(ctx, meta.GlobalKey(namer.FirewallRule()), &compute.Firewall{Name: namer.FirewallRule()}))
+
+	// Resources of another cluster.
+	other := utils.NewNamer("uid2", "fw2")
+	insert(c.BackendServices().Insert(ctx, meta.GlobalKey(other.IGBackend(usedPort)), &compute.BackendService{Name: other.IGBackend(usedPort)}))
+	insert(c.UrlMaps().Insert(ctx, meta.GlobalKey(other.UrlMap("default-used--uid2")), &compute.UrlMap{Name: other.UrlMap("default-used--uid2")}))
+	return c
+}
+
+func newIngress(name, svcName string) *extensions.Ingress {
+	return &extensions.Ingress{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name},
+		Spec: extensions.IngressSpec{
+			Backend: &extensions.IngressBackend{ServiceName: svcName, ServicePort: intstr.FromString("http")},
+		},
+	}
+}
+
+func newService(name string, nodePort int32) *v1.Service {
+	return &v1.Service{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name},
+		Spec: v1.ServiceSpec{
+			Ports: []v1.ServicePort{{Name: "http", Port: 80, NodePort: nodePort}},
+		},
+	}
+}
+
+func TestOrphans(t *testing.T) {
+	c := newCloud(t)
+	s := NewScanner(c, namer, []string{testZone})
+	inv, err := s.List()
+	if err != nil {
+		t.Fatalf("s.List() = %v", err)
+	}
+
+	lbName := namer.LoadBalancer("default/deleted")
+	want := []Resource{
+		{Kind: KindForwardingRule, Name: namer.ForwardingRule(lbName, utils.HTTPSProtocol)},
+		{Kind: KindTargetHTTPSProxy, Name: namer.TargetProxy(lbName, utils.HTTPSProtocol)},
+		{Kind: KindURLMap, Name: namer.UrlMap(lbName)},
+		{Kind: KindSslCertificate, Name: namer.SSLCertName(lbName, "hash")},
+		{Kind: KindBackendService, Name: namer.IGBackend(orphanPort)},
+		{Kind: KindHealthCheck, Name: namer.IGBackend(orphanPort)},
+		{Kind: KindHTTPHealthCheck, Name: namer.IGBackend(30003)},
+		{Kind: KindNetworkEndpointGroup, Zone: testZone, Name: namer.NEG("default", "deleted", 80)},
+	}
+	ings := []*extensions.Ingress{newIngress("used", "svc")}
+	svcs := []*v1.Service{newService("svc", usedPort)}
+	if got, err := inv.Orphans(ings, svcs); err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("inv.Orphans() = %v, %v; want %v, nil", got, err, want)
+	}
+
+	if err := s.Delete(want); err != nil {
+		t.Fatalf("s.Delete() = %v", err)
+	}
+	if inv, err = s.List(); err != nil {
+		t.Fatalf("s.List() = %v", err)
+	}
+	if got, err := inv.Orphans(ings, svcs); err != nil || len(got) != 0 {
+		t.Errorf("inv.Orphans() = %v, %v after deleting the orphans, want none", got, err)
+	}
+	// The resources of the Ingress and of the other cluster are left.
+	ctx := context.Background()
+	if _, err := c.GlobalForwardingRules().Get(ctx, meta.GlobalKey(namer.ForwardingRule(namer.LoadBalancer("default/used"), utils.HTTPSProtocol))); err != nil {
+		t.Errorf("Get() of the forwarding rule of the Ingress = %v", err)
+	}
+	if _, err := c.BackendServices().Get(ctx, meta.GlobalKey(utils.NewNamer("uid2", "fw2").IGBackend(usedPort))); err != nil {
+		t.Errorf("Get() of the BackendService of the other cluster = %v", err)
+	}
+}
+
+func TestOrphansWithoutIngresses(t *testing.T) {
+	s := NewScanner(newCloud(t), namer, []string{testZone})
+	inv, err := s.List()
+	if err != nil {
+		t.Fatalf("s.List() = %v", err)
+	}
+	// The Service keeps its NEG, which is managed by the NEG controller.
+	got, err := inv.Orphans(nil, []*v1.Service{newService("svc", usedPort)})
+	if err != nil {
+		t.Fatalf("inv.Orphans() = %v", err)
+	}
+	if len(got) != 16 {
+		t.Errorf("inv.Orphans() = %v, want the 16 resources of the cluster other than the NEG of the Service", got)
+	}
+	for _, r := range got {
+		if r.Name == namer.NEG("default", "svc", 80) {
+			t.Errorf("inv.Orphans() = %v, want the NEG of the Service to be used", got)
+		}
+	}
+	if last := got[len(got)-1]; last.Kind != KindFirewall {
+		t.Errorf("last orphan = %v, want the firewall rule", last)
+	}
+}
+
+func TestOrphansRouteRules(t *testing.T) {
+	s := NewScanner(newCloud(t), namer, []string{testZone})
+	inv, err := s.List()
+	if err != nil {
+		t.Fatalf("s.List() = %v", err)
+	}
+	// The BackendService of orphanPort is only used by the route rules of
+	// the Ingress, which the GA API does not see in its URL map.
+	ing := newIngress("used", "svc")
+	ing.Annotations = map[string]string{
+		annotations.RouteRulesKey: `[{"backends":[{"serviceName":"canary","servicePort":"http","weight":100}]}]`,
+	}
+	svcs := []*v1.Service{newService("svc", usedPort), newService("canary", orphanPort)}
+	got, err := inv.Orphans([]*extensions.Ingress{ing}, svcs)
+	if err != nil {
+		t.Fatalf("inv.Orphans() = %v", err)
+	}
+	for _, r := range got {
+		if r.Name == namer.IGBackend(orphanPort) {
+			t.Errorf("inv.Orphans() = %v, want %v to be used by the route rules", got, r)
+		}
+	}
+
+	ing.Annotations[annotations.RouteRulesKey] = "not json"
+	if got, err := inv.Orphans([]*extensions.Ingress{ing}, svcs); err == nil {
+		t.Errorf("inv.Orphans() = %v, nil; want an error for invalid route rules", got)
+	}
+}
+
+func TestListWithoutUID(t *testing.T) {
+	s := NewScanner(gce.NewFakeGCECloud(gce.DefaultTestClusterValues()).Compute(), utils.NewNamer("", ""), []string{testZone})
+	if _, err := s.List(); err == nil {
+		t.Errorf("s.List() = nil, want an error for a cluster without a UID")
+	}
+}
@@ -0,0 +1,173 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plan
+
+import (
+	"net/http"
+
+	computebeta "google.golang.org/api/compute/v0.beta"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/kubernetes/pkg/cloudprovider/providers/gce/cloud/meta"
+
+	"k8s.io/ingress-gce/pkg/backends"
+	"k8s.io/ingress-gce/pkg/backends/features"
+	"k8s.io/ingress-gce/pkg/composite"
+	"k8s.io/ingress-gce/pkg/utils"
+)
+
+// backendPool records the changes made through a backends.Pool.
+type backendPool struct {
+	backends.Pool
+	namer  *utils.Namer
+	region string
+	plan   *Plan
+}
+
+// NewBackendPool returns a backends.Pool which records its changes in p
+// rather than making them with pool. Regional BackendServices are in region.
+func NewBackendPool(pool backends.Pool, namer *utils.Namer, region string, p *Plan) backends.Pool {
+	return &backendPool{Pool: pool, namer: namer, region: region, plan: p}
+}
+
+func (b *backendPool) key(name string, scope meta.KeyType) resourceKey {
+	if scope == meta.Regional {
+		return resourceKey{kind: KindBackendService, key: *meta.RegionalKey(name, b.region)}
+	}
+	return resourceKey{kind: KindBackendService, key: *meta.GlobalKey(name)}
+}
+
+// current returns the state of a BackendService in the cloud.
+func (b *backendPool) current(name string, scope meta.KeyType) func() (interface{}, error) {
+	return func() (interface{}, error) {
+		return b.Pool.Get(name, meta.VersionGA, scope)
+	}
+}
+
+// Get implements Pool.
+func (b *backendPool) Get(name string, version meta.Version, scope meta.KeyType) (*composite.BackendService, error) {
+	be := &composite.BackendService{}
+	planned, err := b.plan.get(b.key(name, scope), be)
+	if !planned {
+		return b.Pool.Get(name, version, scope)
+	}
+	if err != nil {
+		return nil, err
+	}
+	// As with the cloud, a lower version is used if the features of the
+	// BackendService require it.
+	be.Version = version
+	if versionRequired := features.VersionFromDescription(be.Description); features.IsLowerVersion(versionRequired, version) {
+		be.Version = versionRequired
+	}
+	be.Scope = scope
+	return be, nil
+}
+
+// Create implements Pool.
+func (b *backendPool) Create(sp utils.ServicePort, hcLink string) (*composite.BackendService, error) {
+	be := backends.NewBackendService(sp, hcLink, b.namer)
+	if err := b.plan.put(b.key(be.Name, be.Scope), be, b.current(be.Name, be.Scope)); err != nil {
+		return nil, err
+	}
+	return b.Get(be.Name, be.Version, be.Scope)
+}
+
+// Update implements Pool.
+func (b *backendPool) Update(be *composite.BackendService) error {
+	return b.plan.put(b.key(be.Name, be.Scope), be, b.current(be.Name, be.Scope))
+}
+
+// Delete implements Pool.
+func (b *backendPool) Delete(name string, scope meta.KeyType) error {
+	err := b.plan.delete(b.key(name, scope), b.current(name, scope))
+	if utils.IsHTTPErrorCode(err, http.StatusNotFound) {
+		return nil
+	}
+	return err
+}
+
+// List implements Pool.
+func (b *backendPool) List(scope meta.KeyType) ([]string, error) {
+	names, err := b.Pool.List(scope)
+	if err != nil {
+		return nil, err
+	}
+	changed := b.plan.changed(KindBackendService, b.key("", scope).key)
+	var result []string
+	for _, name := range names {
+		if _, ok := changed[name]; !ok {
+			result = append(result, name)
+		}
+	}
+	return append(result, existing(changed)...), nil
+}
+
+// GetBetaGlobalBackendService implements Pool.
+func (b *backendPool) GetBetaGlobalBackendService(name string) (*computebeta.BackendService, error) {
+	be := &computebeta.BackendService{}
+	if planned, err := b.plan.get(b.key(name, meta.Global), be); planned {
+		if err != nil {
+			return nil, err
+		}
+		return be, nil
+	}
+	return b.Pool.GetBetaGlobalBackendService(name)
+}
+
+// UpdateBetaGlobalBackendService implements Pool.
+func (b *backendPool) UpdateBetaGlobalBackendService(be *computebeta.BackendService) error {
+	return b.plan.put(b.key(be.Name, meta.Global), be, b.current(be.Name, meta.Global))
+}
+
+// updateBetaGlobal records the change of a global BackendService.
+func (b *backendPool) updateBetaGlobal(name string, change func(*computebeta.BackendService)) error {
+	be, err := b.GetBetaGlobalBackendService(name)
+	if err != nil {
+		return err
+	}
+	change(be)
+	return b.UpdateBetaGlobalBackendService(be)
+}
+
+// SetSecurityPolicyForBetaGlobalBackendService implements Pool.
+func (b *backendPool) SetSecurityPolicyForBetaGlobalBackendService(name string, policyRef *computebeta.SecurityPolicyReference) error {
+	return b.updateBetaGlobal(name, func(be *computebeta.BackendService) {
+		be.SecurityPolicy = ""
+		if policyRef != nil {
+			be.SecurityPolicy = policyRef.SecurityPolicy
+		}
+	})
+}
+
+// AddSignedURLKey implements Pool. Only the name of the key is recorded.
+func (b *backendPool) AddSignedURLKey(name string, key *composite.SignedUrlKey) error {
+	return b.updateBetaGlobal(name, func(be *computebeta.BackendService) {
+		if be.CdnPolicy == nil {
+			be.CdnPolicy = &computebeta.BackendServiceCdnPolicy{}
+		}
+		be.CdnPolicy.SignedUrlKeyNames = sets.NewString(be.CdnPolicy.SignedUrlKeyNames...).Union(sets.NewString(key.KeyName)).List()
+	})
+}
+
+// DeleteSignedURLKey implements Pool.
+func (b *backendPool) DeleteSignedURLKey(name, keyName string) error {
+	return b.updateBetaGlobal(name, func(be *computebeta.BackendService) {
+		if be.CdnPolicy != nil {
+			be.CdnPolicy.SignedUrlKeyNames = sets.NewString(be.CdnPolicy.SignedUrlKeyNames...).Difference(sets.NewString(keyName)).List()
+		}
+	})
+}
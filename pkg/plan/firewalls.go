@@ -0,0 +1,71 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plan
+
+import (
+	compute "google.golang.org/api/compute/v1"
+	"k8s.io/kubernetes/pkg/cloudprovider/providers/gce/cloud/meta"
+
+	"k8s.io/ingress-gce/pkg/firewalls"
+)
+
+// firewall records the changes made through a Firewall.
+type firewall struct {
+	firewalls.Firewall
+	plan *Plan
+}
+
+// NewFirewall returns a Firewall which records its changes in p rather than
+// making them with fw.
+func NewFirewall(fw firewalls.Firewall, p *Plan) firewalls.Firewall {
+	return &firewall{Firewall: fw, plan: p}
+}
+
+func firewallKey(name string) resourceKey {
+	return resourceKey{kind: KindFirewall, key: *meta.GlobalKey(name)}
+}
+
+// CreateFirewall implements Firewall.
+func (f *firewall) CreateFirewall(fw *compute.Firewall) error {
+	return f.UpdateFirewall(fw)
+}
+
+// GetFirewall implements Firewall.
+func (f *firewall) GetFirewall(name string) (*compute.Firewall, error) {
+	fw := &compute.Firewall{}
+	if planned, err := f.plan.get(firewallKey(name), fw); planned {
+		if err != nil {
+			return nil, err
+		}
+		return fw, nil
+	}
+	return f.Firewall.GetFirewall(name)
+}
+
+// DeleteFirewall implements Firewall.
+func (f *firewall) DeleteFirewall(name string) error {
+	return f.plan.delete(firewallKey(name), func() (interface{}, error) {
+		return f.Firewall.GetFirewall(name)
+	})
+}
+
+// UpdateFirewall implements Firewall.
+func (f *firewall) UpdateFirewall(fw *compute.Firewall) error {
+	return f.plan.put(firewallKey(fw.Name), fw, func() (interface{}, error) {
+		return f.Firewall.GetFirewall(fw.Name)
+	})
+}
@@ -0,0 +1,190 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plan
+
+import (
+	computealpha "google.golang.org/api/compute/v0.alpha"
+	computebeta "google.golang.org/api/compute/v0.beta"
+	compute "google.golang.org/api/compute/v1"
+	"k8s.io/kubernetes/pkg/cloudprovider/providers/gce/cloud/meta"
+
+	"k8s.io/ingress-gce/pkg/healthchecks"
+)
+
+// healthChecks records the changes made through a HealthCheckProvider.
+type healthChecks struct {
+	healthchecks.HealthCheckProvider
+	region string
+	plan   *Plan
+}
+
+// NewHealthChecks returns a HealthCheckProvider which records its changes in
+// p rather than making them with hcs. Regional health checks are in region.
+func NewHealthChecks(hcs healthchecks.HealthCheckProvider, region string, p *Plan) healthchecks.HealthCheckProvider {
+	return &healthChecks{HealthCheckProvider: hcs, region: region, plan: p}
+}
+
+func (h *healthChecks) global(name string) resourceKey {
+	return resourceKey{kind: KindHealthCheck, key: *meta.GlobalKey(name)}
+}
+
+func (h *healthChecks) regional(name string) resourceKey {
+	return resourceKey{kind: KindHealthCheck, key: *meta.RegionalKey(name, h.region)}
+}
+
+// CreateHTTPHealthCheck implements HealthCheckProvider.
+func (h *healthChecks) CreateHTTPHealthCheck(hc *compute.HttpHealthCheck) error {
+	return h.UpdateHTTPHealthCheck(hc)
+}
+
+// UpdateHTTPHealthCheck implements HealthCheckProvider.
+func (h *healthChecks) UpdateHTTPHealthCheck(hc *compute.HttpHealthCheck) error {
+	k := resourceKey{kind: KindHTTPHealthCheck, key: *meta.GlobalKey(hc.Name)}
+	return h.plan.put(k, hc, func() (interface{}, error) {
+		return h.HealthCheckProvider.GetHTTPHealthCheck(hc.Name)
+	})
+}
+
+// DeleteHTTPHealthCheck implements HealthCheckProvider.
+func (h *healthChecks) DeleteHTTPHealthCheck(name string) error {
+	k := resourceKey{kind: KindHTTPHealthCheck, key: *meta.GlobalKey(name)}
+	return h.plan.delete(k, func() (interface{}, error) {
+		return h.HealthCheckProvider.GetHTTPHealthCheck(name)
+	})
+}
+
+// GetHTTPHealthCheck implements HealthCheckProvider.
+func (h *healthChecks) GetHTTPHealthCheck(name string) (*compute.HttpHealthCheck, error) {
+	hc := &compute.HttpHealthCheck{}
+	if planned, err := h.plan.get(resourceKey{kind: KindHTTPHealthCheck, key: *meta.GlobalKey(name)}, hc); planned {
+		if err != nil {
+			return nil, err
+		}
+		return hc, nil
+	}
+	return h.HealthCheckProvider.GetHTTPHealthCheck(name)
+}
+
+// CreateAlphaHealthCheck implements HealthCheckProvider.
+func (h *healthChecks) CreateAlphaHealthCheck(hc *computealpha.HealthCheck) error {
+	return h.UpdateAlphaHealthCheck(hc)
+}
+
+// CreateBetaHealthCheck implements HealthCheckProvider.
+func (h *healthChecks) CreateBetaHealthCheck(hc *computebeta.HealthCheck) error {
+	return h.UpdateBetaHealthCheck(hc)
+}
+
+// CreateHealthCheck implements HealthCheckProvider.
+func (h *healthChecks) CreateHealthCheck(hc *compute.HealthCheck) error {
+	return h.UpdateHealthCheck(hc)
+}
+
+// UpdateAlphaHealthCheck implements HealthCheckProvider.
+func (h *healthChecks) UpdateAlphaHealthCheck(hc *computealpha.HealthCheck) error {
+	return h.plan.put(h.global(hc.Name), hc, func() (interface{}, error) {
+		return h.HealthCheckProvider.GetAlphaHealthCheck(hc.Name)
+	})
+}
+
+// UpdateBetaHealthCheck implements HealthCheckProvider.
+func (h *healthChecks) UpdateBetaHealthCheck(hc *computebeta.HealthCheck) error {
+	return h.plan.put(h.global(hc.Name), hc, func() (interface{}, error) {
+		return h.HealthCheckProvider.GetBetaHealthCheck(hc.Name)
+	})
+}
+
+// UpdateHealthCheck implements HealthCheckProvider.
+func (h *healthChecks) UpdateHealthCheck(hc *compute.HealthCheck) error {
+	return h.plan.put(h.global(hc.Name), hc, func() (interface{}, error) {
+		return h.HealthCheckProvider.GetHealthCheck(hc.Name)
+	})
+}
+
+// DeleteHealthCheck implements HealthCheckProvider.
+func (h *healthChecks) DeleteHealthCheck(name string) error {
+	return h.plan.delete(h.global(name), func() (interface{}, error) {
+		return h.HealthCheckProvider.GetHealthCheck(name)
+	})
+}
+
+// GetAlphaHealthCheck implements HealthCheckProvider.
+func (h *healthChecks) GetAlphaHealthCheck(name string) (*computealpha.HealthCheck, error) {
+	hc := &computealpha.HealthCheck{}
+	if planned, err := h.plan.get(h.global(name), hc); planned {
+		if err != nil {
+			return nil, err
+		}
+		return hc, nil
+	}
+	return h.HealthCheckProvider.GetAlphaHealthCheck(name)
+}
+
+// GetBetaHealthCheck implements HealthCheckProvider.
+func (h *healthChecks) GetBetaHealthCheck(name string) (*computebeta.HealthCheck, error) {
+	hc := &computebeta.HealthCheck{}
+	if planned, err := h.plan.get(h.global(name), hc); planned {
+		if err != nil {
+			return nil, err
+		}
+		return hc, nil
+	}
+	return h.HealthCheckProvider.GetBetaHealthCheck(name)
+}
+
+// GetHealthCheck implements HealthCheckProvider.
+func (h *healthChecks) GetHealthCheck(name string) (*compute.HealthCheck, error) {
+	hc := &compute.HealthCheck{}
+	if planned, err := h.plan.get(h.global(name), hc); planned {
+		if err != nil {
+			return nil, err
+		}
+		return hc, nil
+	}
+	return h.HealthCheckProvider.GetHealthCheck(name)
+}
+
+// CreateAlphaRegionHealthCheck implements HealthCheckProvider.
+func (h *healthChecks) CreateAlphaRegionHealthCheck(hc *computealpha.HealthCheck) error {
+	return h.UpdateAlphaRegionHealthCheck(hc)
+}
+
+// UpdateAlphaRegionHealthCheck implements HealthCheckProvider.
+func (h *healthChecks) UpdateAlphaRegionHealthCheck(hc *computealpha.HealthCheck) error {
+	return h.plan.put(h.regional(hc.Name), hc, func() (interface{}, error) {
+		return h.HealthCheckProvider.GetAlphaRegionHealthCheck(hc.Name)
+	})
+}
+
+// DeleteAlphaRegionHealthCheck implements HealthCheckProvider.
+func (h *healthChecks) DeleteAlphaRegionHealthCheck(name string) error {
+	return h.plan.delete(h.regional(name), func() (interface{}, error) {
+		return h.HealthCheckProvider.GetAlphaRegionHealthCheck(name)
+	})
+}
+
+// GetAlphaRegionHealthCheck implements HealthCheckProvider.
+func (h *healthChecks) GetAlphaRegionHealthCheck(name string) (*computealpha.HealthCheck, error) {
+	hc := &computealpha.HealthCheck{}
+	if planned, err := h.plan.get(h.regional(name), hc); planned {
+		if err != nil {
+			return nil, err
+		}
+		return hc, nil
+	}
+	return h.HealthCheckProvider.GetAlphaRegionHealthCheck(name)
+}
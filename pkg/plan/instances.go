@@ -0,0 +1,247 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plan
+
+import (
+	"encoding/json"
+	"sort"
+
+	compute "google.golang.org/api/compute/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/kubernetes/pkg/cloudprovider/providers/gce/cloud/meta"
+
+	"k8s.io/ingress-gce/pkg/instances"
+	"k8s.io/ingress-gce/pkg/utils"
+)
+
+// allInstances lists the instances of a group regardless of their state.
+const allInstances = "ALL"
+
+// plannedInstanceGroup is the recorded state of an instance group, which
+// includes the names of its instances.
+type plannedInstanceGroup struct {
+	InstanceGroup *compute.InstanceGroup
+	Instances     []string
+}
+
+// MarshalJSON returns the JSON of the instance group with an additional
+// "instances" field.
+func (ig *plannedInstanceGroup) MarshalJSON() ([]byte, error) {
+	b, err := json.Marshal(ig.InstanceGroup)
+	if err != nil {
+		return nil, err
+	}
+	fields := map[string]interface{}{}
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return nil, err
+	}
+	if len(ig.Instances) > 0 {
+		fields["instances"] = ig.Instances
+	}
+	return json.Marshal(fields)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (ig *plannedInstanceGroup) UnmarshalJSON(b []byte) error {
+	ig.InstanceGroup = &compute.InstanceGroup{}
+	if err := json.Unmarshal(b, ig.InstanceGroup); err != nil {
+		return err
+	}
+	var members struct {
+		Instances []string `json:"instances"`
+	}
+	if err := json.Unmarshal(b, &members); err != nil {
+		return err
+	}
+	ig.Instances = members.Instances
+	return nil
+}
+
+// instanceGroups records the changes made through an InstanceGroups.
+type instanceGroups struct {
+	instances.InstanceGroups
+	plan *Plan
+}
+
+// NewInstanceGroups returns an InstanceGroups which records its changes in p
+// rather than making them with igs.
+func NewInstanceGroups(igs instances.InstanceGroups, p *Plan) instances.InstanceGroups {
+	return &instanceGroups{InstanceGroups: igs, plan: p}
+}
+
+func instanceGroupKey(name, zone string) resourceKey {
+	return resourceKey{kind: KindInstanceGroup, key: *meta.ZonalKey(name, zone)}
+}
+
+// current returns the instance group in the cloud along with its instances.
+func (i *instanceGroups) current(name, zone string) (interface{}, error) {
+	ig, err := i.InstanceGroups.GetInstanceGroup(name, zone)
+	if err != nil {
+		return nil, err
+	}
+	planned := &plannedInstanceGroup{InstanceGroup: ig}
+	members, err := i.InstanceGroups.ListInstancesInInstanceGroup(name, zone, allInstances)
+	if err != nil {
+		return nil, err
+	}
+	for _, member := range members {
+		instance, err := utils.KeyName(member.Instance)
+		if err != nil {
+			return nil, err
+		}
+		planned.Instances = append(planned.Instances, instance)
+	}
+	sort.Strings(planned.Instances)
+	return planned, nil
+}
+
+// get returns the planned state of an instance group, or false if it was
+// not changed.
+func (i *instanceGroups) get(name, zone string) (*plannedInstanceGroup, bool, error) {
+	planned := &plannedInstanceGroup{}
+	ok, err := i.plan.get(instanceGroupKey(name, zone), planned)
+	return planned, ok, err
+}
+
+// update records the change of an instance group.
+func (i *instanceGroups) update(name, zone string, change func(*plannedInstanceGroup)) error {
+	planned, ok, err := i.get(name, zone)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		current, err := i.current(name, zone)
+		if err != nil {
+			return err
+		}
+		planned = current.(*plannedInstanceGroup)
+	}
+	change(planned)
+	return i.plan.put(instanceGroupKey(name, zone), planned, func() (interface{}, error) {
+		return i.current(name, zone)
+	})
+}
+
+// GetInstanceGroup implements InstanceGroups.
+func (i *instanceGroups) GetInstanceGroup(name, zone string) (*compute.InstanceGroup, error) {
+	planned, ok, err := i.get(name, zone)
+	if !ok {
+		return i.InstanceGroups.GetInstanceGroup(name, zone)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return planned.InstanceGroup, nil
+}
+
+// CreateInstanceGroup implements InstanceGroups.
+func (i *instanceGroups) CreateInstanceGroup(ig *compute.InstanceGroup, zone string) error {
+	created := *ig
+	created.Zone = zone
+	planned := &plannedInstanceGroup{InstanceGroup: &created}
+	return i.plan.put(instanceGroupKey(ig.Name, zone), planned, func() (interface{}, error) {
+		return i.current(ig.Name, zone)
+	})
+}
+
+// DeleteInstanceGroup implements InstanceGroups.
+func (i *instanceGroups) DeleteInstanceGroup(name, zone string) error {
+	return i.plan.delete(instanceGroupKey(name, zone), func() (interface{}, error) {
+		return i.current(name, zone)
+	})
+}
+
+// ListInstanceGroups implements InstanceGroups.
+func (i *instanceGroups) ListInstanceGroups(zone string) ([]*compute.InstanceGroup, error) {
+	igs, err := i.InstanceGroups.ListInstanceGroups(zone)
+	if err != nil {
+		return nil, err
+	}
+	changed := i.plan.changed(KindInstanceGroup, *meta.ZonalKey("", zone))
+	var result []*compute.InstanceGroup
+	for _, ig := range igs {
+		if _, ok := changed[ig.Name]; !ok {
+			result = append(result, ig)
+		}
+	}
+	for _, name := range existing(changed) {
+		ig, err := i.GetInstanceGroup(name, zone)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, ig)
+	}
+	return result, nil
+}
+
+// ListInstancesInInstanceGroup implements InstanceGroups.
+func (i *instanceGroups) ListInstancesInInstanceGroup(name, zone string, state string) ([]*compute.InstanceWithNamedPorts, error) {
+	planned, ok, err := i.get(name, zone)
+	if !ok {
+		return i.InstanceGroups.ListInstancesInInstanceGroup(name, zone, state)
+	}
+	if err != nil {
+		return nil, err
+	}
+	var result []*compute.InstanceWithNamedPorts
+	for _, ref := range i.ToInstanceReferences(zone, planned.Instances) {
+		result = append(result, &compute.InstanceWithNamedPorts{Instance: ref.Instance})
+	}
+	return result, nil
+}
+
+// instanceNames returns the names of the instances of refs.
+func instanceNames(refs []*compute.InstanceReference) (sets.String, error) {
+	names := sets.NewString()
+	for _, ref := range refs {
+		name, err := utils.KeyName(ref.Instance)
+		if err != nil {
+			return nil, err
+		}
+		names.Insert(name)
+	}
+	return names, nil
+}
+
+// AddInstancesToInstanceGroup implements InstanceGroups.
+func (i *instanceGroups) AddInstancesToInstanceGroup(name, zone string, instanceRefs []*compute.InstanceReference) error {
+	names, err := instanceNames(instanceRefs)
+	if err != nil {
+		return err
+	}
+	return i.update(name, zone, func(ig *plannedInstanceGroup) {
+		ig.Instances = sets.NewString(ig.Instances...).Union(names).List()
+	})
+}
+
+// RemoveInstancesFromInstanceGroup implements InstanceGroups.
+func (i *instanceGroups) RemoveInstancesFromInstanceGroup(name, zone string, instanceRefs []*compute.InstanceReference) error {
+	names, err := instanceNames(instanceRefs)
+	if err != nil {
+		return err
+	}
+	return i.update(name, zone, func(ig *plannedInstanceGroup) {
+		ig.Instances = sets.NewString(ig.Instances...).Difference(names).List()
+	})
+}
+
+// SetNamedPortsOfInstanceGroup implements InstanceGroups.
+func (i *instanceGroups) SetNamedPortsOfInstanceGroup(igName, zone string, namedPorts []*compute.NamedPort) error {
+	return i.update(igName, zone, func(ig *plannedInstanceGroup) {
+		ig.InstanceGroup.NamedPorts = namedPorts
+	})
+}
@@ -0,0 +1,649 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plan
+
+import (
+	"sort"
+
+	computebeta "google.golang.org/api/compute/v0.beta"
+	compute "google.golang.org/api/compute/v1"
+	"k8s.io/kubernetes/pkg/cloudprovider/providers/gce/cloud/meta"
+
+	"k8s.io/ingress-gce/pkg/composite"
+	"k8s.io/ingress-gce/pkg/loadbalancers"
+)
+
+// loadBalancers records the changes made through a LoadBalancers.
+type loadBalancers struct {
+	loadbalancers.LoadBalancers
+	plan *Plan
+}
+
+// NewLoadBalancers returns a LoadBalancers which records its changes in p
+// rather than making them with lbs.
+func NewLoadBalancers(lbs loadbalancers.LoadBalancers, p *Plan) loadbalancers.LoadBalancers {
+	return &loadBalancers{LoadBalancers: lbs, plan: p}
+}
+
+func (l *loadBalancers) global(kind, name string) resourceKey {
+	return resourceKey{kind: kind, key: *meta.GlobalKey(name)}
+}
+
+func (l *loadBalancers) regional(kind, name string) resourceKey {
+	return resourceKey{kind: kind, key: *meta.RegionalKey(name, l.Region())}
+}
+
+// GetGlobalForwardingRule implements LoadBalancers.
+func (l *loadBalancers) GetGlobalForwardingRule(name string) (*compute.ForwardingRule, error) {
+	rule := &compute.ForwardingRule{}
+	if planned, err := l.plan.get(l.global(KindForwardingRule, name), rule); planned {
+		if err != nil {
+			return nil, err
+		}
+		return rule, nil
+	}
+	return l.LoadBalancers.GetGlobalForwardingRule(name)
+}
+
+// CreateGlobalForwardingRule implements LoadBalancers.
+func (l *loadBalancers) CreateGlobalForwardingRule(rule *compute.ForwardingRule) error {
+	return l.plan.put(l.global(KindForwardingRule, rule.Name), rule, func() (interface{}, error) {
+		return l.LoadBalancers.GetGlobalForwardingRule(rule.Name)
+	})
+}
+
+// DeleteGlobalForwardingRule implements LoadBalancers.
+func (l *loadBalancers) DeleteGlobalForwardingRule(name string) error {
+	return l.plan.delete(l.global(KindForwardingRule, name), func() (interface{}, error) {
+		return l.LoadBalancers.GetGlobalForwardingRule(name)
+	})
+}
+
+// SetProxyForGlobalForwardingRule implements LoadBalancers.
+func (l *loadBalancers) SetProxyForGlobalForwardingRule(fw, proxy string) error {
+	rule, err := l.GetGlobalForwardingRule(fw)
+	if err != nil {
+		return err
+	}
+	rule.Target = proxy
+	return l.CreateGlobalForwardingRule(rule)
+}
+
+// ListGlobalForwardingRules implements LoadBalancers.
+func (l *loadBalancers) ListGlobalForwardingRules() ([]*compute.ForwardingRule, error) {
+	rules, err := l.LoadBalancers.ListGlobalForwardingRules()
+	if err != nil {
+		return nil, err
+	}
+	changed := l.plan.changed(KindForwardingRule, meta.Key{})
+	var result []*compute.ForwardingRule
+	for _, rule := range rules {
+		if _, ok := changed[rule.Name]; !ok {
+			result = append(result, rule)
+		}
+	}
+	for _, name := range existing(changed) {
+		rule, err := l.GetGlobalForwardingRule(name)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, rule)
+	}
+	return result, nil
+}
+
+// GetURLMap implements LoadBalancers.
+func (l *loadBalancers) GetURLMap(name string) (*compute.UrlMap, error) {
+	um := &compute.UrlMap{}
+	if planned, err := l.plan.get(l.global(KindURLMap, name), um); planned {
+		if err != nil {
+			return nil, err
+		}
+		return um, nil
+	}
+	return l.LoadBalancers.GetURLMap(name)
+}
+
+// CreateURLMap implements LoadBalancers.
+func (l *loadBalancers) CreateURLMap(urlMap *compute.UrlMap) error {
+	return l.UpdateURLMap(urlMap)
+}
+
+// UpdateURLMap implements LoadBalancers.
+func (l *loadBalancers) UpdateURLMap(urlMap *compute.UrlMap) error {
+	return l.plan.put(l.global(KindURLMap, urlMap.Name), urlMap, func() (interface{}, error) {
+		return l.LoadBalancers.GetURLMap(urlMap.Name)
+	})
+}
+
+// DeleteURLMap implements LoadBalancers.
+func (l *loadBalancers) DeleteURLMap(name string) error {
+	return l.plan.delete(l.global(KindURLMap, name), func() (interface{}, error) {
+		return l.LoadBalancers.GetURLMap(name)
+	})
+}
+
+// ListURLMaps implements LoadBalancers.
+func (l *loadBalancers) ListURLMaps() ([]*compute.UrlMap, error) {
+	ums, err := l.LoadBalancers.ListURLMaps()
+	if err != nil {
+		return nil, err
+	}
+	return l.mergeURLMaps(ums, meta.Key{}, l.GetURLMap)
+}
+
+// mergeURLMaps replaces the listed UrlMaps in the scope of key with their
+// recorded state.
+func (l *loadBalancers) mergeURLMaps(ums []*compute.UrlMap, key meta.Key, get func(string) (*compute.UrlMap, error)) ([]*compute.UrlMap, error) {
+	changed := l.plan.changed(KindURLMap, key)
+	var result []*compute.UrlMap
+	for _, um := range ums {
+		if _, ok := changed[um.Name]; !ok {
+			result = append(result, um)
+		}
+	}
+	for _, name := range existing(changed) {
+		um, err := get(name)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, um)
+	}
+	return result, nil
+}
+
+// GetRedirectURLMap implements LoadBalancers.
+func (l *loadBalancers) GetRedirectURLMap(name string) (*composite.UrlMap, error) {
+	um := &composite.UrlMap{Version: meta.VersionGA}
+	if planned, err := l.plan.get(l.global(KindURLMap, name), um); planned {
+		if err != nil {
+			return nil, err
+		}
+		return um, nil
+	}
+	return l.LoadBalancers.GetRedirectURLMap(name)
+}
+
+// CreateRedirectURLMap implements LoadBalancers.
+func (l *loadBalancers) CreateRedirectURLMap(urlMap *composite.UrlMap) error {
+	return l.UpdateRedirectURLMap(urlMap)
+}
+
+// UpdateRedirectURLMap implements LoadBalancers.
+func (l *loadBalancers) UpdateRedirectURLMap(urlMap *composite.UrlMap) error {
+	return l.plan.put(l.global(KindURLMap, urlMap.Name), urlMap, func() (interface{}, error) {
+		return l.LoadBalancers.GetRedirectURLMap(urlMap.Name)
+	})
+}
+
+// GetAlphaURLMap implements LoadBalancers.
+func (l *loadBalancers) GetAlphaURLMap(name string) (*composite.UrlMap, error) {
+	um := &composite.UrlMap{Version: meta.VersionAlpha}
+	if planned, err := l.plan.get(l.global(KindURLMap, name), um); planned {
+		if err != nil {
+			return nil, err
+		}
+		return um, nil
+	}
+	return l.LoadBalancers.GetAlphaURLMap(name)
+}
+
+// CreateAlphaURLMap implements LoadBalancers.
+func (l *loadBalancers) CreateAlphaURLMap(urlMap *composite.UrlMap) error {
+	return l.UpdateAlphaURLMap(urlMap)
+}
+
+// UpdateAlphaURLMap implements LoadBalancers.
+func (l *loadBalancers) UpdateAlphaURLMap(urlMap *composite.UrlMap) error {
+	return l.plan.put(l.global(KindURLMap, urlMap.Name), urlMap, func() (interface{}, error) {
+		return l.LoadBalancers.GetAlphaURLMap(urlMap.Name)
+	})
+}
+
+// GetTargetHTTPProxy implements LoadBalancers.
+func (l *loadBalancers) GetTargetHTTPProxy(name string) (*compute.TargetHttpProxy, error) {
+	proxy := &compute.TargetHttpProxy{}
+	if planned, err := l.plan.get(l.global(KindTargetHTTPProxy, name), proxy); planned {
+		if err != nil {
+			return nil, err
+		}
+		return proxy, nil
+	}
+	return l.LoadBalancers.GetTargetHTTPProxy(name)
+}
+
+// CreateTargetHTTPProxy implements LoadBalancers.
+func (l *loadBalancers) CreateTargetHTTPProxy(proxy *compute.TargetHttpProxy) error {
+	return l.plan.put(l.global(KindTargetHTTPProxy, proxy.Name), proxy, func() (interface{}, error) {
+		return l.LoadBalancers.GetTargetHTTPProxy(proxy.Name)
+	})
+}
+
+// DeleteTargetHTTPProxy implements LoadBalancers.
+func (l *loadBalancers) DeleteTargetHTTPProxy(name string) error {
+	return l.plan.delete(l.global(KindTargetHTTPProxy, name), func() (interface{}, error) {
+		return l.LoadBalancers.GetTargetHTTPProxy(name)
+	})
+}
+
+// SetURLMapForTargetHTTPProxy implements LoadBalancers.
+func (l *loadBalancers) SetURLMapForTargetHTTPProxy(proxy *compute.TargetHttpProxy, urlMapLink string) error {
+	current, err := l.GetTargetHTTPProxy(proxy.Name)
+	if err != nil {
+		return err
+	}
+	current.UrlMap = urlMapLink
+	return l.CreateTargetHTTPProxy(current)
+}
+
+// GetTargetHTTPSProxy implements LoadBalancers.
+func (l *loadBalancers) GetTargetHTTPSProxy(name string) (*compute.TargetHttpsProxy, error) {
+	proxy := &compute.TargetHttpsProxy{}
+	if planned, err := l.plan.get(l.global(KindTargetHTTPSProxy, name), proxy); planned {
+		if err != nil {
+			return nil, err
+		}
+		return proxy, nil
+	}
+	return l.LoadBalancers.GetTargetHTTPSProxy(name)
+}
+
+// CreateTargetHTTPSProxy implements LoadBalancers.
+func (l *loadBalancers) CreateTargetHTTPSProxy(proxy *compute.TargetHttpsProxy) error {
+	return l.plan.put(l.global(KindTargetHTTPSProxy, proxy.Name), proxy, func() (interface{}, error) {
+		return l.LoadBalancers.GetTargetHTTPSProxy(proxy.Name)
+	})
+}
+
+// DeleteTargetHTTPSProxy implements LoadBalancers.
+func (l *loadBalancers) DeleteTargetHTTPSProxy(name string) error {
+	return l.plan.delete(l.global(KindTargetHTTPSProxy, name), func() (interface{}, error) {
+		return l.LoadBalancers.GetTargetHTTPSProxy(name)
+	})
+}
+
+// setTargetHTTPSProxy records the change of a field of a TargetHttpsProxy.
+func (l *loadBalancers) setTargetHTTPSProxy(name string, set func(*compute.TargetHttpsProxy)) error {
+	current, err := l.GetTargetHTTPSProxy(name)
+	if err != nil {
+		return err
+	}
+	set(current)
+	return l.CreateTargetHTTPSProxy(current)
+}
+
+// SetURLMapForTargetHTTPSProxy implements LoadBalancers.
+func (l *loadBalancers) SetURLMapForTargetHTTPSProxy(proxy *compute.TargetHttpsProxy, urlMapLink string) error {
+	return l.setTargetHTTPSProxy(proxy.Name, func(p *compute.TargetHttpsProxy) { p.UrlMap = urlMapLink })
+}
+
+// SetSslCertificateForTargetHTTPSProxy implements LoadBalancers.
+func (l *loadBalancers) SetSslCertificateForTargetHTTPSProxy(proxy *compute.TargetHttpsProxy, sslCertURLs []string) error {
+	return l.setTargetHTTPSProxy(proxy.Name, func(p *compute.TargetHttpsProxy) { p.SslCertificates = sslCertURLs })
+}
+
+// SetSslPolicyForTargetHTTPSProxy implements LoadBalancers.
+func (l *loadBalancers) SetSslPolicyForTargetHTTPSProxy(proxy *compute.TargetHttpsProxy, sslPolicyLink string) error {
+	return l.setTargetHTTPSProxy(proxy.Name, func(p *compute.TargetHttpsProxy) { p.SslPolicy = sslPolicyLink })
+}
+
+// SetQuicOverrideForTargetHTTPSProxy implements LoadBalancers.
+func (l *loadBalancers) SetQuicOverrideForTargetHTTPSProxy(proxy *compute.TargetHttpsProxy, quicOverride string) error {
+	return l.setTargetHTTPSProxy(proxy.Name, func(p *compute.TargetHttpsProxy) { p.QuicOverride = quicOverride })
+}
+
+// GetSslCertificate implements LoadBalancers.
+func (l *loadBalancers) GetSslCertificate(name string) (*compute.SslCertificate, error) {
+	cert := &compute.SslCertificate{}
+	if planned, err := l.plan.get(l.global(KindSslCertificate, name), cert); planned {
+		if err != nil {
+			return nil, err
+		}
+		return cert, nil
+	}
+	return l.LoadBalancers.GetSslCertificate(name)
+}
+
+// ListSslCertificates implements LoadBalancers.
+func (l *loadBalancers) ListSslCertificates() ([]*compute.SslCertificate, error) {
+	certs, err := l.LoadBalancers.ListSslCertificates()
+	if err != nil {
+		return nil, err
+	}
+	return l.mergeSslCertificates(certs, meta.Key{}, l.GetSslCertificate)
+}
+
+// mergeSslCertificates replaces the listed SslCertificates in the scope of
+// key with their recorded state.
+func (l *loadBalancers) mergeSslCertificates(certs []*compute.SslCertificate, key meta.Key, get func(string) (*compute.SslCertificate, error)) ([]*compute.SslCertificate, error) {
+	changed := l.plan.changed(KindSslCertificate, key)
+	var result []*compute.SslCertificate
+	for _, cert := range certs {
+		if _, ok := changed[cert.Name]; !ok {
+			result = append(result, cert)
+		}
+	}
+	for _, name := range existing(changed) {
+		cert, err := get(name)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, cert)
+	}
+	return result, nil
+}
+
+// CreateSslCertificate implements LoadBalancers.
+func (l *loadBalancers) CreateSslCertificate(cert *compute.SslCertificate) (*compute.SslCertificate, error) {
+	if err := l.plan.put(l.global(KindSslCertificate, cert.Name), cert, func() (interface{}, error) {
+		return l.LoadBalancers.GetSslCertificate(cert.Name)
+	}); err != nil {
+		return nil, err
+	}
+	return l.GetSslCertificate(cert.Name)
+}
+
+// DeleteSslCertificate implements LoadBalancers.
+func (l *loadBalancers) DeleteSslCertificate(name string) error {
+	return l.plan.delete(l.global(KindSslCertificate, name), func() (interface{}, error) {
+		return l.LoadBalancers.GetSslCertificate(name)
+	})
+}
+
+// GetBetaSslCertificate implements LoadBalancers.
+func (l *loadBalancers) GetBetaSslCertificate(name string) (*computebeta.SslCertificate, error) {
+	cert := &computebeta.SslCertificate{}
+	if planned, err := l.plan.get(l.global(KindSslCertificate, name), cert); planned {
+		if err != nil {
+			return nil, err
+		}
+		return cert, nil
+	}
+	return l.LoadBalancers.GetBetaSslCertificate(name)
+}
+
+// CreateBetaSslCertificate implements LoadBalancers.
+func (l *loadBalancers) CreateBetaSslCertificate(cert *computebeta.SslCertificate) (*computebeta.SslCertificate, error) {
+	if err := l.plan.put(l.global(KindSslCertificate, cert.Name), cert, func() (interface{}, error) {
+		return l.LoadBalancers.GetBetaSslCertificate(cert.Name)
+	}); err != nil {
+		return nil, err
+	}
+	return l.GetBetaSslCertificate(cert.Name)
+}
+
+// ReserveGlobalAddress implements LoadBalancers.
+func (l *loadBalancers) ReserveGlobalAddress(addr *compute.Address) error {
+	return l.plan.put(l.global(KindAddress, addr.Name), addr, func() (interface{}, error) {
+		return l.LoadBalancers.GetGlobalAddress(addr.Name)
+	})
+}
+
+// GetGlobalAddress implements LoadBalancers.
+func (l *loadBalancers) GetGlobalAddress(name string) (*compute.Address, error) {
+	addr := &compute.Address{}
+	if planned, err := l.plan.get(l.global(KindAddress, name), addr); planned {
+		if err != nil {
+			return nil, err
+		}
+		return addr, nil
+	}
+	return l.LoadBalancers.GetGlobalAddress(name)
+}
+
+// DeleteGlobalAddress implements LoadBalancers.
+func (l *loadBalancers) DeleteGlobalAddress(name string) error {
+	return l.plan.delete(l.global(KindAddress, name), func() (interface{}, error) {
+		return l.LoadBalancers.GetGlobalAddress(name)
+	})
+}
+
+// GetRegionalForwardingRule implements LoadBalancers.
+func (l *loadBalancers) GetRegionalForwardingRule(name string) (*compute.ForwardingRule, error) {
+	rule := &compute.ForwardingRule{}
+	if planned, err := l.plan.get(l.regional(KindForwardingRule, name), rule); planned {
+		if err != nil {
+			return nil, err
+		}
+		return rule, nil
+	}
+	return l.LoadBalancers.GetRegionalForwardingRule(name)
+}
+
+// CreateRegionalForwardingRule implements LoadBalancers.
+func (l *loadBalancers) CreateRegionalForwardingRule(rule *compute.ForwardingRule) error {
+	return l.plan.put(l.regional(KindForwardingRule, rule.Name), rule, func() (interface{}, error) {
+		return l.LoadBalancers.GetRegionalForwardingRule(rule.Name)
+	})
+}
+
+// DeleteRegionalForwardingRule implements LoadBalancers.
+func (l *loadBalancers) DeleteRegionalForwardingRule(name string) error {
+	return l.plan.delete(l.regional(KindForwardingRule, name), func() (interface{}, error) {
+		return l.LoadBalancers.GetRegionalForwardingRule(name)
+	})
+}
+
+// SetProxyForRegionalForwardingRule implements LoadBalancers.
+func (l *loadBalancers) SetProxyForRegionalForwardingRule(fw, proxy string) error {
+	rule, err := l.GetRegionalForwardingRule(fw)
+	if err != nil {
+		return err
+	}
+	rule.Target = proxy
+	return l.CreateRegionalForwardingRule(rule)
+}
+
+// GetRegionalURLMap implements LoadBalancers.
+func (l *loadBalancers) GetRegionalURLMap(name string) (*compute.UrlMap, error) {
+	um := &compute.UrlMap{}
+	if planned, err := l.plan.get(l.regional(KindURLMap, name), um); planned {
+		if err != nil {
+			return nil, err
+		}
+		return um, nil
+	}
+	return l.LoadBalancers.GetRegionalURLMap(name)
+}
+
+// CreateRegionalURLMap implements LoadBalancers.
+func (l *loadBalancers) CreateRegionalURLMap(urlMap *compute.UrlMap) error {
+	return l.UpdateRegionalURLMap(urlMap)
+}
+
+// UpdateRegionalURLMap implements LoadBalancers.
+func (l *loadBalancers) UpdateRegionalURLMap(urlMap *compute.UrlMap) error {
+	return l.plan.put(l.regional(KindURLMap, urlMap.Name), urlMap, func() (interface{}, error) {
+		return l.LoadBalancers.GetRegionalURLMap(urlMap.Name)
+	})
+}
+
+// DeleteRegionalURLMap implements LoadBalancers.
+func (l *loadBalancers) DeleteRegionalURLMap(name string) error {
+	return l.plan.delete(l.regional(KindURLMap, name), func() (interface{}, error) {
+		return l.LoadBalancers.GetRegionalURLMap(name)
+	})
+}
+
+// ListRegionalURLMaps implements LoadBalancers.
+func (l *loadBalancers) ListRegionalURLMaps() ([]*compute.UrlMap, error) {
+	ums, err := l.LoadBalancers.ListRegionalURLMaps()
+	if err != nil {
+		return nil, err
+	}
+	return l.mergeURLMaps(ums, *meta.RegionalKey("", l.Region()), l.GetRegionalURLMap)
+}
+
+// GetRegionalTargetHTTPProxy implements LoadBalancers.
+func (l *loadBalancers) GetRegionalTargetHTTPProxy(name string) (*compute.TargetHttpProxy, error) {
+	proxy := &compute.TargetHttpProxy{}
+	if planned, err := l.plan.get(l.regional(KindTargetHTTPProxy, name), proxy); planned {
+		if err != nil {
+			return nil, err
+		}
+		return proxy, nil
+	}
+	return l.LoadBalancers.GetRegionalTargetHTTPProxy(name)
+}
+
+// CreateRegionalTargetHTTPProxy implements LoadBalancers.
+func (l *loadBalancers) CreateRegionalTargetHTTPProxy(proxy *compute.TargetHttpProxy) error {
+	return l.plan.put(l.regional(KindTargetHTTPProxy, proxy.Name), proxy, func() (interface{}, error) {
+		return l.LoadBalancers.GetRegionalTargetHTTPProxy(proxy.Name)
+	})
+}
+
+// DeleteRegionalTargetHTTPProxy implements LoadBalancers.
+func (l *loadBalancers) DeleteRegionalTargetHTTPProxy(name string) error {
+	return l.plan.delete(l.regional(KindTargetHTTPProxy, name), func() (interface{}, error) {
+		return l.LoadBalancers.GetRegionalTargetHTTPProxy(name)
+	})
+}
+
+// SetURLMapForRegionalTargetHTTPProxy implements LoadBalancers.
+func (l *loadBalancers) SetURLMapForRegionalTargetHTTPProxy(proxy *compute.TargetHttpProxy, urlMapLink string) error {
+	current, err := l.GetRegionalTargetHTTPProxy(proxy.Name)
+	if err != nil {
+		return err
+	}
+	current.UrlMap = urlMapLink
+	return l.CreateRegionalTargetHTTPProxy(current)
+}
+
+// GetRegionalTargetHTTPSProxy implements LoadBalancers.
+func (l *loadBalancers) GetRegionalTargetHTTPSProxy(name string) (*compute.TargetHttpsProxy, error) {
+	proxy := &compute.TargetHttpsProxy{}
+	if planned, err := l.plan.get(l.regional(KindTargetHTTPSProxy, name), proxy); planned {
+		if err != nil {
+			return nil, err
+		}
+		return proxy, nil
+	}
+	return l.LoadBalancers.GetRegionalTargetHTTPSProxy(name)
+}
+
+// CreateRegionalTargetHTTPSProxy implements LoadBalancers.
+func (l *loadBalancers) CreateRegionalTargetHTTPSProxy(proxy *compute.TargetHttpsProxy) error {
+	return l.plan.put(l.regional(KindTargetHTTPSProxy, proxy.Name), proxy, func() (interface{}, error) {
+		return l.LoadBalancers.GetRegionalTargetHTTPSProxy(proxy.Name)
+	})
+}
+
+// DeleteRegionalTargetHTTPSProxy implements LoadBalancers.
+func (l *loadBalancers) DeleteRegionalTargetHTTPSProxy(name string) error {
+	return l.plan.delete(l.regional(KindTargetHTTPSProxy, name), func() (interface{}, error) {
+		return l.LoadBalancers.GetRegionalTargetHTTPSProxy(name)
+	})
+}
+
+// setRegionalTargetHTTPSProxy records the change of a field of a regional
+// TargetHttpsProxy.
+func (l *loadBalancers) setRegionalTargetHTTPSProxy(name string, set func(*compute.TargetHttpsProxy)) error {
+	current, err := l.GetRegionalTargetHTTPSProxy(name)
+	if err != nil {
+		return err
+	}
+	set(current)
+	return l.CreateRegionalTargetHTTPSProxy(current)
+}
+
+// SetURLMapForRegionalTargetHTTPSProxy implements LoadBalancers.
+func (l *loadBalancers) SetURLMapForRegionalTargetHTTPSProxy(proxy *compute.TargetHttpsProxy, urlMapLink string) error {
+	return l.setRegionalTargetHTTPSProxy(proxy.Name, func(p *compute.TargetHttpsProxy) { p.UrlMap = urlMapLink })
+}
+
+// SetSslCertificateForRegionalTargetHTTPSProxy implements LoadBalancers.
+func (l *loadBalancers) SetSslCertificateForRegionalTargetHTTPSProxy(proxy *compute.TargetHttpsProxy, sslCertURLs []string) error {
+	return l.setRegionalTargetHTTPSProxy(proxy.Name, func(p *compute.TargetHttpsProxy) { p.SslCertificates = sslCertURLs })
+}
+
+// GetRegionalSslCertificate implements LoadBalancers.
+func (l *loadBalancers) GetRegionalSslCertificate(name string) (*compute.SslCertificate, error) {
+	cert := &compute.SslCertificate{}
+	if planned, err := l.plan.get(l.regional(KindSslCertificate, name), cert); planned {
+		if err != nil {
+			return nil, err
+		}
+		return cert, nil
+	}
+	return l.LoadBalancers.GetRegionalSslCertificate(name)
+}
+
+// ListRegionalSslCertificates implements LoadBalancers.
+func (l *loadBalancers) ListRegionalSslCertificates() ([]*compute.SslCertificate, error) {
+	certs, err := l.LoadBalancers.ListRegionalSslCertificates()
+	if err != nil {
+		return nil, err
+	}
+	return l.mergeSslCertificates(certs, *meta.RegionalKey("", l.Region()), l.GetRegionalSslCertificate)
+}
+
+// CreateRegionalSslCertificate implements LoadBalancers.
+func (l *loadBalancers) CreateRegionalSslCertificate(cert *compute.SslCertificate) (*compute.SslCertificate, error) {
+	if err := l.plan.put(l.regional(KindSslCertificate, cert.Name), cert, func() (interface{}, error) {
+		return l.LoadBalancers.GetRegionalSslCertificate(cert.Name)
+	}); err != nil {
+		return nil, err
+	}
+	return l.GetRegionalSslCertificate(cert.Name)
+}
+
+// DeleteRegionalSslCertificate implements LoadBalancers.
+func (l *loadBalancers) DeleteRegionalSslCertificate(name string) error {
+	return l.plan.delete(l.regional(KindSslCertificate, name), func() (interface{}, error) {
+		return l.LoadBalancers.GetRegionalSslCertificate(name)
+	})
+}
+
+// ReserveRegionalAddress implements LoadBalancers.
+func (l *loadBalancers) ReserveRegionalAddress(addr *compute.Address) error {
+	return l.plan.put(l.regional(KindAddress, addr.Name), addr, func() (interface{}, error) {
+		return l.LoadBalancers.GetRegionalAddress(addr.Name)
+	})
+}
+
+// GetRegionalAddress implements LoadBalancers.
+func (l *loadBalancers) GetRegionalAddress(name string) (*compute.Address, error) {
+	addr := &compute.Address{}
+	if planned, err := l.plan.get(l.regional(KindAddress, name), addr); planned {
+		if err != nil {
+			return nil, err
+		}
+		return addr, nil
+	}
+	return l.LoadBalancers.GetRegionalAddress(name)
+}
+
+// DeleteRegionalAddress implements LoadBalancers.
+func (l *loadBalancers) DeleteRegionalAddress(name string) error {
+	return l.plan.delete(l.regional(KindAddress, name), func() (interface{}, error) {
+		return l.LoadBalancers.GetRegionalAddress(name)
+	})
+}
+
+// existing returns the sorted names of the changed resources which still
+// exist.
+func existing(changed map[string]bool) []string {
+	var names []string
+	for name, exists := range changed {
+		if exists {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,333 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package plan records the changes which the controller would make to GCE
+// resources, without making them. The providers of this package wrap those
+// of the controller: reads are served by the cloud, and writes are recorded
+// in a Plan whose state is returned to later reads, so that a sync against
+// them behaves as it would against the changed resources.
+package plan
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"sync"
+
+	"google.golang.org/api/googleapi"
+	"k8s.io/ingress-gce/pkg/utils"
+	"k8s.io/kubernetes/pkg/cloudprovider/providers/gce/cloud"
+	"k8s.io/kubernetes/pkg/cloudprovider/providers/gce/cloud/meta"
+)
+
+// Action is the change made to a GCE resource.
+type Action string
+
+const (
+	Create Action = "Create"
+	Update Action = "Update"
+	Delete Action = "Delete"
+)
+
+// Kinds of the GCE resources in a Plan.
+const (
+	KindForwardingRule   = "ForwardingRule"
+	KindTargetHTTPProxy  = "TargetHttpProxy"
+	KindTargetHTTPSProxy = "TargetHttpsProxy"
+	KindURLMap           = "UrlMap"
+	KindSslCertificate   = "SslCertificate"
+	KindAddress          = "Address"
+	KindBackendService   = "BackendService"
+	KindHealthCheck      = "HealthCheck"
+	KindHTTPHealthCheck  = "HttpHealthCheck"
+	KindInstanceGroup    = "InstanceGroup"
+	KindFirewall         = "Firewall"
+)
+
+// collections are the API collections of each kind, used in self links.
+var collections = map[string]string{
+	KindForwardingRule:   "forwardingRules",
+	KindTargetHTTPProxy:  "targetHttpProxies",
+	KindTargetHTTPSProxy: "targetHttpsProxies",
+	KindURLMap:           "urlMaps",
+	KindSslCertificate:   "sslCertificates",
+	KindAddress:          "addresses",
+	KindBackendService:   "backendServices",
+	KindHealthCheck:      "healthChecks",
+	KindHTTPHealthCheck:  "httpHealthChecks",
+	KindInstanceGroup:    "instanceGroups",
+	KindFirewall:         "firewalls",
+}
+
+// ignoredFields are set by GCE rather than by the controller, and are not
+// compared between the states of a resource.
+var ignoredFields = map[string]bool{
+	"creationTimestamp": true,
+	"fingerprint":       true,
+	"id":                true,
+	"kind":              true,
+	"selfLink":          true,
+}
+
+// Change is a change to a GCE resource.
+type Change struct {
+	Action Action `json:"action"`
+	Kind   string `json:"kind"`
+	// Scope is "global", or the region or zone of the resource.
+	Scope string `json:"scope"`
+	Name  string `json:"name"`
+	// Fields are the top-level fields changed by an update. An update
+	// without fields is still made, but leaves the resource unchanged.
+	Fields []string `json:"fields,omitempty"`
+}
+
+func (c Change) String() string {
+	s := fmt.Sprintf("%s %s %s/%s", c.Action, c.Kind, c.Scope, c.Name)
+	if len(c.Fields) > 0 {
+		s += fmt.Sprintf(" %v", c.Fields)
+	}
+	return s
+}
+
+// resourceKey identifies a GCE resource.
+type resourceKey struct {
+	kind string
+	key  meta.Key
+}
+
+func (k resourceKey) scope() string {
+	switch k.key.Type() {
+	case meta.Regional:
+		return k.key.Region
+	case meta.Zonal:
+		return k.key.Zone
+	}
+	return "global"
+}
+
+// resource is the state of a changed GCE resource, as JSON. A nil state is
+// a resource which does not exist.
+type resource struct {
+	before []byte
+	after  []byte
+}
+
+// Plan records changes to GCE resources.
+type Plan struct {
+	projectID string
+
+	lock      sync.Mutex
+	resources map[resourceKey]*resource
+}
+
+// NewPlan returns an empty Plan for the resources of the given project.
+func NewPlan(projectID string) *Plan {
+	return &Plan{
+		projectID: projectID,
+		resources: map[resourceKey]*resource{},
+	}
+}
+
+// Changes returns the changes recorded by the Plan, ordered by kind, scope
+// and name.
+func (p *Plan) Changes() ([]Change, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	var changes []Change
+	for k, r := range p.resources {
+		c := Change{Kind: k.kind, Scope: k.scope(), Name: k.key.Name}
+		switch {
+		case r.before == nil && r.after == nil:
+			continue
+		case r.before == nil:
+			c.Action = Create
+		case r.after == nil:
+			c.Action = Delete
+		default:
+			fields, err := changedFields(r.before, r.after)
+			if err != nil {
+				return nil, fmt.Errorf("error comparing %s %s: %v", k.kind, k.key, err)
+			}
+			c.Action = Update
+			c.Fields = fields
+		}
+		changes = append(changes, c)
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		a, b := changes[i], changes[j]
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		if a.Scope != b.Scope {
+			return a.Scope < b.Scope
+		}
+		return a.Name < b.Name
+	})
+	return changes, nil
+}
+
+// get unmarshals the recorded state of a resource into obj. It returns false
+// if the resource was not changed, in which case it must be read from the
+// cloud, and a not found error if it was deleted.
+func (p *Plan) get(k resourceKey, obj interface{}) (bool, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	r, ok := p.resources[k]
+	if !ok {
+		return false, nil
+	}
+	if r.after == nil {
+		return true, notFoundError(k)
+	}
+	return true, json.Unmarshal(r.after, obj)
+}
+
+// put records obj as the state of a resource. current returns the state of
+// the resource in the cloud, and is only called on the first change of the
+// resource.
+func (p *Plan) put(k resourceKey, obj interface{}, current func() (interface{}, error)) error {
+	after, err := p.marshal(k, obj)
+	if err != nil {
+		return err
+	}
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	r, err := p.resourceLocked(k, current)
+	if err != nil {
+		return err
+	}
+	r.after = after
+	return nil
+}
+
+// delete records the deletion of a resource. It returns a not found error if
+// the resource does not exist.
+func (p *Plan) delete(k resourceKey, current func() (interface{}, error)) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	r, err := p.resourceLocked(k, current)
+	if err != nil {
+		return err
+	}
+	if r.after == nil {
+		return notFoundError(k)
+	}
+	r.after = nil
+	return nil
+}
+
+// changed returns the names of the resources of kind in the scope of key
+// which were changed, and whether each one still exists.
+func (p *Plan) changed(kind string, key meta.Key) map[string]bool {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	names := map[string]bool{}
+	for k, r := range p.resources {
+		if k.kind == kind && k.key.Region == key.Region && k.key.Zone == key.Zone {
+			names[k.key.Name] = r.after != nil
+		}
+	}
+	return names
+}
+
+// resourceLocked returns the recorded resource, which is initialized with its
+// state in the cloud if it was not changed before. The caller must hold lock.
+func (p *Plan) resourceLocked(k resourceKey, current func() (interface{}, error)) (*resource, error) {
+	if r, ok := p.resources[k]; ok {
+		return r, nil
+	}
+	obj, err := current()
+	if err != nil && !utils.IsHTTPErrorCode(err, http.StatusNotFound) {
+		return nil, err
+	}
+	r := &resource{}
+	if err == nil && !isNil(obj) {
+		if r.before, err = json.Marshal(obj); err != nil {
+			return nil, err
+		}
+		r.after = r.before
+	}
+	p.resources[k] = r
+	return r, nil
+}
+
+// marshal returns the JSON of obj, with the self link of the resource set
+// as GCE would.
+func (p *Plan) marshal(k resourceKey, obj interface{}) ([]byte, error) {
+	b, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	fields := map[string]interface{}{}
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return nil, err
+	}
+	if _, ok := fields["selfLink"]; ok {
+		return b, nil
+	}
+	key := k.key
+	fields["selfLink"] = cloud.SelfLink(meta.VersionGA, p.projectID, collections[k.kind], &key)
+	return json.Marshal(fields)
+}
+
+// changedFields returns the top-level fields which differ between two
+// states of a resource.
+func changedFields(before, after []byte) ([]string, error) {
+	a, b := map[string]interface{}{}, map[string]interface{}{}
+	if err := json.Unmarshal(before, &a); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(after, &b); err != nil {
+		return nil, err
+	}
+	var fields []string
+	for name := range b {
+		if _, ok := a[name]; !ok {
+			a[name] = nil
+		}
+	}
+	for name, value := range a {
+		if ignoredFields[name] || reflect.DeepEqual(value, b[name]) {
+			continue
+		}
+		fields = append(fields, name)
+	}
+	sort.Strings(fields)
+	return fields, nil
+}
+
+func notFoundError(k resourceKey) error {
+	return &googleapi.Error{
+		Code:    http.StatusNotFound,
+		Message: fmt.Sprintf("%s %s is deleted by the plan", k.kind, k.key),
+	}
+}
+
+// isNil returns true if obj is nil or a nil pointer.
+func isNil(obj interface{}) bool {
+	if obj == nil {
+		return true
+	}
+	v := reflect.ValueOf(obj)
+	return v.Kind() == reflect.Ptr && v.IsNil()
+}
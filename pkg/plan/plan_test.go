@@ -0,0 +1,155 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plan
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+
+	compute "google.golang.org/api/compute/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"k8s.io/ingress-gce/pkg/firewalls"
+	"k8s.io/ingress-gce/pkg/instances"
+	"k8s.io/ingress-gce/pkg/utils"
+)
+
+var namer = utils.NewNamer("uid1", "fw1")
+
+func TestFirewallChanges(t *testing.T) {
+	fake := firewalls.NewFakeFirewallsProvider(false, false)
+	p := NewPlan("test-project")
+	pool := firewalls.NewFirewallPool(NewFirewall(fake, p), namer, []string{"1.1.1.1/11"}, []string{"30000-32767"})
+	ruleName := namer.FirewallRule()
+
+	if err := pool.Sync([]string{"node-a"}); err != nil {
+		t.Fatalf("pool.Sync() = %v", err)
+	}
+	if _, err := fake.GetFirewall(ruleName); !utils.IsHTTPErrorCode(err, http.StatusNotFound) {
+		t.Errorf("fake.GetFirewall(%q) = %v, want a 404 since the plan does not create the rule", ruleName, err)
+	}
+	// Later syncs see the planned rule.
+	if err := pool.Sync([]string{"node-a", "node-b"}); err != nil {
+		t.Fatalf("pool.Sync() = %v", err)
+	}
+	assertChanges(t, p, []Change{{Action: Create, Kind: KindFirewall, Scope: "global", Name: ruleName}})
+
+	if err := pool.GC(); err != nil {
+		t.Fatalf("pool.GC() = %v", err)
+	}
+	assertChanges(t, p, nil)
+}
+
+func TestFirewallUpdateAndDelete(t *testing.T) {
+	fake := firewalls.NewFakeFirewallsProvider(false, false)
+	pool := firewalls.NewFirewallPool(fake, namer, []string{"1.1.1.1/11"}, []string{"30000-32767"})
+	if err := pool.Sync([]string{"node-a"}); err != nil {
+		t.Fatalf("pool.Sync() = %v", err)
+	}
+	ruleName := namer.FirewallRule()
+	want, err := fake.GetFirewall(ruleName)
+	if err != nil {
+		t.Fatalf("fake.GetFirewall(%q) = %v", ruleName, err)
+	}
+
+	p := NewPlan("test-project")
+	pool = firewalls.NewFirewallPool(NewFirewall(fake, p), namer, []string{"1.1.1.1/11"}, []string{"30000-32767"})
+	if err := pool.Sync([]string{"node-a", "node-b"}); err != nil {
+		t.Fatalf("pool.Sync() = %v", err)
+	}
+	assertChanges(t, p, []Change{{Action: Update, Kind: KindFirewall, Scope: "global", Name: ruleName, Fields: []string{"targetTags"}}})
+
+	if err := pool.GC(); err != nil {
+		t.Fatalf("pool.GC() = %v", err)
+	}
+	assertChanges(t, p, []Change{{Action: Delete, Kind: KindFirewall, Scope: "global", Name: ruleName}})
+	if got, err := fake.GetFirewall(ruleName); err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("fake.GetFirewall(%q) = %+v, %v, want %+v, nil since the plan does not change the rule", ruleName, got, err, want)
+	}
+}
+
+func TestInstanceGroupChanges(t *testing.T) {
+	fake := instances.NewFakeInstanceGroups(sets.NewString("node-a", "node-b"), namer)
+	if err := fake.CreateInstanceGroup(&compute.InstanceGroup{Name: "ig1"}, "zone1"); err != nil {
+		t.Fatalf("fake.CreateInstanceGroup() = %v", err)
+	}
+	p := NewPlan("test-project")
+	igs := NewInstanceGroups(fake, p)
+
+	if err := igs.RemoveInstancesFromInstanceGroup("ig1", "zone1", igs.ToInstanceReferences("zone1", []string{"node-b"})); err != nil {
+		t.Fatalf("igs.RemoveInstancesFromInstanceGroup() = %v", err)
+	}
+	members, err := igs.ListInstancesInInstanceGroup("ig1", "zone1", allInstances)
+	if err != nil {
+		t.Fatalf("igs.ListInstancesInInstanceGroup() = %v", err)
+	}
+	if len(members) != 1 {
+		t.Errorf("igs.ListInstancesInInstanceGroup() = %d instances, want 1", len(members))
+	}
+
+	if err := igs.CreateInstanceGroup(&compute.InstanceGroup{Name: "ig2"}, "zone2"); err != nil {
+		t.Fatalf("igs.CreateInstanceGroup() = %v", err)
+	}
+	if err := igs.AddInstancesToInstanceGroup("ig2", "zone2", igs.ToInstanceReferences("zone2", []string{"node-c"})); err != nil {
+		t.Fatalf("igs.AddInstancesToInstanceGroup() = %v", err)
+	}
+	groups, err := igs.ListInstanceGroups("zone2")
+	if err != nil {
+		t.Fatalf("igs.ListInstanceGroups() = %v", err)
+	}
+	if len(groups) != 2 {
+		t.Errorf("igs.ListInstanceGroups() = %d groups, want the one in the fake and the planned one", len(groups))
+	}
+
+	assertChanges(t, p, []Change{
+		{Action: Update, Kind: KindInstanceGroup, Scope: "zone1", Name: "ig1", Fields: []string{"instances"}},
+		{Action: Create, Kind: KindInstanceGroup, Scope: "zone2", Name: "ig2"},
+	})
+	if got := fake.GetInstancesByZone(); len(got) != 0 {
+		t.Errorf("fake.GetInstancesByZone() = %v, want no instances changed by the plan", got)
+	}
+}
+
+func TestUpdateWithoutChanges(t *testing.T) {
+	fake := firewalls.NewFakeFirewallsProvider(false, false)
+	rule := &compute.Firewall{Name: "rule1", SourceRanges: []string{"1.1.1.1/11"}}
+	if err := fake.CreateFirewall(rule); err != nil {
+		t.Fatalf("fake.CreateFirewall() = %v", err)
+	}
+	p := NewPlan("test-project")
+	fw := NewFirewall(fake, p)
+	if err := fw.UpdateFirewall(rule); err != nil {
+		t.Fatalf("fw.UpdateFirewall() = %v", err)
+	}
+	assertChanges(t, p, []Change{{Action: Update, Kind: KindFirewall, Scope: "global", Name: "rule1"}})
+
+	if err := fw.DeleteFirewall("rule2"); !utils.IsHTTPErrorCode(err, http.StatusNotFound) {
+		t.Errorf("fw.DeleteFirewall(%q) = %v, want a 404", "rule2", err)
+	}
+}
+
+func assertChanges(t *testing.T, p *Plan, want []Change) {
+	t.Helper()
+	got, err := p.Changes()
+	if err != nil {
+		t.Fatalf("p.Changes() = %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("p.Changes() = %v, want %v", got, want)
+	}
+}
@@ -20,10 +20,8 @@ package sync
 type Syncer interface {
 	// Sync creates a full GCLB given some state related to an Ingress.
 	Sync(state interface{}) error
-	// GC cleans up GCLB resources for all Ingresses and can optionally
-	// use some arbitrary to help with the process.
-	// TODO(rramkumar): Do we need to rethink the strategy of GC'ing
-	// all Ingresses at once?
+	// GC cleans up GCLB resources given some state, which may cover a
+	// single Ingress or all Ingresses.
 	GC(state interface{}) error
 }
 
@@ -32,11 +30,11 @@ type Syncer interface {
 type Controller interface {
 	// SyncBackends syncs the backends for a GCLB given some existing state.
 	SyncBackends(state interface{}) error
-	// GCBackends garbage collects backends for all Ingresses given some existing state.
+	// GCBackends garbage collects backends for one or all Ingresses given some existing state.
 	GCBackends(state interface{}) error
 	// SyncLoadBalancer syncs the front-end load balancer resources for a GCLB given some existing state.
 	SyncLoadBalancer(state interface{}) error
-	// GCLoadBalancers garbage collects front-end load balancer resources for all Ingresses given some existing state.
+	// GCLoadBalancers garbage collects front-end load balancer resources for one or all Ingresses given some existing state.
 	GCLoadBalancers(state interface{}) error
 	// PostProcess allows for doing some post-processing after an Ingress is synced to a GCLB.
 	PostProcess(state interface{}) error
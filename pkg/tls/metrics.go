@@ -0,0 +1,90 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tls
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/ingress-gce/pkg/metrics"
+)
+
+var certProblems = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Namespace: metrics.GLBC_NAMESPACE,
+		Subsystem: "tls",
+		Name:      "cert_problems",
+		Help:      "Problems found validating the TLS certs of Ingresses, by secret and problem. Set to 1 while the problem persists",
+	},
+	[]string{"secret", "problem"},
+)
+
+var (
+	// problemSecrets are the secrets with cert problems reported.
+	problemSecrets     = sets.NewString()
+	problemSecretsLock sync.Mutex
+)
+
+func init() {
+	prometheus.MustRegister(certProblems)
+}
+
+// observeCertProblems sets the problems found validating the cert of the
+// secret with the given namespaced name and clears those it no longer has.
+func observeCertProblems(secret string, problems []CertProblem) {
+	problemSecretsLock.Lock()
+	defer problemSecretsLock.Unlock()
+	setCertProblems(secret, problems)
+}
+
+// ClearCertProblems clears the problems reported for the cert of the secret
+// with the given namespaced name, such as once the secret is deleted.
+func ClearCertProblems(secret string) {
+	observeCertProblems(secret, nil)
+}
+
+// PruneCertProblems clears the problems reported for the certs of all
+// secrets but the given ones, which are the secrets still referenced by
+// Ingresses.
+func PruneCertProblems(secrets sets.String) {
+	problemSecretsLock.Lock()
+	defer problemSecretsLock.Unlock()
+	for secret := range problemSecrets.Difference(secrets) {
+		setCertProblems(secret, nil)
+	}
+}
+
+// setCertProblems must be called with problemSecretsLock held.
+func setCertProblems(secret string, problems []CertProblem) {
+	if len(problems) == 0 {
+		problemSecrets.Delete(secret)
+	} else {
+		problemSecrets.Insert(secret)
+	}
+	found := map[string]bool{}
+	for _, p := range problems {
+		found[p.Problem] = true
+	}
+	for _, problem := range allProblems {
+		if found[problem] {
+			certProblems.WithLabelValues(secret, problem).Set(1)
+		} else {
+			certProblems.DeleteLabelValues(secret, problem)
+		}
+	}
+}
@@ -23,8 +23,10 @@ import (
 
 	api_v1 "k8s.io/api/core/v1"
 	extensions "k8s.io/api/extensions/v1beta1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/ingress-gce/pkg/events"
 	"k8s.io/ingress-gce/pkg/loadbalancers"
 )
 
@@ -32,21 +34,29 @@ import (
 type TlsLoader interface {
 	// Load loads the relevant TLSCerts based on ing.Spec.TLS
 	Load(ing *extensions.Ingress) ([]*loadbalancers.TLSCerts, error)
-	// Validate validates the given TLSCerts and returns an error if they are invalid.
-	Validate(certs *loadbalancers.TLSCerts) error
+	// Validate validates the given TLSCerts, which are served for the given
+	// hosts, and returns an error if they are invalid.
+	Validate(certs *loadbalancers.TLSCerts, hosts []string) error
 }
 
-// TODO: Add better cert validation.
 type noOPValidator struct{}
 
-func (n *noOPValidator) Validate(certs *loadbalancers.TLSCerts) error {
+func (n *noOPValidator) Validate(certs *loadbalancers.TLSCerts, hosts []string) error {
 	return nil
 }
 
-// TLSCertsFromSecretsLoader loads TLS certs from kubernetes secrets.
+// TLSCertsFromSecretsLoader loads TLS certs from kubernetes secrets of any
+// type. Only changes to secrets of type kubernetes.io/tls are watched, those
+// to other secrets are picked up on the periodic resync.
 type TLSCertsFromSecretsLoader struct {
-	noOPValidator
+	certValidator
 	Client kubernetes.Interface
+	// Recorders, if set, record the problems of the certs of an Ingress
+	// as warning events on the Ingress.
+	Recorders events.RecorderProducer
+	// Strict refuses to load broken certs, instead of only reporting their
+	// problems.
+	Strict bool
 }
 
 // Ensure that TLSCertsFromSecretsLoader implements TlsLoader interface.
@@ -61,8 +71,12 @@ func (t *TLSCertsFromSecretsLoader) Load(ing *extensions.Ingress) ([]*loadbalanc
 	for _, tlsSecret := range ing.Spec.TLS {
 		// TODO: Replace this for a secret watcher.
 		klog.V(3).Infof("Retrieving secret for ing %v with name %v", ing.Name, tlsSecret.SecretName)
+		secretKey := ing.Namespace + "/" + tlsSecret.SecretName
 		secret, err := t.Client.Core().Secrets(ing.Namespace).Get(tlsSecret.SecretName, meta_v1.GetOptions{})
 		if err != nil {
+			if errors.IsNotFound(err) {
+				observeCertProblems(secretKey, nil)
+			}
 			return nil, err
 		}
 		cert, ok := secret.Data[api_v1.TLSCertKey]
@@ -75,8 +89,21 @@ func (t *TLSCertsFromSecretsLoader) Load(ing *extensions.Ingress) ([]*loadbalanc
 		}
 		newCert := &loadbalancers.TLSCerts{Key: string(key), Cert: string(cert), Name: tlsSecret.SecretName,
 			CertHash: loadbalancers.GetCertHash(string(cert))}
-		if err := t.Validate(newCert); err != nil {
-			return nil, err
+		err = t.Validate(newCert, tlsSecret.Hosts)
+		validationErr, ok := err.(*ValidationError)
+		if ok {
+			observeCertProblems(secretKey, validationErr.Problems)
+		} else if err == nil {
+			observeCertProblems(secretKey, nil)
+		}
+		if err != nil {
+			if !ok || (t.Strict && validationErr.Broken()) {
+				return nil, err
+			}
+			klog.Warningf("Ingress %v/%v: %v", ing.Namespace, ing.Name, err)
+			if t.Recorders != nil {
+				t.Recorders.Recorder(ing.Namespace).Event(ing, api_v1.EventTypeWarning, "TLSCert", err.Error())
+			}
 		}
 		certs = append(certs, newCert)
 	}
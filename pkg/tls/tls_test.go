@@ -0,0 +1,366 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tls
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	api_v1 "k8s.io/api/core/v1"
+	extensions "k8s.io/api/extensions/v1beta1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/ingress-gce/pkg/loadbalancers"
+)
+
+var testNow = time.Date(2019, 6, 1, 0, 0, 0, 0, time.UTC)
+
+type testCert struct {
+	cert    *x509.Certificate
+	key     *ecdsa.PrivateKey
+	certPEM string
+	keyPEM  string
+}
+
+// newTestCert returns a cert for dnsNames which expires at notAfter, signed
+// by parent, or self-signed as a CA if parent is nil.
+func newTestCert(t *testing.T, parent *testCert, notAfter time.Time, dnsNames ...string) *testCert {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("ecdsa.GenerateKey() = %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: "test"},
+		NotBefore:    testNow.Add(-time.Hour),
+		NotAfter:     notAfter,
+		DNSNames:     dnsNames,
+	}
+	signer, signerKey := template, key
+	if parent == nil {
+		template.Subject.CommonName = "test-ca"
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+		template.KeyUsage = x509.KeyUsageCertSign
+	} else {
+		signer, signerKey = parent.cert, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, signer, &key.PublicKey, signerKey)
+	if err != nil {
+		t.Fatalf("x509.CreateCertificate() = %v", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("x509.ParseCertificate() = %v", err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("x509.MarshalECPrivateKey() = %v", err)
+	}
+	return &testCert{
+		cert:    cert,
+		key:     key,
+		certPEM: string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+		keyPEM:  string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})),
+	}
+}
+
+func TestCertValidator(t *testing.T) {
+	validUntil := testNow.Add(365 * 24 * time.Hour)
+	ca := newTestCert(t, nil, validUntil)
+	leaf := newTestCert(t, ca, validUntil, "foo.example.com", "*.bar.example.com")
+	otherLeaf := newTestCert(t, ca, validUntil, "foo.example.com")
+	expiring := newTestCert(t, ca, testNow.Add(24*time.Hour), "foo.example.com")
+	expired := newTestCert(t, ca, testNow.Add(-time.Minute), "foo.example.com")
+
+	for _, tc := range []struct {
+		desc         string
+		cert         string
+		key          string
+		hosts        []string
+		wantProblems []string
+		wantBroken   bool
+	}{
+		{
+			desc:  "valid chain",
+			cert:  leaf.certPEM + ca.certPEM,
+			key:   leaf.keyPEM,
+			hosts: []string{"foo.example.com", "a.bar.example.com", "*.bar.example.com"},
+		},
+		{
+			desc:         "not PEM",
+			cert:         "cert",
+			key:          leaf.keyPEM,
+			wantProblems: []string{problemInvalidCert},
+			wantBroken:   true,
+		},
+		{
+			desc:         "mismatched key",
+			cert:         leaf.certPEM,
+			key:          otherLeaf.keyPEM,
+			wantProblems: []string{problemKeyMismatch},
+			wantBroken:   true,
+		},
+		{
+			desc:         "chain out of order",
+			cert:         leaf.certPEM + otherLeaf.certPEM + ca.certPEM,
+			key:          leaf.keyPEM,
+			wantProblems: []string{problemChainOrder},
+			wantBroken:   true,
+		},
+		{
+			desc:         "expired",
+			cert:         expired.certPEM,
+			key:          expired.keyPEM,
+			wantProblems: []string{problemExpired},
+			wantBroken:   true,
+		},
+		{
+			desc:         "about to expire",
+			cert:         expiring.certPEM,
+			key:          expiring.keyPEM,
+			wantProblems: []string{problemExpiring},
+		},
+		{
+			desc:         "hosts not covered",
+			cert:         leaf.certPEM,
+			key:          leaf.keyPEM,
+			hosts:        []string{"foo.example.com", "baz.example.com", "*.example.com"},
+			wantProblems: []string{problemHostNotCovered},
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			v := &certValidator{now: func() time.Time { return testNow }}
+			err := v.Validate(&loadbalancers.TLSCerts{Name: "secret", Cert: tc.cert, Key: tc.key}, tc.hosts)
+			if len(tc.wantProblems) == 0 {
+				if err != nil {
+					t.Fatalf("Validate() = %v, want nil", err)
+				}
+				return
+			}
+			validationErr, ok := err.(*ValidationError)
+			if !ok {
+				t.Fatalf("Validate() = %v, want a *ValidationError", err)
+			}
+			var got []string
+			for _, p := range validationErr.Problems {
+				got = append(got, p.Problem)
+			}
+			if strings.Join(got, ",") != strings.Join(tc.wantProblems, ",") {
+				t.Errorf("Validate() = %v, want problems %v", err, tc.wantProblems)
+			}
+			if validationErr.Broken() != tc.wantBroken {
+				t.Errorf("Validate().Broken() = %v, want %v", validationErr.Broken(), tc.wantBroken)
+			}
+		})
+	}
+}
+
+type fakeRecorders struct {
+	recorder *record.FakeRecorder
+}
+
+func (f *fakeRecorders) Recorder(ns string) record.EventRecorder {
+	return f.recorder
+}
+
+func TestTLSCertsFromSecretsLoader(t *testing.T) {
+	validUntil := testNow.Add(365 * 24 * time.Hour)
+	ca := newTestCert(t, nil, validUntil)
+	leaf := newTestCert(t, ca, validUntil, "foo.example.com")
+	otherLeaf := newTestCert(t, ca, validUntil, "foo.example.com")
+
+	ing := &extensions.Ingress{
+		ObjectMeta: meta_v1.ObjectMeta{Name: "ing", Namespace: "default"},
+		Spec: extensions.IngressSpec{
+			TLS: []extensions.IngressTLS{{Hosts: []string{"bar.example.com"}, SecretName: "secret"}},
+		},
+	}
+	for _, tc := range []struct {
+		desc       string
+		key        string
+		strict     bool
+		wantErr    bool
+		wantEvents int
+	}{
+		{desc: "host not covered", key: leaf.keyPEM, wantEvents: 1},
+		{desc: "host not covered in strict mode", key: leaf.keyPEM, strict: true, wantEvents: 1},
+		{desc: "mismatched key", key: otherLeaf.keyPEM, wantEvents: 1},
+		{desc: "mismatched key in strict mode", key: otherLeaf.keyPEM, strict: true, wantErr: true},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			client := fake.NewSimpleClientset(&api_v1.Secret{
+				ObjectMeta: meta_v1.ObjectMeta{Name: "secret", Namespace: "default"},
+				Type:       api_v1.SecretTypeTLS,
+				Data: map[string][]byte{
+					api_v1.TLSCertKey:       []byte(leaf.certPEM),
+					api_v1.TLSPrivateKeyKey: []byte(tc.key),
+				},
+			})
+			recorders := &fakeRecorders{record.NewFakeRecorder(10)}
+			loader := &TLSCertsFromSecretsLoader{
+				certValidator: certValidator{now: func() time.Time { return testNow }},
+				Client:        client,
+				Recorders:     recorders,
+				Strict:        tc.strict,
+			}
+			certs, err := loader.Load(ing)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("Load() = _, %v; want error %v", err, tc.wantErr)
+			}
+			if !tc.wantErr && len(certs) != 1 {
+				t.Errorf("Load() = %v, want 1 cert", certs)
+			}
+			if got := len(recorders.recorder.Events); got != tc.wantEvents {
+				t.Errorf("Load() recorded %d events, want %d", got, tc.wantEvents)
+			}
+		})
+	}
+}
+
+func TestTLSCertsFromSecretsLoaderSecretType(t *testing.T) {
+	leaf := newTestCert(t, nil, testNow.Add(365*24*time.Hour), "foo.example.com")
+	ing := &extensions.Ingress{
+		ObjectMeta: meta_v1.ObjectMeta{Name: "ing", Namespace: "default"},
+		Spec: extensions.IngressSpec{
+			TLS: []extensions.IngressTLS{{SecretName: "secret"}},
+		},
+	}
+	// Opaque secrets are not watched, but are still loaded.
+	client := fake.NewSimpleClientset(&api_v1.Secret{
+		ObjectMeta: meta_v1.ObjectMeta{Name: "secret", Namespace: "default"},
+		Type:       api_v1.SecretTypeOpaque,
+		Data: map[string][]byte{
+			api_v1.TLSCertKey:       []byte(leaf.certPEM),
+			api_v1.TLSPrivateKeyKey: []byte(leaf.keyPEM),
+		},
+	})
+	loader := &TLSCertsFromSecretsLoader{
+		certValidator: certValidator{now: func() time.Time { return testNow }},
+		Client:        client,
+	}
+	certs, err := loader.Load(ing)
+	if err != nil {
+		t.Fatalf("Load() = _, %v; want nil", err)
+	}
+	if len(certs) != 1 || certs[0].Cert != leaf.certPEM {
+		t.Errorf("Load() = %v, want the cert of the Opaque secret", certs)
+	}
+}
+
+// certProblemSet returns true if the cert problems metric reports the given
+// problem for the secret.
+func certProblemSet(t *testing.T, secret, problem string) bool {
+	t.Helper()
+	ch := make(chan prometheus.Metric, 100)
+	certProblems.Collect(ch)
+	close(ch)
+	for m := range ch {
+		metric := &dto.Metric{}
+		if err := m.Write(metric); err != nil {
+			t.Fatalf("Write() = %v", err)
+		}
+		labels := map[string]string{}
+		for _, l := range metric.Label {
+			labels[l.GetName()] = l.GetValue()
+		}
+		if labels["secret"] == secret && labels["problem"] == problem {
+			return metric.Gauge.GetValue() == 1
+		}
+	}
+	return false
+}
+
+func TestCertProblemsMetric(t *testing.T) {
+	validUntil := testNow.Add(365 * 24 * time.Hour)
+	leaf := newTestCert(t, nil, validUntil, "foo.example.com")
+	otherLeaf := newTestCert(t, nil, validUntil, "foo.example.com")
+	ing := &extensions.Ingress{
+		ObjectMeta: meta_v1.ObjectMeta{Name: "ing", Namespace: "default"},
+		Spec: extensions.IngressSpec{
+			TLS: []extensions.IngressTLS{{SecretName: "metric-secret"}},
+		},
+	}
+	secret := &api_v1.Secret{
+		ObjectMeta: meta_v1.ObjectMeta{Name: "metric-secret", Namespace: "default"},
+		Type:       api_v1.SecretTypeTLS,
+		Data: map[string][]byte{
+			api_v1.TLSCertKey:       []byte(leaf.certPEM),
+			api_v1.TLSPrivateKeyKey: []byte(otherLeaf.keyPEM),
+		},
+	}
+	client := fake.NewSimpleClientset(secret)
+	loader := &TLSCertsFromSecretsLoader{
+		certValidator: certValidator{now: func() time.Time { return testNow }},
+		Client:        client,
+	}
+
+	for _, tc := range []struct {
+		desc    string
+		key     string
+		wantSet bool
+	}{
+		{desc: "mismatched key", key: otherLeaf.keyPEM, wantSet: true},
+		// Loading it again does not change the metric.
+		{desc: "mismatched key loaded again", key: otherLeaf.keyPEM, wantSet: true},
+		{desc: "fixed key", key: leaf.keyPEM},
+	} {
+		secret.Data[api_v1.TLSPrivateKeyKey] = []byte(tc.key)
+		if _, err := client.Core().Secrets("default").Update(secret); err != nil {
+			t.Fatalf("%s: Update() = %v", tc.desc, err)
+		}
+		if _, err := loader.Load(ing); err != nil {
+			t.Fatalf("%s: Load() = %v", tc.desc, err)
+		}
+		if got := certProblemSet(t, "default/metric-secret", problemKeyMismatch); got != tc.wantSet {
+			t.Errorf("%s: %s problem set = %v, want %v", tc.desc, problemKeyMismatch, got, tc.wantSet)
+		}
+	}
+}
+
+func TestPruneCertProblems(t *testing.T) {
+	problems := []CertProblem{{Problem: problemKeyMismatch}}
+	observeCertProblems("default/used", problems)
+	observeCertProblems("default/unused", problems)
+	observeCertProblems("default/deleted", problems)
+
+	ClearCertProblems("default/deleted")
+	PruneCertProblems(sets.NewString("default/used"))
+	for secret, wantSet := range map[string]bool{
+		"default/used":    true,
+		"default/unused":  false,
+		"default/deleted": false,
+	} {
+		if got := certProblemSet(t, secret, problemKeyMismatch); got != wantSet {
+			t.Errorf("%s problem set for %s = %v, want %v", problemKeyMismatch, secret, got, wantSet)
+		}
+	}
+	ClearCertProblems("default/used")
+}
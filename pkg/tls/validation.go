@@ -0,0 +1,189 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tls
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"strings"
+	"time"
+
+	"k8s.io/ingress-gce/pkg/loadbalancers"
+)
+
+// certExpiryWarning is how long before it expires a cert is reported as
+// about to expire.
+const certExpiryWarning = 30 * 24 * time.Hour
+
+// Problems found validating a TLS cert. They are the values of the problem
+// label of the cert problems metric.
+const (
+	problemInvalidCert    = "invalid_cert"
+	problemKeyMismatch    = "key_mismatch"
+	problemChainOrder     = "chain_order"
+	problemExpired        = "expired"
+	problemExpiring       = "expiring"
+	problemHostNotCovered = "host_not_covered"
+)
+
+var allProblems = []string{
+	problemInvalidCert,
+	problemKeyMismatch,
+	problemChainOrder,
+	problemExpired,
+	problemExpiring,
+	problemHostNotCovered,
+}
+
+// CertProblem is a problem found validating a TLS cert.
+type CertProblem struct {
+	// Problem is the kind of the problem, such as key_mismatch.
+	Problem string
+	Message string
+	// Broken is true if the load balancer cannot serve the cert correctly,
+	// as opposed to problems which only deserve a warning.
+	Broken bool
+}
+
+// ValidationError lists the problems found validating a TLS cert.
+type ValidationError struct {
+	// Name is the name of the cert, which is the name of its secret.
+	Name     string
+	Problems []CertProblem
+}
+
+func (e *ValidationError) Error() string {
+	var msgs []string
+	for _, p := range e.Problems {
+		msgs = append(msgs, p.Message)
+	}
+	return fmt.Sprintf("TLS cert %v: %v", e.Name, strings.Join(msgs, "; "))
+}
+
+// Broken returns true if any problem makes the cert unusable.
+func (e *ValidationError) Broken() bool {
+	for _, p := range e.Problems {
+		if p.Broken {
+			return true
+		}
+	}
+	return false
+}
+
+// certValidator validates the TLSCerts of an Ingress before they are
+// uploaded. Mismatched keys, chains out of order and expired certs are
+// broken, while certs about to expire and hosts not covered by the SANs of
+// a cert only deserve a warning.
+type certValidator struct {
+	// now returns the current time if set. Abstracted for testing.
+	now func() time.Time
+}
+
+// Validate returns a *ValidationError listing the problems of certs, which
+// is served for the given hosts, or nil if it has none.
+func (v *certValidator) Validate(certs *loadbalancers.TLSCerts, hosts []string) error {
+	problems := v.problems(certs, hosts)
+	if len(problems) == 0 {
+		return nil
+	}
+	return &ValidationError{Name: certs.Name, Problems: problems}
+}
+
+func (v *certValidator) problems(certs *loadbalancers.TLSCerts, hosts []string) []CertProblem {
+	chain, err := parseCertChain([]byte(certs.Cert))
+	if err != nil {
+		return []CertProblem{{Problem: problemInvalidCert, Message: err.Error(), Broken: true}}
+	}
+	var problems []CertProblem
+	if _, err := tls.X509KeyPair([]byte(certs.Cert), []byte(certs.Key)); err != nil {
+		problems = append(problems, CertProblem{Problem: problemKeyMismatch, Message: fmt.Sprintf("key does not match the cert: %v", err), Broken: true})
+	}
+	// Each cert of the chain must be signed by the one which follows it.
+	for i := 0; i+1 < len(chain); i++ {
+		if err := chain[i].CheckSignatureFrom(chain[i+1]); err != nil {
+			problems = append(problems, CertProblem{
+				Problem: problemChainOrder,
+				Message: fmt.Sprintf("cert %d of the chain (%v) is not signed by cert %d (%v): %v", i, chain[i].Subject, i+1, chain[i+1].Subject, err),
+				Broken:  true,
+			})
+			break
+		}
+	}
+
+	leaf := chain[0]
+	now := time.Now()
+	if v.now != nil {
+		now = v.now()
+	}
+	switch {
+	case now.After(leaf.NotAfter):
+		problems = append(problems, CertProblem{Problem: problemExpired, Message: fmt.Sprintf("cert expired at %v", leaf.NotAfter.UTC()), Broken: true})
+	case leaf.NotAfter.Sub(now) < certExpiryWarning:
+		problems = append(problems, CertProblem{Problem: problemExpiring, Message: fmt.Sprintf("cert expires soon, at %v", leaf.NotAfter.UTC())})
+	}
+
+	var uncovered []string
+	for _, host := range hosts {
+		if !coversHost(leaf, host) {
+			uncovered = append(uncovered, host)
+		}
+	}
+	if len(uncovered) > 0 {
+		problems = append(problems, CertProblem{Problem: problemHostNotCovered, Message: fmt.Sprintf("cert does not cover hosts %v", uncovered)})
+	}
+	return problems
+}
+
+// parseCertChain parses the PEM encoded certs of a chain, leaf first.
+func parseCertChain(data []byte) ([]*x509.Certificate, error) {
+	var chain []*x509.Certificate
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("cert %d of the chain cannot be parsed: %v", len(chain), err)
+		}
+		chain = append(chain, cert)
+	}
+	if len(chain) == 0 {
+		return nil, fmt.Errorf("no PEM encoded certs")
+	}
+	return chain, nil
+}
+
+// coversHost returns true if cert is valid for host. A wildcard host is
+// only covered by the same wildcard.
+func coversHost(cert *x509.Certificate, host string) bool {
+	if strings.HasPrefix(host, "*.") {
+		for _, name := range cert.DNSNames {
+			if strings.EqualFold(name, host) {
+				return true
+			}
+		}
+		return false
+	}
+	return cert.VerifyHostname(host) == nil
+}
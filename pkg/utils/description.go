@@ -27,6 +27,9 @@ type Description struct {
 	ServiceName string   `json:"kubernetes.io/service-name"`
 	ServicePort string   `json:"kubernetes.io/service-port"`
 	XFeatures   []string `json:"x-features,omitempty"`
+	// SignedURLKeys maps the name of each CDN signed URL key managed by the
+	// controller to a fingerprint of its value.
+	SignedURLKeys map[string]string `json:"x-signed-url-keys,omitempty"`
 }
 
 // String returns the string representation of a Description.
@@ -57,6 +57,15 @@ func TestString(t *testing.T) {
 			},
 			expectedString: `{"kubernetes.io/service-name":"my-service","kubernetes.io/service-port":"my-port","x-features":["feature1","feature2"]}`,
 		},
+		{
+			desc: "signed url keys",
+			description: Description{
+				ServiceName:   "my-service",
+				ServicePort:   "my-port",
+				SignedURLKeys: map[string]string{"key-2": "fp2", "key-1": "fp1"},
+			},
+			expectedString: `{"kubernetes.io/service-name":"my-service","kubernetes.io/service-port":"my-port","x-signed-url-keys":{"key-1":"fp1","key-2":"fp2"}}`,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -97,6 +106,11 @@ func TestDescriptionFromString(t *testing.T) {
 			backendServiceDesc: `{"kubernetes.io/service-name":"my-service","kubernetes.io/service-port":"my-port","x-features":["feature1","feature2"]}`,
 			expectedDesc:       Description{ServiceName: "my-service", ServicePort: "my-port", XFeatures: []string{"feature1", "feature2"}},
 		},
+		{
+			desc:               "signed url keys",
+			backendServiceDesc: `{"kubernetes.io/service-name":"my-service","kubernetes.io/service-port":"my-port","x-signed-url-keys":{"key-1":"fp1"}}`,
+			expectedDesc:       Description{ServiceName: "my-service", ServicePort: "my-port", SignedURLKeys: map[string]string{"key-1": "fp1"}},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -15,6 +15,7 @@ package utils
 
 import (
 	"fmt"
+	"reflect"
 	"strings"
 
 	"k8s.io/klog"
@@ -38,12 +39,67 @@ type GCEURLMap struct {
 type HostRule struct {
 	Hostname string
 	Paths    []PathRule
+	// RouteRules are matched in order, before the Paths of the host.
+	RouteRules []RouteRule
 }
 
 // PathRule encapsulates the information for a single path -> backend mapping.
 type PathRule struct {
 	Path    string
 	Backend ServicePort
+	// Rewrite, if set, rewrites requests before they are sent to the Backend.
+	Rewrite *PathRewrite
+	// Redirect, if set, redirects requests instead of sending them to the
+	// Backend.
+	Redirect *PathRedirect
+}
+
+// PathRewrite rewrites the path prefix matched by a PathRule and the host
+// of a request. Empty fields are left unchanged.
+type PathRewrite struct {
+	PathPrefix string
+	Host       string
+}
+
+// PathRedirect redirects requests matched by a PathRule. Empty fields are
+// taken from the request. At most one of Path and PathPrefix is set.
+type PathRedirect struct {
+	Host       string
+	Path       string
+	PathPrefix string
+	HTTPS      bool
+	StripQuery bool
+	// ResponseCode is the name of the redirect response code, such as FOUND.
+	ResponseCode string
+}
+
+// RouteRule encapsulates the information for a single request match ->
+// weighted backends mapping.
+type RouteRule struct {
+	Match    RouteMatch
+	Backends []WeightedBackend
+}
+
+// RouteMatch matches requests on their path prefix, headers and query
+// parameters. A request must satisfy all of them to match.
+type RouteMatch struct {
+	PathPrefix      string
+	Headers         []ValueMatch
+	QueryParameters []ValueMatch
+}
+
+// ValueMatch matches a header or query parameter by name. An empty Value
+// only requires the header or query parameter to be present.
+type ValueMatch struct {
+	Name  string
+	Value string
+}
+
+// WeightedBackend is a backend which receives a share of the requests
+// matched by a RouteRule, proportional to its Weight.
+type WeightedBackend struct {
+	Backend ServicePort
+	Weight  int64
 }
 
 // NewGCEURLMap returns an empty GCEURLMap
@@ -83,6 +139,37 @@ func EqualMapping(a, b *GCEURLMap) bool {
 			if aPath.Backend.ID != bPath.Backend.ID {
 				return false
 			}
+			if !reflect.DeepEqual(aPath.Rewrite, bPath.Rewrite) || !reflect.DeepEqual(aPath.Redirect, bPath.Redirect) {
+				return false
+			}
+		}
+
+		if !equalRouteRules(aRules.RouteRules, bRules.RouteRules) {
+			return false
+		}
+	}
+	return true
+}
+
+// equalRouteRules returns true if both lists of route rules match the same
+// requests to the same weighted ServicePortIDs.
+func equalRouteRules(a, b []RouteRule) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i, aRule := range a {
+		bRule := b[i]
+		if !reflect.DeepEqual(aRule.Match, bRule.Match) {
+			return false
+		}
+		if len(aRule.Backends) != len(bRule.Backends) {
+			return false
+		}
+		for j, aBackend := range aRule.Backends {
+			bBackend := bRule.Backends[j]
+			if aBackend.Weight != bBackend.Weight || aBackend.Backend.ID != bBackend.Backend.ID {
+				return false
+			}
 		}
 	}
 	return true
@@ -125,6 +212,62 @@ func (g *GCEURLMap) PutPathRulesForHost(hostname string, pathRules []PathRule) {
 	return
 }
 
+// PutRouteRulesForHost sets the route rules of a single hostname, adding
+// the hostname without path rules if it does not exist. As adding path
+// rules replaces the host, it must be called after PutPathRulesForHost.
+func (g *GCEURLMap) PutRouteRulesForHost(hostname string, routeRules []RouteRule) {
+	for i := range g.HostRules {
+		if g.HostRules[i].Hostname == hostname {
+			g.HostRules[i].RouteRules = routeRules
+			return
+		}
+	}
+	g.HostRules = append(g.HostRules, HostRule{Hostname: hostname, RouteRules: routeRules})
+	g.hosts[hostname] = true
+}
+
+// HasRouteRules returns true if any host of the GCEURLMap has route rules.
+func (g *GCEURLMap) HasRouteRules() bool {
+	for _, rules := range g.HostRules {
+		if len(rules.RouteRules) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// SetPathAction sets the rewrite and redirect of the given path of a host,
+// and returns false if the path does not exist. As adding path rules replaces
+// the host, it must be called after PutPathRulesForHost.
+func (g *GCEURLMap) SetPathAction(hostname, path string, rewrite *PathRewrite, redirect *PathRedirect) bool {
+	for i := range g.HostRules {
+		if g.HostRules[i].Hostname != hostname {
+			continue
+		}
+		for j := range g.HostRules[i].Paths {
+			if g.HostRules[i].Paths[j].Path == path {
+				g.HostRules[i].Paths[j].Rewrite = rewrite
+				g.HostRules[i].Paths[j].Redirect = redirect
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// HasPathActions returns true if any path of the GCEURLMap is rewritten or
+// redirected.
+func (g *GCEURLMap) HasPathActions() bool {
+	for _, rules := range g.HostRules {
+		for _, rule := range rules.Paths {
+			if rule.Rewrite != nil || rule.Redirect != nil {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // AllServicePorts return a list of all ServicePorts contained in the GCEURLMap.
 func (g *GCEURLMap) AllServicePorts() (svcPorts []ServicePort) {
 	if g.DefaultBackend != nil {
@@ -135,6 +278,11 @@ func (g *GCEURLMap) AllServicePorts() (svcPorts []ServicePort) {
 		for _, rule := range rules.Paths {
 			svcPorts = append(svcPorts, rule.Backend)
 		}
+		for _, rule := range rules.RouteRules {
+			for _, backend := range rule.Backends {
+				svcPorts = append(svcPorts, backend.Backend)
+			}
+		}
 	}
 
 	return
@@ -184,7 +332,21 @@ func (g *GCEURLMap) String() string {
 		b.WriteString(fmt.Sprintf("%v\n", hostRule.Hostname))
 		for _, rule := range hostRule.Paths {
 			b.WriteString(fmt.Sprintf("\t%v: ", rule.Path))
-			b.WriteString(fmt.Sprintf("%+v\n", rule.Backend))
+			b.WriteString(fmt.Sprintf("%+v", rule.Backend))
+			if rule.Rewrite != nil {
+				b.WriteString(fmt.Sprintf(" (rewrite %+v)", *rule.Rewrite))
+			}
+			if rule.Redirect != nil {
+				b.WriteString(fmt.Sprintf(" (redirect %+v)", *rule.Redirect))
+			}
+			b.WriteString("\n")
+		}
+		for _, rule := range hostRule.RouteRules {
+			b.WriteString(fmt.Sprintf("\t%+v: ", rule.Match))
+			for _, backend := range rule.Backends {
+				b.WriteString(fmt.Sprintf("%+v (weight %d) ", backend.Backend, backend.Weight))
+			}
+			b.WriteString("\n")
 		}
 	}
 	b.WriteString(fmt.Sprintf("Default Backend: %+v", g.DefaultBackend))
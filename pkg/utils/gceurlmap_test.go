@@ -178,6 +178,109 @@ func TestAllServicePorts(t *testing.T) {
 
 }
 
+func TestGCEURLMapRouteRules(t *testing.T) {
+	t.Parallel()
+	m := newTestMap()
+	if m.HasRouteRules() {
+		t.Errorf("HasRouteRules() = true for %v, want false", m)
+	}
+
+	routeRules := []RouteRule{
+		{
+			Match: RouteMatch{
+				PathPrefix: "/ex1",
+				Headers:    []ValueMatch{{Name: "x-canary", Value: "true"}},
+			},
+			Backends: []WeightedBackend{
+				{Backend: NewServicePortWithID("svc-A", "ns", intstr.FromInt(80)), Weight: 90},
+				{Backend: NewServicePortWithID("svc-E", "ns", intstr.FromInt(80)), Weight: 10},
+			},
+		},
+	}
+	m.PutRouteRulesForHost("example.com", routeRules)
+	m.PutRouteRulesForHost("new.com", routeRules[:1])
+	if !m.HasRouteRules() {
+		t.Errorf("HasRouteRules() = false for %v, want true", m)
+	}
+	if !m.HostExists("new.com") {
+		t.Errorf("Expected hostname new.com to exist in %v", m)
+	}
+	if _, ok := m.PathExists("example.com", "/ex1"); !ok {
+		t.Errorf("Expected path /ex1 for hostname example.com to be kept in %v", m)
+	}
+
+	wantPorts := []ServicePort{
+		NewServicePortWithID("svc-X", "ns", intstr.FromInt(80)),
+		NewServicePortWithID("svc-A", "ns", intstr.FromInt(80)),
+		NewServicePortWithID("svc-B", "ns", intstr.FromInt(80)),
+		NewServicePortWithID("svc-A", "ns", intstr.FromInt(80)),
+		NewServicePortWithID("svc-E", "ns", intstr.FromInt(80)),
+		NewServicePortWithID("svc-C", "ns", intstr.FromInt(80)),
+		NewServicePortWithID("svc-D", "ns", intstr.FromInt(80)),
+		NewServicePortWithID("svc-A", "ns", intstr.FromInt(80)),
+		NewServicePortWithID("svc-E", "ns", intstr.FromInt(80)),
+	}
+	if gotPorts := m.AllServicePorts(); !reflect.DeepEqual(gotPorts, wantPorts) {
+		t.Errorf("AllServicePorts(%v) = \n%+v\nwant\n%+v", m, gotPorts, wantPorts)
+	}
+
+	if EqualMapping(m, newTestMap()) {
+		t.Errorf("EqualMapping(%v, %v) = true, want false", m, newTestMap())
+	}
+	diffWeights := newTestMap()
+	diffWeights.PutRouteRulesForHost("example.com", []RouteRule{
+		{
+			Match:    routeRules[0].Match,
+			Backends: []WeightedBackend{routeRules[0].Backends[0], {Backend: routeRules[0].Backends[1].Backend, Weight: 20}},
+		},
+	})
+	diffWeights.PutRouteRulesForHost("new.com", routeRules)
+	if EqualMapping(m, diffWeights) {
+		t.Errorf("EqualMapping(%v, %v) = true, want false", m, diffWeights)
+	}
+	diffWeights.HostRules[0].RouteRules = routeRules
+	if !EqualMapping(m, diffWeights) {
+		t.Errorf("EqualMapping(%v, %v) = false, want true", m, diffWeights)
+	}
+}
+
+func TestGCEURLMapPathActions(t *testing.T) {
+	t.Parallel()
+	m := newTestMap()
+	if m.HasPathActions() {
+		t.Errorf("HasPathActions() = true for %v, want false", m)
+	}
+
+	rewrite := &PathRewrite{PathPrefix: "/"}
+	redirect := &PathRedirect{PathPrefix: "/v2", ResponseCode: "FOUND"}
+	if !m.SetPathAction("example.com", "/ex1", rewrite, nil) {
+		t.Errorf("SetPathAction(example.com, /ex1) = false, want true")
+	}
+	if !m.SetPathAction("foo.bar.com", "/foo2", nil, redirect) {
+		t.Errorf("SetPathAction(foo.bar.com, /foo2) = false, want true")
+	}
+	if m.SetPathAction("example.com", "/foo2", rewrite, nil) {
+		t.Errorf("SetPathAction(example.com, /foo2) = true for a missing path, want false")
+	}
+	if !m.HasPathActions() {
+		t.Errorf("HasPathActions() = false for %v, want true", m)
+	}
+
+	other := newTestMap()
+	other.SetPathAction("example.com", "/ex1", rewrite, nil)
+	if EqualMapping(m, other) {
+		t.Errorf("EqualMapping(%v, %v) = true, want false", m, other)
+	}
+	other.SetPathAction("foo.bar.com", "/foo2", nil, &PathRedirect{PathPrefix: "/v2", ResponseCode: "SEE_OTHER"})
+	if EqualMapping(m, other) {
+		t.Errorf("EqualMapping(%v, %v) = true, want false", m, other)
+	}
+	other.SetPathAction("foo.bar.com", "/foo2", nil, &PathRedirect{PathPrefix: "/v2", ResponseCode: "FOUND"})
+	if !EqualMapping(m, other) {
+		t.Errorf("EqualMapping(%v, %v) = false, want true", m, other)
+	}
+}
+
 func newTestMap() *GCEURLMap {
 	m := NewGCEURLMap()
 	b := NewServicePortWithID("svc-X", "ns", intstr.FromInt(80))
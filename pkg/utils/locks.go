@@ -0,0 +1,87 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"sync"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// Kinds of the GCE resources shared by concurrent syncs.
+const (
+	BackendServiceResource = "BackendService"
+	InstanceGroupResource  = "InstanceGroup"
+	FirewallResource       = "Firewall"
+)
+
+// ResourceKey returns the key of a GCE resource in ResourceLocks.
+func ResourceKey(kind, name string) string {
+	return kind + "/" + name
+}
+
+// ResourceLocks are mutexes of the GCE resources shared by concurrent syncs,
+// by resource key. The mutex of a key only exists while it is held or
+// waited for.
+type ResourceLocks struct {
+	lock  sync.Mutex
+	locks map[string]*resourceLock
+}
+
+type resourceLock struct {
+	sync.Mutex
+	// waiters is the number of callers holding or waiting for the lock.
+	waiters int
+}
+
+// NewResourceLocks returns ResourceLocks where no resource is locked.
+func NewResourceLocks() *ResourceLocks {
+	return &ResourceLocks{locks: map[string]*resourceLock{}}
+}
+
+// Lock locks the resources with the given keys and returns a function which
+// unlocks them. Keys are locked in sorted order, so that callers locking
+// overlapping resources cannot deadlock.
+func (l *ResourceLocks) Lock(keys ...string) (unlock func()) {
+	sorted := sets.NewString(keys...).List()
+	held := make([]*resourceLock, 0, len(sorted))
+	for _, key := range sorted {
+		l.lock.Lock()
+		rl, ok := l.locks[key]
+		if !ok {
+			rl = &resourceLock{}
+			l.locks[key] = rl
+		}
+		rl.waiters++
+		l.lock.Unlock()
+
+		rl.Lock()
+		held = append(held, rl)
+	}
+
+	return func() {
+		for i, rl := range held {
+			rl.Unlock()
+			l.lock.Lock()
+			rl.waiters--
+			if rl.waiters == 0 {
+				delete(l.locks, sorted[i])
+			}
+			l.lock.Unlock()
+		}
+	}
+}
@@ -0,0 +1,56 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestResourceLocks(t *testing.T) {
+	t.Parallel()
+	locks := NewResourceLocks()
+	a := ResourceKey(BackendServiceResource, "a")
+	b := ResourceKey(BackendServiceResource, "b")
+	ig := ResourceKey(InstanceGroupResource, "ig")
+
+	// Callers locking overlapping keys in any order neither deadlock nor
+	// hold a key at the same time.
+	var wg sync.WaitGroup
+	counts := map[string]int{}
+	for i := 0; i < 50; i++ {
+		for _, keys := range [][]string{{a, b}, {b, a, ig}, {ig, a}, {a, a}} {
+			wg.Add(1)
+			go func(keys []string) {
+				defer wg.Done()
+				unlock := locks.Lock(keys...)
+				defer unlock()
+				for _, key := range keys {
+					counts[key]++
+				}
+			}(keys)
+		}
+	}
+	wg.Wait()
+
+	if want := 50 * 5; counts[a] != want {
+		t.Errorf("counts[%q] = %d, want %d", a, counts[a], want)
+	}
+	if len(locks.locks) != 0 {
+		t.Errorf("locks.locks = %v, want empty once unlocked", locks.locks)
+	}
+}
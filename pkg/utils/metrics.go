@@ -0,0 +1,38 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/ingress-gce/pkg/metrics"
+)
+
+var queueWait = prometheus.NewHistogramVec(
+	prometheus.HistogramOpts{
+		Namespace: metrics.GLBC_NAMESPACE,
+		Subsystem: "queue",
+		Name:      "wait_duration_seconds",
+		Help:      "Time items waited in a task queue before a worker synced them, by resource",
+		// 1ms to about 9 minutes.
+		Buckets: prometheus.ExponentialBuckets(0.001, 2, 20),
+	},
+	[]string{"resource"},
+)
+
+func init() {
+	prometheus.MustRegister(queueWait)
+}
@@ -38,10 +38,19 @@ const (
 	// This prefix is used along with namespace/name of ingress in legacy cert names. New names use this prefix along
 	// with hash of the ingress/namespace name and cert contents.
 	sslCertPrefix = "ssl"
+	// This prefix is used along with the hash of the ingress/namespace name
+	// and the hash of the domains in Google-managed cert names.
+	managedSslCertPrefix = "mcrt"
 	// TODO: this should really be "fr" and "frs".
 	forwardingRulePrefix      = "fw"
 	httpsForwardingRulePrefix = "fws"
 	urlMapPrefix              = "um"
+	redirectUrlMapPrefix      = "rm"
+
+	// The IPv6 forwarding rules of a loadbalancer are created next to the
+	// IPv4 ones.
+	ipv6ForwardingRulePrefix      = "fw6"
+	ipv6HttpsForwardingRulePrefix = "fws6"
 
 	// This allows sharing of backends across loadbalancers.
 	backendPrefix = "be"
@@ -224,7 +233,7 @@ func (n *Namer) ParseName(name string) *NameComponents {
 	if len(c) >= 2 {
 		resource = c[1]
 	}
-	if resource == sslCertPrefix {
+	if resource == sslCertPrefix || resource == managedSslCertPrefix {
 		// For ssl certs, the cluster uid is followed by a hyphen and the cert hash, so one more string split needed.
 		uid = strings.Split(uid, "-")[0]
 	}
@@ -388,6 +397,22 @@ func (n *Namer) SSLCertName(lbName string, secretHash string) string {
 	return n.decorateName(fmt.Sprintf("%s-%s-%s-%s", n.prefix, sslCertPrefix, lbNameHash, secretHash))
 }
 
+// IsManagedCertUsedForLB returns true if the resourceName is a Google-managed
+// certificate of this cluster's ingress.
+func (n *Namer) IsManagedCertUsedForLB(lbName, resourceName string) bool {
+	lbNameHash := n.lbNameToHash(lbName)
+	prefix := fmt.Sprintf("%s-%s-%s", n.prefix, managedSslCertPrefix, lbNameHash)
+	return strings.HasPrefix(resourceName, prefix) && strings.HasSuffix(resourceName, n.UID())
+}
+
+// ManagedSSLCertName returns the name of the Google-managed certificate for
+// the given hash of its domains.
+func (n *Namer) ManagedSSLCertName(lbName string, domainsHash string) string {
+	lbNameHash := n.lbNameToHash(lbName)
+	// k8s-mcrt-[lbNameHash]-[domainsHash]--[clusterUID]
+	return n.decorateName(fmt.Sprintf("%s-%s-%s-%s", n.prefix, managedSslCertPrefix, lbNameHash, domainsHash))
+}
+
 // ForwardingRule returns the name of the forwarding rule prefix.
 func (n *Namer) ForwardingRule(lbName string, protocol NamerProtocol) string {
 	switch protocol {
@@ -400,11 +425,30 @@ func (n *Namer) ForwardingRule(lbName string, protocol NamerProtocol) string {
 	return "invalid"
 }
 
+// IPv6ForwardingRule returns the name of the IPv6 forwarding rule for the
+// given protocol of a load balancer.
+func (n *Namer) IPv6ForwardingRule(lbName string, protocol NamerProtocol) string {
+	switch protocol {
+	case HTTPProtocol:
+		return truncate(fmt.Sprintf("%v-%v-%v", n.prefix, ipv6ForwardingRulePrefix, lbName))
+	case HTTPSProtocol:
+		return truncate(fmt.Sprintf("%v-%v-%v", n.prefix, ipv6HttpsForwardingRulePrefix, lbName))
+	}
+	klog.Fatalf("invalid IPv6ForwardingRule protocol: %q", protocol)
+	return "invalid"
+}
+
 // UrlMap returns the name for the UrlMap for a given load balancer.
 func (n *Namer) UrlMap(lbName string) string {
 	return truncate(fmt.Sprintf("%v-%v-%v", n.prefix, urlMapPrefix, lbName))
 }
 
+// RedirectUrlMap returns the name for the UrlMap which redirects HTTP to
+// HTTPS for a given load balancer.
+func (n *Namer) RedirectUrlMap(lbName string) string {
+	return truncate(fmt.Sprintf("%v-%v-%v", n.prefix, redirectUrlMapPrefix, lbName))
+}
+
 // NamedPort returns the name for a named port.
 func (n *Namer) NamedPort(port int64) string {
 	return fmt.Sprintf("port%v", port)
@@ -107,9 +107,13 @@ func TestNamerParseName(t *testing.T) {
 		{namer.TargetProxy(lbName, HTTPSProtocol), &NameComponents{ClusterName: uid, Resource: "tps"}},
 		{namer.SSLCertName("default/my-ing", secretHash), &NameComponents{ClusterName: uid, Resource: "ssl"}},
 		{namer.SSLCertName("default/my-ing", secretHash), &NameComponents{ClusterName: uid, Resource: "ssl"}},
+		{namer.ManagedSSLCertName("default/my-ing", secretHash), &NameComponents{ClusterName: uid, Resource: "mcrt"}},
 		{namer.ForwardingRule(lbName, HTTPProtocol), &NameComponents{ClusterName: uid, Resource: "fw"}},
 		{namer.ForwardingRule(lbName, HTTPSProtocol), &NameComponents{ClusterName: uid, Resource: "fws"}},
+		{namer.IPv6ForwardingRule(lbName, HTTPProtocol), &NameComponents{ClusterName: uid, Resource: "fw6"}},
+		{namer.IPv6ForwardingRule(lbName, HTTPSProtocol), &NameComponents{ClusterName: uid, Resource: "fws6"}},
 		{namer.UrlMap(lbName), &NameComponents{ClusterName: uid, Resource: "um", LbName: "key1"}},
+		{namer.RedirectUrlMap(lbName), &NameComponents{ClusterName: uid, Resource: "rm"}},
 	} {
 		nc := namer.ParseName(tc.in)
 		if *nc != *tc.want {
@@ -136,17 +140,21 @@ func TestNameBelongsToCluster(t *testing.T) {
 			namer.TargetProxy(lbName, HTTPProtocol),
 			namer.TargetProxy(lbName, HTTPSProtocol),
 			namer.SSLCertName("default/my-ing", secretHash),
+			namer.ManagedSSLCertName("default/my-ing", secretHash),
 			namer.ForwardingRule(lbName, HTTPProtocol),
 			namer.ForwardingRule(lbName, HTTPSProtocol),
 			namer.UrlMap(lbName),
+			namer.RedirectUrlMap(lbName),
 			namer.NEG("ns", "n", int32(80)),
 			// long names that are truncated
 			namer.TargetProxy(longLBName, HTTPProtocol),
 			namer.TargetProxy(longLBName, HTTPSProtocol),
 			namer.SSLCertName(longLBName, secretHash),
+			namer.ManagedSSLCertName(longLBName, secretHash),
 			namer.ForwardingRule(longLBName, HTTPProtocol),
 			namer.ForwardingRule(longLBName, HTTPSProtocol),
 			namer.UrlMap(longLBName),
+			namer.RedirectUrlMap(longLBName),
 			namer.NEG(strings.Repeat(longKey, 3), strings.Repeat(longKey, 3), int32(88888)),
 		} {
 			if !namer.NameBelongsToCluster(tc) {
@@ -168,6 +176,7 @@ func TestNameBelongsToCluster(t *testing.T) {
 		namer.ForwardingRule(longLBName, HTTPProtocol),
 		namer.ForwardingRule(longLBName, HTTPSProtocol),
 		namer.UrlMap(longLBName),
+		namer.RedirectUrlMap(longLBName),
 	} {
 		if namer.NameBelongsToCluster(tc) {
 			t.Errorf("namer.NameBelongsToCluster(%q) = true, want false", tc)
@@ -294,13 +303,16 @@ func TestNamerLoadBalancer(t *testing.T) {
 	for _, tc := range []struct {
 		prefix string
 
-		lbName              string
-		targetHTTPProxy     string
-		targetHTTPSProxy    string
-		sslCert             string
-		forwardingRuleHTTP  string
-		forwardingRuleHTTPS string
-		urlMap              string
+		lbName                  string
+		targetHTTPProxy         string
+		targetHTTPSProxy        string
+		sslCert                 string
+		forwardingRuleHTTP      string
+		forwardingRuleHTTPS     string
+		ipv6ForwardingRuleHTTP  string
+		ipv6ForwardingRuleHTTPS string
+		urlMap                  string
+		redirectUrlMap          string
 	}{
 		{
 			"k8s",
@@ -310,7 +322,10 @@ func TestNamerLoadBalancer(t *testing.T) {
 			"k8s-ssl-%s-%s--uid1",
 			"k8s-fw-key1--uid1",
 			"k8s-fws-key1--uid1",
+			"k8s-fw6-key1--uid1",
+			"k8s-fws6-key1--uid1",
 			"k8s-um-key1--uid1",
+			"k8s-rm-key1--uid1",
 		},
 		{
 			"mci",
@@ -320,7 +335,10 @@ func TestNamerLoadBalancer(t *testing.T) {
 			"mci-ssl-%s-%s--uid1",
 			"mci-fw-key1--uid1",
 			"mci-fws-key1--uid1",
+			"mci-fw6-key1--uid1",
+			"mci-fws6-key1--uid1",
 			"mci-um-key1--uid1",
+			"mci-rm-key1--uid1",
 		},
 	} {
 		namer := NewNamerWithPrefix(tc.prefix, "uid1", "fw1")
@@ -353,10 +371,22 @@ func TestNamerLoadBalancer(t *testing.T) {
 		if name != tc.forwardingRuleHTTPS {
 			t.Errorf("namer.ForwardingRule(%q, HTTPSProtocol) = %q, want %q", lbName, name, tc.forwardingRuleHTTPS)
 		}
+		name = namer.IPv6ForwardingRule(lbName, HTTPProtocol)
+		if name != tc.ipv6ForwardingRuleHTTP {
+			t.Errorf("namer.IPv6ForwardingRule(%q, HTTPProtocol) = %q, want %q", lbName, name, tc.ipv6ForwardingRuleHTTP)
+		}
+		name = namer.IPv6ForwardingRule(lbName, HTTPSProtocol)
+		if name != tc.ipv6ForwardingRuleHTTPS {
+			t.Errorf("namer.IPv6ForwardingRule(%q, HTTPSProtocol) = %q, want %q", lbName, name, tc.ipv6ForwardingRuleHTTPS)
+		}
 		name = namer.UrlMap(lbName)
 		if name != tc.urlMap {
 			t.Errorf("namer.UrlMap(%q) = %q, want %q", lbName, name, tc.urlMap)
 		}
+		name = namer.RedirectUrlMap(lbName)
+		if name != tc.redirectUrlMap {
+			t.Errorf("namer.RedirectUrlMap(%q) = %q, want %q", lbName, name, tc.redirectUrlMap)
+		}
 	}
 }
 
@@ -403,6 +433,14 @@ func TestNamerIsCertUsedForLB(t *testing.T) {
 			if v := namer.IsCertUsedForLB(lbName, certName); !v {
 				t.Errorf("namer.IsCertUsedForLB(%q, %q) = %v, want %v", lbName, certName, v, true)
 			}
+
+			managedCertName := namer.ManagedSSLCertName(lbName, secretHash)
+			if v := namer.IsManagedCertUsedForLB(lbName, managedCertName); !v {
+				t.Errorf("namer.IsManagedCertUsedForLB(%q, %q) = %v, want %v", lbName, managedCertName, v, true)
+			}
+			if v := namer.IsCertUsedForLB(lbName, managedCertName); v {
+				t.Errorf("namer.IsCertUsedForLB(%q, %q) = %v, want %v", lbName, managedCertName, v, false)
+			}
 		})
 	}
 }
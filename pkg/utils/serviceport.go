@@ -23,7 +23,7 @@ import (
 
 	"fmt"
 	"k8s.io/ingress-gce/pkg/annotations"
-	backendconfigv1beta1 "k8s.io/ingress-gce/pkg/apis/backendconfig/v1beta1"
+	backendconfigv1 "k8s.io/ingress-gce/pkg/apis/backendconfig/v1"
 )
 
 // ServicePortID contains the Service and Port fields.
@@ -47,7 +47,10 @@ type ServicePort struct {
 	Protocol      annotations.AppProtocol
 	TargetPort    string
 	NEGEnabled    bool
-	BackendConfig *backendconfigv1beta1.BackendConfig
+	BackendConfig *backendconfigv1.BackendConfig
+	// L7ILBEnabled is true if the backend is served by an internal HTTP(S)
+	// load balancer, in which case its backend service is regional.
+	L7ILBEnabled bool
 }
 
 // GetDescription returns a Description for this ServicePort.
@@ -17,6 +17,9 @@ limitations under the License.
 package utils
 
 import (
+	"sync"
+	"time"
+
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/util/workqueue"
 	"k8s.io/klog"
@@ -35,7 +38,8 @@ type TaskQueue interface {
 
 // PeriodicTaskQueue invokes the given sync function for every work item
 // inserted. If the sync() function results in an error, the item is put on
-// the work queue after a rate-limit.
+// the work queue after a rate-limit. Items are synced by one or more
+// workers, but an item is never synced by two workers at once.
 type PeriodicTaskQueue struct {
 	// resource is used for logging to distinguish the queue being used.
 	resource string
@@ -45,18 +49,40 @@ type PeriodicTaskQueue struct {
 	queue workqueue.RateLimitingInterface
 	// sync is called for each item in the queue.
 	sync func(string) error
-	// workerDone is closed when the worker exits.
+	// numWorkers is the number of workers calling sync concurrently.
+	numWorkers int
+	// workerDone is closed when all workers exit.
 	workerDone chan struct{}
+	// enqueuedLock protects enqueued.
+	enqueuedLock sync.Mutex
+	// enqueued are the times at which the keys waiting for a worker were
+	// enqueued. Keys requeued after an error are not included, as their
+	// wait is mostly the rate-limit.
+	enqueued map[string]time.Time
 }
 
 // Run the task queue. This will block until the Shutdown() has been called.
 func (t *PeriodicTaskQueue) Run() {
+	var wg sync.WaitGroup
+	for i := 0; i < t.numWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			t.worker()
+		}()
+	}
+	wg.Wait()
+	close(t.workerDone)
+}
+
+// worker syncs the items of the queue until it is shut down.
+func (t *PeriodicTaskQueue) worker() {
 	for {
 		key, quit := t.queue.Get()
 		if quit {
-			close(t.workerDone)
 			return
 		}
+		t.observeWait(key.(string))
 		klog.V(4).Infof("Syncing %v (%v)", key, t.resource)
 		if err := t.sync(key.(string)); err != nil {
 			klog.Errorf("Requeuing %q due to error: %v (%v)", key, err, t.resource)
@@ -78,11 +104,27 @@ func (t *PeriodicTaskQueue) Enqueue(objs ...interface{}) {
 			return
 		}
 		klog.V(4).Infof("Enqueue key=%q (%v)", key, t.resource)
+		t.enqueuedLock.Lock()
+		if _, ok := t.enqueued[key]; !ok {
+			t.enqueued[key] = time.Now()
+		}
+		t.enqueuedLock.Unlock()
 		t.queue.Add(key)
 	}
 }
 
-// Shutdown shuts down the work queue and waits for the worker to ACK
+// observeWait records how long a key waited in the queue for a worker.
+func (t *PeriodicTaskQueue) observeWait(key string) {
+	t.enqueuedLock.Lock()
+	enqueued, ok := t.enqueued[key]
+	delete(t.enqueued, key)
+	t.enqueuedLock.Unlock()
+	if ok {
+		queueWait.WithLabelValues(t.resource).Observe(time.Since(enqueued).Seconds())
+	}
+}
+
+// Shutdown shuts down the work queue and waits for the workers to ACK
 func (t *PeriodicTaskQueue) Shutdown() {
 	klog.V(2).Infof("Shutdown")
 	t.queue.ShutDown()
@@ -95,6 +137,16 @@ func NewPeriodicTaskQueue(name, resource string, syncFn func(string) error) *Per
 	return NewPeriodicTaskQueueWithLimiter(name, resource, syncFn, rl)
 }
 
+// NewPeriodicTaskQueueWithMultipleWorkers creates a new task queue with the
+// default rate limiter, whose items are synced by numWorkers workers.
+func NewPeriodicTaskQueueWithMultipleWorkers(name, resource string, numWorkers int, syncFn func(string) error) *PeriodicTaskQueue {
+	t := NewPeriodicTaskQueue(name, resource, syncFn)
+	if numWorkers > 1 {
+		t.numWorkers = numWorkers
+	}
+	return t
+}
+
 // NewPeriodicTaskQueueWithLimiter creates a new task queue with the given sync function
 // and rate limiter. The sync function is called for every element inserted into the queue.
 func NewPeriodicTaskQueueWithLimiter(name, resource string, syncFn func(string) error, rl workqueue.RateLimiter) *PeriodicTaskQueue {
@@ -110,6 +162,8 @@ func NewPeriodicTaskQueueWithLimiter(name, resource string, syncFn func(string)
 		keyFunc:    KeyFunc,
 		queue:      queue,
 		sync:       syncFn,
+		numWorkers: 1,
 		workerDone: make(chan struct{}),
+		enqueued:   map[string]time.Time{},
 	}
 }
@@ -20,7 +20,10 @@ import (
 	"errors"
 	"reflect"
 	"testing"
+	"time"
 
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/tools/cache"
 )
 
@@ -67,3 +70,38 @@ func TestPeriodicTaskQueue(t *testing.T) {
 		t.Errorf("task queue synced %+v, want %+v", synced, expected)
 	}
 }
+
+func TestPeriodicTaskQueueWithMultipleWorkers(t *testing.T) {
+	t.Parallel()
+	keys := []string{"a", "b", "c"}
+	started := make(chan string, len(keys))
+	release := make(chan struct{})
+	sync := func(key string) error {
+		started <- key
+		<-release
+		return nil
+	}
+	tq := NewPeriodicTaskQueueWithMultipleWorkers("", "test", len(keys), sync)
+
+	go tq.Run()
+	for _, key := range keys {
+		tq.Enqueue(cache.ExplicitKey(key))
+	}
+
+	// Each key is synced by a worker of its own while the others are blocked.
+	synced := sets.NewString()
+	for range keys {
+		select {
+		case key := <-started:
+			synced.Insert(key)
+		case <-time.After(wait.ForeverTestTimeout):
+			t.Fatalf("Timed out waiting for concurrent syncs, synced %v", synced.List())
+		}
+	}
+	close(release)
+	tq.Shutdown()
+
+	if !synced.Equal(sets.NewString(keys...)) {
+		t.Errorf("task queue synced %v, want %v", synced.List(), keys)
+	}
+}
@@ -31,6 +31,7 @@ import (
 	api_v1 "k8s.io/api/core/v1"
 	extensions "k8s.io/api/extensions/v1beta1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
 	listers "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/ingress-gce/pkg/annotations"
@@ -264,6 +265,9 @@ func IGLinks(igs []*compute.InstanceGroup) (igLinks []string) {
 // IsGCEIngress returns true if the Ingress matches the class managed by this
 // controller.
 func IsGCEIngress(ing *extensions.Ingress) bool {
+	if IsGCEL7ILBIngress(ing) {
+		return true
+	}
 	class := annotations.FromIngress(ing).IngressClass()
 	if flags.F.IngressClass == "" {
 		return class == "" || class == annotations.GceIngressClass
@@ -271,6 +275,13 @@ func IsGCEIngress(ing *extensions.Ingress) bool {
 	return class == flags.F.IngressClass
 }
 
+// IsGCEL7ILBIngress returns true if the given Ingress has ingress.class
+// annotation set to "gce-internal" and internal load balancers are enabled.
+func IsGCEL7ILBIngress(ing *extensions.Ingress) bool {
+	class := annotations.FromIngress(ing).IngressClass()
+	return flags.F.EnableL7Ilb && class == annotations.GceL7ILBIngressClass
+}
+
 // IsGCEMultiClusterIngress returns true if the given Ingress has
 // ingress.class annotation set to "gce-multi-cluster".
 func IsGCEMultiClusterIngress(ing *extensions.Ingress) bool {
@@ -350,6 +361,34 @@ func NewNamespaceIndexer() cache.Indexers {
 	return cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}
 }
 
+// TLSSecretIndex is the name of the index of Ingresses by the namespaced
+// names of the TLS secrets they reference.
+const TLSSecretIndex = "tlsSecret"
+
+// NewIngressIndexer returns a new Indexer for use by Ingress
+// SharedIndexInformers, which also indexes Ingresses by TLSSecretIndex.
+func NewIngressIndexer() cache.Indexers {
+	indexers := NewNamespaceIndexer()
+	indexers[TLSSecretIndex] = tlsSecretIndexFunc
+	return indexers
+}
+
+// tlsSecretIndexFunc returns the namespace/name keys of the TLS secrets of
+// an Ingress.
+func tlsSecretIndexFunc(obj interface{}) ([]string, error) {
+	ing, ok := obj.(*extensions.Ingress)
+	if !ok {
+		return nil, fmt.Errorf("expected an Ingress, got %T", obj)
+	}
+	keys := sets.NewString()
+	for _, tls := range ing.Spec.TLS {
+		if tls.SecretName != "" {
+			keys.Insert(fmt.Sprintf("%v/%v", ing.Namespace, tls.SecretName))
+		}
+	}
+	return keys.List(), nil
+}
+
 // JoinErrs returns an aggregated error based on the passed in list of errors.
 func JoinErrs(errs []error) error {
 	var errStrs []string
@@ -20,8 +20,12 @@ import (
 	"testing"
 
 	extensions "k8s.io/api/extensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"k8s.io/ingress-gce/pkg/annotations"
+	"k8s.io/ingress-gce/pkg/flags"
 )
 
 func TestTrimFieldsEvenly(t *testing.T) {
@@ -452,6 +456,36 @@ func TestTraverseIngressBackends(t *testing.T) {
 	}
 }
 
+func TestIsGCEIngress(t *testing.T) {
+	defer func(enabled bool) { flags.F.EnableL7Ilb = enabled }(flags.F.EnableL7Ilb)
+
+	for _, tc := range []struct {
+		class       string
+		enableL7Ilb bool
+		want        bool
+		wantL7ILB   bool
+	}{
+		{class: "", want: true},
+		{class: annotations.GceIngressClass, want: true},
+		{class: annotations.GceMultiIngressClass},
+		{class: annotations.GceL7ILBIngressClass},
+		{class: annotations.GceL7ILBIngressClass, enableL7Ilb: true, want: true, wantL7ILB: true},
+		{class: annotations.GceIngressClass, enableL7Ilb: true, want: true},
+		{class: "nginx", enableL7Ilb: true},
+	} {
+		flags.F.EnableL7Ilb = tc.enableL7Ilb
+		ing := &extensions.Ingress{ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{annotations.IngressClassKey: tc.class},
+		}}
+		if got := IsGCEIngress(ing); got != tc.want {
+			t.Errorf("IsGCEIngress() with class %q and EnableL7Ilb %v = %v, want %v", tc.class, tc.enableL7Ilb, got, tc.want)
+		}
+		if got := IsGCEL7ILBIngress(ing); got != tc.wantL7ILB {
+			t.Errorf("IsGCEL7ILBIngress() with class %q and EnableL7Ilb %v = %v, want %v", tc.class, tc.enableL7Ilb, got, tc.wantL7ILB)
+		}
+	}
+}
+
 func getTestIngress() {
 	return
 }
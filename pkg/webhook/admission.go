@@ -0,0 +1,112 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/klog"
+)
+
+// maxRequestBytes bounds the size of an AdmissionReview read from a request.
+const maxRequestBytes = 3 * 1024 * 1024
+
+// AdmitFunc decides whether the object of an AdmissionRequest is admitted.
+type AdmitFunc func(*admissionv1beta1.AdmissionRequest) *admissionv1beta1.AdmissionResponse
+
+// admissionHandler returns an http.HandlerFunc which decodes the
+// AdmissionReview of a request, passes it to admit and writes back the
+// AdmissionReview holding its response.
+func admissionHandler(admit AdmitFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, fmt.Sprintf("unsupported method %v", r.Method), http.StatusMethodNotAllowed)
+			return
+		}
+		if contentType := r.Header.Get("Content-Type"); contentType != "application/json" {
+			http.Error(w, fmt.Sprintf("unsupported content type %q, expected application/json", contentType), http.StatusUnsupportedMediaType)
+			return
+		}
+		body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, maxRequestBytes))
+		if err != nil {
+			http.Error(w, fmt.Sprintf("error reading request body: %v", err), http.StatusBadRequest)
+			return
+		}
+
+		review := &admissionv1beta1.AdmissionReview{}
+		if err := json.Unmarshal(body, review); err != nil {
+			http.Error(w, fmt.Sprintf("error decoding AdmissionReview: %v", err), http.StatusBadRequest)
+			return
+		}
+		if review.Request == nil {
+			http.Error(w, "AdmissionReview has no request", http.StatusBadRequest)
+			return
+		}
+
+		response := admit(review.Request)
+		response.UID = review.Request.UID
+		review.Request = nil
+		review.Response = response
+
+		data, err := json.Marshal(review)
+		if err != nil {
+			klog.Errorf("Error encoding AdmissionReview: %v", err)
+			http.Error(w, fmt.Sprintf("error encoding AdmissionReview: %v", err), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(data)
+	}
+}
+
+// allowed returns a response admitting an object.
+func allowed() *admissionv1beta1.AdmissionResponse {
+	return &admissionv1beta1.AdmissionResponse{Allowed: true}
+}
+
+// invalid returns a response rejecting an object because of errs. The
+// errors are returned as the causes of an Invalid status, so that clients
+// report each of them against the field it concerns.
+func invalid(kind schema.GroupKind, name string, errs field.ErrorList) *admissionv1beta1.AdmissionResponse {
+	status := errors.NewInvalid(kind, name, errs).Status()
+	return &admissionv1beta1.AdmissionResponse{
+		Allowed: false,
+		Result:  &status,
+	}
+}
+
+// badRequest returns a response rejecting a request which could not be
+// processed.
+func badRequest(err error) *admissionv1beta1.AdmissionResponse {
+	return &admissionv1beta1.AdmissionResponse{
+		Allowed: false,
+		Result: &metav1.Status{
+			Status:  metav1.StatusFailure,
+			Message: err.Error(),
+			Reason:  metav1.StatusReasonBadRequest,
+			Code:    http.StatusBadRequest,
+		},
+	}
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+)
+
+func TestAdmissionHandler(t *testing.T) {
+	admit := func(req *admissionv1beta1.AdmissionRequest) *admissionv1beta1.AdmissionResponse {
+		return allowed()
+	}
+	handler := admissionHandler(admit)
+
+	testCases := []struct {
+		desc        string
+		method      string
+		contentType string
+		body        string
+		wantCode    int
+		wantAllowed bool
+	}{
+		{
+			desc:        "allowed",
+			method:      "POST",
+			contentType: "application/json",
+			body:        `{"apiVersion": "admission.k8s.io/v1beta1", "kind": "AdmissionReview", "request": {"uid": "123"}}`,
+			wantCode:    http.StatusOK,
+			wantAllowed: true,
+		},
+		{
+			desc:        "wrong method",
+			method:      "GET",
+			contentType: "application/json",
+			wantCode:    http.StatusMethodNotAllowed,
+		},
+		{
+			desc:        "wrong content type",
+			method:      "POST",
+			contentType: "text/plain",
+			body:        `{}`,
+			wantCode:    http.StatusUnsupportedMediaType,
+		},
+		{
+			desc:        "malformed review",
+			method:      "POST",
+			contentType: "application/json",
+			body:        `{"request":`,
+			wantCode:    http.StatusBadRequest,
+		},
+		{
+			desc:        "review without request",
+			method:      "POST",
+			contentType: "application/json",
+			body:        `{"apiVersion": "admission.k8s.io/v1beta1", "kind": "AdmissionReview"}`,
+			wantCode:    http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			req := httptest.NewRequest(tc.method, BackendConfigPath, bytes.NewBufferString(tc.body))
+			req.Header.Set("Content-Type", tc.contentType)
+			w := httptest.NewRecorder()
+			handler(w, req)
+
+			if w.Code != tc.wantCode {
+				t.Fatalf("Got code %d, want %d (body: %q)", w.Code, tc.wantCode, w.Body.String())
+			}
+			if tc.wantCode != http.StatusOK {
+				return
+			}
+			review := &admissionv1beta1.AdmissionReview{}
+			if err := json.Unmarshal(w.Body.Bytes(), review); err != nil {
+				t.Fatalf("Error decoding response %q: %v", w.Body.String(), err)
+			}
+			if review.Request != nil {
+				t.Errorf("Response review has request %+v, want nil", review.Request)
+			}
+			if review.Response == nil || review.Response.UID != "123" || review.Response.Allowed != tc.wantAllowed {
+				t.Errorf("Got response %+v, want UID 123 and allowed = %v", review.Response, tc.wantAllowed)
+			}
+		})
+	}
+}
@@ -0,0 +1,144 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	apiextensionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog"
+
+	apisbackendconfig "k8s.io/ingress-gce/pkg/apis/backendconfig"
+	backendconfigv1 "k8s.io/ingress-gce/pkg/apis/backendconfig/v1"
+	"k8s.io/ingress-gce/pkg/backendconfig"
+)
+
+const (
+	// BackendConfigPath is the path on which BackendConfigs are validated.
+	BackendConfigPath = "/validate/backendconfig"
+	// degradedAnnotation is the audit annotation recorded when a BackendConfig
+	// was admitted without checking the Secrets it references.
+	degradedAnnotation = "degraded"
+)
+
+// BackendConfigValidator validates BackendConfigs on admission, so that
+// invalid configurations are rejected when they are applied rather than
+// when an Ingress using them is synced.
+type BackendConfigValidator struct {
+	kubeClient kubernetes.Interface
+	schema     *apiextensionsv1beta1.JSONSchemaProps
+}
+
+// NewBackendConfigValidator returns a BackendConfigValidator which reads
+// referenced Secrets with kubeClient.
+func NewBackendConfigValidator(kubeClient kubernetes.Interface) (*BackendConfigValidator, error) {
+	validation, err := backendconfig.CRDMeta().Validation()
+	if err != nil {
+		return nil, fmt.Errorf("error building BackendConfig schema: %v", err)
+	}
+	v := &BackendConfigValidator{kubeClient: kubeClient}
+	if validation != nil {
+		v.schema = validation.OpenAPIV3Schema
+	}
+	return v, nil
+}
+
+// Admit validates the BackendConfig of an admission request, first against
+// the BackendConfig schema and then with backendconfig.ValidateFields.
+// If a referenced Secret cannot be retrieved, e.g. because the API server
+// is unreachable, the BackendConfig is validated without the checks which
+// need Secrets and the gap is recorded rather than the request rejected.
+func (v *BackendConfigValidator) Admit(req *admissionv1beta1.AdmissionRequest) *admissionv1beta1.AdmissionResponse {
+	if req.Kind.Group != apisbackendconfig.GroupName || req.Kind.Kind != "BackendConfig" {
+		return badRequest(fmt.Errorf("unexpected kind %v, expected BackendConfig", req.Kind))
+	}
+	// Deletions and status updates by the controller are never rejected.
+	if req.Operation == admissionv1beta1.Delete || req.SubResource != "" {
+		return allowed()
+	}
+	kind := backendconfigv1.SchemeGroupVersion.WithKind("BackendConfig").GroupKind()
+
+	untyped, err := decodeUntyped(req.Object.Raw)
+	if err != nil {
+		return badRequest(fmt.Errorf("error decoding BackendConfig: %v", err))
+	}
+	// Updates which leave the spec untouched, such as the rewrites of a
+	// storage migration, are admitted even if the BackendConfig predates
+	// this validation.
+	if req.Operation == admissionv1beta1.Update && specUnchanged(untyped, req.OldObject.Raw) {
+		return allowed()
+	}
+	if errs := validateSchema(untyped, v.schema, nil); len(errs) > 0 {
+		return invalid(kind, req.Name, errs)
+	}
+
+	beConfig := &backendconfigv1.BackendConfig{}
+	if err := json.Unmarshal(req.Object.Raw, beConfig); err != nil {
+		return badRequest(fmt.Errorf("error decoding BackendConfig: %v", err))
+	}
+	// Objects being created may not carry their namespace yet.
+	if beConfig.Namespace == "" {
+		beConfig.Namespace = req.Namespace
+	}
+
+	// ValidateFields fills in values read from Secrets, so work on copies.
+	errs := backendconfig.ValidateFields(v.kubeClient, beConfig.DeepCopy())
+	var degraded field.ErrorList
+	for _, err := range errs {
+		if err.Type == field.ErrorTypeInternal {
+			degraded = append(degraded, err)
+		}
+	}
+	if len(degraded) > 0 {
+		klog.Warningf("Validating BackendConfig %s/%s without Secrets: %v", beConfig.Namespace, beConfig.Name, degraded.ToAggregate())
+		errs = backendconfig.ValidateFields(nil, beConfig.DeepCopy())
+	}
+	if len(errs) > 0 {
+		return invalid(kind, req.Name, errs)
+	}
+
+	response := allowed()
+	if len(degraded) > 0 {
+		response.AuditAnnotations = map[string]string{
+			degradedAnnotation: fmt.Sprintf("referenced Secrets were not checked: %v", degraded.ToAggregate()),
+		}
+	}
+	return response
+}
+
+// specUnchanged returns true if the spec of obj, decoded by decodeUntyped,
+// is the same as the one of the JSON encoded oldObj.
+func specUnchanged(obj interface{}, oldObj []byte) bool {
+	old, err := decodeUntyped(oldObj)
+	if err != nil {
+		return false
+	}
+	objMap, ok := obj.(map[string]interface{})
+	if !ok {
+		return false
+	}
+	oldMap, ok := old.(map[string]interface{})
+	if !ok {
+		return false
+	}
+	return reflect.DeepEqual(objMap["spec"], oldMap["spec"])
+}
@@ -0,0 +1,220 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	core "k8s.io/client-go/testing"
+)
+
+var backendConfigKind = metav1.GroupVersionKind{Group: "cloud.google.com", Version: "v1beta1", Kind: "BackendConfig"}
+
+func backendConfigRequest(spec string) *admissionv1beta1.AdmissionRequest {
+	return &admissionv1beta1.AdmissionRequest{
+		UID:       "uid",
+		Kind:      backendConfigKind,
+		Name:      "config",
+		Namespace: "default",
+		Operation: admissionv1beta1.Create,
+		Object: runtime.RawExtension{
+			Raw: []byte(fmt.Sprintf(`{"apiVersion": "cloud.google.com/v1beta1", "kind": "BackendConfig", "metadata": {"name": "config"}, "spec": %s}`, spec)),
+		},
+	}
+}
+
+func TestBackendConfigValidatorAdmit(t *testing.T) {
+	iapSpec := `{"iap": {"enabled": true, "oauthclientCredentials": {"secretName": "iap"}}}`
+	iapSecret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "iap", Namespace: "default"},
+		Data: map[string][]byte{
+			"client_id":     []byte("id"),
+			"client_secret": []byte("secret"),
+		},
+	}
+	unreachable := func(kubeClient *fake.Clientset) {
+		kubeClient.PrependReactor("get", "secrets", func(action core.Action) (bool, runtime.Object, error) {
+			return true, nil, fmt.Errorf("connection refused")
+		})
+	}
+
+	testCases := []struct {
+		desc          string
+		init          func(kubeClient *fake.Clientset)
+		req           *admissionv1beta1.AdmissionRequest
+		wantAllowed   bool
+		wantCauses    []string
+		wantDegraded  bool
+		wantErrorCode int32
+	}{
+		{
+			desc:        "valid config",
+			req:         backendConfigRequest(`{"timeoutSec": 40, "sessionAffinity": {"affinityType": "CLIENT_IP"}}`),
+			wantAllowed: true,
+		},
+		{
+			desc:        "unknown field",
+			req:         backendConfigRequest(`{"sessionAfinity": {"affinityType": "CLIENT_IP"}}`),
+			wantAllowed: false,
+			wantCauses:  []string{"spec.sessionAfinity"},
+		},
+		{
+			desc:        "field of the wrong type",
+			req:         backendConfigRequest(`{"timeoutSec": "40"}`),
+			wantAllowed: false,
+			wantCauses:  []string{"spec.timeoutSec"},
+		},
+		{
+			desc:        "invalid fields",
+			req:         backendConfigRequest(`{"sessionAffinity": {"affinityType": "WRONG"}, "localityLbPolicy": "FASTEST"}`),
+			wantAllowed: false,
+			wantCauses:  []string{"spec.sessionAffinity", "spec.localityLbPolicy"},
+		},
+		{
+			desc: "referenced secret exists",
+			init: func(kubeClient *fake.Clientset) {
+				kubeClient.Core().Secrets("default").Create(iapSecret)
+			},
+			req:         backendConfigRequest(iapSpec),
+			wantAllowed: true,
+		},
+		{
+			desc:        "referenced secret is missing",
+			req:         backendConfigRequest(iapSpec),
+			wantAllowed: false,
+			wantCauses:  []string{"spec.iap"},
+		},
+		{
+			desc:         "secrets are unreachable",
+			init:         unreachable,
+			req:          backendConfigRequest(iapSpec),
+			wantAllowed:  true,
+			wantDegraded: true,
+		},
+		{
+			desc:        "secrets are unreachable but other checks fail",
+			init:        unreachable,
+			req:         backendConfigRequest(`{"iap": {"enabled": true, "oauthclientCredentials": {"secretName": "iap"}}, "cdn": {"enabled": true}}`),
+			wantAllowed: false,
+			wantCauses:  []string{"spec.iap"},
+		},
+		{
+			desc: "v1 config",
+			req: func() *admissionv1beta1.AdmissionRequest {
+				req := backendConfigRequest(`{"localityLbPolicy": "FASTEST"}`)
+				req.Kind.Version = "v1"
+				return req
+			}(),
+			wantAllowed: false,
+			wantCauses:  []string{"spec.localityLbPolicy"},
+		},
+		{
+			desc: "update leaving an invalid spec untouched",
+			req: func() *admissionv1beta1.AdmissionRequest {
+				req := backendConfigRequest(`{"localityLbPolicy": "FASTEST"}`)
+				req.Operation = admissionv1beta1.Update
+				req.OldObject = backendConfigRequest(`{"localityLbPolicy": "FASTEST"}`).Object
+				return req
+			}(),
+			wantAllowed: true,
+		},
+		{
+			desc: "update changing the spec",
+			req: func() *admissionv1beta1.AdmissionRequest {
+				req := backendConfigRequest(`{"localityLbPolicy": "FASTEST"}`)
+				req.Operation = admissionv1beta1.Update
+				req.OldObject = backendConfigRequest(`{"localityLbPolicy": "MAGLEV"}`).Object
+				return req
+			}(),
+			wantAllowed: false,
+			wantCauses:  []string{"spec.localityLbPolicy"},
+		},
+		{
+			desc: "deletion",
+			req: func() *admissionv1beta1.AdmissionRequest {
+				req := backendConfigRequest(`{"localityLbPolicy": "FASTEST"}`)
+				req.Operation = admissionv1beta1.Delete
+				return req
+			}(),
+			wantAllowed: true,
+		},
+		{
+			desc: "unexpected kind",
+			req: func() *admissionv1beta1.AdmissionRequest {
+				req := backendConfigRequest(`{}`)
+				req.Kind = metav1.GroupVersionKind{Version: "v1", Kind: "Service"}
+				return req
+			}(),
+			wantAllowed:   false,
+			wantErrorCode: http.StatusBadRequest,
+		},
+		{
+			desc: "malformed object",
+			req: func() *admissionv1beta1.AdmissionRequest {
+				req := backendConfigRequest(`{}`)
+				req.Object.Raw = []byte(`{"spec":`)
+				return req
+			}(),
+			wantAllowed:   false,
+			wantErrorCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			kubeClient := fake.NewSimpleClientset()
+			if tc.init != nil {
+				tc.init(kubeClient)
+			}
+			validator, err := NewBackendConfigValidator(kubeClient)
+			if err != nil {
+				t.Fatalf("NewBackendConfigValidator() = %v", err)
+			}
+
+			resp := validator.Admit(tc.req)
+			if resp.Allowed != tc.wantAllowed {
+				t.Fatalf("Admit().Allowed = %v, want %v (result: %+v)", resp.Allowed, tc.wantAllowed, resp.Result)
+			}
+			if _, degraded := resp.AuditAnnotations[degradedAnnotation]; degraded != tc.wantDegraded {
+				t.Errorf("Admit().AuditAnnotations = %v, want degraded = %v", resp.AuditAnnotations, tc.wantDegraded)
+			}
+			if tc.wantErrorCode != 0 && (resp.Result == nil || resp.Result.Code != tc.wantErrorCode) {
+				t.Errorf("Admit().Result = %+v, want code %d", resp.Result, tc.wantErrorCode)
+			}
+			if len(tc.wantCauses) == 0 {
+				return
+			}
+			if resp.Result == nil || resp.Result.Details == nil {
+				t.Fatalf("Admit().Result = %+v, want causes for %v", resp.Result, tc.wantCauses)
+			}
+			var gotCauses []string
+			for _, cause := range resp.Result.Details.Causes {
+				gotCauses = append(gotCauses, cause.Field)
+			}
+			if fmt.Sprint(gotCauses) != fmt.Sprint(tc.wantCauses) {
+				t.Errorf("Admit() causes = %v, want %v", gotCauses, tc.wantCauses)
+			}
+		})
+	}
+}
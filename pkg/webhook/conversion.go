@@ -0,0 +1,103 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	apiextensionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/klog"
+)
+
+// BackendConfigConversionPath is the path on which BackendConfigs are
+// converted between versions.
+const BackendConfigConversionPath = "/convert/backendconfig"
+
+// ConvertFunc converts a JSON encoded object to desiredAPIVersion.
+type ConvertFunc func(data []byte, desiredAPIVersion string) ([]byte, error)
+
+// conversionHandler returns an http.HandlerFunc which decodes the
+// ConversionReview of a request, converts each of its objects with convert
+// and writes back the ConversionReview holding the converted objects.
+func conversionHandler(convert ConvertFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, fmt.Sprintf("unsupported method %v", r.Method), http.StatusMethodNotAllowed)
+			return
+		}
+		if contentType := r.Header.Get("Content-Type"); contentType != "application/json" {
+			http.Error(w, fmt.Sprintf("unsupported content type %q, expected application/json", contentType), http.StatusUnsupportedMediaType)
+			return
+		}
+		body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, maxRequestBytes))
+		if err != nil {
+			http.Error(w, fmt.Sprintf("error reading request body: %v", err), http.StatusBadRequest)
+			return
+		}
+
+		review := &apiextensionsv1beta1.ConversionReview{}
+		if err := json.Unmarshal(body, review); err != nil {
+			http.Error(w, fmt.Sprintf("error decoding ConversionReview: %v", err), http.StatusBadRequest)
+			return
+		}
+		if review.Request == nil {
+			http.Error(w, "ConversionReview has no request", http.StatusBadRequest)
+			return
+		}
+
+		review.Response = convertObjects(review.Request, convert)
+		review.Request = nil
+
+		data, err := json.Marshal(review)
+		if err != nil {
+			klog.Errorf("Error encoding ConversionReview: %v", err)
+			http.Error(w, fmt.Sprintf("error encoding ConversionReview: %v", err), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(data)
+	}
+}
+
+// convertObjects converts all objects of req. Failing to convert any of
+// them fails the whole conversion, as the API server requires.
+func convertObjects(req *apiextensionsv1beta1.ConversionRequest, convert ConvertFunc) *apiextensionsv1beta1.ConversionResponse {
+	response := &apiextensionsv1beta1.ConversionResponse{
+		UID:              req.UID,
+		ConvertedObjects: []runtime.RawExtension{},
+	}
+	for i, obj := range req.Objects {
+		converted, err := convert(obj.Raw, req.DesiredAPIVersion)
+		if err != nil {
+			klog.Errorf("Error converting object %d of conversion request %v to %v: %v", i, req.UID, req.DesiredAPIVersion, err)
+			response.ConvertedObjects = []runtime.RawExtension{}
+			response.Result = metav1.Status{
+				Status:  metav1.StatusFailure,
+				Message: fmt.Sprintf("error converting object to %v: %v", req.DesiredAPIVersion, err),
+			}
+			return response
+		}
+		response.ConvertedObjects = append(response.ConvertedObjects, runtime.RawExtension{Raw: converted})
+	}
+	response.Result = metav1.Status{Status: metav1.StatusSuccess}
+	return response
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	apiextensionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestConversionHandler(t *testing.T) {
+	convert := func(data []byte, desiredAPIVersion string) ([]byte, error) {
+		if string(data) == `{"fail":true}` {
+			return nil, fmt.Errorf("conversion failed")
+		}
+		return []byte(fmt.Sprintf(`{"apiVersion":%q}`, desiredAPIVersion)), nil
+	}
+	handler := conversionHandler(convert)
+
+	testCases := []struct {
+		desc          string
+		body          string
+		wantCode      int
+		wantStatus    string
+		wantConverted []string
+	}{
+		{
+			desc:          "all objects converted",
+			body:          `{"apiVersion": "apiextensions.k8s.io/v1beta1", "kind": "ConversionReview", "request": {"uid": "123", "desiredAPIVersion": "cloud.google.com/v1", "objects": [{"a": 1}, {"b": 2}]}}`,
+			wantCode:      http.StatusOK,
+			wantStatus:    metav1.StatusSuccess,
+			wantConverted: []string{`{"apiVersion":"cloud.google.com/v1"}`, `{"apiVersion":"cloud.google.com/v1"}`},
+		},
+		{
+			desc:       "one object fails",
+			body:       `{"apiVersion": "apiextensions.k8s.io/v1beta1", "kind": "ConversionReview", "request": {"uid": "123", "desiredAPIVersion": "cloud.google.com/v1", "objects": [{"a": 1}, {"fail":true}]}}`,
+			wantCode:   http.StatusOK,
+			wantStatus: metav1.StatusFailure,
+		},
+		{
+			desc:     "review without request",
+			body:     `{"apiVersion": "apiextensions.k8s.io/v1beta1", "kind": "ConversionReview"}`,
+			wantCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			req := httptest.NewRequest("POST", BackendConfigConversionPath, bytes.NewBufferString(tc.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			handler(w, req)
+
+			if w.Code != tc.wantCode {
+				t.Fatalf("Got code %d, want %d (body: %q)", w.Code, tc.wantCode, w.Body.String())
+			}
+			if tc.wantCode != http.StatusOK {
+				return
+			}
+			review := &apiextensionsv1beta1.ConversionReview{}
+			if err := json.Unmarshal(w.Body.Bytes(), review); err != nil {
+				t.Fatalf("Error decoding response %q: %v", w.Body.String(), err)
+			}
+			if review.Response == nil || review.Response.UID != "123" || review.Response.Result.Status != tc.wantStatus {
+				t.Fatalf("Got response %+v, want UID 123 and status %q", review.Response, tc.wantStatus)
+			}
+			var converted []string
+			for _, obj := range review.Response.ConvertedObjects {
+				converted = append(converted, string(obj.Raw))
+			}
+			if fmt.Sprint(converted) != fmt.Sprint(tc.wantConverted) {
+				t.Errorf("Got converted objects %v, want %v", converted, tc.wantConverted)
+			}
+		})
+	}
+}
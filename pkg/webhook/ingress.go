@@ -0,0 +1,247 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	extensions "k8s.io/api/extensions/v1beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog"
+
+	"k8s.io/ingress-gce/pkg/annotations"
+	"k8s.io/ingress-gce/pkg/backendconfig"
+	"k8s.io/ingress-gce/pkg/context"
+	"k8s.io/ingress-gce/pkg/controller/errors"
+	"k8s.io/ingress-gce/pkg/controller/translator"
+	"k8s.io/ingress-gce/pkg/utils"
+)
+
+// IngressPath is the path on which Ingresses are validated.
+const IngressPath = "/validate/ingress"
+
+// ingressGroups are the API groups in which Ingresses are served.
+var ingressGroups = map[string]bool{
+	"extensions":        true,
+	"networking.k8s.io": true,
+}
+
+// IngressValidator validates Ingresses handled by the controller on
+// admission, so that specs which the controller cannot translate are
+// rejected when they are applied rather than reported as events on sync.
+type IngressValidator struct {
+	translator *translator.Translator
+	kubeClient kubernetes.Interface
+	hasSynced  func() bool
+}
+
+// NewIngressValidator returns an IngressValidator which translates Ingresses
+// against the Service and BackendConfig informers of ctx.
+func NewIngressValidator(ctx *context.ControllerContext) *IngressValidator {
+	return &IngressValidator{
+		translator: translator.NewTranslator(ctx),
+		kubeClient: ctx.KubeClient,
+		hasSynced: func() bool {
+			// The BackendConfig informer only runs if BackendConfig is enabled.
+			return ctx.ServiceInformer.HasSynced() && (ctx.BackendConfigClient == nil || ctx.BackendConfigInformer.HasSynced())
+		},
+	}
+}
+
+// Admit validates the Ingress of an admission request. Ingresses of classes
+// not handled by the controller are always admitted. Otherwise the
+// annotations and paths of the Ingress are checked and each of its backends
+// is translated. Problems which may be transient, such as a Service not yet
+// seen by the informers or an unreachable Secret, do not reject the Ingress.
+func (v *IngressValidator) Admit(req *admissionv1beta1.AdmissionRequest) *admissionv1beta1.AdmissionResponse {
+	if !ingressGroups[req.Kind.Group] || req.Kind.Kind != "Ingress" {
+		return badRequest(fmt.Errorf("unexpected kind %v, expected Ingress", req.Kind))
+	}
+	// Deletions and status updates by the controller are never rejected.
+	if req.Operation == admissionv1beta1.Delete || req.SubResource != "" {
+		return allowed()
+	}
+
+	ing := &extensions.Ingress{}
+	if err := json.Unmarshal(req.Object.Raw, ing); err != nil {
+		return badRequest(fmt.Errorf("error decoding Ingress: %v", err))
+	}
+	if !utils.IsGLBCIngress(ing) {
+		return allowed()
+	}
+	// Objects being created may not carry their namespace yet.
+	if ing.Namespace == "" {
+		ing.Namespace = req.Namespace
+	}
+
+	errs := validateIngressAnnotations(ing)
+	errs = append(errs, validateIngressPaths(ing)...)
+
+	var skipped []string
+	if !v.hasSynced() {
+		skipped = append(skipped, "informers have not synced, backends were not checked")
+	} else {
+		backendErrs, backendSkipped := v.validateBackends(ing)
+		errs = append(errs, backendErrs...)
+		skipped = append(skipped, backendSkipped...)
+	}
+
+	kind := schema.GroupKind{Group: req.Kind.Group, Kind: req.Kind.Kind}
+	if len(errs) > 0 {
+		return invalid(kind, req.Name, errs)
+	}
+	response := allowed()
+	if len(skipped) > 0 {
+		klog.Warningf("Admitting Ingress %s/%s without complete validation: %s", ing.Namespace, ing.Name, strings.Join(skipped, "; "))
+		response.AuditAnnotations = map[string]string{
+			degradedAnnotation: strings.Join(skipped, "; "),
+		}
+	}
+	return response
+}
+
+// validateIngressAnnotations returns an error for each annotation of ing
+// which the controller cannot interpret.
+func validateIngressAnnotations(ing *extensions.Ingress) field.ErrorList {
+	var errs field.ErrorList
+	parseErrs := annotations.FromIngress(ing).ParseErrors()
+	keys := make([]string, 0, len(parseErrs))
+	for key := range parseErrs {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	annotationsPath := field.NewPath("metadata", "annotations")
+	for _, key := range keys {
+		errs = append(errs, field.Invalid(annotationsPath.Key(key), ing.Annotations[key], parseErrs[key].Error()))
+	}
+	return errs
+}
+
+// validateIngressPaths returns an error for each path of ing which cannot
+// be used in a GCE URL map.
+func validateIngressPaths(ing *extensions.Ingress) field.ErrorList {
+	var errs field.ErrorList
+	for i, rule := range ing.Spec.Rules {
+		if rule.HTTP == nil {
+			continue
+		}
+		for j, p := range rule.HTTP.Paths {
+			if err := validatePath(p.Path); err != nil {
+				errs = append(errs, field.Invalid(field.NewPath("spec", "rules").Index(i).Child("http", "paths").Index(j).Child("path"), p.Path, err.Error()))
+			}
+		}
+	}
+	return errs
+}
+
+// validatePath returns an error if path is not a valid GCE URL map path.
+// An empty path is replaced by the catch-all path during translation.
+func validatePath(path string) error {
+	if path == "" {
+		return nil
+	}
+	if !strings.HasPrefix(path, "/") {
+		return fmt.Errorf("path must begin with /")
+	}
+	if strings.ContainsAny(path, "?#") {
+		return fmt.Errorf("path must not contain ? or #")
+	}
+	if i := strings.Index(path, "*"); i != -1 && (i != len(path)-1 || !strings.HasSuffix(path, "/*")) {
+		return fmt.Errorf("* is only allowed at the end of a path, following a /")
+	}
+	return nil
+}
+
+// validateBackends translates each backend of ing on its own, so that
+// translation errors are attributed to the backend which caused them. It
+// returns the errors found along with a description of each check which was
+// skipped because its failure may be transient.
+func (v *IngressValidator) validateBackends(ing *extensions.Ingress) (field.ErrorList, []string) {
+	var errs field.ErrorList
+	var skipped []string
+	// The route rules and path actions would otherwise be translated along
+	// with each backend.
+	objectMeta := ing.ObjectMeta.DeepCopy()
+	delete(objectMeta.Annotations, annotations.RouteRulesKey)
+	delete(objectMeta.Annotations, annotations.PathActionsKey)
+	check := func(backend extensions.IngressBackend, fldPath *field.Path) {
+		single := &extensions.Ingress{
+			ObjectMeta: *objectMeta,
+			Spec:       extensions.IngressSpec{Backend: &backend},
+		}
+		// The system default backend is not used when the Ingress has one.
+		_, translateErrs := v.translator.TranslateIngress(single, utils.ServicePortID{})
+		for _, err := range translateErrs {
+			if reason := v.transient(ing.Namespace, err); reason != "" {
+				skipped = append(skipped, fmt.Sprintf("%v: %s", fldPath, reason))
+				continue
+			}
+			errs = append(errs, field.Invalid(fldPath, fmt.Sprintf("%s:%s", backend.ServiceName, backend.ServicePort.String()), err.Error()))
+		}
+	}
+
+	if ing.Spec.Backend != nil {
+		check(*ing.Spec.Backend, field.NewPath("spec", "backend"))
+	}
+	for i, rule := range ing.Spec.Rules {
+		if rule.HTTP == nil {
+			continue
+		}
+		for j, p := range rule.HTTP.Paths {
+			check(p.Backend, field.NewPath("spec", "rules").Index(i).Child("http", "paths").Index(j).Child("backend"))
+		}
+	}
+	// Route rules which cannot be parsed are reported by
+	// validateIngressAnnotations.
+	routeRules, _ := annotations.FromIngress(ing).RouteRules()
+	for _, rule := range routeRules {
+		for _, b := range rule.Backends {
+			check(b.IngressBackend, field.NewPath("metadata", "annotations").Key(annotations.RouteRulesKey))
+		}
+	}
+	return errs, skipped
+}
+
+// transient returns why a translation error may be transient, or an empty
+// string if the error is permanent.
+func (v *IngressValidator) transient(namespace string, err error) string {
+	switch e := err.(type) {
+	case errors.ErrSvcNotFound:
+		// The Service may have been created moments ago and not be in the
+		// informer cache yet.
+		_, getErr := v.kubeClient.CoreV1().Services(namespace).Get(e.Service.Name, metav1.GetOptions{})
+		if getErr == nil {
+			return fmt.Sprintf("service %q is not yet known to the controller", e.Service)
+		}
+		if !apierrors.IsNotFound(getErr) {
+			return fmt.Sprintf("service %q could not be retrieved: %v", e.Service, getErr)
+		}
+	case errors.ErrBackendConfigValidation:
+		if _, ok := e.Err.(*backendconfig.SecretUnavailableError); ok {
+			return fmt.Sprintf("BackendConfig %v/%v could not be fully validated: %v", e.BackendConfig.Namespace, e.BackendConfig.Name, e.Err)
+		}
+	}
+	return ""
+}
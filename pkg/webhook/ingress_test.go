@@ -0,0 +1,265 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	apiv1 "k8s.io/api/core/v1"
+	extensions "k8s.io/api/extensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"k8s.io/ingress-gce/pkg/annotations"
+	backendconfigclient "k8s.io/ingress-gce/pkg/backendconfig/client/clientset/versioned/fake"
+	"k8s.io/ingress-gce/pkg/context"
+)
+
+func newService(name string, svcType apiv1.ServiceType, svcAnnotations map[string]string) *apiv1.Service {
+	return &apiv1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Annotations: svcAnnotations},
+		Spec: apiv1.ServiceSpec{
+			Type:  svcType,
+			Ports: []apiv1.ServicePort{{Name: "http", Port: 80, NodePort: 30080}},
+		},
+	}
+}
+
+func ingressRequest(t *testing.T, ing *extensions.Ingress) *admissionv1beta1.AdmissionRequest {
+	t.Helper()
+	raw, err := json.Marshal(ing)
+	if err != nil {
+		t.Fatalf("Error encoding Ingress: %v", err)
+	}
+	return &admissionv1beta1.AdmissionRequest{
+		UID:       "uid",
+		Kind:      metav1.GroupVersionKind{Group: "extensions", Version: "v1beta1", Kind: "Ingress"},
+		Name:      ing.Name,
+		Namespace: "default",
+		Operation: admissionv1beta1.Create,
+		Object:    runtime.RawExtension{Raw: raw},
+	}
+}
+
+func newIngress(ingAnnotations map[string]string, path string, backends ...string) *extensions.Ingress {
+	ing := &extensions.Ingress{
+		ObjectMeta: metav1.ObjectMeta{Name: "ing", Annotations: ingAnnotations},
+	}
+	var paths []extensions.HTTPIngressPath
+	for _, backend := range backends {
+		paths = append(paths, extensions.HTTPIngressPath{
+			Path:    path,
+			Backend: extensions.IngressBackend{ServiceName: backend, ServicePort: intstr.FromString("http")},
+		})
+	}
+	ing.Spec.Rules = []extensions.IngressRule{{
+		Host:             "foo.example.com",
+		IngressRuleValue: extensions.IngressRuleValue{HTTP: &extensions.HTTPIngressRuleValue{Paths: paths}},
+	}}
+	return ing
+}
+
+func TestIngressValidatorAdmit(t *testing.T) {
+	negAnnotation := map[string]string{annotations.NEGAnnotationKey: `{"ingress": true}`}
+
+	testCases := []struct {
+		desc         string
+		ing          *extensions.Ingress
+		liveServices []*apiv1.Service
+		unsynced     bool
+		wantAllowed  bool
+		wantCauses   []string
+		wantDegraded bool
+	}{
+		{
+			desc:        "valid Ingress",
+			ing:         newIngress(nil, "/foo/*", "nodeport", "neg"),
+			wantAllowed: true,
+		},
+		{
+			desc: "valid default backend",
+			ing: &extensions.Ingress{
+				ObjectMeta: metav1.ObjectMeta{Name: "ing"},
+				Spec: extensions.IngressSpec{
+					Backend: &extensions.IngressBackend{ServiceName: "nodeport", ServicePort: intstr.FromString("http")},
+				},
+			},
+			wantAllowed: true,
+		},
+		{
+			desc:        "missing service",
+			ing:         newIngress(nil, "/foo", "nodeport", "missing"),
+			wantAllowed: false,
+			wantCauses:  []string{"spec.rules[0].http.paths[1].backend"},
+		},
+		{
+			desc:         "service not yet in the informer cache",
+			ing:          newIngress(nil, "/foo", "new"),
+			liveServices: []*apiv1.Service{newService("new", apiv1.ServiceTypeNodePort, nil)},
+			wantAllowed:  true,
+			wantDegraded: true,
+		},
+		{
+			desc:        "missing service port",
+			ing:         newIngress(nil, "/foo", "nodeport", "nodeport-https"),
+			wantAllowed: false,
+			wantCauses:  []string{"spec.rules[0].http.paths[1].backend"},
+		},
+		{
+			desc:        "ClusterIP service without NEG",
+			ing:         newIngress(nil, "/foo", "clusterip"),
+			wantAllowed: false,
+			wantCauses:  []string{"spec.rules[0].http.paths[0].backend"},
+		},
+		{
+			desc:        "invalid path",
+			ing:         newIngress(nil, "/foo*", "nodeport"),
+			wantAllowed: false,
+			wantCauses:  []string{"spec.rules[0].http.paths[0].path"},
+		},
+		{
+			desc:        "bad pre-shared cert annotation",
+			ing:         newIngress(map[string]string{annotations.PreSharedCertKey: "cert_1"}, "/foo", "nodeport"),
+			wantAllowed: false,
+			wantCauses:  []string{"metadata.annotations[ingress.gcp.kubernetes.io/pre-shared-cert]"},
+		},
+		{
+			desc: "valid route rules",
+			ing: newIngress(map[string]string{
+				annotations.RouteRulesKey: `[{"match": {"headers": [{"name": "x-canary"}]}, "backends": [{"serviceName": "neg", "servicePort": "http"}]}]`,
+			}, "/foo", "nodeport"),
+			wantAllowed: true,
+		},
+		{
+			desc: "route rule with missing service",
+			ing: newIngress(map[string]string{
+				annotations.RouteRulesKey: `[{"backends": [{"serviceName": "nodeport", "servicePort": "http", "weight": 90}, {"serviceName": "missing", "servicePort": "http", "weight": 10}]}]`,
+			}, "/foo", "nodeport"),
+			wantAllowed: false,
+			wantCauses:  []string{"metadata.annotations[networking.gke.io/route-rules]"},
+		},
+		{
+			desc:        "invalid route rules",
+			ing:         newIngress(map[string]string{annotations.RouteRulesKey: `[{"backends": []}]`}, "/foo", "nodeport"),
+			wantAllowed: false,
+			wantCauses:  []string{"metadata.annotations[networking.gke.io/route-rules]"},
+		},
+		{
+			desc: "valid path actions",
+			ing: newIngress(map[string]string{
+				annotations.PathActionsKey: `[{"host": "foo.example.com", "path": "/foo", "rewrite": {"pathPrefix": "/"}}]`,
+			}, "/foo", "nodeport"),
+			wantAllowed: true,
+		},
+		{
+			desc:        "invalid path actions",
+			ing:         newIngress(map[string]string{annotations.PathActionsKey: `[{"path": "/foo"}]`}, "/foo", "nodeport"),
+			wantAllowed: false,
+			wantCauses:  []string{"metadata.annotations[networking.gke.io/path-actions]"},
+		},
+		{
+			desc:         "informers not synced",
+			ing:          newIngress(nil, "/foo", "missing"),
+			unsynced:     true,
+			wantAllowed:  true,
+			wantDegraded: true,
+		},
+		{
+			desc: "other ingress class",
+			ing: newIngress(map[string]string{
+				annotations.IngressClassKey:  "nginx",
+				annotations.PreSharedCertKey: "cert_1",
+			}, "foo", "missing"),
+			wantAllowed: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			kubeClient := fake.NewSimpleClientset()
+			for _, svc := range tc.liveServices {
+				kubeClient.CoreV1().Services(svc.Namespace).Create(svc)
+			}
+			ctx := context.NewControllerContext(kubeClient, backendconfigclient.NewSimpleClientset(), nil, nil, nil, context.ControllerContextConfig{
+				Namespace:    apiv1.NamespaceAll,
+				ResyncPeriod: time.Second,
+			})
+			for _, svc := range []*apiv1.Service{
+				newService("nodeport", apiv1.ServiceTypeNodePort, nil),
+				newService("clusterip", apiv1.ServiceTypeClusterIP, nil),
+				newService("neg", apiv1.ServiceTypeClusterIP, negAnnotation),
+			} {
+				ctx.ServiceInformer.GetIndexer().Add(svc)
+			}
+			svc := newService("nodeport-https", apiv1.ServiceTypeNodePort, nil)
+			svc.Spec.Ports[0].Name = "https"
+			ctx.ServiceInformer.GetIndexer().Add(svc)
+
+			validator := NewIngressValidator(ctx)
+			validator.hasSynced = func() bool { return !tc.unsynced }
+
+			resp := validator.Admit(ingressRequest(t, tc.ing))
+			if resp.Allowed != tc.wantAllowed {
+				t.Fatalf("Admit().Allowed = %v, want %v (result: %+v)", resp.Allowed, tc.wantAllowed, resp.Result)
+			}
+			if _, degraded := resp.AuditAnnotations[degradedAnnotation]; degraded != tc.wantDegraded {
+				t.Errorf("Admit().AuditAnnotations = %v, want degraded = %v", resp.AuditAnnotations, tc.wantDegraded)
+			}
+			if len(tc.wantCauses) == 0 {
+				return
+			}
+			if resp.Result == nil || resp.Result.Details == nil {
+				t.Fatalf("Admit().Result = %+v, want causes for %v", resp.Result, tc.wantCauses)
+			}
+			var gotCauses []string
+			for _, cause := range resp.Result.Details.Causes {
+				gotCauses = append(gotCauses, cause.Field)
+			}
+			if fmt.Sprint(gotCauses) != fmt.Sprint(tc.wantCauses) {
+				t.Errorf("Admit() causes = %v, want %v", gotCauses, tc.wantCauses)
+			}
+		})
+	}
+}
+
+func TestValidatePath(t *testing.T) {
+	for _, tc := range []struct {
+		path    string
+		wantErr bool
+	}{
+		{path: ""},
+		{path: "/"},
+		{path: "/*"},
+		{path: "/foo/bar"},
+		{path: "/foo/*"},
+		{path: "foo", wantErr: true},
+		{path: "/foo*", wantErr: true},
+		{path: "/*/foo", wantErr: true},
+		{path: "/foo?bar", wantErr: true},
+		{path: "/foo#bar", wantErr: true},
+	} {
+		if err := validatePath(tc.path); (err != nil) != tc.wantErr {
+			t.Errorf("validatePath(%q) = %v, want error = %v", tc.path, err, tc.wantErr)
+		}
+	}
+}
@@ -0,0 +1,153 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	apiextensionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// decodeUntyped decodes a JSON document, keeping numbers as json.Number so
+// that integers can be told apart from other numbers.
+func decodeUntyped(data []byte) (interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// validateSchema checks a value decoded by decodeUntyped against the OpenAPI
+// schema of a CRD. It returns an error for each field whose value is of the
+// wrong type and for each field which is not declared in the schema, since
+// the API server silently keeps unknown fields of custom resources.
+// Schemas which declare no type or no properties are not checked further.
+func validateSchema(value interface{}, schema *apiextensionsv1beta1.JSONSchemaProps, fldPath *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	// Omitted optional fields may be serialized as null.
+	if value == nil || schema == nil {
+		return errs
+	}
+
+	if schemaType := schema.Type; schemaType != "" && !hasType(value, schemaType) {
+		return append(errs, field.Invalid(fldPath, jsonType(value), fmt.Sprintf("must be of type %s", schemaType)))
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if len(schema.Properties) == 0 {
+			return errs
+		}
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			propSchema, ok := schema.Properties[key]
+			if !ok {
+				errs = append(errs, field.Forbidden(childPath(fldPath, key), fmt.Sprintf("unknown field, should be one of %s", strings.Join(propertyNames(schema), ", "))))
+				continue
+			}
+			errs = append(errs, validateSchema(v[key], &propSchema, childPath(fldPath, key))...)
+		}
+	case []interface{}:
+		if schema.Items == nil || schema.Items.Schema == nil {
+			return errs
+		}
+		for i, item := range v {
+			errs = append(errs, validateSchema(item, schema.Items.Schema, fldPath.Index(i))...)
+		}
+	}
+	return errs
+}
+
+// hasType returns true if value is of the given OpenAPI type.
+func hasType(value interface{}, schemaType string) bool {
+	switch schemaType {
+	case "object":
+		_, ok := value.(map[string]interface{})
+		return ok
+	case "array":
+		_, ok := value.([]interface{})
+		return ok
+	case "string":
+		_, ok := value.(string)
+		return ok
+	case "boolean":
+		_, ok := value.(bool)
+		return ok
+	case "integer":
+		n, ok := value.(json.Number)
+		if !ok {
+			return false
+		}
+		_, err := n.Int64()
+		return err == nil
+	case "number":
+		_, ok := value.(json.Number)
+		return ok
+	}
+	// Types unknown to this check are accepted.
+	return true
+}
+
+// jsonType returns the OpenAPI type of a value decoded by decodeUntyped.
+func jsonType(value interface{}) string {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return "object"
+	case []interface{}:
+		return "array"
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	case json.Number:
+		if _, err := v.Int64(); err == nil {
+			return "integer"
+		}
+		return "number"
+	}
+	return fmt.Sprintf("%T", value)
+}
+
+// childPath returns the path of a field named name within fldPath, which
+// is nil for the root of an object.
+func childPath(fldPath *field.Path, name string) *field.Path {
+	if fldPath == nil {
+		return field.NewPath(name)
+	}
+	return fldPath.Child(name)
+}
+
+func propertyNames(schema *apiextensionsv1beta1.JSONSchemaProps) []string {
+	var names []string
+	for name := range schema.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"testing"
+
+	apiextensionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func TestValidateSchema(t *testing.T) {
+	schema := &apiextensionsv1beta1.JSONSchemaProps{
+		Type: "object",
+		Properties: map[string]apiextensionsv1beta1.JSONSchemaProps{
+			"metadata": {Type: "object"},
+			"spec": {
+				Type: "object",
+				Properties: map[string]apiextensionsv1beta1.JSONSchemaProps{
+					"enabled":    {Type: "boolean"},
+					"timeoutSec": {Type: "integer"},
+					"sampleRate": {Type: "number"},
+					"headers": {
+						Type:  "array",
+						Items: &apiextensionsv1beta1.JSONSchemaPropsOrArray{Schema: &apiextensionsv1beta1.JSONSchemaProps{Type: "string"}},
+					},
+				},
+			},
+		},
+	}
+
+	testCases := []struct {
+		desc       string
+		json       string
+		wantFields []string
+		wantTypes  []field.ErrorType
+	}{
+		{
+			desc: "valid object",
+			json: `{"metadata": {"name": "foo", "labels": {"a": "b"}}, "spec": {"enabled": true, "timeoutSec": 10, "sampleRate": 0.5, "headers": ["X-Foo: bar"]}}`,
+		},
+		{
+			desc: "null fields are accepted",
+			json: `{"spec": {"enabled": null, "headers": null}}`,
+		},
+		{
+			desc:       "unknown fields",
+			json:       `{"spec": {"enabled": true, "enabeld": true, "timeoutSecs": 5}}`,
+			wantFields: []string{"spec.enabeld", "spec.timeoutSecs"},
+			wantTypes:  []field.ErrorType{field.ErrorTypeForbidden, field.ErrorTypeForbidden},
+		},
+		{
+			desc:       "wrong types",
+			json:       `{"spec": {"enabled": "true", "timeoutSec": 1.5, "sampleRate": "0.5"}}`,
+			wantFields: []string{"spec.enabled", "spec.sampleRate", "spec.timeoutSec"},
+			wantTypes:  []field.ErrorType{field.ErrorTypeInvalid, field.ErrorTypeInvalid, field.ErrorTypeInvalid},
+		},
+		{
+			desc:       "wrong array item type",
+			json:       `{"spec": {"headers": ["X-Foo: bar", 5]}}`,
+			wantFields: []string{"spec.headers[1]"},
+			wantTypes:  []field.ErrorType{field.ErrorTypeInvalid},
+		},
+		{
+			desc:       "object expected",
+			json:       `{"spec": []}`,
+			wantFields: []string{"spec"},
+			wantTypes:  []field.ErrorType{field.ErrorTypeInvalid},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			value, err := decodeUntyped([]byte(tc.json))
+			if err != nil {
+				t.Fatalf("decodeUntyped(%q) = %v", tc.json, err)
+			}
+			errs := validateSchema(value, schema, nil)
+			if len(errs) != len(tc.wantFields) {
+				t.Fatalf("validateSchema() = %v, want errors for %v", errs, tc.wantFields)
+			}
+			for i, err := range errs {
+				if err.Field != tc.wantFields[i] || err.Type != tc.wantTypes[i] {
+					t.Errorf("validateSchema()[%d] = %v, want %v error for %q", i, err, tc.wantTypes[i], tc.wantFields[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"k8s.io/klog"
+)
+
+// Server serves admission webhooks over TLS. It is independent of the
+// controllers, so every replica of the controller serves webhooks whether
+// or not it holds the leader lock.
+type Server struct {
+	addr  string
+	certs *certLoader
+	mux   *http.ServeMux
+}
+
+// NewServer returns a Server listening on the given port with the
+// certificate and key in certFile and keyFile. The files are reloaded when
+// they change, so that certificates can be rotated without a restart.
+func NewServer(port int, certFile, keyFile string) (*Server, error) {
+	if certFile == "" || keyFile == "" {
+		return nil, fmt.Errorf("both a certificate and a key file are required to serve webhooks")
+	}
+	certs := &certLoader{certFile: certFile, keyFile: keyFile}
+	if _, err := certs.getCertificate(nil); err != nil {
+		return nil, err
+	}
+	return &Server{
+		addr:  fmt.Sprintf(":%d", port),
+		certs: certs,
+		mux:   http.NewServeMux(),
+	}, nil
+}
+
+// Handle registers admit to decide on the AdmissionReviews sent to path.
+func (s *Server) Handle(path string, admit AdmitFunc) {
+	s.mux.HandleFunc(path, admissionHandler(admit))
+}
+
+// HandleConversion registers convert to convert the objects of the
+// ConversionReviews sent to path.
+func (s *Server) HandleConversion(path string, convert ConvertFunc) {
+	s.mux.HandleFunc(path, conversionHandler(convert))
+}
+
+// Run serves webhooks until an error occurs.
+func (s *Server) Run() error {
+	server := &http.Server{
+		Addr:    s.addr,
+		Handler: s.mux,
+		TLSConfig: &tls.Config{
+			MinVersion:     tls.VersionTLS12,
+			GetCertificate: s.certs.getCertificate,
+		},
+		ReadTimeout:  30 * time.Second,
+		WriteTimeout: 30 * time.Second,
+	}
+	return server.ListenAndServeTLS("", "")
+}
+
+// certLoader loads a certificate and key pair from files, reloading them
+// whenever either file is modified.
+type certLoader struct {
+	certFile string
+	keyFile  string
+
+	lock     sync.Mutex
+	cert     *tls.Certificate
+	certTime time.Time
+	keyTime  time.Time
+}
+
+// getCertificate returns the current certificate. It is suitable for use
+// as tls.Config.GetCertificate.
+func (l *certLoader) getCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	certInfo, certErr := os.Stat(l.certFile)
+	keyInfo, keyErr := os.Stat(l.keyFile)
+	if certErr != nil || keyErr != nil {
+		if l.cert != nil {
+			// Keep serving the last certificate while the files are replaced.
+			return l.cert, nil
+		}
+		return nil, fmt.Errorf("error reading webhook certificate %v and key %v: %v, %v", l.certFile, l.keyFile, certErr, keyErr)
+	}
+	if l.cert != nil && certInfo.ModTime().Equal(l.certTime) && keyInfo.ModTime().Equal(l.keyTime) {
+		return l.cert, nil
+	}
+
+	cert, err := tls.LoadX509KeyPair(l.certFile, l.keyFile)
+	if err != nil {
+		if l.cert != nil {
+			// Do not retry until the files are modified again.
+			l.certTime = certInfo.ModTime()
+			l.keyTime = keyInfo.ModTime()
+			klog.Errorf("Error reloading webhook certificate, serving the previous one: %v", err)
+			return l.cert, nil
+		}
+		return nil, fmt.Errorf("error loading webhook certificate %v and key %v: %v", l.certFile, l.keyFile, err)
+	}
+	klog.V(2).Infof("Loaded webhook certificate %v", l.certFile)
+	l.cert = &cert
+	l.certTime = certInfo.ModTime()
+	l.keyTime = keyInfo.ModTime()
+	return l.cert, nil
+}
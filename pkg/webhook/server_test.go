@@ -0,0 +1,121 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// writeCertificate writes a self-signed certificate for commonName and its
+// key to certFile and keyFile, with the given modification time.
+func writeCertificate(t *testing.T, certFile, keyFile, commonName string, modTime time.Time) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Error generating key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("Error creating certificate: %v", err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("Error marshalling key: %v", err)
+	}
+	for file, block := range map[string]*pem.Block{
+		certFile: {Type: "CERTIFICATE", Bytes: der},
+		keyFile:  {Type: "EC PRIVATE KEY", Bytes: keyDER},
+	} {
+		if err := ioutil.WriteFile(file, pem.EncodeToMemory(block), 0600); err != nil {
+			t.Fatalf("Error writing %v: %v", file, err)
+		}
+		if err := os.Chtimes(file, modTime, modTime); err != nil {
+			t.Fatalf("Error setting modification time of %v: %v", file, err)
+		}
+	}
+}
+
+func TestCertLoader(t *testing.T) {
+	dir, err := ioutil.TempDir("", "webhook")
+	if err != nil {
+		t.Fatalf("Error creating temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	certFile := filepath.Join(dir, "tls.crt")
+	keyFile := filepath.Join(dir, "tls.key")
+
+	if _, err := NewServer(8443, certFile, keyFile); err == nil {
+		t.Fatalf("NewServer() with missing files = nil, want error")
+	}
+
+	now := time.Now()
+	writeCertificate(t, certFile, keyFile, "first", now.Add(-time.Minute))
+	server, err := NewServer(8443, certFile, keyFile)
+	if err != nil {
+		t.Fatalf("NewServer() = %v", err)
+	}
+
+	commonName := func() string {
+		cert, err := server.certs.getCertificate(nil)
+		if err != nil {
+			t.Fatalf("getCertificate() = %v", err)
+		}
+		parsed, err := x509.ParseCertificate(cert.Certificate[0])
+		if err != nil {
+			t.Fatalf("Error parsing certificate: %v", err)
+		}
+		return parsed.Subject.CommonName
+	}
+	if got := commonName(); got != "first" {
+		t.Errorf("Got certificate %q, want %q", got, "first")
+	}
+
+	// A rotated certificate is picked up.
+	writeCertificate(t, certFile, keyFile, "second", now)
+	if got := commonName(); got != "second" {
+		t.Errorf("Got certificate %q after rotation, want %q", got, "second")
+	}
+
+	// A broken or missing certificate does not replace the last valid one.
+	if err := ioutil.WriteFile(certFile, []byte("garbage"), 0600); err != nil {
+		t.Fatalf("Error writing %v: %v", certFile, err)
+	}
+	if got := commonName(); got != "second" {
+		t.Errorf("Got certificate %q after corruption, want %q", got, "second")
+	}
+	os.Remove(certFile)
+	if got := commonName(); got != "second" {
+		t.Errorf("Got certificate %q after removal, want %q", got, "second")
+	}
+}